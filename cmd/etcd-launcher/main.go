@@ -52,6 +52,8 @@ const (
 	etcdCommandPath          = "/usr/local/bin/etcd"
 	initialStateExisting     = "existing"
 	initialStateNew          = "new"
+	// revisionCompactionRetention is the number of revisions kept by the revision compaction mode
+	revisionCompactionRetention = 1000
 )
 
 type config struct {
@@ -63,6 +65,7 @@ type config struct {
 	dataDir               string
 	token                 string
 	enableCorruptionCheck bool
+	compactionMode        string
 	initialState          string
 }
 
@@ -248,6 +251,7 @@ func (e *etcdCluster) parseConfigFlags() error {
 	flag.StringVar(&config.etcdctlAPIVersion, "api-version", defaultEtcdctlAPIVersion, "etcdctl API version")
 	flag.StringVar(&config.token, "token", "", "etcd database token")
 	flag.BoolVar(&config.enableCorruptionCheck, "enable-corruption-check", false, "enable etcd experimental corruption check")
+	flag.StringVar(&config.compactionMode, "compaction-mode", kubermaticv1.EtcdCompactionModePeriodic, "etcd auto compaction mode, either periodic or revision")
 	flag.Parse()
 
 	if config.namespace == "" {
@@ -300,7 +304,15 @@ func etcdCmd(config *config) []string {
 		fmt.Sprintf("--peer-trusted-ca-file=%s", resources.EtcdTrustedCAFile),
 		fmt.Sprintf("--peer-cert-file=%s", resources.EtcdPeerCertFile),
 		fmt.Sprintf("--peer-key-file=%s", resources.EtcdPeerKeyFile),
-		"--auto-compaction-retention=8",
+	}
+
+	if config.compactionMode == kubermaticv1.EtcdCompactionModeRevision {
+		cmd = append(cmd,
+			"--auto-compaction-mode=revision",
+			fmt.Sprintf("--auto-compaction-retention=%d", revisionCompactionRetention),
+		)
+	} else {
+		cmd = append(cmd, "--auto-compaction-retention=8")
 	}
 
 	if config.enableCorruptionCheck {
@@ -128,7 +128,14 @@ func main() {
 	if err != nil {
 		log.Fatalw("failed to create update manager", "error", err)
 	}
-	apiHandler, err := createAPIHandler(options, providers, oidcIssuerVerifier, tokenVerifiers, tokenExtractors, updateManager)
+	var securityAdvisories []*version.SecurityAdvisory
+	if options.advisoriesFile != "" {
+		securityAdvisories, err = version.LoadSecurityAdvisories(options.advisoriesFile)
+		if err != nil {
+			log.Fatalw("failed to load security advisories", "file", options.advisoriesFile, "error", err)
+		}
+	}
+	apiHandler, err := createAPIHandler(options, providers, oidcIssuerVerifier, tokenVerifiers, tokenExtractors, updateManager, securityAdvisories)
 	if err != nil {
 		log.Fatalw("failed to create API Handler", "error", err)
 	}
@@ -353,7 +360,7 @@ func createAuthClients(options serverRunOptions, prov providers) (auth.TokenVeri
 	return tokenVerifiers, tokenExtractors, nil
 }
 
-func createAPIHandler(options serverRunOptions, prov providers, oidcIssuerVerifier auth.OIDCIssuerVerifier, tokenVerifiers auth.TokenVerifier, tokenExtractors auth.TokenExtractor, updateManager common.UpdateManager) (http.HandlerFunc, error) {
+func createAPIHandler(options serverRunOptions, prov providers, oidcIssuerVerifier auth.OIDCIssuerVerifier, tokenVerifiers auth.TokenVerifier, tokenExtractors auth.TokenExtractor, updateManager common.UpdateManager, securityAdvisories []*version.SecurityAdvisory) (http.HandlerFunc, error) {
 	var prometheusClient prometheusapi.Client
 	if options.featureGates.Enabled(features.PrometheusEndpoint) {
 		var err error
@@ -409,6 +416,7 @@ func createAPIHandler(options serverRunOptions, prov providers, oidcIssuerVerifi
 		ExternalClusterProvider:               prov.externalClusterProvider,
 		PrivilegedExternalClusterProvider:     prov.privilegedExternalClusterProvider,
 		ConstraintTemplateProvider:            prov.constraintTemplateProvider,
+		SecurityAdvisories:                    securityAdvisories,
 	}
 
 	r := handler.NewRouting(routingParams)
@@ -42,6 +42,7 @@ type serverRunOptions struct {
 	versionsFile     string
 	updatesFile      string
 	presetsFile      string
+	advisoriesFile   string
 	swaggerFile      string
 	domain           string
 	exposeStrategy   corev1.ServiceType
@@ -88,6 +89,7 @@ func newServerRunOptions() (serverRunOptions, error) {
 	flag.StringVar(&s.versionsFile, "versions", "versions.yaml", "The versions.yaml file path")
 	flag.StringVar(&s.updatesFile, "updates", "updates.yaml", "The updates.yaml file path")
 	flag.StringVar(&s.presetsFile, "presets", "", "The optional file path for a file containing presets")
+	flag.StringVar(&s.advisoriesFile, "security-advisories", "", "The optional file path for a file containing known security advisories of Kubernetes versions")
 	flag.StringVar(&s.swaggerFile, "swagger", "./cmd/kubermatic-api/swagger.json", "The swagger.json file path")
 	flag.StringVar(&rawAccessibleAddons, "accessible-addons", "", "Comma-separated list of user cluster addons to expose via the API")
 	flag.StringVar(&s.oidcURL, "oidc-url", "", "URL of the OpenID token issuer. Example: http://auth.int.kubermatic.io")
@@ -82,6 +82,8 @@ type controllerRunOptions struct {
 	updateWindowStart             string
 	updateWindowLength            string
 	dnsClusterIP                  string
+	adminGroups                   string
+	dnsUpstreams                  string
 }
 
 func main() {
@@ -109,6 +111,8 @@ func main() {
 	flag.StringVar(&runOp.openshiftConsoleCallbackURI, "openshift-console-callback-uri", "", "The callback uri for the openshift console")
 	flag.StringVar(&runOp.ownerEmail, "owner-email", "", "An email address of the user who created the cluster. Used as default subject for the admin cluster role binding")
 	flag.StringVar(&runOp.updateWindowStart, "update-window-start", "", "The start time of the update window, e.g. 02:00")
+	flag.StringVar(&runOp.adminGroups, "admin-groups", "", "A comma separated list of groups which get bound to the cluster-admin cluster role")
+	flag.StringVar(&runOp.dnsUpstreams, "dns-upstreams", "", "A json-encoded list of DNS upstreams CoreDNS forwards queries to. If unset, the resolvers of the nodes are used.")
 	flag.StringVar(&runOp.updateWindowLength, "update-window-length", "", "The length of the update window, e.g. 1h")
 	flag.Parse()
 
@@ -152,6 +156,18 @@ func main() {
 		}
 	}
 
+	var adminGroups []string
+	if runOp.adminGroups != "" {
+		adminGroups = strings.Split(runOp.adminGroups, ",")
+	}
+
+	var dnsUpstreams []kubermaticv1.DNSUpstream
+	if runOp.dnsUpstreams != "" {
+		if err := json.Unmarshal([]byte(runOp.dnsUpstreams), &dnsUpstreams); err != nil {
+			log.Fatalw("Failed to unmarshal value of --dns-upstreams arg", zap.Error(err))
+		}
+	}
+
 	var g run.Group
 
 	healthHandler := healthcheck.NewHandler()
@@ -226,6 +242,8 @@ func main() {
 		cloudCredentialSecretTemplate,
 		runOp.openshiftConsoleCallbackURI,
 		runOp.dnsClusterIP,
+		adminGroups,
+		dnsUpstreams,
 		log,
 	); err != nil {
 		log.Fatalw("Failed to register user cluster controller", zap.Error(err))
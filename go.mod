@@ -52,12 +52,14 @@ require (
 	github.com/oklog/run v1.1.0
 	github.com/onsi/ginkgo v1.14.0
 	github.com/open-policy-agent/frameworks/constraint v0.0.0-20200803193800-bcb6432d79b7
+	github.com/open-policy-agent/opa v0.19.1
 	github.com/packethost/packngo v0.1.1-0.20190410075950-a02c426e4888
 	github.com/pkg/errors v0.9.1
 	github.com/pmezard/go-difflib v1.0.0
 	github.com/poy/onpar v0.0.0-20200406201722-06f95a1c68e8 // indirect
+	github.com/prometheus/alertmanager v0.21.0
 	github.com/prometheus/client_golang v1.7.1
-	github.com/prometheus/common v0.11.1 // indirect
+	github.com/prometheus/common v0.11.1
 	github.com/robfig/cron v1.2.0
 	github.com/sirupsen/logrus v1.6.0
 	github.com/spf13/cobra v1.0.0
@@ -88,6 +90,7 @@ require (
 	k8s.io/klog v1.0.0
 	k8s.io/klog/v2 v2.3.0 // indirect
 	k8s.io/kube-aggregator v0.16.4
+	k8s.io/kube-scheduler v0.19.0
 	k8s.io/metrics v0.16.4
 	k8s.io/test-infra v0.0.0-20200220102703-18fae0a00a2c
 	k8s.io/utils v0.0.0-20200731180307-f00132d28269
@@ -110,6 +113,7 @@ replace (
 	k8s.io/client-go => k8s.io/client-go v0.19.0
 	k8s.io/code-generator => k8s.io/code-generator v0.19.0
 	k8s.io/kube-aggregator => k8s.io/kube-aggregator v0.19.0
+	k8s.io/kube-scheduler => k8s.io/kube-scheduler v0.19.0
 	k8s.io/kubelet => k8s.io/kubelet v0.19.0
 	k8s.io/metrics => k8s.io/metrics v0.19.0
 )
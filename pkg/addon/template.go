@@ -86,6 +86,11 @@ func NewTemplateData(
 		variables = make(map[string]interface{})
 	}
 
+	maxPodsPerNode := int32(resources.DefaultMaxPodsPerNode)
+	if cluster.Spec.MaxPodsPerNode != nil {
+		maxPodsPerNode = *cluster.Spec.MaxPodsPerNode
+	}
+
 	clusterType := ClusterTypeKubernetes
 	if cluster.IsOpenshift() {
 		clusterType = ClusterTypeOpenshift
@@ -112,12 +117,14 @@ func NewTemplateData(
 			Version:              semver.MustParse(cluster.Spec.Version.String()),
 			MajorMinorVersion:    cluster.Spec.Version.MajorMinor(),
 			Features:             sets.StringKeySet(cluster.Spec.Features),
+			MaxPodsPerNode:       maxPodsPerNode,
 			Network: ClusterNetwork{
 				DNSClusterIP:      dnsClusterIP,
 				DNSResolverIP:     dnsResolverIP,
 				PodCIDRBlocks:     cluster.Spec.ClusterNetwork.Pods.CIDRBlocks,
 				ServiceCIDRBlocks: cluster.Spec.ClusterNetwork.Services.CIDRBlocks,
 				ProxyMode:         cluster.Spec.ClusterNetwork.ProxyMode,
+				IPFamily:          cluster.Spec.ClusterNetwork.IPFamily,
 			},
 		},
 	}, nil
@@ -170,6 +177,8 @@ type ClusterData struct {
 	Network ClusterNetwork
 	// Features is a set of enabled features for this cluster.
 	Features sets.String
+	// MaxPodsPerNode is the maximum number of pods the kubelets run.
+	MaxPodsPerNode int32
 }
 
 type ClusterNetwork struct {
@@ -178,6 +187,7 @@ type ClusterNetwork struct {
 	PodCIDRBlocks     []string
 	ServiceCIDRBlocks []string
 	ProxyMode         string
+	IPFamily          string
 }
 
 func ParseFromFolder(log *zap.SugaredLogger, overwriteRegistry string, manifestPath string, data *TemplateData) ([]runtime.RawExtension, error) {
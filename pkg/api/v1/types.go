@@ -93,6 +93,18 @@ type DatacenterSpec struct {
 	// EnforcePodSecurityPolicy enforces pod security policy plugin on every clusters within the DC,
 	// ignoring cluster-specific settings
 	EnforcePodSecurityPolicy bool `json:"enforcePodSecurityPolicy"`
+
+	// MaxClusters limits the number of clusters that can be created within the DC.
+	// 0 means unlimited.
+	MaxClusters int `json:"maxClusters,omitempty"`
+
+	// NodeNetworks are the network ranges (CIDRs) the nodes of the DC are attached to.
+	// The pods and services network ranges of the clusters within the DC must not overlap them.
+	NodeNetworks []string `json:"nodeNetworks,omitempty"`
+
+	// LoadBalancerClasses are the load balancer implementations available in the DC.
+	// Clusters within the DC can only select one of them as their default load balancer class.
+	LoadBalancerClasses []string `json:"loadBalancerClasses,omitempty"`
 }
 
 // DatacenterList represents a list of datacenters
@@ -514,6 +526,13 @@ type Kubeconfig struct {
 	Config []byte
 }
 
+// ClustersManifest is a YAML manifest of the clusters of a project
+// swagger:response ClustersManifest
+type ClustersManifest struct {
+	// in: body
+	Manifest []byte
+}
+
 // OpenstackSize is the object representing openstack's sizes.
 // swagger:model OpenstackSize
 type OpenstackSize struct {
@@ -629,6 +648,10 @@ type MasterVersion struct {
 	// If true, then given version control plane version is not compatible
 	// with one of the kubelets inside cluster and shouldn't be used.
 	RestrictedByKubeletVersion bool `json:"restrictedByKubeletVersion,omitempty"`
+
+	// RestrictingMachineDeployments lists the names of the machine deployments
+	// running kubelets which are not compatible with the given version.
+	RestrictingMachineDeployments []string `json:"restrictingMachineDeployments,omitempty"`
 }
 
 // CreateClusterSpec is the structure that is used to create cluster with its initial node deployment
@@ -636,6 +659,8 @@ type MasterVersion struct {
 type CreateClusterSpec struct {
 	Cluster        Cluster         `json:"cluster"`
 	NodeDeployment *NodeDeployment `json:"nodeDeployment,omitempty"`
+	// InitialNamespaces are created in the cluster once it is ready
+	InitialNamespaces []string `json:"initialNamespaces,omitempty"`
 }
 
 const (
@@ -660,6 +685,11 @@ type Cluster struct {
 	Credential      string            `json:"credential,omitempty"`
 	Spec            ClusterSpec       `json:"spec"`
 	Status          ClusterStatus     `json:"status"`
+	// Warnings are non-blocking advisories about the cluster. They are only returned when the cluster is created or patched.
+	Warnings []string `json:"warnings,omitempty"`
+	// EnforcedByDatacenter lists the spec fields, e.g. "spec.auditLogging.enabled", whose values were enforced
+	// by the datacenter regardless of the requested values. They are only returned when the cluster is created.
+	EnforcedByDatacenter []string `json:"enforcedByDatacenter,omitempty"`
 }
 
 // ClusterSpec defines the cluster specification
@@ -693,6 +723,140 @@ type ClusterSpec struct {
 
 	// Openshift holds all openshift-specific settings
 	Openshift *kubermaticv1.Openshift `json:"openshift,omitempty"`
+
+	// Description is an optional free-form description of the cluster
+	Description string `json:"description,omitempty"`
+
+	// AdminGroups is a list of groups which are granted cluster-admin in the user cluster.
+	// It can only be set when the cluster is created.
+	AdminGroups []string `json:"adminGroups,omitempty"`
+
+	// KubeProxy holds the kube-proxy settings of the cluster
+	KubeProxy *KubeProxySettings `json:"kubeProxy,omitempty"`
+
+	// ClusterNetwork holds the network settings of the cluster. It can only be set when the cluster is created.
+	ClusterNetwork *ClusterNetworkSettings `json:"clusterNetwork,omitempty"`
+
+	// ComponentsOverride holds the settings of the control plane components
+	ComponentsOverride *ComponentSettings `json:"componentsOverride,omitempty"`
+
+	// Autoscaler holds the settings of the cluster-autoscaler. Disabled by default.
+	Autoscaler *kubermaticv1.AutoscalerSettings `json:"autoscaler,omitempty"`
+
+	// AddonVersions pins addons to one of their available versions, keyed by the addon name.
+	// Addons without a pinned version use the default manifests. It can only be set when the cluster is created.
+	AddonVersions map[string]string `json:"addonVersions,omitempty"`
+
+	// ExternalDNS configures the external-dns addon. It is not installed when this is not set.
+	ExternalDNS *kubermaticv1.ExternalDNSSettings `json:"externalDNS,omitempty"`
+
+	// EgressAllowlist restricts the egress traffic of the cluster workloads to the given CIDRs. It requires
+	// the canal CNI, so it is not supported for openshift clusters. Egress traffic is not restricted by default.
+	EgressAllowlist []string `json:"egressAllowlist,omitempty"`
+
+	// Connectivity selects how the control plane connects to the nodes, either "openvpn" or "konnectivity".
+	// Konnectivity is not supported yet. OpenVPN is used when this is not set.
+	Connectivity kubermaticv1.ClusterConnectivity `json:"connectivity,omitempty"`
+
+	// DisableNodeSSH disables the SSH access to the nodes. SSH keys can not be assigned to the cluster
+	// and the SSH port is closed in the node firewall.
+	DisableNodeSSH bool `json:"disableNodeSSH,omitempty"`
+
+	// AlertmanagerConfig is the raw Alertmanager configuration (YAML) the alerts of the cluster are routed with.
+	// It must define a route and the receivers the route refers to.
+	AlertmanagerConfig string `json:"alertmanagerConfig,omitempty"`
+
+	// MaxPodsPerNode is the maximum number of pods per node, defaults to 110. It can only be set when the
+	// cluster is created and must leave room for at least one node in the pod network ranges.
+	MaxPodsPerNode *int32 `json:"maxPodsPerNode,omitempty"`
+
+	// ServiceNodePortRange is the port range NodePort services are allocated from, e.g. "30000-32767". It must not
+	// contain privileged ports or the ports of the kubelet and kube-proxy. Changing it may break existing NodePort services.
+	ServiceNodePortRange string `json:"serviceNodePortRange,omitempty"`
+
+	// RegistryMirror is the registry the images of the control plane, the addons and the nodes are pulled from.
+	// Set the validateRegistry query parameter when creating the cluster to check that the registry is reachable.
+	RegistryMirror *kubermaticv1.RegistryMirrorSettings `json:"registryMirror,omitempty"`
+
+	// SchedulerConfig is a KubeSchedulerConfiguration in YAML or JSON format, used to configure the scheduler
+	// profiles and plugins. Its apiVersion must be the one supported by the Kubernetes version of the cluster.
+	SchedulerConfig string `json:"schedulerConfig,omitempty"`
+
+	// DNS configures the DNS servers the cluster DNS forwards queries to, e.g. to resolve the names of
+	// corporate DNS zones. The resolvers of the nodes are used by default.
+	DNS *kubermaticv1.DNSSettings `json:"dns,omitempty"`
+
+	// ExposeStrategy is the approach used to expose the control plane of the cluster, either NodePort or
+	// LoadBalancer. Changing it changes the endpoint of the cluster, so a patch changing it has to be
+	// acknowledged with ?acknowledge=disruption. The status URL is updated once the new endpoint is ready.
+	ExposeStrategy corev1.ServiceType `json:"exposeStrategy,omitempty"`
+
+	// MetricsServer explicitly installs or removes the metrics-server addon, which the HorizontalPodAutoscaler
+	// depends on. The default addons of the installation decide whether it is installed when this is not set.
+	MetricsServer *kubermaticv1.MetricsServerSettings `json:"metricsServer,omitempty"`
+
+	// LoadBalancerClass is the load balancer implementation Services of type LoadBalancer use by default,
+	// for datacenters offering several of them, e.g. a cloud load balancer and MetalLB. It must be one of
+	// the loadBalancerClasses of the datacenter.
+	LoadBalancerClass string `json:"loadBalancerClass,omitempty"`
+}
+
+// KubeProxySettings defines the kube-proxy settings of a cluster
+type KubeProxySettings struct {
+	// Mode is the kube-proxy mode, one of ipvs or iptables. Defaults to ipvs.
+	Mode string `json:"mode,omitempty"`
+}
+
+// ClusterNetworkSettings defines the network settings of a cluster
+type ClusterNetworkSettings struct {
+	// IPFamily is the IP family of the cluster network, one of ipv4, ipv6 or dualstack. Defaults to ipv4.
+	// The IPv4 default network ranges are used when no ranges are given, so ipv6 and dualstack
+	// clusters must specify the ranges of all their families.
+	IPFamily string `json:"ipFamily,omitempty"`
+	// Pods are the network ranges from which POD networks are allocated
+	Pods []string `json:"pods,omitempty"`
+	// Services are the network ranges from which service VIPs are allocated
+	Services []string `json:"services,omitempty"`
+}
+
+// ComponentSettings defines the settings of the control plane components of a cluster
+type ComponentSettings struct {
+	Apiserver         *ComponentOverride `json:"apiserver,omitempty"`
+	ControllerManager *ComponentOverride `json:"controllerManager,omitempty"`
+	Scheduler         *ComponentOverride `json:"scheduler,omitempty"`
+	// MachineController holds the settings of the machine-controller running in the seed
+	MachineController *MachineControllerOverride `json:"machineController,omitempty"`
+	// Etcd holds the maintenance settings of the etcd cluster
+	Etcd *EtcdOverride `json:"etcd,omitempty"`
+}
+
+// ComponentOverride defines the settings of a single control plane component
+type ComponentOverride struct {
+	// LogLevel is the log verbosity (-v flag) of the component, in the range 0-10
+	LogLevel *int `json:"logLevel,omitempty"`
+}
+
+// MachineControllerOverride defines the settings of the machine-controller of a cluster
+type MachineControllerOverride struct {
+	// Resources are the resource requests and limits of the machine-controller container
+	Resources *ResourceRequirements `json:"resources,omitempty"`
+}
+
+// EtcdOverride defines the maintenance settings of the etcd cluster of a cluster
+type EtcdOverride struct {
+	// CompactionMode is the auto compaction mode of etcd, either "periodic" or "revision". Defaults to "periodic".
+	CompactionMode string `json:"compactionMode,omitempty"`
+	// DefragSchedule is the cron schedule of the etcd defragmentation, e.g. "0 3 * * *". Defaults to every 3 hours.
+	DefragSchedule string `json:"defragSchedule,omitempty"`
+}
+
+// ResourceRequirements describes the compute resource requests and limits of a container,
+// e.g. {"requests":{"cpu":"100m","memory":"512Mi"}}
+type ResourceRequirements struct {
+	// Requests maps the resource names to the minimum amount of the resource required
+	Requests map[string]string `json:"requests,omitempty"`
+	// Limits maps the resource names to the maximum amount of the resource allowed
+	Limits map[string]string `json:"limits,omitempty"`
 }
 
 // MarshalJSON marshals ClusterSpec object into JSON. It is overwritten to control data
@@ -702,12 +866,32 @@ func (cs *ClusterSpec) MarshalJSON() ([]byte, error) {
 		Cloud                               PublicCloudSpec                        `json:"cloud"`
 		MachineNetworks                     []kubermaticv1.MachineNetworkingConfig `json:"machineNetworks,omitempty"`
 		Version                             ksemver.Semver                         `json:"version"`
-		OIDC                                kubermaticv1.OIDCSettings              `json:"oidc"`
+		OIDC                                PublicOIDCSettings                     `json:"oidc"`
 		UpdateWindow                        *kubermaticv1.UpdateWindow             `json:"updateWindow,omitempty"`
 		UsePodSecurityPolicyAdmissionPlugin bool                                   `json:"usePodSecurityPolicyAdmissionPlugin,omitempty"`
 		UsePodNodeSelectorAdmissionPlugin   bool                                   `json:"usePodNodeSelectorAdmissionPlugin,omitempty"`
 		AuditLogging                        *kubermaticv1.AuditLoggingSettings     `json:"auditLogging,omitempty"`
 		AdmissionPlugins                    []string                               `json:"admissionPlugins,omitempty"`
+		Description                         string                                 `json:"description,omitempty"`
+		AdminGroups                         []string                               `json:"adminGroups,omitempty"`
+		KubeProxy                           *KubeProxySettings                     `json:"kubeProxy,omitempty"`
+		ClusterNetwork                      *ClusterNetworkSettings                `json:"clusterNetwork,omitempty"`
+		ComponentsOverride                  *ComponentSettings                     `json:"componentsOverride,omitempty"`
+		Autoscaler                          *kubermaticv1.AutoscalerSettings       `json:"autoscaler,omitempty"`
+		AddonVersions                       map[string]string                      `json:"addonVersions,omitempty"`
+		ExternalDNS                         *kubermaticv1.ExternalDNSSettings      `json:"externalDNS,omitempty"`
+		EgressAllowlist                     []string                               `json:"egressAllowlist,omitempty"`
+		Connectivity                        kubermaticv1.ClusterConnectivity       `json:"connectivity,omitempty"`
+		DisableNodeSSH                      bool                                   `json:"disableNodeSSH,omitempty"`
+		AlertmanagerConfig                  string                                 `json:"alertmanagerConfig,omitempty"`
+		MaxPodsPerNode                      *int32                                 `json:"maxPodsPerNode,omitempty"`
+		ServiceNodePortRange                string                                 `json:"serviceNodePortRange,omitempty"`
+		RegistryMirror                      *kubermaticv1.RegistryMirrorSettings   `json:"registryMirror,omitempty"`
+		SchedulerConfig                     string                                 `json:"schedulerConfig,omitempty"`
+		DNS                                 *kubermaticv1.DNSSettings              `json:"dns,omitempty"`
+		ExposeStrategy                      corev1.ServiceType                     `json:"exposeStrategy,omitempty"`
+		MetricsServer                       *kubermaticv1.MetricsServerSettings    `json:"metricsServer,omitempty"`
+		LoadBalancerClass                   string                                 `json:"loadBalancerClass,omitempty"`
 	}{
 		Cloud: PublicCloudSpec{
 			DatacenterName: cs.Cloud.DatacenterName,
@@ -726,17 +910,59 @@ func (cs *ClusterSpec) MarshalJSON() ([]byte, error) {
 		},
 		Version:                             cs.Version,
 		MachineNetworks:                     cs.MachineNetworks,
-		OIDC:                                cs.OIDC,
+		OIDC:                                newPublicOIDCSettings(cs.OIDC),
 		UpdateWindow:                        cs.UpdateWindow,
 		UsePodSecurityPolicyAdmissionPlugin: cs.UsePodSecurityPolicyAdmissionPlugin,
 		UsePodNodeSelectorAdmissionPlugin:   cs.UsePodNodeSelectorAdmissionPlugin,
 		AuditLogging:                        cs.AuditLogging,
 		AdmissionPlugins:                    cs.AdmissionPlugins,
+		Description:                         cs.Description,
+		AdminGroups:                         cs.AdminGroups,
+		KubeProxy:                           cs.KubeProxy,
+		ClusterNetwork:                      cs.ClusterNetwork,
+		ComponentsOverride:                  cs.ComponentsOverride,
+		Autoscaler:                          cs.Autoscaler,
+		AddonVersions:                       cs.AddonVersions,
+		ExternalDNS:                         cs.ExternalDNS,
+		EgressAllowlist:                     cs.EgressAllowlist,
+		Connectivity:                        cs.Connectivity,
+		DisableNodeSSH:                      cs.DisableNodeSSH,
+		AlertmanagerConfig:                  cs.AlertmanagerConfig,
+		MaxPodsPerNode:                      cs.MaxPodsPerNode,
+		ServiceNodePortRange:                cs.ServiceNodePortRange,
+		RegistryMirror:                      cs.RegistryMirror,
+		SchedulerConfig:                     cs.SchedulerConfig,
+		DNS:                                 cs.DNS,
+		ExposeStrategy:                      cs.ExposeStrategy,
+		MetricsServer:                       cs.MetricsServer,
+		LoadBalancerClass:                   cs.LoadBalancerClass,
 	})
 
 	return ret, err
 }
 
+// PublicOIDCSettings is a public counterpart of kubermaticv1.OIDCSettings without the client secret.
+// swagger:model PublicOIDCSettings
+type PublicOIDCSettings struct {
+	IssuerURL     string `json:"issuerUrl,omitempty"`
+	ClientID      string `json:"clientId,omitempty"`
+	UsernameClaim string `json:"usernameClaim,omitempty"`
+	GroupsClaim   string `json:"groupsClaim,omitempty"`
+	RequiredClaim string `json:"requiredClaim,omitempty"`
+	ExtraScopes   string `json:"extraScopes,omitempty"`
+}
+
+func newPublicOIDCSettings(internal kubermaticv1.OIDCSettings) PublicOIDCSettings {
+	return PublicOIDCSettings{
+		IssuerURL:     internal.IssuerURL,
+		ClientID:      internal.ClientID,
+		UsernameClaim: internal.UsernameClaim,
+		GroupsClaim:   internal.GroupsClaim,
+		RequiredClaim: internal.RequiredClaim,
+		ExtraScopes:   internal.ExtraScopes,
+	}
+}
+
 // PublicCloudSpec is a public counterpart of apiv1.CloudSpec.
 // swagger:model PublicCloudSpec
 type PublicCloudSpec struct {
@@ -912,6 +1138,20 @@ type ClusterStatus struct {
 
 	// URL specifies the address at which the cluster is available
 	URL string `json:"url"`
+
+	// LastBackupTime is the completion time of the most recent successful etcd backup.
+	// It is not set when the cluster has not been backed up yet.
+	LastBackupTime *Time `json:"lastBackupTime,omitempty"`
+
+	// LastReconcileTime is the time the controller last finished reconciling the cluster, the value is refreshed
+	// in intervals of a few minutes. It is not set when the cluster has not been reconciled yet.
+	LastReconcileTime *Time `json:"lastReconcileTime,omitempty"`
+
+	// LastReconcileDuration is the duration of the reconciliation finished at LastReconcileTime, e.g. "1.5s"
+	LastReconcileDuration string `json:"lastReconcileDuration,omitempty"`
+
+	// Reconciling is true while the changes to the cluster spec are still being applied to the control plane
+	Reconciling bool `json:"reconciling,omitempty"`
 }
 
 // ClusterHealth stores health information about the cluster's components.
@@ -924,6 +1164,46 @@ type ClusterHealth struct {
 	Etcd                         kubermaticv1.HealthStatus `json:"etcd"`
 	CloudProviderInfrastructure  kubermaticv1.HealthStatus `json:"cloudProviderInfrastructure"`
 	UserClusterControllerManager kubermaticv1.HealthStatus `json:"userClusterControllerManager"`
+
+	// Status is the overall health of the cluster, one of "Healthy", "Degraded" or "Unhealthy"
+	Status ClusterHealthStatus `json:"status"`
+
+	// Details explain the health status of the components, keyed by the names of the fields above.
+	// They are only returned when the detailed health is requested.
+	Details map[string]ComponentHealthDetails `json:"details,omitempty"`
+}
+
+// ClusterHealthStatus is the overall health of a cluster
+// swagger:model ClusterHealthStatus
+type ClusterHealthStatus string
+
+const (
+	// ClusterHealthStatusHealthy means that all components of the cluster are up
+	ClusterHealthStatusHealthy ClusterHealthStatus = "Healthy"
+	// ClusterHealthStatusDegraded means that the apiserver and etcd are up, but another component is not
+	ClusterHealthStatusDegraded ClusterHealthStatus = "Degraded"
+	// ClusterHealthStatusUnhealthy means that the apiserver or etcd is down
+	ClusterHealthStatusUnhealthy ClusterHealthStatus = "Unhealthy"
+)
+
+// ComponentHealthDetails explains the health status of a cluster component
+// swagger:model ComponentHealthDetails
+type ComponentHealthDetails struct {
+	// Reason is a human readable explanation of the health status
+	Reason string `json:"reason"`
+	// LastTransitionTime is the last time the condition related to the component changed
+	LastTransitionTime *Time `json:"lastTransitionTime,omitempty"`
+	// Replicas are the configured and the ready replicas of the component, only set for the apiserver
+	Replicas *ComponentReplicas `json:"replicas,omitempty"`
+}
+
+// ComponentReplicas are the configured and the ready replicas of a control plane component
+// swagger:model ComponentReplicas
+type ComponentReplicas struct {
+	// Desired is the number of replicas configured for the component
+	Desired int32 `json:"desired"`
+	// Ready is the number of replicas of the component which are ready
+	Ready int32 `json:"ready"`
 }
 
 // AccessibleAddons represents an array of addons that can be configured in the user clusters.
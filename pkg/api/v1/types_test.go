@@ -132,6 +132,18 @@ func TestNewClusterSpec_MarshalJSON(t *testing.T) {
 				},
 			},
 		},
+		{
+			"case 7: filter client secret from OIDC",
+			ClusterSpec{
+				Version: *semver.NewSemverOrDie("1.2.3"),
+				OIDC: kubermaticv1.OIDCSettings{
+					IssuerURL:    "https://dex.example.com",
+					ClientID:     "kubernetes",
+					ClientSecret: valueToBeFiltered,
+					GroupsClaim:  "groups",
+				},
+			},
+		},
 	}
 
 	for _, c := range cases {
@@ -16,7 +16,14 @@ limitations under the License.
 
 package v2
 
-import "github.com/open-policy-agent/frameworks/constraint/pkg/apis/templates/v1beta1"
+import (
+	"github.com/open-policy-agent/frameworks/constraint/pkg/apis/templates/v1beta1"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+)
 
 // ConstraintTemplate represents a gatekeeper ConstraintTemplate
 // swagger:model ConstraintTemplate
@@ -26,3 +33,509 @@ type ConstraintTemplate struct {
 	Spec   v1beta1.ConstraintTemplateSpec   `json:"spec"`
 	Status v1beta1.ConstraintTemplateStatus `json:"status"`
 }
+
+// ConstraintTemplateList represents a page of the constraint templates ordered by name
+// swagger:model ConstraintTemplateList
+type ConstraintTemplateList struct {
+	Items []*ConstraintTemplate `json:"items"`
+	// TotalCount is the number of all constraint templates
+	TotalCount int `json:"totalCount"`
+}
+
+// ConstraintTemplateAssignment lists the clusters a constraint template shall be synced to
+// swagger:model ConstraintTemplateAssignment
+type ConstraintTemplateAssignment struct {
+	// ClusterIDs are the IDs of the clusters to sync the constraint template to
+	ClusterIDs []string `json:"clusterIDs,omitempty"`
+	// All syncs the constraint template to all clusters, the cluster IDs must not be set then
+	All bool `json:"all,omitempty"`
+}
+
+// ConstraintTemplateAssignmentStatus is the outcome of syncing a constraint template to a single cluster
+// swagger:model ConstraintTemplateAssignmentStatus
+type ConstraintTemplateAssignmentStatus struct {
+	ClusterID string `json:"clusterID"`
+	// Status is one of "assigned", "skipped" or "failed"
+	Status ConstraintTemplateAssignmentResult `json:"status"`
+	// Message explains why the constraint template was not synced to the cluster
+	Message string `json:"message,omitempty"`
+}
+
+// ConstraintTemplateAssignmentResult is the result of syncing a constraint template to a cluster
+type ConstraintTemplateAssignmentResult string
+
+const (
+	ConstraintTemplateAssigned          ConstraintTemplateAssignmentResult = "assigned"
+	ConstraintTemplateAssignmentSkipped ConstraintTemplateAssignmentResult = "skipped"
+	ConstraintTemplateAssignmentFailed  ConstraintTemplateAssignmentResult = "failed"
+)
+
+// Constraint represents a gatekeeper Constraint of a cluster
+// swagger:model Constraint
+type Constraint struct {
+	Name string `json:"name"`
+
+	Spec kubermaticv1.ConstraintSpec `json:"spec"`
+}
+
+// MachineDeploymentNode represents a node that belongs to a machine deployment
+// swagger:model MachineDeploymentNode
+type MachineDeploymentNode struct {
+	Name string `json:"name"`
+	// Version is the kubelet version reported by the node
+	Version    string          `json:"version"`
+	Conditions []NodeCondition `json:"conditions"`
+}
+
+// NodeCondition represents the state of a single node condition
+// swagger:model NodeCondition
+type NodeCondition struct {
+	Type    string `json:"type"`
+	Status  string `json:"status"`
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// InstanceTypeList represents the node instance types available for a cluster.
+// Only the field matching the cloud provider of the cluster is set.
+// swagger:model InstanceTypeList
+type InstanceTypeList struct {
+	AWS          apiv1.AWSSizeList             `json:"aws,omitempty"`
+	Alibaba      apiv1.AlibabaInstanceTypeList `json:"alibaba,omitempty"`
+	Azure        apiv1.AzureSizeList           `json:"azure,omitempty"`
+	Digitalocean *apiv1.DigitaloceanSizeList   `json:"digitalocean,omitempty"`
+	GCP          apiv1.GCPMachineSizeList      `json:"gcp,omitempty"`
+	Hetzner      *apiv1.HetznerSizeList        `json:"hetzner,omitempty"`
+	Openstack    []apiv1.OpenstackSize         `json:"openstack,omitempty"`
+	Packet       apiv1.PacketSizeList          `json:"packet,omitempty"`
+	VSphere      []VSphereSize                 `json:"vsphere,omitempty"`
+	Kubevirt     []KubevirtSize                `json:"kubevirt,omitempty"`
+}
+
+// VSphereSize is a predefined size of a vSphere node, vSphere has no instance types of its own
+// swagger:model VSphereSize
+type VSphereSize struct {
+	Name string `json:"name"`
+	CPUs int    `json:"cpus"`
+	// Memory in MB
+	Memory int `json:"memory"`
+}
+
+// KubevirtSize is a predefined size of a KubeVirt node, KubeVirt has no instance types of its own
+// swagger:model KubevirtSize
+type KubevirtSize struct {
+	Name string `json:"name"`
+	CPUs string `json:"cpus"`
+	// Memory as a Kubernetes quantity, e.g. 4Gi
+	Memory string `json:"memory"`
+}
+
+// ClusterCertificate represents a certificate of the cluster control plane
+// swagger:model ClusterCertificate
+type ClusterCertificate struct {
+	// Name of the certificate, one of "ca", "apiserver", "etcd" or "apiserver-etcd-client"
+	Name string `json:"name"`
+	// Expiry is the date after which the certificate is no longer valid
+	Expiry apiv1.Time `json:"expiry"`
+}
+
+// SchedulerConfig is the scheduler configuration of a cluster
+// swagger:model SchedulerConfig
+type SchedulerConfig struct {
+	// Config is the KubeSchedulerConfiguration of the cluster in YAML or JSON format, empty when the scheduler uses its defaults
+	Config string `json:"config"`
+}
+
+// JoinCommand is the command joining a node to a cluster manually
+// swagger:model JoinCommand
+type JoinCommand struct {
+	// Command is the kubeadm join command to run on the node, it contains a bootstrap token
+	Command string `json:"command"`
+	// Expiration is the date after which the bootstrap token of the command can no longer be used
+	Expiration apiv1.Time `json:"expiration"`
+}
+
+// NodeCountSample is the number of nodes of a cluster at a point in time
+// swagger:model NodeCountSample
+type NodeCountSample struct {
+	Timestamp apiv1.Time `json:"timestamp"`
+	Count     int        `json:"count"`
+}
+
+// WebhookDiagnostic is the result of probing an admission webhook registered in a cluster
+// swagger:model WebhookDiagnostic
+type WebhookDiagnostic struct {
+	// Configuration is the name of the webhook configuration the webhook belongs to
+	Configuration string `json:"configuration"`
+	// Name of the webhook
+	Name string `json:"name"`
+	// Type of the webhook, either "validating" or "mutating"
+	Type string `json:"type"`
+	// Endpoint is the URL the API server calls the webhook at
+	Endpoint string `json:"endpoint"`
+	// FailurePolicy of the webhook, an unreachable webhook with the "Fail" policy blocks the requests it intercepts
+	FailurePolicy string `json:"failurePolicy,omitempty"`
+	// Probed is false for webhooks called by URL, only webhooks backed by a service are probed through the API server of the cluster
+	Probed bool `json:"probed"`
+	// Reachable is false when the webhook did not answer within its timeout
+	Reachable bool `json:"reachable"`
+	// LatencyMilliseconds is the time it took to probe the webhook
+	LatencyMilliseconds int64 `json:"latencyMilliseconds"`
+	// Error describes why the webhook is not reachable
+	Error string `json:"error,omitempty"`
+}
+
+// OPAStatus represents the status of the Gatekeeper webhook of a cluster
+// swagger:model OPAStatus
+type OPAStatus struct {
+	// WebhookPods is the number of running Gatekeeper webhook pods the metrics were collected from
+	WebhookPods int `json:"webhookPods"`
+	// WebhookRequests is the number of admission requests evaluated by the Gatekeeper webhook since its pods started
+	WebhookRequests int64 `json:"webhookRequests"`
+	// AverageWebhookLatencyMilliseconds is the average time the Gatekeeper webhook took to evaluate an admission request
+	AverageWebhookLatencyMilliseconds float64 `json:"averageWebhookLatencyMilliseconds"`
+}
+
+// AdmissionCheck is the result of a dry-run admission of a Kubernetes object in a cluster
+// swagger:model AdmissionCheck
+type AdmissionCheck struct {
+	// Admitted is true when the object passed the validation and all admission plugins of the cluster
+	Admitted bool `json:"admitted"`
+	// Reason why the object was denied
+	Reason string `json:"reason,omitempty"`
+	// Constraints are the names of the Gatekeeper constraints which denied the object
+	Constraints []string `json:"constraints,omitempty"`
+}
+
+// APFConfiguration is the API priority and fairness configuration of a cluster
+// swagger:model APFConfiguration
+type APFConfiguration struct {
+	// FlowSchemas in the order the API server evaluates them
+	FlowSchemas []APFFlowSchema `json:"flowSchemas"`
+	// PriorityLevels sorted by name
+	PriorityLevels []APFPriorityLevel `json:"priorityLevels"`
+}
+
+// APFFlowSchema classifies requests into a priority level
+// swagger:model APFFlowSchema
+type APFFlowSchema struct {
+	Name string `json:"name"`
+	// PriorityLevel is the name of the priority level the matching requests are assigned to
+	PriorityLevel string `json:"priorityLevel"`
+	// MatchingPrecedence of the flow schema, the schema with the lowest value matching a request wins
+	MatchingPrecedence int32 `json:"matchingPrecedence"`
+	// DistinguisherMethod is either "ByUser" or "ByNamespace", requests are not split into flows when it is empty
+	DistinguisherMethod string `json:"distinguisherMethod,omitempty"`
+	// Rules is the number of rules of the flow schema
+	Rules int `json:"rules"`
+}
+
+// APFPriorityLevel limits the concurrency of the requests assigned to it
+// swagger:model APFPriorityLevel
+type APFPriorityLevel struct {
+	Name string `json:"name"`
+	// Type is either "Exempt" or "Limited"
+	Type string `json:"type"`
+	// AssuredConcurrencyShares is the share of the concurrency limit of the API server reserved for a limited priority level
+	AssuredConcurrencyShares int32 `json:"assuredConcurrencyShares,omitempty"`
+	// LimitResponse is either "Queue" or "Reject" and defines what happens with requests exceeding the limit
+	LimitResponse string `json:"limitResponse,omitempty"`
+	// Queues, HandSize and QueueLengthLimit configure the queuing of the "Queue" limit response
+	Queues           int32 `json:"queues,omitempty"`
+	HandSize         int32 `json:"handSize,omitempty"`
+	QueueLengthLimit int32 `json:"queueLengthLimit,omitempty"`
+}
+
+// ClusterComponentVersion represents the image of a control plane component of the cluster
+// swagger:model ClusterComponentVersion
+type ClusterComponentVersion struct {
+	// Name of the component, one of "apiserver", "controller-manager", "scheduler", "etcd" or "machine-controller"
+	Name string `json:"name"`
+	// Image is the full image reference the component is running
+	Image string `json:"image"`
+	// Version is the tag or digest of the image. It is empty when the image has neither.
+	Version string `json:"version,omitempty"`
+}
+
+// ControlPlanePodDisruptionBudget represents the status of a PodDisruptionBudget protecting a control plane component
+// swagger:model ControlPlanePodDisruptionBudget
+type ControlPlanePodDisruptionBudget struct {
+	// Name of the PodDisruptionBudget, either "apiserver" or "etcd"
+	Name string `json:"name"`
+	// CurrentHealthy is the number of healthy pods
+	CurrentHealthy int32 `json:"currentHealthy"`
+	// DesiredHealthy is the minimum number of healthy pods the budget requires
+	DesiredHealthy int32 `json:"desiredHealthy"`
+	// ExpectedPods is the total number of pods counted by the budget
+	ExpectedPods int32 `json:"expectedPods"`
+	// DisruptionsAllowed is the number of pods which can be evicted right now
+	DisruptionsAllowed int32 `json:"disruptionsAllowed"`
+}
+
+// ControlPlanePodRestarts represents the restarts of the container of a control plane component in one of its pods
+// swagger:model ControlPlanePodRestarts
+type ControlPlanePodRestarts struct {
+	// Component is the name of the control plane component, one of "apiserver", "etcd" or "controller-manager"
+	Component string `json:"component"`
+	// Pod is the name of the pod in the seed
+	Pod string `json:"pod"`
+	// RestartCount is the number of times the container of the component was restarted
+	RestartCount int32 `json:"restartCount"`
+	// CrashLooping is true when the container is waiting to be restarted after crashing repeatedly
+	CrashLooping bool `json:"crashLooping"`
+	// Reason is why the container was last terminated, e.g. "Error" or "OOMKilled"
+	Reason string `json:"reason,omitempty"`
+	// ExitCode is the exit code of the last termination of the container
+	ExitCode int32 `json:"exitCode,omitempty"`
+	// LastRestart is the time the container was last terminated
+	LastRestart *apiv1.Time `json:"lastRestart,omitempty"`
+}
+
+// ClusterFeatureGates represents the feature gates enabled or disabled on the components of the cluster,
+// keyed by the name of the feature gate. Gates which are not set explicitly use the Kubernetes defaults
+// and are omitted.
+// swagger:model ClusterFeatureGates
+type ClusterFeatureGates struct {
+	Apiserver         map[string]bool `json:"apiserver,omitempty"`
+	ControllerManager map[string]bool `json:"controllerManager,omitempty"`
+	Scheduler         map[string]bool `json:"scheduler,omitempty"`
+	Kubelet           map[string]bool `json:"kubelet,omitempty"`
+}
+
+// NodeDrainStatus represents the progress of draining a node
+// swagger:model NodeDrainStatus
+type NodeDrainStatus struct {
+	// Cordoned is true when no new pods are scheduled to the node
+	Cordoned bool `json:"cordoned"`
+	// RemainingPods are the pods which still have to be evicted from the node, as "namespace/name"
+	RemainingPods []string `json:"remainingPods"`
+	// Completed is true when the node is cordoned and all pods were evicted
+	Completed bool `json:"completed"`
+}
+
+// RawCluster is the cluster object as it is stored in the seed cluster
+// swagger:model RawCluster
+type RawCluster struct {
+	kubermaticv1.Cluster
+}
+
+// SecurityAdvisory represents a known vulnerability affecting the Kubernetes version of a cluster
+// swagger:model SecurityAdvisory
+type SecurityAdvisory struct {
+	// ID is the identifier of the advisory, e.g. CVE-2020-8558
+	ID          string `json:"id"`
+	Description string `json:"description,omitempty"`
+	URL         string `json:"url,omitempty"`
+	// AffectedVersions is the range of Kubernetes versions affected by the advisory
+	AffectedVersions string `json:"affectedVersions"`
+}
+
+// ClusterAccess represents the permissions the requesting user has in a user cluster
+// swagger:model ClusterAccess
+type ClusterAccess struct {
+	// Namespace the permissions were evaluated in. Cluster-wide permissions are included as well.
+	Namespace string `json:"namespace"`
+	// ResourceRules is the list of actions the user can perform on resources
+	ResourceRules []authorizationv1.ResourceRule `json:"resourceRules"`
+	// NonResourceRules is the list of actions the user can perform on non-resource URLs
+	NonResourceRules []authorizationv1.NonResourceRule `json:"nonResourceRules"`
+	// Incomplete is true when the list of rules is not complete, e.g. because the cluster
+	// uses an authorizer which doesn't support rules evaluation
+	Incomplete bool `json:"incomplete"`
+}
+
+// ClusterAutoscaler represents the cluster-autoscaler configuration and status of a cluster
+// swagger:model ClusterAutoscaler
+type ClusterAutoscaler struct {
+	Enabled bool `json:"enabled"`
+	// NodeGroups are the machine deployments the cluster-autoscaler is allowed to scale
+	NodeGroups []ClusterAutoscalerNodeGroup `json:"nodeGroups"`
+	// Events are the most recent events emitted by the cluster-autoscaler, newest first
+	Events []apiv1.Event `json:"events"`
+}
+
+// ClusterAutoscalerNodeGroup represents a machine deployment managed by the cluster-autoscaler
+// swagger:model ClusterAutoscalerNodeGroup
+type ClusterAutoscalerNodeGroup struct {
+	// Name of the machine deployment
+	Name        string `json:"name"`
+	MinReplicas int    `json:"minReplicas"`
+	MaxReplicas int    `json:"maxReplicas"`
+	// Replicas is the current number of replicas of the machine deployment
+	Replicas int32 `json:"replicas"`
+}
+
+// ClusterCredentialReference represents the seed secret holding the cloud provider credentials of a cluster
+// swagger:model ClusterCredentialReference
+type ClusterCredentialReference struct {
+	// Name of the credential secret
+	Name string `json:"name"`
+	// Namespace of the credential secret
+	Namespace string `json:"namespace"`
+}
+
+// ClusterNetworkUsage represents the number of allocated pod and service IPs of a cluster
+// swagger:model ClusterNetworkUsage
+type ClusterNetworkUsage struct {
+	Pods     NetworkUsage `json:"pods"`
+	Services NetworkUsage `json:"services"`
+}
+
+// NetworkUsage represents the number of allocated IPs of a cluster network
+// swagger:model NetworkUsage
+type NetworkUsage struct {
+	// CIDRBlocks of the network
+	CIDRBlocks []string `json:"cidrBlocks"`
+	// Allocated is the number of IPs which are currently in use
+	Allocated int `json:"allocated"`
+	// Capacity is the number of IPs in the CIDR blocks of the network
+	Capacity int64 `json:"capacity"`
+}
+
+// NodeDensity represents the number of pods and the resources requested on a node of a cluster
+// swagger:model NodeDensity
+type NodeDensity struct {
+	// Name of the node
+	Name string `json:"name"`
+	// Pods is the number of pods on the node, finished pods are not counted
+	Pods int `json:"pods"`
+	// MaxPods is the number of pods the node can run
+	MaxPods int64 `json:"maxPods"`
+	// Requested are the CPU and memory requested by the pods on the node
+	Requested apiv1.NodeResources `json:"requested"`
+	// Allocatable are the CPU and memory of the node which can be requested by pods
+	Allocatable apiv1.NodeResources `json:"allocatable"`
+}
+
+// ClusterVolume represents a PersistentVolume of a cluster
+// swagger:model ClusterVolume
+type ClusterVolume struct {
+	// Name of the PersistentVolume
+	Name string `json:"name"`
+	// Capacity of the volume, e.g. "10Gi"
+	Capacity string `json:"capacity,omitempty"`
+	// StorageClass the volume belongs to
+	StorageClass string `json:"storageClass,omitempty"`
+	// Phase of the volume, e.g. "Bound"
+	Phase string `json:"phase,omitempty"`
+	// Claim is the namespaced name of the PersistentVolumeClaim the volume is bound to
+	Claim string `json:"claim,omitempty"`
+	// DiskID identifies the cloud disk backing the volume. It is empty when the volume is not
+	// backed by a known cloud disk.
+	DiskID string `json:"diskID,omitempty"`
+}
+
+// ClusterDescription combines the cluster with its health, node summary and recent events.
+// Sections which could not be fetched, e.g. because the cluster is not reachable, are null
+// and the reason is listed in Errors.
+// swagger:model ClusterDescription
+type ClusterDescription struct {
+	Cluster *apiv1.Cluster       `json:"cluster"`
+	Health  *apiv1.ClusterHealth `json:"health"`
+	Nodes   *ClusterNodeSummary  `json:"nodes"`
+	// Events are the most recent events of the cluster, newest first
+	Events []apiv1.Event `json:"events"`
+	// Errors maps the name of every section which could not be fetched to the reason
+	Errors map[string]string `json:"errors,omitempty"`
+}
+
+// ClusterNodeSummary represents the number of nodes of a cluster
+// swagger:model ClusterNodeSummary
+type ClusterNodeSummary struct {
+	// Total is the number of nodes which joined the cluster
+	Total int `json:"total"`
+	// Ready is the number of nodes with the Ready condition
+	Ready int `json:"ready"`
+}
+
+// ProjectMachineDeploymentList represents the machine deployments of all clusters of a project
+// swagger:model ProjectMachineDeploymentList
+type ProjectMachineDeploymentList struct {
+	MachineDeployments []ProjectMachineDeployment `json:"machineDeployments"`
+	// UnreachableClusters maps the IDs of the clusters whose machine deployments could not be listed to the reason
+	UnreachableClusters map[string]string `json:"unreachableClusters,omitempty"`
+}
+
+// ProjectMachineDeployment represents a machine deployment of one of the clusters of a project
+// swagger:model ProjectMachineDeployment
+type ProjectMachineDeployment struct {
+	// ClusterID is the ID of the cluster the machine deployment belongs to
+	ClusterID string `json:"clusterID"`
+	// Name of the machine deployment
+	Name string `json:"name"`
+	// Replicas is the desired number of replicas
+	Replicas int32 `json:"replicas"`
+	// ReadyReplicas is the number of replicas with a ready node
+	ReadyReplicas int32 `json:"readyReplicas"`
+	// Version of the kubelet of the replicas
+	Version string `json:"version"`
+}
+
+// ClusterDeletion lists the clusters which shall be deleted
+// swagger:model ClusterDeletion
+type ClusterDeletion struct {
+	// ClusterIDs are the IDs of the clusters to delete, at most 100 clusters can be deleted at once
+	ClusterIDs []string `json:"clusterIDs"`
+}
+
+// ClusterDeletionStatus is the outcome of the deletion of a single cluster
+// swagger:model ClusterDeletionStatus
+type ClusterDeletionStatus struct {
+	ClusterID string `json:"clusterID"`
+	// Status is one of "deleted", "not-found", "forbidden" or "failed"
+	Status ClusterDeletionResult `json:"status"`
+	// Message explains why the cluster was not deleted
+	Message string `json:"message,omitempty"`
+}
+
+// ClusterDeletionResult is the result of the deletion of a cluster
+type ClusterDeletionResult string
+
+const (
+	ClusterDeleted           ClusterDeletionResult = "deleted"
+	ClusterDeletionNotFound  ClusterDeletionResult = "not-found"
+	ClusterDeletionForbidden ClusterDeletionResult = "forbidden"
+	ClusterDeletionFailed    ClusterDeletionResult = "failed"
+)
+
+// ClusterDrift tells which parts of the cluster are not in sync with its spec yet and why
+// swagger:model ClusterDrift
+type ClusterDrift struct {
+	// InSync is true when all the parts of the cluster match the spec
+	InSync bool `json:"inSync"`
+	// OutOfSync lists the parts of the cluster which are still being reconciled
+	OutOfSync []ResourceDrift `json:"outOfSync,omitempty"`
+}
+
+// ResourceDrift explains why a part of the cluster is not in sync with its spec
+// swagger:model ResourceDrift
+type ResourceDrift struct {
+	// Resource is one of "apiserver", "etcd" or "addon"
+	Resource string `json:"resource"`
+	// Name of the addon, empty for the other resources
+	Name string `json:"name,omitempty"`
+	// Reason tells why the resource is not in sync
+	Reason string `json:"reason"`
+}
+
+// MachineDeploymentRolloutStatus tells how far the rollout of a machine deployment has progressed
+// swagger:model MachineDeploymentRolloutStatus
+type MachineDeploymentRolloutStatus struct {
+	// Replicas is the desired number of replicas
+	Replicas int32 `json:"replicas"`
+	// UpdatedReplicas is the number of replicas which already run the current spec
+	UpdatedReplicas int32 `json:"updatedReplicas"`
+	// AvailableReplicas is the number of replicas which are available
+	AvailableReplicas int32 `json:"availableReplicas"`
+	// Phase is one of "Progressing", "Complete" or "Paused"
+	Phase MachineDeploymentRolloutPhase `json:"phase"`
+}
+
+// MachineDeploymentRolloutPhase is the phase of the rollout of a machine deployment
+type MachineDeploymentRolloutPhase string
+
+const (
+	MachineDeploymentRolloutProgressing MachineDeploymentRolloutPhase = "Progressing"
+	MachineDeploymentRolloutComplete    MachineDeploymentRolloutPhase = "Complete"
+	MachineDeploymentRolloutPaused      MachineDeploymentRolloutPhase = "Paused"
+)
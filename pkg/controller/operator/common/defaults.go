@@ -818,10 +818,24 @@ func CreateVersionsYAML(config *operatorv1alpha1.KubermaticVersionsConfiguration
 
 	appendOrchestrator := func(cfg *operatorv1alpha1.KubermaticVersioningConfiguration, kind string) {
 		for _, v := range cfg.Versions {
+			deprecated := false
+			for _, d := range cfg.Deprecated {
+				if v.Equal(d) {
+					deprecated = true
+				}
+			}
+			endOfLife := false
+			for _, e := range cfg.EndOfLife {
+				if v.Equal(e) {
+					endOfLife = true
+				}
+			}
 			output.Versions = append(output.Versions, &version.Version{
-				Version: v,
-				Default: v.Equal(cfg.Default),
-				Type:    kind,
+				Version:    v,
+				Default:    v.Equal(cfg.Default),
+				Type:       kind,
+				Deprecated: deprecated,
+				EndOfLife:  endOfLife,
 			})
 		}
 	}
@@ -343,7 +343,14 @@ func (r *Reconciler) getAddonManifests(log *zap.SugaredLogger, addon *kubermatic
 	}
 
 	manifestPath := path.Join(addonDir, addon.Spec.Name)
-	allManifests, err := addonutils.ParseFromFolder(log, r.overwriteRegistry, manifestPath, data)
+	if version := cluster.Spec.AddonVersions[addon.Spec.Name]; version != "" {
+		manifestPath = path.Join(manifestPath, version)
+	}
+	overwriteRegistry := r.overwriteRegistry
+	if cluster.Spec.RegistryMirror != nil {
+		overwriteRegistry = cluster.Spec.RegistryMirror.ImageRegistry()
+	}
+	allManifests, err := addonutils.ParseFromFolder(log, overwriteRegistry, manifestPath, data)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse addon templates in %s: %v", manifestPath, err)
 	}
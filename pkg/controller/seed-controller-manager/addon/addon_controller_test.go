@@ -319,6 +319,49 @@ func TestController_getAddonDeploymentManifestsDefault(t *testing.T) {
 	}
 }
 
+func TestController_getAddonDeploymentManifestsPinnedVersion(t *testing.T) {
+	cluster := setupTestCluster("10.240.16.0/20")
+	cluster.Spec.AddonVersions = map[string]string{"test": "v2"}
+	addon := setupTestAddon("test")
+
+	addonDir, err := ioutil.TempDir("/tmp", "kubermatic-tests-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(addonDir)
+
+	if err := os.MkdirAll(path.Join(addonDir, addon.Spec.Name, "v2"), 0777); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path.Join(addonDir, addon.Spec.Name, "testManifest.yaml"), []byte(testManifest1WithDeployment), 0644); err != nil {
+		t.Fatal(err)
+	}
+	pinnedManifest := strings.Replace(testManifest1WithDeployment, "test:1.2.3", "test:2.0.0", 1)
+	if err := ioutil.WriteFile(path.Join(addonDir, addon.Spec.Name, "v2", "testManifest.yaml"), []byte(pinnedManifest), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	log := kubermaticlog.New(true, kubermaticlog.FormatConsole).Sugar()
+
+	controller := &Reconciler{
+		kubernetesAddonDir: addonDir,
+		KubeconfigProvider: &fakeKubeconfigProvider{},
+	}
+	manifests, err := controller.getAddonManifests(log, addon, cluster)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(manifests) != 1 {
+		t.Fatalf("invalid number of manifests returned. Expected 1, Got %d", len(manifests))
+	}
+
+	expectedImage := "foo.io/test:2.0.0"
+	if !strings.Contains(string(manifests[0].Raw), expectedImage) {
+		t.Fatalf("invalid image returned. Expected \n%s, Got \n%s", expectedImage, manifests[0].String())
+	}
+}
+
 func TestController_getAddonManifests(t *testing.T) {
 	cluster := setupTestCluster("10.240.16.0/20")
 	addon := setupTestAddon("test")
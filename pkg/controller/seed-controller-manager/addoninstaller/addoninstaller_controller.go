@@ -18,6 +18,7 @@ package addoninstaller
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"strconv"
@@ -47,6 +48,12 @@ import (
 const (
 	ControllerName  = "kubermatic_addoninstaller_controller"
 	addonDefaultKey = ".spec.isDefault"
+	// externalDNSAddonName is the addon installed for clusters with external DNS settings
+	externalDNSAddonName = "external-dns"
+	// egressAllowlistAddonName is the addon installed for clusters with an egress allowlist
+	egressAllowlistAddonName = "egress-allowlist"
+	// metricsServerAddonName is the addon toggled by the metrics server settings of a cluster
+	metricsServerAddonName = "metrics-server"
 )
 
 type Reconciler struct {
@@ -164,6 +171,24 @@ func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, clus
 	} else {
 		log = log.With("clustertype", "kubernetes")
 		addonsToInstall = r.kubernetesAddons.DeepCopy()
+
+		if cluster.Spec.ExternalDNS != nil {
+			addon, err := addonWithVariables(externalDNSAddonName, cluster.Spec.ExternalDNS)
+			if err != nil {
+				return nil, err
+			}
+			addonsToInstall.Items = append(addonsToInstall.Items, *addon)
+		}
+		if len(cluster.Spec.EgressAllowlist) > 0 {
+			addon, err := addonWithVariables(egressAllowlistAddonName, map[string][]string{"cidrs": cluster.Spec.EgressAllowlist})
+			if err != nil {
+				return nil, err
+			}
+			addonsToInstall.Items = append(addonsToInstall.Items, *addon)
+		}
+		if cluster.Spec.MetricsServer != nil {
+			addonsToInstall.Items = withMetricsServerAddon(addonsToInstall.Items, cluster.Spec.MetricsServer.Enabled)
+		}
 	}
 
 	// Wait until the Apiserver is running to ensure the namespace exists at least.
@@ -176,6 +201,41 @@ func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, clus
 	return nil, r.ensureAddons(ctx, log, cluster, *addonsToInstall)
 }
 
+// addonWithVariables returns the addon with the given name, the variables are passed to the addon manifests
+func addonWithVariables(name string, variables interface{}) (*kubermaticv1.Addon, error) {
+	raw, err := json.Marshal(variables)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode the variables of addon %q: %v", name, err)
+	}
+	return &kubermaticv1.Addon{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: kubermaticv1.AddonSpec{
+			Variables: runtime.RawExtension{Raw: raw},
+		},
+	}, nil
+}
+
+// withMetricsServerAddon adds the metrics-server addon to the given addons when enabled and removes it otherwise
+func withMetricsServerAddon(addons []kubermaticv1.Addon, enabled bool) []kubermaticv1.Addon {
+	result := make([]kubermaticv1.Addon, 0, len(addons)+1)
+	found := false
+	for _, addon := range addons {
+		if addon.Name == metricsServerAddonName {
+			if !enabled {
+				continue
+			}
+			found = true
+		}
+		result = append(result, addon)
+	}
+	if enabled && !found {
+		result = append(result, kubermaticv1.Addon{ObjectMeta: metav1.ObjectMeta{Name: metricsServerAddonName}})
+	}
+	return result
+}
+
 func (r *Reconciler) ensureAddons(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster, addons kubermaticv1.AddonList) error {
 	ensuredAddonsMap := map[string]struct{}{}
 	for _, addon := range addons.Items {
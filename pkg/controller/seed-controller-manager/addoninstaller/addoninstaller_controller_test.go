@@ -130,6 +130,155 @@ func TestCreateAddon(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "successfully created the external-dns addon",
+			expectedClusterAddons: []*kubermaticv1.Addon{
+				{
+					TypeMeta: metav1.TypeMeta{
+						APIVersion: "kubermatic.k8s.io/v1",
+						Kind:       "Addon",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            "external-dns",
+						Namespace:       "cluster-" + name,
+						ResourceVersion: "1",
+						OwnerReferences: []metav1.OwnerReference{
+							{
+								APIVersion:         "kubermatic.k8s.io/v1",
+								Kind:               "Cluster",
+								Name:               name,
+								Controller:         truePtr(),
+								BlockOwnerDeletion: truePtr(),
+							},
+						},
+					},
+					Spec: kubermaticv1.AddonSpec{
+						Name: "external-dns",
+						Cluster: corev1.ObjectReference{
+							Kind: "Cluster",
+							Name: name,
+						},
+						Variables: runtime.RawExtension{Raw: []byte(`{"provider":"aws","zone":"example.com"}`)},
+						IsDefault: true,
+					},
+				},
+			},
+			cluster: &kubermaticv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: name,
+				},
+				Spec: kubermaticv1.ClusterSpec{
+					ExternalDNS: &kubermaticv1.ExternalDNSSettings{
+						Provider: "aws",
+						Zone:     "example.com",
+					},
+				},
+				Address: kubermaticv1.ClusterAddress{},
+				Status: kubermaticv1.ClusterStatus{
+					ExtendedHealth: kubermaticv1.ExtendedClusterHealth{
+						Apiserver: kubermaticv1.HealthStatusUp,
+					},
+					NamespaceName: "cluster-" + name,
+				},
+			},
+		},
+		{
+			name: "successfully created the egress-allowlist addon",
+			expectedClusterAddons: []*kubermaticv1.Addon{
+				{
+					TypeMeta: metav1.TypeMeta{
+						APIVersion: "kubermatic.k8s.io/v1",
+						Kind:       "Addon",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            "egress-allowlist",
+						Namespace:       "cluster-" + name,
+						ResourceVersion: "1",
+						OwnerReferences: []metav1.OwnerReference{
+							{
+								APIVersion:         "kubermatic.k8s.io/v1",
+								Kind:               "Cluster",
+								Name:               name,
+								Controller:         truePtr(),
+								BlockOwnerDeletion: truePtr(),
+							},
+						},
+					},
+					Spec: kubermaticv1.AddonSpec{
+						Name: "egress-allowlist",
+						Cluster: corev1.ObjectReference{
+							Kind: "Cluster",
+							Name: name,
+						},
+						Variables: runtime.RawExtension{Raw: []byte(`{"cidrs":["10.0.0.0/8","192.168.1.0/24"]}`)},
+						IsDefault: true,
+					},
+				},
+			},
+			cluster: &kubermaticv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: name,
+				},
+				Spec: kubermaticv1.ClusterSpec{
+					EgressAllowlist: []string{"10.0.0.0/8", "192.168.1.0/24"},
+				},
+				Address: kubermaticv1.ClusterAddress{},
+				Status: kubermaticv1.ClusterStatus{
+					ExtendedHealth: kubermaticv1.ExtendedClusterHealth{
+						Apiserver: kubermaticv1.HealthStatusUp,
+					},
+					NamespaceName: "cluster-" + name,
+				},
+			},
+		},
+		{
+			name: "successfully created the metrics-server addon",
+			expectedClusterAddons: []*kubermaticv1.Addon{
+				{
+					TypeMeta: metav1.TypeMeta{
+						APIVersion: "kubermatic.k8s.io/v1",
+						Kind:       "Addon",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            "metrics-server",
+						Namespace:       "cluster-" + name,
+						ResourceVersion: "1",
+						OwnerReferences: []metav1.OwnerReference{
+							{
+								APIVersion:         "kubermatic.k8s.io/v1",
+								Kind:               "Cluster",
+								Name:               name,
+								Controller:         truePtr(),
+								BlockOwnerDeletion: truePtr(),
+							},
+						},
+					},
+					Spec: kubermaticv1.AddonSpec{
+						Name: "metrics-server",
+						Cluster: corev1.ObjectReference{
+							Kind: "Cluster",
+							Name: name,
+						},
+						IsDefault: true,
+					},
+				},
+			},
+			cluster: &kubermaticv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: name,
+				},
+				Spec: kubermaticv1.ClusterSpec{
+					MetricsServer: &kubermaticv1.MetricsServerSettings{Enabled: true},
+				},
+				Address: kubermaticv1.ClusterAddress{},
+				Status: kubermaticv1.ClusterStatus{
+					ExtendedHealth: kubermaticv1.ExtendedClusterHealth{
+						Apiserver: kubermaticv1.HealthStatusUp,
+					},
+					NamespaceName: "cluster-" + name,
+				},
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -165,6 +314,51 @@ func TestCreateAddon(t *testing.T) {
 	}
 }
 
+func TestWithMetricsServerAddon(t *testing.T) {
+	tests := []struct {
+		name          string
+		addons        []string
+		enabled       bool
+		expectedNames []string
+	}{
+		{
+			name:          "addon is appended when enabled",
+			addons:        []string{"Foo"},
+			enabled:       true,
+			expectedNames: []string{"Foo", "metrics-server"},
+		},
+		{
+			name:          "existing addon is kept when enabled",
+			addons:        []string{"metrics-server", "Foo"},
+			enabled:       true,
+			expectedNames: []string{"metrics-server", "Foo"},
+		},
+		{
+			name:          "addon is removed when disabled",
+			addons:        []string{"Foo", "metrics-server", "Bar"},
+			enabled:       false,
+			expectedNames: []string{"Foo", "Bar"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var in []kubermaticv1.Addon
+			for _, name := range test.addons {
+				in = append(in, kubermaticv1.Addon{ObjectMeta: metav1.ObjectMeta{Name: name}})
+			}
+
+			var names []string
+			for _, addon := range withMetricsServerAddon(in, test.enabled) {
+				names = append(names, addon.Name)
+			}
+			if diff := deep.Equal(names, test.expectedNames); diff != nil {
+				t.Errorf("got unexpected addons, diff: %v", diff)
+			}
+		})
+	}
+}
+
 func TestUpdateAddon(t *testing.T) {
 	name := "test-cluster"
 	tests := []struct {
@@ -374,7 +374,10 @@ func (r *Reconciler) cronjob(cluster *kubermaticv1.Cluster) reconciling.NamedCro
 			cronJob.Spec.Schedule = r.backupScheduleString
 			cronJob.Spec.ConcurrencyPolicy = batchv1beta1.ForbidConcurrent
 			cronJob.Spec.Suspend = utilpointer.BoolPtr(false)
-			cronJob.Spec.SuccessfulJobsHistoryLimit = utilpointer.Int32Ptr(0)
+			// Keep the last successful job, its completion time is reported as the last backup time of the cluster
+			cronJob.Spec.SuccessfulJobsHistoryLimit = utilpointer.Int32Ptr(1)
+			// The API looks the backup jobs of a cluster up by these labels
+			cronJob.Spec.JobTemplate.Labels = resources.AppClusterLabels(resources.EtcdBackupJobAppName, cluster.Name, nil)
 
 			endpoints := etcd.GetClientEndpoints(cluster.Status.NamespaceName)
 			image := r.backupContainerImage
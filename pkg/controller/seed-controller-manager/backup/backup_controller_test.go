@@ -103,11 +103,15 @@ func TestEnsureBackupCronJob(t *testing.T) {
 		t.Fatalf("Expected exactly one cronjob, got %v", len(cronJobs.Items))
 	}
 
-	if *cronJobs.Items[0].Spec.SuccessfulJobsHistoryLimit != 0 {
-		t.Errorf("Expected spec.SuccessfulJobsHistoryLimit to be 0 but was %v",
+	if *cronJobs.Items[0].Spec.SuccessfulJobsHistoryLimit != 1 {
+		t.Errorf("Expected spec.SuccessfulJobsHistoryLimit to be 1 but was %v",
 			*cronJobs.Items[0].Spec.SuccessfulJobsHistoryLimit)
 	}
 
+	if clusterLabel := cronJobs.Items[0].Spec.JobTemplate.Labels[resources.ClusterLabelKey]; clusterLabel != cluster.Name {
+		t.Errorf("Expected the jobs to have the cluster label %q but was %q", cluster.Name, clusterLabel)
+	}
+
 	cronJobs.Items[0].Spec.JobTemplate.Spec.Template.Spec.Containers = []corev1.Container{}
 	cronJobs.Items[0].Spec.JobTemplate.Spec.Template.Spec.InitContainers = []corev1.Container{}
 	if err := reconciler.Update(context.Background(), &cronJobs.Items[0]); err != nil {
@@ -0,0 +1,233 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package constraintsyncer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	k8cuserclusterclient "k8c.io/kubermatic/v2/pkg/cluster/client"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	utiljson "k8s.io/apimachinery/pkg/util/json"
+	"k8s.io/client-go/tools/record"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	ControllerName = "kubermatic_constraint_syncer"
+
+	// cleanupFinalizerName makes sure the Gatekeeper constraint is removed from the user cluster
+	cleanupFinalizerName = "kubermatic.io/cleanup-gatekeeper-constraint"
+	// constraintsAPIVersion is the API version of the constraint kinds Gatekeeper creates for its constraint templates
+	constraintsAPIVersion = "constraints.gatekeeper.sh/v1beta1"
+)
+
+// userClusterConnectionProvider offers functions to retrieve clients for the given user clusters
+type userClusterConnectionProvider interface {
+	GetClient(*kubermaticv1.Cluster, ...k8cuserclusterclient.ConfigOption) (ctrlruntimeclient.Client, error)
+}
+
+type Reconciler struct {
+	log        *zap.SugaredLogger
+	workerName string
+	ctrlruntimeclient.Client
+	userClusterConnProvider userClusterConnectionProvider
+	recorder                record.EventRecorder
+}
+
+// Add creates a new constraint syncer controller
+func Add(
+	log *zap.SugaredLogger,
+	mgr manager.Manager,
+	numWorkers int,
+	workerName string,
+	userClusterConnProvider userClusterConnectionProvider,
+) error {
+	log = log.Named(ControllerName)
+	reconciler := &Reconciler{
+		log:                     log,
+		workerName:              workerName,
+		Client:                  mgr.GetClient(),
+		userClusterConnProvider: userClusterConnProvider,
+		recorder:                mgr.GetEventRecorderFor(ControllerName),
+	}
+
+	c, err := controller.New(ControllerName, mgr, controller.Options{
+		Reconciler:              reconciler,
+		MaxConcurrentReconciles: numWorkers,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create controller: %v", err)
+	}
+
+	if err := c.Watch(&source.Kind{Type: &kubermaticv1.Constraint{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return fmt.Errorf("failed to create watch for constraints: %v", err)
+	}
+	return nil
+}
+
+func (r *Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	log := r.log.With("request", request)
+	log.Debug("Processing")
+
+	constraint := &kubermaticv1.Constraint{}
+	if err := r.Get(ctx, request.NamespacedName, constraint); err != nil {
+		if kerrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	cluster := &kubermaticv1.Cluster{}
+	clusterName := strings.TrimPrefix(request.Namespace, "cluster-")
+	if err := r.Get(ctx, types.NamespacedName{Name: clusterName}, cluster); err != nil {
+		if !kerrors.IsNotFound(err) {
+			return reconcile.Result{}, err
+		}
+		// the Gatekeeper constraint can not be removed from a cluster which is already gone
+		return reconcile.Result{}, r.removeCleanupFinalizer(ctx, constraint)
+	}
+	if cluster.Labels[kubermaticv1.WorkerNameLabelKey] != r.workerName || cluster.Spec.Pause {
+		return reconcile.Result{}, nil
+	}
+
+	result, err := r.reconcile(ctx, log, constraint, cluster)
+	if err != nil {
+		log.Errorw("Reconciling failed", zap.Error(err))
+		r.recorder.Event(constraint, corev1.EventTypeWarning, "ReconcilingError", err.Error())
+	}
+	return result, err
+}
+
+func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, constraint *kubermaticv1.Constraint, cluster *kubermaticv1.Cluster) (reconcile.Result, error) {
+	if cluster.Status.ExtendedHealth.Apiserver != kubermaticv1.HealthStatusUp {
+		log.Debug("API server is not running, trying again in 10 seconds")
+		return reconcile.Result{RequeueAfter: 10 * time.Second}, nil
+	}
+
+	userClusterClient, err := r.userClusterConnProvider.GetClient(cluster)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to get user cluster client: %v", err)
+	}
+
+	if constraint.DeletionTimestamp != nil {
+		gatekeeperConstraint := newGatekeeperConstraint(constraint)
+		if err := userClusterClient.Delete(ctx, gatekeeperConstraint); err != nil && !kerrors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+			return reconcile.Result{}, fmt.Errorf("failed to delete the gatekeeper constraint: %v", err)
+		}
+		return reconcile.Result{}, r.removeCleanupFinalizer(ctx, constraint)
+	}
+
+	if !kuberneteshelper.HasFinalizer(constraint, cleanupFinalizerName) {
+		oldConstraint := constraint.DeepCopy()
+		kuberneteshelper.AddFinalizer(constraint, cleanupFinalizerName)
+		if err := r.Patch(ctx, constraint, ctrlruntimeclient.MergeFrom(oldConstraint)); err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to add the cleanup finalizer: %v", err)
+		}
+	}
+
+	spec, err := gatekeeperConstraintSpec(constraint)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	gatekeeperConstraint := newGatekeeperConstraint(constraint)
+	err = userClusterClient.Get(ctx, types.NamespacedName{Name: constraint.Name}, gatekeeperConstraint)
+	switch {
+	case meta.IsNoMatchError(err):
+		// Gatekeeper creates the kind once the constraint template is installed in the user cluster
+		log.Debugw("Constraint type is not known in the user cluster yet, trying again in 1 minute", "type", constraint.Spec.ConstraintType)
+		return reconcile.Result{RequeueAfter: time.Minute}, nil
+	case kerrors.IsNotFound(err):
+		gatekeeperConstraint.Object["spec"] = spec
+		if err := userClusterClient.Create(ctx, gatekeeperConstraint); err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to create the gatekeeper constraint: %v", err)
+		}
+		return reconcile.Result{}, nil
+	case err != nil:
+		return reconcile.Result{}, fmt.Errorf("failed to get the gatekeeper constraint: %v", err)
+	}
+
+	if equality.Semantic.DeepEqual(gatekeeperConstraint.Object["spec"], spec) {
+		return reconcile.Result{}, nil
+	}
+	gatekeeperConstraint.Object["spec"] = spec
+	if err := userClusterClient.Update(ctx, gatekeeperConstraint); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to update the gatekeeper constraint: %v", err)
+	}
+	return reconcile.Result{}, nil
+}
+
+func (r *Reconciler) removeCleanupFinalizer(ctx context.Context, constraint *kubermaticv1.Constraint) error {
+	if !kuberneteshelper.HasFinalizer(constraint, cleanupFinalizerName) {
+		return nil
+	}
+	oldConstraint := constraint.DeepCopy()
+	kuberneteshelper.RemoveFinalizer(constraint, cleanupFinalizerName)
+	return r.Patch(ctx, constraint, ctrlruntimeclient.MergeFrom(oldConstraint))
+}
+
+// newGatekeeperConstraint returns the Gatekeeper constraint of the kubermatic constraint, without its spec
+func newGatekeeperConstraint(constraint *kubermaticv1.Constraint) *unstructured.Unstructured {
+	gatekeeperConstraint := &unstructured.Unstructured{}
+	gatekeeperConstraint.SetAPIVersion(constraintsAPIVersion)
+	gatekeeperConstraint.SetKind(constraint.Spec.ConstraintType)
+	gatekeeperConstraint.SetName(constraint.Name)
+	return gatekeeperConstraint
+}
+
+// gatekeeperConstraintSpec returns the spec of the Gatekeeper constraint, it consists of the match and parameters of
+// the kubermatic constraint
+func gatekeeperConstraintSpec(constraint *kubermaticv1.Constraint) (map[string]interface{}, error) {
+	raw, err := json.Marshal(struct {
+		Match      kubermaticv1.Match         `json:"match,omitempty"`
+		Parameters map[string]json.RawMessage `json:"parameters,omitempty"`
+	}{
+		Match:      constraint.Spec.Match,
+		Parameters: constraint.Spec.Parameters,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode the constraint spec: %v", err)
+	}
+
+	// the numbers are decoded the same way the client decodes the Gatekeeper constraint, so both specs can be compared
+	spec := map[string]interface{}{}
+	if err := utiljson.Unmarshal(raw, &spec); err != nil {
+		return nil, fmt.Errorf("failed to decode the constraint spec: %v", err)
+	}
+	return spec, nil
+}
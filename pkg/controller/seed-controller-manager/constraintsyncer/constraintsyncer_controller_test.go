@@ -0,0 +1,195 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package constraintsyncer
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/go-test/deep"
+
+	k8cuserclusterclient "k8c.io/kubermatic/v2/pkg/cluster/client"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlruntimefakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	clusterName   = "test-cluster"
+	clusterNS     = "cluster-test-cluster"
+	constraintKey = "require-labels"
+)
+
+func TestReconcile(t *testing.T) {
+	now := metav1.Now()
+	testCases := []struct {
+		name                       string
+		constraint                 *kubermaticv1.Constraint
+		userClusterObjects         []runtime.Object
+		expectedGatekeeperSpec     map[string]interface{}
+		expectedGatekeeperDeletion bool
+		expectedFinalizer          bool
+	}{
+		{
+			name:       "the gatekeeper constraint is created",
+			constraint: genConstraint(nil),
+			expectedGatekeeperSpec: map[string]interface{}{
+				"match": map[string]interface{}{
+					"kinds": []interface{}{
+						map[string]interface{}{"kinds": []interface{}{"Namespace"}, "apiGroups": []interface{}{""}},
+					},
+				},
+				"parameters": map[string]interface{}{
+					"labels":   []interface{}{"owner"},
+					"maxCount": int64(3),
+				},
+			},
+			expectedFinalizer: true,
+		},
+		{
+			name:       "the gatekeeper constraint is updated",
+			constraint: genConstraint(nil),
+			userClusterObjects: []runtime.Object{
+				genGatekeeperConstraint(map[string]interface{}{"parameters": map[string]interface{}{"labels": []interface{}{"team"}}}),
+			},
+			expectedGatekeeperSpec: map[string]interface{}{
+				"match": map[string]interface{}{
+					"kinds": []interface{}{
+						map[string]interface{}{"kinds": []interface{}{"Namespace"}, "apiGroups": []interface{}{""}},
+					},
+				},
+				"parameters": map[string]interface{}{
+					"labels":   []interface{}{"owner"},
+					"maxCount": int64(3),
+				},
+			},
+			expectedFinalizer: true,
+		},
+		{
+			name:       "the gatekeeper constraint is removed with the constraint",
+			constraint: genConstraint(&now),
+			userClusterObjects: []runtime.Object{
+				genGatekeeperConstraint(map[string]interface{}{}),
+			},
+			expectedGatekeeperDeletion: true,
+			expectedFinalizer:          false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cluster := &kubermaticv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: clusterName},
+				Status: kubermaticv1.ClusterStatus{
+					NamespaceName:  clusterNS,
+					ExtendedHealth: kubermaticv1.ExtendedClusterHealth{Apiserver: kubermaticv1.HealthStatusUp},
+				},
+			}
+			seedClient := ctrlruntimefakeclient.NewFakeClient(cluster, tc.constraint)
+			userClusterClient := ctrlruntimefakeclient.NewFakeClient(tc.userClusterObjects...)
+
+			r := &Reconciler{
+				log:                     kubermaticlog.New(true, kubermaticlog.FormatConsole).Sugar(),
+				Client:                  seedClient,
+				userClusterConnProvider: &fakeUserClusterConnectionProvider{client: userClusterClient},
+				recorder:                record.NewFakeRecorder(10),
+			}
+
+			request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: clusterNS, Name: constraintKey}}
+			if _, err := r.Reconcile(request); err != nil {
+				t.Fatalf("reconciling failed: %v", err)
+			}
+
+			constraint := &kubermaticv1.Constraint{}
+			if err := seedClient.Get(context.Background(), request.NamespacedName, constraint); err != nil {
+				t.Fatalf("failed to get the constraint: %v", err)
+			}
+			if hasFinalizer := kuberneteshelper.HasFinalizer(constraint, cleanupFinalizerName); hasFinalizer != tc.expectedFinalizer {
+				t.Errorf("expected the cleanup finalizer to be set %t, got %t", tc.expectedFinalizer, hasFinalizer)
+			}
+
+			gatekeeperConstraint := genGatekeeperConstraint(nil)
+			err := userClusterClient.Get(context.Background(), types.NamespacedName{Name: constraintKey}, gatekeeperConstraint)
+			if tc.expectedGatekeeperDeletion {
+				if !kerrors.IsNotFound(err) {
+					t.Fatalf("expected the gatekeeper constraint to be removed, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to get the gatekeeper constraint: %v", err)
+			}
+			if diff := deep.Equal(gatekeeperConstraint.Object["spec"], interface{}(tc.expectedGatekeeperSpec)); diff != nil {
+				t.Errorf("the gatekeeper constraint spec differs from the expected one: %v", diff)
+			}
+		})
+	}
+}
+
+func genConstraint(deletionTimestamp *metav1.Time) *kubermaticv1.Constraint {
+	constraint := &kubermaticv1.Constraint{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              constraintKey,
+			Namespace:         clusterNS,
+			DeletionTimestamp: deletionTimestamp,
+		},
+		Spec: kubermaticv1.ConstraintSpec{
+			ConstraintType: "RequiredLabels",
+			Match: kubermaticv1.Match{
+				Kinds: []kubermaticv1.Kind{{Kinds: []string{"Namespace"}, APIGroups: []string{""}}},
+			},
+			Parameters: map[string]json.RawMessage{
+				"labels":   json.RawMessage(`["owner"]`),
+				"maxCount": json.RawMessage(`3`),
+			},
+		},
+	}
+	if deletionTimestamp != nil {
+		constraint.Finalizers = []string{cleanupFinalizerName}
+	}
+	return constraint
+}
+
+func genGatekeeperConstraint(spec map[string]interface{}) *unstructured.Unstructured {
+	gatekeeperConstraint := &unstructured.Unstructured{}
+	gatekeeperConstraint.SetAPIVersion(constraintsAPIVersion)
+	gatekeeperConstraint.SetKind("RequiredLabels")
+	gatekeeperConstraint.SetName(constraintKey)
+	if spec != nil {
+		gatekeeperConstraint.Object["spec"] = spec
+	}
+	return gatekeeperConstraint
+}
+
+type fakeUserClusterConnectionProvider struct {
+	client ctrlruntimeclient.Client
+}
+
+func (f *fakeUserClusterConnectionProvider) GetClient(*kubermaticv1.Cluster, ...k8cuserclusterclient.ConfigOption) (ctrlruntimeclient.Client, error) {
+	return f.client, nil
+}
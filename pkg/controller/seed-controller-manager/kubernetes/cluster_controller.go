@@ -52,6 +52,11 @@ import (
 
 const (
 	ControllerName = "kubermatic_kubernetes_controller"
+
+	// reconcileStatusInterval is the minimum interval in which the time and duration of the last
+	// reconciliation are written to the cluster status. Every update of the cluster triggers another
+	// reconciliation, so they can not be updated on every run.
+	reconcileStatusInterval = 5 * time.Minute
 )
 
 // userClusterConnectionProvider offers functions to retrieve clients for the given user clusters
@@ -214,6 +219,8 @@ func (r *Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, err
 		return reconcile.Result{}, nil
 	}
 
+	reconcileStart := time.Now()
+
 	// Add a wrapping here so we can emit an event on error
 	result, err := kubermaticv1helper.ClusterReconcileWrapper(
 		ctx,
@@ -238,6 +245,10 @@ func (r *Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, err
 		r.recorder.Event(cluster, corev1.EventTypeWarning, "ReconcilingError", err.Error())
 	}
 
+	if recordErr := r.recordReconcileDuration(ctx, cluster, reconcileStart); recordErr != nil {
+		log.Errorw("Failed to record the reconcile duration", zap.Error(recordErr))
+	}
+
 	if result == nil {
 		result = &reconcile.Result{}
 	}
@@ -245,6 +256,25 @@ func (r *Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, err
 	return *result, err
 }
 
+// recordReconcileDuration writes the time and duration of the reconciliation which started at the given time
+// to the cluster status, if the last recorded reconciliation is older than the reconcileStatusInterval.
+func (r *Reconciler) recordReconcileDuration(ctx context.Context, cluster *kubermaticv1.Cluster, reconcileStart time.Time) error {
+	// the reconciliation has been skipped by the wrapper
+	if cluster.Labels[kubermaticv1.WorkerNameLabelKey] != r.workerName || cluster.Spec.Pause {
+		return nil
+	}
+
+	now := time.Now()
+	if lastReconcileTime := cluster.Status.LastReconcileTime; lastReconcileTime != nil && now.Sub(lastReconcileTime.Time) < reconcileStatusInterval {
+		return nil
+	}
+
+	oldCluster := cluster.DeepCopy()
+	cluster.Status.LastReconcileTime = &metav1.Time{Time: now}
+	cluster.Status.LastReconcileDuration = &metav1.Duration{Duration: now.Sub(reconcileStart)}
+	return ctrlruntimeclient.IgnoreNotFound(r.Patch(ctx, cluster, ctrlruntimeclient.MergeFrom(oldCluster)))
+}
+
 func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster) (*reconcile.Result, error) {
 	// synchronize cluster.status.health for Kubernetes clusters
 	if err := r.syncHealth(ctx, cluster); err != nil {
@@ -81,14 +81,14 @@ func (r *Reconciler) ensureClusterNetworkDefaults(ctx context.Context, cluster *
 
 	if len(cluster.Spec.ClusterNetwork.Services.CIDRBlocks) == 0 {
 		setServiceNetwork := func(c *kubermaticv1.Cluster) {
-			c.Spec.ClusterNetwork.Services.CIDRBlocks = []string{"10.240.16.0/20"}
+			c.Spec.ClusterNetwork.Services.CIDRBlocks = []string{resources.DefaultServicesCIDR}
 		}
 		modifiers = append(modifiers, setServiceNetwork)
 	}
 
 	if len(cluster.Spec.ClusterNetwork.Pods.CIDRBlocks) == 0 {
 		setPodNetwork := func(c *kubermaticv1.Cluster) {
-			c.Spec.ClusterNetwork.Pods.CIDRBlocks = []string{"172.25.0.0/16"}
+			c.Spec.ClusterNetwork.Pods.CIDRBlocks = []string{resources.DefaultPodsCIDR}
 		}
 		modifiers = append(modifiers, setPodNetwork)
 	}
@@ -107,6 +107,13 @@ func (r *Reconciler) ensureClusterNetworkDefaults(ctx context.Context, cluster *
 		modifiers = append(modifiers, setProxyMode)
 	}
 
+	if cluster.Spec.ClusterNetwork.IPFamily == "" {
+		setIPFamily := func(c *kubermaticv1.Cluster) {
+			c.Spec.ClusterNetwork.IPFamily = resources.IPv4IPFamily
+		}
+		modifiers = append(modifiers, setIPFamily)
+	}
+
 	return r.updateCluster(ctx, cluster, func(c *kubermaticv1.Cluster) {
 		for _, modify := range modifiers {
 			modify(c)
@@ -242,7 +242,7 @@ func GetDeploymentCreators(data *resources.TemplateData, enableAPIserverOIDCAuth
 		usercluster.DeploymentCreator(data, false),
 		kubernetesdashboard.DeploymentCreator(data),
 	}
-	if data.Cluster().Annotations[kubermaticv1.AnnotationNameClusterAutoscalerEnabled] != "" {
+	if data.Cluster().ClusterAutoscalerEnabled() {
 		deployments = append(deployments, clusterautoscaler.DeploymentCreator(data))
 	}
 	if flag := data.Cluster().Spec.Features[kubermaticv1.ClusterFeatureExternalCloudProvider]; flag {
@@ -259,11 +259,16 @@ func (r *Reconciler) ensureDeployments(ctx context.Context, cluster *kubermaticv
 
 // GetSecretCreators returns all SecretCreators that are currently in use
 func (r *Reconciler) GetSecretCreators(data *resources.TemplateData) []reconciling.NamedSecretCreatorGetter {
+	imagePullSecretCreator := resources.ImagePullSecretCreator(r.dockerPullConfigJSON)
+	if mirror := data.Cluster().Spec.RegistryMirror; mirror != nil && mirror.CredentialsReference != nil {
+		imagePullSecretCreator = resources.RegistryMirrorImagePullSecretCreator(r.dockerPullConfigJSON, data)
+	}
+
 	creators := []reconciling.NamedSecretCreatorGetter{
 		certificates.RootCACreator(data),
 		openvpn.CACreator(),
 		certificates.FrontProxyCACreator(),
-		resources.ImagePullSecretCreator(r.dockerPullConfigJSON),
+		imagePullSecretCreator,
 		apiserver.FrontProxyClientCertificateCreator(data),
 		etcd.TLSCertificateCreator(data),
 		apiserver.EtcdClientCertificateCreator(data),
@@ -353,12 +358,19 @@ func (r *Reconciler) ensureRoleBindings(ctx context.Context, c *kubermaticv1.Clu
 
 // GetConfigMapCreators returns all ConfigMapCreators that are currently in use
 func GetConfigMapCreators(data *resources.TemplateData) []reconciling.NamedConfigMapCreatorGetter {
-	return []reconciling.NamedConfigMapCreatorGetter{
+	creators := []reconciling.NamedConfigMapCreatorGetter{
 		cloudconfig.ConfigMapCreator(data),
 		openvpn.ServerClientConfigsConfigMapCreator(data),
 		dns.ConfigMapCreator(data),
 		apiserver.AuditConfigMapCreator(),
 	}
+	if data.Cluster().KonnectivityEnabled() {
+		creators = append(creators, apiserver.KonnectivityEgressSelectorConfigMapCreator())
+	}
+	if data.Cluster().Spec.SchedulerConfig != "" {
+		creators = append(creators, scheduler.ConfigMapCreator(data))
+	}
+	return creators
 }
 
 func (r *Reconciler) ensureConfigMaps(ctx context.Context, c *kubermaticv1.Cluster, data *resources.TemplateData) error {
@@ -101,7 +101,7 @@ func (r *Reconciler) ensureDeployments(ctx context.Context, cluster *kubermaticv
 
 // GetSecretCreatorOperations returns all SecretCreators that are currently in use
 func GetSecretCreatorOperations(data *resources.TemplateData) []reconciling.NamedSecretCreatorGetter {
-	return []reconciling.NamedSecretCreatorGetter{
+	creators := []reconciling.NamedSecretCreatorGetter{
 		certificates.GetClientCertificateCreator(
 			resources.PrometheusApiserverClientCertificateSecretName,
 			resources.PrometheusCertUsername, nil,
@@ -110,6 +110,12 @@ func GetSecretCreatorOperations(data *resources.TemplateData) []reconciling.Name
 			data.GetRootCA,
 		),
 	}
+
+	if data.Cluster().Spec.AlertmanagerConfig != "" {
+		creators = append(creators, prometheus.AlertmanagerConfigSecretCreator(data))
+	}
+
+	return creators
 }
 
 func (r *Reconciler) ensureSecrets(ctx context.Context, cluster *kubermaticv1.Cluster, data *resources.TemplateData) error {
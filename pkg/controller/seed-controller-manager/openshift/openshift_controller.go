@@ -483,7 +483,7 @@ func (r *Reconciler) getAllDeploymentCreators(ctx context.Context, osData *opens
 		metricsserver.DeploymentCreator(osData),
 	}
 
-	if osData.Cluster().Annotations[kubermaticv1.AnnotationNameClusterAutoscalerEnabled] != "" {
+	if osData.Cluster().ClusterAutoscalerEnabled() {
 		creators = append(creators, clusterautoscaler.DeploymentCreator(osData))
 	}
 
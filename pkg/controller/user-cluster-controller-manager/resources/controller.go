@@ -27,6 +27,7 @@ import (
 	"github.com/heptiolabs/healthcheck"
 	"go.uber.org/zap"
 
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/resources"
 	"k8c.io/kubermatic/v2/pkg/resources/certificates/triple"
 
@@ -71,6 +72,8 @@ func Add(
 	cloudCredentialSecretTemplate *corev1.Secret,
 	openshiftConsoleCallbackURI string,
 	dnsClusterIP string,
+	adminGroups []string,
+	dnsUpstreams []kubermaticv1.DNSUpstream,
 	log *zap.SugaredLogger) error {
 	r := &reconciler{
 		openshift:                     openshift,
@@ -84,6 +87,8 @@ func Add(
 		platform:                      cloudProviderName,
 		openshiftConsoleCallbackURI:   openshiftConsoleCallbackURI,
 		dnsClusterIP:                  dnsClusterIP,
+		adminGroups:                   adminGroups,
+		dnsUpstreams:                  dnsUpstreams,
 	}
 
 	if r.openshift {
@@ -223,6 +228,8 @@ type reconciler struct {
 	cloudCredentialSecretTemplate *corev1.Secret
 	openshiftConsoleCallbackURI   string
 	dnsClusterIP                  string
+	adminGroups                   []string
+	dnsUpstreams                  []kubermaticv1.DNSUpstream
 
 	rLock                      *sync.Mutex
 	reconciledSuccessfullyOnce bool
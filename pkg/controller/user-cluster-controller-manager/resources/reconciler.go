@@ -376,6 +376,10 @@ func (r *reconciler) reconcileClusterRoleBindings(ctx context.Context) error {
 			}...)
 	}
 
+	if len(r.adminGroups) > 0 {
+		creators = append(creators, userauth.AdminGroupsClusterRoleBindingCreator(r.adminGroups))
+	}
+
 	if err := reconciling.ReconcileClusterRoleBindings(ctx, creators, "", r.Client); err != nil {
 		return fmt.Errorf("failed to reconcile ClusterRoleBindings: %v", err)
 	}
@@ -459,8 +463,8 @@ func (r *reconciler) reconcileConfigMaps(ctx context.Context, data reconcileData
 		creators = append(creators, openshift.ControlplaneConfigCreator(r.platform))
 	} else {
 		creators = append(creators, []reconciling.NamedConfigMapCreatorGetter{
-			coredns.ConfigMapCreator(),
-			nodelocaldns.ConfigMapCreator(r.dnsClusterIP),
+			coredns.ConfigMapCreator(r.dnsUpstreams),
+			nodelocaldns.ConfigMapCreator(r.dnsClusterIP, len(r.dnsUpstreams) > 0),
 		}...)
 	}
 
@@ -17,6 +17,10 @@ limitations under the License.
 package coredns
 
 import (
+	"fmt"
+	"strings"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/resources"
 	"k8c.io/kubermatic/v2/pkg/resources/reconciling"
 
@@ -24,14 +28,44 @@ import (
 )
 
 // ConfigMapCreator returns a ConfigMap containing the config for the CoreDNS
-func ConfigMapCreator() reconciling.NamedConfigMapCreatorGetter {
+func ConfigMapCreator(upstreams []kubermaticv1.DNSUpstream) reconciling.NamedConfigMapCreatorGetter {
 	return func() (string, reconciling.ConfigMapCreator) {
 		return resources.CoreDNSConfigMapName, func(cm *corev1.ConfigMap) (*corev1.ConfigMap, error) {
 			if cm.Data == nil {
 				cm.Data = map[string]string{}
 			}
 			cm.Labels = resources.BaseAppLabels(resources.CoreDNSServiceName, nil)
-			cm.Data["Corefile"] = `
+			cm.Data["Corefile"] = corefile(upstreams)
+
+			return cm, nil
+		}
+	}
+}
+
+// corefile returns the CoreDNS configuration. The queries which are not answered by the cluster DNS are
+// forwarded to the upstreams without a zone, or to the resolvers of the node when there are none. Every
+// zone of the other upstreams gets a server block of its own.
+func corefile(upstreams []kubermaticv1.DNSUpstream) string {
+	var defaultUpstreams, zones []string
+	zoneUpstreams := map[string][]string{}
+	for _, upstream := range upstreams {
+		if upstream.Zone == "" {
+			defaultUpstreams = append(defaultUpstreams, upstream.Address)
+			continue
+		}
+		zone := strings.TrimSuffix(upstream.Zone, ".")
+		if _, ok := zoneUpstreams[zone]; !ok {
+			zones = append(zones, zone)
+		}
+		zoneUpstreams[zone] = append(zoneUpstreams[zone], upstream.Address)
+	}
+
+	proxy := "/etc/resolv.conf"
+	if len(defaultUpstreams) > 0 {
+		proxy = strings.Join(defaultUpstreams, " ")
+	}
+
+	config := fmt.Sprintf(`
       .:53 {
           errors
           health
@@ -41,15 +75,20 @@ func ConfigMapCreator() reconciling.NamedConfigMapCreatorGetter {
              fallthrough in-addr.arpa ip6.arpa
           }
           prometheus :9153
-          proxy . /etc/resolv.conf
+          proxy . %s
           cache 30
           loop
           reload
           loadbalance
       }
-      `
-
-			return cm, nil
-		}
+`, proxy)
+	for _, zone := range zones {
+		config += fmt.Sprintf(`      %s:53 {
+          errors
+          cache 30
+          proxy . %s
+      }
+`, zone, strings.Join(zoneUpstreams[zone], " "))
 	}
+	return config + "      "
 }
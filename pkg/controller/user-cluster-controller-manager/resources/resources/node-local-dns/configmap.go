@@ -31,8 +31,10 @@ const (
 	reconcileModeValue  = "Reconcile"
 )
 
-// ConfigMapCreator returns a ConfigMap containing the config for Node Local DNS cache
-func ConfigMapCreator(dnsClusterIP string) reconciling.NamedConfigMapCreatorGetter {
+// ConfigMapCreator returns a ConfigMap containing the config for Node Local DNS cache. All queries are
+// forwarded to the cluster DNS when it has upstreams configured, otherwise the resolvers of the node
+// answer the queries outside of the cluster.
+func ConfigMapCreator(dnsClusterIP string, forwardToClusterDNS bool) reconciling.NamedConfigMapCreatorGetter {
 	return func() (string, reconciling.ConfigMapCreator) {
 		return resources.NodeLocalDNSConfigMapName, func(cm *corev1.ConfigMap) (*corev1.ConfigMap, error) {
 			if cm.Labels == nil {
@@ -45,7 +47,14 @@ func ConfigMapCreator(dnsClusterIP string) reconciling.NamedConfigMapCreatorGett
 				return nil, err
 			}
 			configBuf := bytes.Buffer{}
-			if err := t.Execute(&configBuf, struct{ DNSClusterIP string }{dnsClusterIP}); err != nil {
+			data := struct {
+				DNSClusterIP        string
+				ForwardToClusterDNS bool
+			}{
+				DNSClusterIP:        dnsClusterIP,
+				ForwardToClusterDNS: forwardToClusterDNS,
+			}
+			if err := t.Execute(&configBuf, data); err != nil {
 				return nil, err
 			}
 
@@ -104,7 +113,7 @@ ip6.arpa:53 {
     reload
     loop
     bind 169.254.20.10
-    forward . /etc/resolv.conf {
+    forward . {{ if .ForwardToClusterDNS }}{{ .DNSClusterIP }}{{ else }}/etc/resolv.conf{{ end }} {
             force_tcp
     }
     prometheus :9253
@@ -26,6 +26,8 @@ import (
 
 const (
 	ServiceAccountName = "external-admin-user"
+
+	adminGroupsClusterRoleBindingName = "kubermatic:admin-groups"
 )
 
 // ServiceAccountCreator returns a func to create/update the ServiceAccount used by the cluster user
@@ -57,3 +59,26 @@ func ClusterRoleBindingCreator() reconciling.NamedClusterRoleBindingCreatorGette
 		}
 	}
 }
+
+// AdminGroupsClusterRoleBindingCreator returns a func to create/update the ClusterRoleBinding which gives
+// the groups specified at cluster creation full admin access
+func AdminGroupsClusterRoleBindingCreator(groups []string) reconciling.NamedClusterRoleBindingCreatorGetter {
+	return func() (string, reconciling.ClusterRoleBindingCreator) {
+		return adminGroupsClusterRoleBindingName, func(crb *rbacv1.ClusterRoleBinding) (*rbacv1.ClusterRoleBinding, error) {
+			crb.RoleRef = rbacv1.RoleRef{
+				Name:     "cluster-admin",
+				Kind:     "ClusterRole",
+				APIGroup: rbacv1.GroupName,
+			}
+			crb.Subjects = make([]rbacv1.Subject, 0, len(groups))
+			for _, group := range groups {
+				crb.Subjects = append(crb.Subjects, rbacv1.Subject{
+					Kind:     rbacv1.GroupKind,
+					APIGroup: rbacv1.GroupName,
+					Name:     group,
+				})
+			}
+			return crb, nil
+		}
+	}
+}
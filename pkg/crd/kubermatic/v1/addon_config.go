@@ -45,6 +45,8 @@ type AddonConfigSpec struct {
 	LogoFormat string `json:"logoFormat,omitempty"`
 	// Controls that can be set for configured addon
 	Controls []AddonFormControl `json:"formSpec,omitempty"`
+	// Versions of the configured addon that clusters can be pinned to
+	Versions []string `json:"versions,omitempty"`
 }
 
 // AddonFormControl specifies addon form control
@@ -20,6 +20,7 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"strings"
 
 	providerconfig "github.com/kubermatic/machine-controller/pkg/providerconfig/types"
 
@@ -97,6 +98,13 @@ type ClusterSpec struct {
 	// HumanReadableName is the cluster name provided by the user
 	HumanReadableName string `json:"humanReadableName"`
 
+	// Description is an optional free-form description of the cluster provided by the user
+	Description string `json:"description,omitempty"`
+
+	// AdminGroups is a list of groups which get bound to the cluster-admin ClusterRole
+	// in the user cluster once it is ready
+	AdminGroups []string `json:"adminGroups,omitempty"`
+
 	// ExposeStrategy is the approach we use to expose this cluster, either via NodePort
 	// or via a dedicated LoadBalancer
 	ExposeStrategy corev1.ServiceType `json:"exposeStrategy"`
@@ -127,6 +135,59 @@ type ClusterSpec struct {
 	AdmissionPlugins                    []string `json:"admissionPlugins,omitempty"`
 
 	AuditLogging *AuditLoggingSettings `json:"auditLogging,omitempty"`
+
+	// Autoscaler holds the settings of the cluster-autoscaler
+	Autoscaler *AutoscalerSettings `json:"autoscaler,omitempty"`
+
+	// AddonVersions pins addons to a specific version, keyed by the addon name. The manifests
+	// of a pinned addon are taken from the sub-folder of the addon named after the version.
+	AddonVersions map[string]string `json:"addonVersions,omitempty"`
+
+	// ExternalDNS configures the external-dns addon, which is only installed when this is set
+	ExternalDNS *ExternalDNSSettings `json:"externalDNS,omitempty"`
+
+	// EgressAllowlist restricts the egress traffic of the pods outside of kube-system to the given CIDRs
+	// and the cluster networks. The restriction is enforced by a Calico policy, so it requires the canal CNI.
+	// Egress traffic is not restricted when this is empty.
+	EgressAllowlist []string `json:"egressAllowlist,omitempty"`
+
+	// Connectivity selects how the control plane reaches the nodes of the cluster, either via an
+	// OpenVPN tunnel or via the Konnectivity agent. OpenVPN is used when this is empty.
+	Connectivity ClusterConnectivity `json:"connectivity,omitempty"`
+
+	// DisableNodeSSH disables the SSH access to the nodes. No SSH keys are injected into the nodes
+	// and the SSH port is not opened in the security groups created for the cluster.
+	DisableNodeSSH bool `json:"disableNodeSSH,omitempty"`
+
+	// AlertmanagerConfig is the raw Alertmanager configuration used to route the alerts of the cluster.
+	// It is stored in the cluster namespace for the Alertmanager of the seed to pick up.
+	AlertmanagerConfig string `json:"alertmanagerConfig,omitempty"`
+
+	// MaxPodsPerNode is the maximum number of pods the kubelet runs on a node. The network range
+	// assigned to each node is sized to hold twice as many addresses. Defaults to 110.
+	MaxPodsPerNode *int32 `json:"maxPodsPerNode,omitempty"`
+
+	// ServiceNodePortRange is the port range NodePort services are allocated from, e.g. "30000-32767".
+	// It is passed to the apiserver with --service-node-port-range. Defaults to the range configured for the seed.
+	ServiceNodePortRange string `json:"serviceNodePortRange,omitempty"`
+
+	// RegistryMirror is the registry the images of the control plane, the addons and the nodes are pulled from
+	RegistryMirror *RegistryMirrorSettings `json:"registryMirror,omitempty"`
+
+	// SchedulerConfig is a KubeSchedulerConfiguration in YAML or JSON format, passed to the scheduler with --config.
+	// Its apiVersion must be the one supported by the Kubernetes version of the cluster.
+	SchedulerConfig string `json:"schedulerConfig,omitempty"`
+
+	// DNS configures the DNS servers CoreDNS forwards the queries to which are not answered by the cluster DNS
+	DNS *DNSSettings `json:"dns,omitempty"`
+
+	// MetricsServer explicitly installs or removes the metrics-server addon. When this is not set, the addon
+	// is installed if it is one of the default addons of the installation.
+	MetricsServer *MetricsServerSettings `json:"metricsServer,omitempty"`
+
+	// LoadBalancerClass is the load balancer implementation Services of type LoadBalancer use by default.
+	// It must be one of the load balancer classes of the datacenter.
+	LoadBalancerClass string `json:"loadBalancerClass,omitempty"`
 }
 
 const (
@@ -250,6 +311,12 @@ type ClusterStatus struct {
 
 	// InheritedLabels are labels the cluster inherited from the project. They are read-only for users.
 	InheritedLabels map[string]string `json:"inheritedLabels,omitempty"`
+
+	// LastReconcileTime is the time the cluster controller last finished reconciling the cluster.
+	// It is only refreshed periodically, to not trigger additional reconciliations by updating it.
+	LastReconcileTime *metav1.Time `json:"lastReconcileTime,omitempty"`
+	// LastReconcileDuration is the duration of the reconciliation finished at LastReconcileTime.
+	LastReconcileDuration *metav1.Duration `json:"lastReconcileDuration,omitempty"`
 }
 
 // HasConditionValue returns true if the cluster status has the given condition with the given status.
@@ -275,6 +342,9 @@ const (
 
 type Openshift struct {
 	ImagePullSecret string `json:"imagePullSecret,omitempty"`
+	// ImagePullSecretRef is the name of a secret of the project holding the image pull secret under the
+	// .dockerconfigjson key. It is used when creating or updating a cluster and gets resolved into ImagePullSecret.
+	ImagePullSecretRef string `json:"imagePullSecretRef,omitempty"`
 }
 
 type OIDCSettings struct {
@@ -291,12 +361,84 @@ type AuditLoggingSettings struct {
 	Enabled bool `json:"enabled,omitempty"`
 }
 
+// AutoscalerSettings defines the cluster-wide settings of the cluster-autoscaler. The minimum and maximum
+// size of the node groups are configured on the MachineDeployments.
+type AutoscalerSettings struct {
+	// Enabled deploys the cluster-autoscaler for the cluster
+	Enabled bool `json:"enabled,omitempty"`
+	// ScaleDownDelayAfterAdd is the duration after a scale up after which scale down evaluation resumes, e.g. "10m".
+	// Defaults to the cluster-autoscaler default.
+	ScaleDownDelayAfterAdd string `json:"scaleDownDelayAfterAdd,omitempty"`
+}
+
+// ExternalDNSSettings defines the settings of the external-dns addon, which creates DNS records for
+// the services and ingresses of the cluster
+type ExternalDNSSettings struct {
+	// Provider is the DNS provider the records are created in, e.g. "aws" or "google"
+	Provider string `json:"provider"`
+	// Zone is the domain external-dns is allowed to manage records in
+	Zone string `json:"zone"`
+	// CredentialsSecret is the name of a secret in the kube-system namespace of the user cluster
+	// holding the credentials of the DNS provider. Its keys are exposed as environment variables.
+	CredentialsSecret string `json:"credentialsSecret,omitempty"`
+}
+
+// DNSSettings configures the DNS of the user cluster
+type DNSSettings struct {
+	// Upstreams are the DNS servers CoreDNS forwards queries to. Queries outside of the zones of the
+	// upstreams are forwarded to the resolvers of the nodes when no upstream without a zone is set.
+	Upstreams []DNSUpstream `json:"upstreams,omitempty"`
+}
+
+// MetricsServerSettings configures the metrics-server addon, which serves the resource metrics used by
+// the HorizontalPodAutoscaler and kubectl top
+type MetricsServerSettings struct {
+	Enabled bool `json:"enabled"`
+}
+
+// DNSUpstream is a DNS server queries are forwarded to
+type DNSUpstream struct {
+	// Zone restricts the forwarding to the queries for the zone, e.g. "corp.example.com".
+	// All queries are forwarded when it is empty.
+	Zone string `json:"zone,omitempty"`
+	// Address of the DNS server as IP or IP:port, the port defaults to 53
+	Address string `json:"address"`
+}
+
+// RegistryMirrorSettings configures the registry all images of a cluster are pulled from
+type RegistryMirrorSettings struct {
+	// URL of the registry, e.g. "https://registry.example.com/mirror". The registries of the image
+	// references are replaced by the host and path of the URL.
+	URL string `json:"url"`
+	// CredentialsReference refers to a secret holding the docker config (.dockerconfigjson) used to pull from the registry
+	CredentialsReference *providerconfig.GlobalSecretKeySelector `json:"credentialsReference,omitempty"`
+}
+
+// ImageRegistry returns the registry the image references are rewritten to, which is the URL without its scheme
+func (s *RegistryMirrorSettings) ImageRegistry() string {
+	registry := strings.TrimPrefix(strings.TrimPrefix(s.URL, "https://"), "http://")
+	return strings.TrimSuffix(registry, "/")
+}
+
+// ClusterConnectivity is the mechanism the control plane uses to connect to the nodes of a cluster
+type ClusterConnectivity string
+
+const (
+	// ClusterConnectivityOpenVPN connects the control plane to the nodes via an OpenVPN tunnel
+	ClusterConnectivityOpenVPN ClusterConnectivity = "openvpn"
+	// ClusterConnectivityKonnectivity connects the control plane to the nodes via the Konnectivity agent,
+	// which requires the egress selector of Kubernetes 1.18
+	ClusterConnectivityKonnectivity ClusterConnectivity = "konnectivity"
+)
+
 type ComponentSettings struct {
 	Apiserver         APIServerSettings       `json:"apiserver"`
 	ControllerManager DeploymentSettings      `json:"controllerManager"`
 	Scheduler         DeploymentSettings      `json:"scheduler"`
 	Etcd              EtcdStatefulSetSettings `json:"etcd"`
 	Prometheus        StatefulSetSettings     `json:"prometheus"`
+	// MachineController holds the settings of the machine-controller running in the seed
+	MachineController MachineControllerSettings `json:"machineController"`
 }
 
 type APIServerSettings struct {
@@ -308,15 +450,32 @@ type APIServerSettings struct {
 type DeploymentSettings struct {
 	Replicas  *int32                       `json:"replicas,omitempty"`
 	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+	// LogLevel is the log verbosity (-v flag) of the component, in the range 0-10
+	LogLevel *int `json:"logLevel,omitempty"`
 }
 
 type StatefulSetSettings struct {
 	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
 }
 
+type MachineControllerSettings struct {
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+const (
+	// EtcdCompactionModePeriodic compacts the etcd history older than the retention period
+	EtcdCompactionModePeriodic = "periodic"
+	// EtcdCompactionModeRevision compacts all but the latest revisions of the etcd history
+	EtcdCompactionModeRevision = "revision"
+)
+
 type EtcdStatefulSetSettings struct {
 	ClusterSize int                          `json:"clusterSize,omitempty"`
 	Resources   *corev1.ResourceRequirements `json:"resources,omitempty"`
+	// CompactionMode is the auto compaction mode of etcd, either "periodic" or "revision". Defaults to "periodic".
+	CompactionMode string `json:"compactionMode,omitempty"`
+	// DefragSchedule is the cron schedule of the etcd defragmentation job. Defaults to every 3 hours.
+	DefragSchedule string `json:"defragSchedule,omitempty"`
 }
 
 // ClusterNetworkingConfig specifies the different networking
@@ -334,6 +493,10 @@ type ClusterNetworkingConfig struct {
 	// ProxyMode defines the kube-proxy mode (ipvs/iptables).
 	// Defaults to ipvs.
 	ProxyMode string `json:"proxyMode"`
+
+	// IPFamily is the IP family of the cluster network (ipv4/ipv6/dualstack).
+	// Defaults to ipv4.
+	IPFamily string `json:"ipFamily,omitempty"`
 }
 
 // MachineNetworkingConfig specifies the networking parameters used for IPAM.
@@ -670,3 +833,18 @@ func (cluster *Cluster) IsOpenshift() bool {
 func (cluster *Cluster) IsKubernetes() bool {
 	return !cluster.IsOpenshift()
 }
+
+// KonnectivityEnabled returns whether the control plane connects to the nodes via the Konnectivity agent
+// instead of OpenVPN.
+func (cluster *Cluster) KonnectivityEnabled() bool {
+	return cluster.Spec.Connectivity == ClusterConnectivityKonnectivity
+}
+
+// ClusterAutoscalerEnabled returns whether the cluster-autoscaler should be deployed for the cluster,
+// either via the cluster spec or via the AnnotationNameClusterAutoscalerEnabled annotation.
+func (cluster *Cluster) ClusterAutoscalerEnabled() bool {
+	if cluster.Spec.Autoscaler != nil && cluster.Spec.Autoscaler.Enabled {
+		return true
+	}
+	return cluster.Annotations[AnnotationNameClusterAutoscalerEnabled] != ""
+}
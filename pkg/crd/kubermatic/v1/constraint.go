@@ -0,0 +1,82 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"encoding/json"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ConstraintResourceName represents "Resource" defined in Kubernetes
+	ConstraintResourceName = "constraints"
+
+	// ConstraintKind represents "Kind" defined in Kubernetes
+	ConstraintKind = "Constraint"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Constraint is the object representing a kubermatic wrapper for a gatekeeper constraint of a cluster.
+// It lives in the namespace of the cluster.
+type Constraint struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ConstraintSpec `json:"spec"`
+}
+
+// ConstraintSpec specifies the data for the constraint.
+type ConstraintSpec struct {
+	// ConstraintType is the kind of the gatekeeper constraint, it is defined by the constraint template
+	// with the lowercased kind as name
+	ConstraintType string `json:"constraintType"`
+	// Match contains the constraint to resource matching data
+	Match Match `json:"match,omitempty"`
+	// Parameters are passed to the rego of the constraint template, keyed by the parameter names
+	Parameters map[string]json.RawMessage `json:"parameters,omitempty"`
+}
+
+// Match contains the constraint to resource matching data
+type Match struct {
+	// Kinds accepts a list of objects with apiGroups and kinds fields that list the groups/kinds of objects to which
+	// the constraint will apply
+	Kinds []Kind `json:"kinds,omitempty"`
+	// Namespaces is a list of namespace names. If defined, a constraint will only apply to resources in a listed namespace.
+	Namespaces []string `json:"namespaces,omitempty"`
+	// ExcludedNamespaces is a list of namespace names. If defined, a constraint will only apply to resources not in a listed namespace.
+	ExcludedNamespaces []string `json:"excludedNamespaces,omitempty"`
+}
+
+// Kind specifies the resource Kind and APIGroup
+type Kind struct {
+	// Kinds specifies the kinds of the resources
+	Kinds []string `json:"kinds,omitempty"`
+	// APIGroups specifies the APIGroups of the resources
+	APIGroups []string `json:"apiGroups,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ConstraintList specifies a list of constraints
+type ConstraintList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []Constraint `json:"items"`
+}
@@ -166,6 +166,18 @@ type DatacenterSpec struct {
 	// EnforcePodSecurityPolicy enforces pod security policy plugin on every clusters within the DC,
 	// ignoring cluster-specific settings
 	EnforcePodSecurityPolicy bool `json:"enforcePodSecurityPolicy,omitempty"`
+
+	// Optional: MaxClusters limits the number of clusters that can be created within the DC.
+	// Creating further clusters is rejected once the limit is reached. Defaults to 0 (unlimited).
+	MaxClusters int `json:"maxClusters,omitempty"`
+
+	// Optional: NodeNetworks are the network ranges (CIDRs) the nodes of the DC are attached to.
+	// The pods and services network ranges of the clusters within the DC must not overlap them.
+	NodeNetworks []string `json:"nodeNetworks,omitempty"`
+
+	// Optional: LoadBalancerClasses are the load balancer implementations available in the DC, e.g. a cloud
+	// load balancer and MetalLB. Clusters can only select one of them as their default load balancer class.
+	LoadBalancerClasses []string `json:"loadBalancerClasses,omitempty"`
 }
 
 // ImageList defines a map of operating system and the image to use
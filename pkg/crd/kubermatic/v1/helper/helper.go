@@ -197,3 +197,17 @@ func GetHealthStatus(status kubermaticv1.HealthStatus, cluster *kubermaticv1.Clu
 
 	return status
 }
+
+// ClusterReconciling returns whether the controllers are still applying the spec of the cluster, which is
+// the case while the controller of the cluster type or the rollout of the control plane did not succeed yet.
+// The metadata.generation can not be used to tell, as the cluster has no status subresource and so every
+// status update increments the generation as well.
+func ClusterReconciling(cluster *kubermaticv1.Cluster) bool {
+	controllerCondition := kubermaticv1.ClusterConditionClusterControllerReconcilingSuccess
+	if cluster.IsOpenshift() {
+		controllerCondition = kubermaticv1.ClusterConditionOpenshiftControllerReconcilingSuccess
+	}
+
+	return cluster.Status.HasConditionValue(controllerCondition, corev1.ConditionFalse) ||
+		cluster.Status.HasConditionValue(kubermaticv1.ClusterConditionSeedResourcesUpToDate, corev1.ConditionFalse)
+}
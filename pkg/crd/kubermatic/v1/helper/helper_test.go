@@ -302,3 +302,59 @@ func clusterWithAllSuccessfulConditions(openshift bool) *kubermaticv1.Cluster {
 	}
 	return c
 }
+
+func TestClusterReconciling(t *testing.T) {
+	testCases := []struct {
+		name              string
+		openshift         bool
+		conditionType     kubermaticv1.ClusterConditionType
+		conditionStatus   corev1.ConditionStatus
+		expectReconciling bool
+	}{
+		{
+			name:              "Control plane is up to date",
+			conditionType:     kubermaticv1.ClusterConditionSeedResourcesUpToDate,
+			conditionStatus:   corev1.ConditionTrue,
+			expectReconciling: false,
+		},
+		{
+			name:              "Control plane is rolling out",
+			conditionType:     kubermaticv1.ClusterConditionSeedResourcesUpToDate,
+			conditionStatus:   corev1.ConditionFalse,
+			expectReconciling: true,
+		},
+		{
+			name:              "Cluster controller did not succeed",
+			conditionType:     kubermaticv1.ClusterConditionClusterControllerReconcilingSuccess,
+			conditionStatus:   corev1.ConditionFalse,
+			expectReconciling: true,
+		},
+		{
+			name:              "Openshift cluster ignores cluster controller condition",
+			openshift:         true,
+			conditionType:     kubermaticv1.ClusterConditionClusterControllerReconcilingSuccess,
+			conditionStatus:   corev1.ConditionFalse,
+			expectReconciling: false,
+		},
+		{
+			name:              "Other controllers are ignored",
+			conditionType:     kubermaticv1.ClusterConditionBackupControllerReconcilingSuccess,
+			conditionStatus:   corev1.ConditionFalse,
+			expectReconciling: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cluster := &kubermaticv1.Cluster{}
+			if tc.openshift {
+				cluster.Annotations = map[string]string{"kubermatic.io/openshift": "true"}
+			}
+			SetClusterCondition(cluster, tc.conditionType, tc.conditionStatus, "", "")
+
+			if reconciling := ClusterReconciling(cluster); reconciling != tc.expectReconciling {
+				t.Errorf("expected reconciling to be %t, got %t", tc.expectReconciling, reconciling)
+			}
+		})
+	}
+}
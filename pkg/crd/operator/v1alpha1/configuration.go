@@ -273,6 +273,12 @@ type KubermaticVersioningConfiguration struct {
 	Versions []*semver.Version `json:"versions,omitempty"`
 	// Default is the default version to offer users.
 	Default *semver.Version `json:"default,omitempty"`
+	// Deprecated lists available versions which will be removed soon. Clusters can still be
+	// created with them, but the creation returns a warning.
+	Deprecated []*semver.Version `json:"deprecated,omitempty"`
+	// EndOfLife lists available versions which reached their end of life. Existing clusters keep
+	// running them, but creating new clusters with them is rejected.
+	EndOfLife []*semver.Version `json:"endOfLife,omitempty"`
 
 	// Updates is a list of available and automatic upgrades.
 	// All 'to' versions must be configured in the version list for this orchestrator.
@@ -410,6 +410,28 @@ func (in *KubermaticVersioningConfiguration) DeepCopyInto(out *KubermaticVersion
 		*out = new(semver.Version)
 		**out = **in
 	}
+	if in.Deprecated != nil {
+		in, out := &in.Deprecated, &out.Deprecated
+		*out = make([]*semver.Version, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(semver.Version)
+				**out = **in
+			}
+		}
+	}
+	if in.EndOfLife != nil {
+		in, out := &in.EndOfLife, &out.EndOfLife
+		*out = make([]*semver.Version, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(semver.Version)
+				**out = **in
+			}
+		}
+	}
 	if in.Updates != nil {
 		in, out := &in.Updates, &out.Updates
 		*out = make([]Update, len(*in))
@@ -30,6 +30,7 @@ import (
 
 	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1/helper"
 	"k8c.io/kubermatic/v2/pkg/handler/middleware"
 	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
 	"k8c.io/kubermatic/v2/pkg/handler/v1/label"
@@ -37,20 +38,28 @@ import (
 	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
 	"k8c.io/kubermatic/v2/pkg/provider"
 	kubernetesprovider "k8c.io/kubermatic/v2/pkg/provider/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/resources"
 	"k8c.io/kubermatic/v2/pkg/resources/cloudcontroller"
 	"k8c.io/kubermatic/v2/pkg/resources/cluster"
 	machineresource "k8c.io/kubermatic/v2/pkg/resources/machine"
 	"k8c.io/kubermatic/v2/pkg/util/errors"
 	"k8c.io/kubermatic/v2/pkg/validation"
 
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/rand"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/klog"
 	"k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // NodeDeploymentEvent represents type of events related to Node Deployment
@@ -62,6 +71,11 @@ const (
 	nodeDeploymentCreationFail    NodeDeploymentEvent = "NodeDeploymentCreationFail"
 )
 
+const (
+	initialNamespacesCreationSuccess = "InitialNamespacesCreationSuccess"
+	initialNamespacesCreationFail    = "InitialNamespacesCreationFail"
+)
+
 // ClusterTypes holds a list of supported cluster types
 var ClusterTypes = sets.NewString(apiv1.OpenShiftClusterType, apiv1.KubernetesClusterType)
 
@@ -79,7 +93,7 @@ type patchCluster struct {
 
 func CreateEndpoint(ctx context.Context, projectID string, body apiv1.CreateClusterSpec, sshKeyProvider provider.SSHKeyProvider, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, seedsGetter provider.SeedsGetter,
 	initNodeDeploymentFailures *prometheus.CounterVec, eventRecorderProvider provider.EventRecorderProvider, credentialManager provider.PresetProvider,
-	exposeStrategy corev1.ServiceType, userInfoGetter provider.UserInfoGetter) (interface{}, error) {
+	exposeStrategy corev1.ServiceType, userInfoGetter provider.UserInfoGetter, warnings []string) (interface{}, error) {
 
 	clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
 	privilegedClusterProvider := ctx.Value(middleware.PrivilegedClusterProviderContextKey).(provider.PrivilegedClusterProvider)
@@ -107,6 +121,14 @@ func CreateEndpoint(ctx context.Context, projectID string, body apiv1.CreateClus
 		body.Cluster.Spec.Cloud = *cloudSpec
 	}
 
+	if err := validateAuditLogging(body.Cluster.Spec.AuditLogging, body.Cluster.Spec.Cloud.DatacenterName, dc); err != nil {
+		return nil, err
+	}
+
+	if err := resolveOpenshiftImagePullSecret(ctx, privilegedClusterProvider.GetSeedClusterAdminRuntimeClient(), projectID, body.Cluster.Spec.Openshift); err != nil {
+		return nil, err
+	}
+
 	// Create the cluster.
 	secretKeyGetter := provider.SecretKeySelectorValueFuncFactory(ctx, privilegedClusterProvider.GetSeedClusterAdminRuntimeClient())
 	spec, err := cluster.Spec(body.Cluster, dc, secretKeyGetter)
@@ -129,6 +151,10 @@ func CreateEndpoint(ctx context.Context, projectID string, body apiv1.CreateClus
 		return nil, errors.NewAlreadyExists("cluster", spec.HumanReadableName)
 	}
 
+	if err := checkDatacenterCapacity(clusterProvider, body.Cluster.Spec.Cloud.DatacenterName, dc); err != nil {
+		return nil, err
+	}
+
 	if err = validation.ValidateUpdateWindow(spec.UpdateWindow); err != nil {
 		return nil, common.KubernetesErrorToHTTPError(err)
 	}
@@ -150,16 +176,32 @@ func CreateEndpoint(ctx context.Context, projectID string, body apiv1.CreateClus
 		}
 	}
 
+	if err := validateEgressAllowlistCNI(partialCluster); err != nil {
+		return nil, err
+	}
+
+	// the initial node deployment is created in the background, so the operating system has to be
+	// checked upfront to not end up with a cluster without nodes
+	if body.NodeDeployment != nil && body.NodeDeployment.Spec.Replicas > 0 {
+		if err := machineresource.ValidateOperatingSystem(partialCluster.Spec.Cloud, body.NodeDeployment.Spec.Template); err != nil {
+			return nil, errors.NewBadRequest("invalid initial node deployment: %v", err)
+		}
+	}
+
+	var enforcedByDatacenter []string
+
 	// Enforce audit logging
 	if dc.Spec.EnforceAuditLogging {
 		partialCluster.Spec.AuditLogging = &kubermaticv1.AuditLoggingSettings{
 			Enabled: true,
 		}
+		enforcedByDatacenter = append(enforcedByDatacenter, "spec.auditLogging.enabled")
 	}
 
 	// Enforce PodSecurityPolicy
 	if dc.Spec.EnforcePodSecurityPolicy {
 		partialCluster.Spec.UsePodSecurityPolicyAdmissionPlugin = true
+		enforcedByDatacenter = append(enforcedByDatacenter, "spec.usePodSecurityPolicyAdmissionPlugin")
 	}
 
 	// generate the name here so that it can be used in the secretName below
@@ -206,6 +248,21 @@ func CreateEndpoint(ctx context.Context, projectID string, body apiv1.CreateClus
 		}
 	}
 
+	// Create the initial namespaces in the background.
+	if len(body.InitialNamespaces) > 0 {
+		go func() {
+			defer utilruntime.HandleCrash()
+			err := createInitialNamespacesWithRetries(ctx, body.InitialNamespaces, newCluster, project, clusterProvider, privilegedClusterProvider, userInfoGetter)
+			if err != nil {
+				eventRecorderProvider.ClusterRecorderFor(k8sClient).Eventf(newCluster, corev1.EventTypeWarning, initialNamespacesCreationFail, "Failed to create initial namespaces %s: %v", strings.Join(body.InitialNamespaces, ", "), err)
+				klog.Errorf("failed to create initial namespaces for cluster %s: %v", newCluster.Name, err)
+			} else {
+				eventRecorderProvider.ClusterRecorderFor(k8sClient).Eventf(newCluster, corev1.EventTypeNormal, initialNamespacesCreationSuccess, "Successfully created initial namespaces %s", strings.Join(body.InitialNamespaces, ", "))
+				klog.V(5).Infof("created initial namespaces for cluster %s", newCluster.Name)
+			}
+		}()
+	}
+
 	log := kubermaticlog.Logger.With("cluster", newCluster.Name)
 
 	// Block for up to 10 seconds to give the rbac controller time to create the bindings.
@@ -222,7 +279,34 @@ func CreateEndpoint(ctx context.Context, projectID string, body apiv1.CreateClus
 		return convertInternalClusterToExternal(newCluster, true), errors.New(http.StatusInternalServerError, "timed out waiting for cluster to become ready")
 	}
 
-	return convertInternalClusterToExternal(newCluster, true), nil
+	apiCluster := convertInternalClusterToExternal(newCluster, true)
+	apiCluster.Warnings = warnings
+	apiCluster.EnforcedByDatacenter = enforcedByDatacenter
+	return apiCluster, nil
+}
+
+// checkDatacenterCapacity returns an error when the datacenter has reached its configured maximum number of clusters
+func checkDatacenterCapacity(clusterProvider provider.ClusterProvider, datacenterName string, dc *kubermaticv1.Datacenter) error {
+	if dc.Spec.MaxClusters <= 0 {
+		return nil
+	}
+
+	clusters, err := clusterProvider.ListAll()
+	if err != nil {
+		return common.KubernetesErrorToHTTPError(err)
+	}
+
+	var clustersInDatacenter int
+	for _, existingCluster := range clusters.Items {
+		if existingCluster.Spec.Cloud.DatacenterName == datacenterName {
+			clustersInDatacenter++
+		}
+	}
+
+	if clustersInDatacenter >= dc.Spec.MaxClusters {
+		return errors.New(http.StatusServiceUnavailable, fmt.Sprintf("datacenter %s is at capacity", datacenterName))
+	}
+	return nil
 }
 
 func GetExternalClusters(ctx context.Context, userInfoGetter provider.UserInfoGetter, clusterProvider provider.ClusterProvider, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, projectID string) ([]*apiv1.Cluster, error) {
@@ -261,7 +345,45 @@ func GetEndpoint(ctx context.Context, projectProvider provider.ProjectProvider,
 		return nil, err
 	}
 
-	return convertInternalClusterToExternal(cluster, true), nil
+	return ConvertClusterToAPIWithStatus(ctx, cluster)
+}
+
+// ConvertClusterToAPIWithStatus converts the cluster to its API representation, including the parts
+// of the status which are read from the seed cluster
+func ConvertClusterToAPIWithStatus(ctx context.Context, cluster *kubermaticv1.Cluster) (*apiv1.Cluster, error) {
+	var err error
+	apiCluster := convertInternalClusterToExternal(cluster, true)
+
+	privilegedClusterProvider := ctx.Value(middleware.PrivilegedClusterProviderContextKey).(provider.PrivilegedClusterProvider)
+	apiCluster.Status.LastBackupTime, err = getLastBackupTime(ctx, privilegedClusterProvider.GetSeedClusterAdminRuntimeClient(), cluster.Name)
+	if err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+
+	return apiCluster, nil
+}
+
+// getLastBackupTime returns the completion time of the most recent successful etcd backup job of the cluster,
+// or nil if there is none
+func getLastBackupTime(ctx context.Context, seedClient ctrlruntimeclient.Client, clusterName string) (*apiv1.Time, error) {
+	jobs := &batchv1.JobList{}
+	if err := seedClient.List(ctx, jobs,
+		ctrlruntimeclient.InNamespace(metav1.NamespaceSystem),
+		ctrlruntimeclient.MatchingLabels(resources.AppClusterLabels(resources.EtcdBackupJobAppName, clusterName, nil))); err != nil {
+		return nil, err
+	}
+
+	var lastBackupTime *apiv1.Time
+	for _, job := range jobs.Items {
+		if job.Status.Succeeded == 0 || job.Status.CompletionTime == nil {
+			continue
+		}
+		if lastBackupTime == nil || job.Status.CompletionTime.Time.After(lastBackupTime.Time) {
+			completionTime := apiv1.NewTime(job.Status.CompletionTime.Time)
+			lastBackupTime = &completionTime
+		}
+	}
+	return lastBackupTime, nil
 }
 
 func DeleteEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectID, clusterID string, deleteVolumes, deleteLoadBalancers bool, sshKeyProvider provider.SSHKeyProvider, privilegedSSHKeyProvider provider.PrivilegedSSHKeyProvider, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider) (interface{}, error) {
@@ -305,7 +427,7 @@ func DeleteEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGetter,
 	return nil, updateAndDeleteCluster(ctx, userInfoGetter, clusterProvider, privilegedClusterProvider, project, existingCluster)
 }
 
-func PatchEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectID, clusterID string, patch json.RawMessage, seedsGetter provider.SeedsGetter, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider) (interface{}, error) {
+func PatchEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectID, clusterID string, patch json.RawMessage, disruptionAcknowledged bool, seedsGetter provider.SeedsGetter, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider) (interface{}, error) {
 	clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
 	privilegedClusterProvider := ctx.Value(middleware.PrivilegedClusterProviderContextKey).(provider.PrivilegedClusterProvider)
 
@@ -346,6 +468,86 @@ func PatchEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGetter,
 		return nil, errors.NewBadRequest("cannot decode patched cluster: %v", err)
 	}
 
+	if err := validateExposeStrategyChange(oldInternalCluster.Spec.ExposeStrategy, patchedCluster.Spec.ExposeStrategy, disruptionAcknowledged); err != nil {
+		return nil, err
+	}
+
+	return updateClusterFromAPI(ctx, userInfoGetter, clusterProvider, privilegedClusterProvider, project, projectID, seedsGetter, oldInternalCluster, patchedCluster)
+}
+
+// UpdateEndpoint replaces the spec of the cluster with the spec of the given cluster. The status of the cluster
+// is preserved. Credentials are never returned by the API, so the credentials of the stored cloud spec are kept
+// when the given cloud spec does not set them.
+func UpdateEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectID, clusterID string, newCluster apiv1.Cluster, seedsGetter provider.SeedsGetter, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider) (interface{}, error) {
+	clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
+	privilegedClusterProvider := ctx.Value(middleware.PrivilegedClusterProviderContextKey).(provider.PrivilegedClusterProvider)
+
+	if newCluster.ID != "" && newCluster.ID != clusterID {
+		return nil, errors.NewBadRequest("the cluster ID %q of the body does not match the cluster ID %q of the path", newCluster.ID, clusterID)
+	}
+	if newCluster.Spec.Version.Semver() == nil {
+		return nil, errors.NewBadRequest("spec.version is required")
+	}
+
+	project, err := common.GetProject(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, projectID, nil)
+	if err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+
+	oldInternalCluster, err := GetInternalCluster(ctx, userInfoGetter, clusterProvider, privilegedClusterProvider, project, projectID, clusterID, &provider.ClusterGetOptions{})
+	if err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+
+	oldProviderName, err := provider.ClusterCloudProviderName(oldInternalCluster.Spec.Cloud)
+	if err != nil {
+		return nil, errors.New(http.StatusInternalServerError, err.Error())
+	}
+	newProviderName, err := provider.ClusterCloudProviderName(newCluster.Spec.Cloud)
+	if err != nil {
+		return nil, errors.NewBadRequest("invalid cloud spec: %v", err)
+	}
+	if newProviderName != oldProviderName {
+		return nil, errors.NewBadRequest("changing the cloud provider from %q to %q is not allowed", oldProviderName, newProviderName)
+	}
+
+	oldCloudJSON, err := json.Marshal(oldInternalCluster.Spec.Cloud)
+	if err != nil {
+		return nil, errors.New(http.StatusInternalServerError, err.Error())
+	}
+	newCloudJSON, err := json.Marshal(newCluster.Spec.Cloud)
+	if err != nil {
+		return nil, errors.NewBadRequest("cannot decode cloud spec: %v", err)
+	}
+	mergedCloudJSON, err := jsonpatch.MergePatch(oldCloudJSON, newCloudJSON)
+	if err != nil {
+		return nil, errors.NewBadRequest("cannot merge cloud spec: %v", err)
+	}
+	newCluster.Spec.Cloud = kubermaticv1.CloudSpec{}
+	if err := json.Unmarshal(mergedCloudJSON, &newCluster.Spec.Cloud); err != nil {
+		return nil, errors.NewBadRequest("cannot decode cloud spec: %v", err)
+	}
+
+	if newCluster.Spec.ExposeStrategy != "" && newCluster.Spec.ExposeStrategy != oldInternalCluster.Spec.ExposeStrategy {
+		return nil, errors.NewBadRequest("the exposeStrategy can only be changed with a patch acknowledging the disruption")
+	}
+
+	// the system labels are not returned by the API, they must not get lost
+	for _, systemLabel := range label.GetSystemLabels()[label.ClusterResourceType] {
+		if value, ok := oldInternalCluster.Labels[systemLabel]; ok {
+			if newCluster.Labels == nil {
+				newCluster.Labels = map[string]string{}
+			}
+			newCluster.Labels[systemLabel] = value
+		}
+	}
+
+	return updateClusterFromAPI(ctx, userInfoGetter, clusterProvider, privilegedClusterProvider, project, projectID, seedsGetter, oldInternalCluster, &newCluster)
+}
+
+// updateClusterFromAPI updates the cluster with the user-modifiable fields of the given API cluster, after
+// validating the version skew of the nodes and the new spec
+func updateClusterFromAPI(ctx context.Context, userInfoGetter provider.UserInfoGetter, clusterProvider provider.ClusterProvider, privilegedClusterProvider provider.PrivilegedClusterProvider, project *kubermaticv1.Project, projectID string, seedsGetter provider.SeedsGetter, oldInternalCluster *kubermaticv1.Cluster, patchedCluster *apiv1.Cluster) (*apiv1.Cluster, error) {
 	// Only specific fields from old internal cluster will be updated by a patch.
 	// It prevents user from changing other fields like resource ID or version that should not be modified.
 	newInternalCluster := oldInternalCluster.DeepCopy()
@@ -361,13 +563,34 @@ func PatchEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGetter,
 	newInternalCluster.Spec.AuditLogging = patchedCluster.Spec.AuditLogging
 	newInternalCluster.Spec.Openshift = patchedCluster.Spec.Openshift
 	newInternalCluster.Spec.UpdateWindow = patchedCluster.Spec.UpdateWindow
+	newInternalCluster.Spec.Description = patchedCluster.Spec.Description
+	newInternalCluster.Spec.Autoscaler = patchedCluster.Spec.Autoscaler
+	newInternalCluster.Spec.ExternalDNS = patchedCluster.Spec.ExternalDNS
+	newInternalCluster.Spec.EgressAllowlist = patchedCluster.Spec.EgressAllowlist
+	newInternalCluster.Spec.Connectivity = patchedCluster.Spec.Connectivity
+	newInternalCluster.Spec.DisableNodeSSH = patchedCluster.Spec.DisableNodeSSH
+	newInternalCluster.Spec.AlertmanagerConfig = patchedCluster.Spec.AlertmanagerConfig
+	newInternalCluster.Spec.RegistryMirror = patchedCluster.Spec.RegistryMirror
+	newInternalCluster.Spec.SchedulerConfig = patchedCluster.Spec.SchedulerConfig
+	newInternalCluster.Spec.DNS = patchedCluster.Spec.DNS
+	newInternalCluster.Spec.ServiceNodePortRange = patchedCluster.Spec.ServiceNodePortRange
+	newInternalCluster.Spec.MetricsServer = patchedCluster.Spec.MetricsServer
+	newInternalCluster.Spec.LoadBalancerClass = patchedCluster.Spec.LoadBalancerClass
+	if patchedCluster.Spec.ExposeStrategy != "" {
+		newInternalCluster.Spec.ExposeStrategy = patchedCluster.Spec.ExposeStrategy
+	}
+	cluster.SetComponentLogLevels(&newInternalCluster.Spec.ComponentsOverride, patchedCluster.Spec.ComponentsOverride)
+	cluster.SetEtcdMaintenance(&newInternalCluster.Spec.ComponentsOverride, patchedCluster.Spec.ComponentsOverride)
+	if err := cluster.SetMachineControllerResources(&newInternalCluster.Spec.ComponentsOverride, patchedCluster.Spec.ComponentsOverride); err != nil {
+		return nil, errors.NewBadRequest("invalid cluster: %v", err)
+	}
 
 	incompatibleKubelets, err := common.CheckClusterVersionSkew(ctx, userInfoGetter, clusterProvider, newInternalCluster, projectID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check existing nodes' version skew: %v", err)
 	}
 	if len(incompatibleKubelets) > 0 {
-		return nil, errors.NewBadRequest("Cluster contains nodes running the following incompatible kubelet versions: %v. Upgrade your nodes before you upgrade the cluster.", incompatibleKubelets)
+		return nil, errors.NewBadRequest("Cluster contains nodes running incompatible kubelet versions: %s. Upgrade your nodes before you upgrade the cluster.", strings.Join(incompatibleKubelets, ", "))
 	}
 
 	userInfo, err := userInfoGetter(ctx, "")
@@ -376,9 +599,26 @@ func PatchEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGetter,
 	}
 	_, dc, err := provider.DatacenterFromSeedMap(userInfo, seedsGetter, newInternalCluster.Spec.Cloud.DatacenterName)
 	if err != nil {
+		// a changed datacenter must be accessible by the user the same way as on creation,
+		// otherwise email-restricted datacenters could be reached by patching an existing cluster
+		if newInternalCluster.Spec.Cloud.DatacenterName != oldInternalCluster.Spec.Cloud.DatacenterName {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
 		return nil, fmt.Errorf("error getting dc: %v", err)
 	}
 
+	if err := validateAuditLogging(newInternalCluster.Spec.AuditLogging, newInternalCluster.Spec.Cloud.DatacenterName, dc); err != nil {
+		return nil, err
+	}
+
+	if err := validateEgressAllowlistCNI(newInternalCluster); err != nil {
+		return nil, err
+	}
+
+	if err := resolveOpenshiftImagePullSecret(ctx, privilegedClusterProvider.GetSeedClusterAdminRuntimeClient(), projectID, newInternalCluster.Spec.Openshift); err != nil {
+		return nil, err
+	}
+
 	if err := kubernetesprovider.CreateOrUpdateCredentialSecretForCluster(ctx, privilegedClusterProvider.GetSeedClusterAdminRuntimeClient(), newInternalCluster); err != nil {
 		return nil, err
 	}
@@ -406,15 +646,46 @@ func PatchEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGetter,
 		return nil, common.KubernetesErrorToHTTPError(err)
 	}
 
+	metricsServerWarning := metricsServerDisableWarning(ctx, userInfoGetter, clusterProvider, projectID, oldInternalCluster, newInternalCluster)
+
 	updatedCluster, err := updateCluster(ctx, userInfoGetter, clusterProvider, privilegedClusterProvider, project, newInternalCluster)
 	if err != nil {
 		return nil, common.KubernetesErrorToHTTPError(err)
 	}
 
-	return convertInternalClusterToExternal(updatedCluster, true), nil
+	apiCluster := convertInternalClusterToExternal(updatedCluster, true)
+	if updatedCluster.Spec.ServiceNodePortRange != oldInternalCluster.Spec.ServiceNodePortRange {
+		apiCluster.Warnings = append(apiCluster.Warnings, "the service node port range has changed, existing NodePort services with ports outside of the new range may stop working")
+	}
+	if metricsServerWarning != "" {
+		apiCluster.Warnings = append(apiCluster.Warnings, metricsServerWarning)
+	}
+	return apiCluster, nil
+}
+
+// metricsServerDisableWarning returns a warning when the metrics-server addon gets disabled
+// while HorizontalPodAutoscalers exist in the user cluster. The check is best effort,
+// an unreachable user cluster results in no warning.
+func metricsServerDisableWarning(ctx context.Context, userInfoGetter provider.UserInfoGetter, clusterProvider provider.ClusterProvider, projectID string, oldCluster, newCluster *kubermaticv1.Cluster) string {
+	if newCluster.Spec.MetricsServer == nil || newCluster.Spec.MetricsServer.Enabled {
+		return ""
+	}
+	if oldCluster.Spec.MetricsServer != nil && !oldCluster.Spec.MetricsServer.Enabled {
+		return ""
+	}
+
+	client, err := common.GetClusterClient(ctx, userInfoGetter, clusterProvider, newCluster, projectID)
+	if err != nil {
+		return ""
+	}
+	hpas := &autoscalingv1.HorizontalPodAutoscalerList{}
+	if err := client.List(ctx, hpas); err != nil || len(hpas.Items) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("the metrics-server addon is being removed, %d HorizontalPodAutoscaler(s) of the cluster will stop scaling", len(hpas.Items))
 }
 
-func GetClusterEventsEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectID, clusterID, eventType string, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider) (interface{}, error) {
+func GetClusterEventsEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectID, clusterID, eventType, search string, fieldSelector fields.Selector, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider) (interface{}, error) {
 	clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
 	privilegedClusterProvider := ctx.Value(middleware.PrivilegedClusterProviderContextKey).(provider.PrivilegedClusterProvider)
 	client := privilegedClusterProvider.GetSeedClusterAdminRuntimeClient()
@@ -429,6 +700,17 @@ func GetClusterEventsEndpoint(ctx context.Context, userInfoGetter provider.UserI
 		return nil, common.KubernetesErrorToHTTPError(err)
 	}
 
+	events, err := ListClusterEvents(ctx, client, cluster, eventType, search, fieldSelector)
+	if err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+
+	return events, nil
+}
+
+// ListClusterEvents returns the events of the cluster of the given type which match the search string and the
+// field selector. The events are listed with the given seed client, the caller must have authorized the user.
+func ListClusterEvents(ctx context.Context, seedClient ctrlruntimeclient.Client, cluster *kubermaticv1.Cluster, eventType, search string, fieldSelector fields.Selector) ([]apiv1.Event, error) {
 	eventTypeAPI := ""
 	switch eventType {
 	case "warning":
@@ -437,9 +719,9 @@ func GetClusterEventsEndpoint(ctx context.Context, userInfoGetter provider.UserI
 		eventTypeAPI = corev1.EventTypeNormal
 	}
 
-	events, err := common.GetEvents(ctx, client, cluster, "")
+	events, err := common.SearchEvents(ctx, seedClient, cluster, "", search, fieldSelector)
 	if err != nil {
-		return nil, common.KubernetesErrorToHTTPError(err)
+		return nil, err
 	}
 
 	if len(eventTypeAPI) > 0 {
@@ -449,7 +731,9 @@ func GetClusterEventsEndpoint(ctx context.Context, userInfoGetter provider.UserI
 	return events, nil
 }
 
-func HealthEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectID, clusterID string, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider) (interface{}, error) {
+// HealthEndpoint returns the health of the cluster components. The details explaining the health of every component,
+// including the configured and the ready apiserver replicas, are only returned when detailed is set.
+func HealthEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectID, clusterID string, detailed bool, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider) (interface{}, error) {
 	clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
 	privilegedClusterProvider := ctx.Value(middleware.PrivilegedClusterProviderContextKey).(provider.PrivilegedClusterProvider)
 	project, err := common.GetProject(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, projectID, nil)
@@ -462,7 +746,7 @@ func HealthEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGetter,
 		return nil, common.KubernetesErrorToHTTPError(err)
 	}
 
-	return apiv1.ClusterHealth{
+	health := apiv1.ClusterHealth{
 		Apiserver:                    existingCluster.Status.ExtendedHealth.Apiserver,
 		Scheduler:                    existingCluster.Status.ExtendedHealth.Scheduler,
 		Controller:                   existingCluster.Status.ExtendedHealth.Controller,
@@ -470,7 +754,109 @@ func HealthEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGetter,
 		Etcd:                         existingCluster.Status.ExtendedHealth.Etcd,
 		CloudProviderInfrastructure:  existingCluster.Status.ExtendedHealth.CloudProviderInfrastructure,
 		UserClusterControllerManager: existingCluster.Status.ExtendedHealth.UserClusterControllerManager,
-	}, nil
+		Status:                       clusterHealthStatus(existingCluster.Status.ExtendedHealth),
+	}
+	if !detailed {
+		return health, nil
+	}
+
+	details := clusterHealthDetails(existingCluster)
+	apiserverReplicas, err := getApiserverReplicas(ctx, privilegedClusterProvider.GetSeedClusterAdminRuntimeClient(), existingCluster)
+	if err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+	apiserverDetails := details["apiserver"]
+	apiserverDetails.Replicas = apiserverReplicas
+	details["apiserver"] = apiserverDetails
+	health.Details = details
+
+	return health, nil
+}
+
+// getApiserverReplicas returns the configured and the ready replicas of the apiserver, which tells a
+// control plane running all of its replicas apart from one that is merely up.
+func getApiserverReplicas(ctx context.Context, client ctrlruntimeclient.Client, cluster *kubermaticv1.Cluster) (*apiv1.ComponentReplicas, error) {
+	replicas := &apiv1.ComponentReplicas{Desired: 1}
+	if cluster.Spec.ComponentsOverride.Apiserver.Replicas != nil {
+		replicas.Desired = *cluster.Spec.ComponentsOverride.Apiserver.Replicas
+	}
+
+	deployment := &appsv1.Deployment{}
+	err := client.Get(ctx, types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: resources.ApiserverDeploymentName}, deployment)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return replicas, nil
+		}
+		return nil, err
+	}
+	replicas.Ready = deployment.Status.ReadyReplicas
+	return replicas, nil
+}
+
+// clusterHealthStatus rolls the health of the components up into the health of the cluster. The cluster is
+// unhealthy without a running apiserver or etcd and degraded as long as any other component is not up, which
+// includes components that are still being provisioned.
+func clusterHealthStatus(health kubermaticv1.ExtendedClusterHealth) apiv1.ClusterHealthStatus {
+	if health.Apiserver == kubermaticv1.HealthStatusDown || health.Etcd == kubermaticv1.HealthStatusDown {
+		return apiv1.ClusterHealthStatusUnhealthy
+	}
+	if !health.AllHealthy() {
+		return apiv1.ClusterHealthStatusDegraded
+	}
+	return apiv1.ClusterHealthStatusHealthy
+}
+
+// clusterHealthDetails explains the health status of every component of the cluster. The health of the
+// cloud provider infrastructure and of etcd comes with a condition of its own, the health of the control
+// plane deployments with the condition of the controller reconciling them.
+func clusterHealthDetails(cluster *kubermaticv1.Cluster) map[string]apiv1.ComponentHealthDetails {
+	health := cluster.Status.ExtendedHealth
+	controllerCondition := kubermaticv1.ClusterConditionClusterControllerReconcilingSuccess
+	if cluster.IsOpenshift() {
+		controllerCondition = kubermaticv1.ClusterConditionOpenshiftControllerReconcilingSuccess
+	}
+
+	components := []struct {
+		field      string
+		status     kubermaticv1.HealthStatus
+		downReason string
+		condition  kubermaticv1.ClusterConditionType
+	}{
+		{field: "apiserver", status: health.Apiserver, downReason: "the apiserver deployment has no ready replicas", condition: controllerCondition},
+		{field: "scheduler", status: health.Scheduler, downReason: "the scheduler deployment has no ready replicas", condition: controllerCondition},
+		{field: "controller", status: health.Controller, downReason: "the controller-manager deployment has no ready replicas", condition: controllerCondition},
+		{field: "machineController", status: health.MachineController, downReason: "the machine-controller deployment has no ready replicas", condition: controllerCondition},
+		{field: "etcd", status: health.Etcd, downReason: "the etcd statefulset has less than 2 ready members", condition: kubermaticv1.ClusterConditionEtcdClusterInitialized},
+		{field: "cloudProviderInfrastructure", status: health.CloudProviderInfrastructure, downReason: "the cloud provider infrastructure was not set up", condition: kubermaticv1.ClusterConditionCloudControllerReconcilingSuccess},
+		{field: "userClusterControllerManager", status: health.UserClusterControllerManager, downReason: "the usercluster-controller deployment has no ready replicas", condition: controllerCondition},
+	}
+
+	details := make(map[string]apiv1.ComponentHealthDetails, len(components))
+	for _, component := range components {
+		var componentDetails apiv1.ComponentHealthDetails
+		switch component.status {
+		case kubermaticv1.HealthStatusUp:
+			componentDetails.Reason = "the component is running"
+		case kubermaticv1.HealthStatusProvisioning:
+			componentDetails.Reason = "the component is being provisioned"
+		default:
+			componentDetails.Reason = component.downReason
+		}
+
+		_, condition := kubermaticv1helper.GetClusterCondition(cluster, component.condition)
+		if condition != nil {
+			// a failing condition tells why the component is not healthy
+			if component.status != kubermaticv1.HealthStatusUp && condition.Status != corev1.ConditionTrue && condition.Message != "" {
+				componentDetails.Reason = fmt.Sprintf("%s: %s", componentDetails.Reason, condition.Message)
+			}
+			if !condition.LastTransitionTime.IsZero() {
+				lastTransitionTime := apiv1.NewTime(condition.LastTransitionTime.Time)
+				componentDetails.LastTransitionTime = &lastTransitionTime
+			}
+		}
+		details[component.field] = componentDetails
+	}
+	return details
 }
 
 func UpdateClusterSSHKey(ctx context.Context, userInfoGetter provider.UserInfoGetter, sshKeyProvider provider.SSHKeyProvider, privilegedSSHKeyProvider provider.PrivilegedSSHKeyProvider, clusterSSHKey *kubermaticv1.UserSSHKey, projectID string) error {
@@ -641,6 +1027,43 @@ func createInitialNodeDeployment(endpointContext context.Context, nodeDeployment
 	return client.Create(ctx, md)
 }
 
+func createInitialNamespacesWithRetries(endpointContext context.Context, namespaces []string, cluster *kubermaticv1.Cluster, project *kubermaticv1.Project,
+	clusterProvider provider.ClusterProvider, privilegedClusterProvider provider.PrivilegedClusterProvider, userInfoGetter provider.UserInfoGetter) error {
+	return wait.Poll(5*time.Second, 30*time.Minute, func() (bool, error) {
+		if err := createInitialNamespaces(endpointContext, namespaces, cluster, project, clusterProvider, privilegedClusterProvider, userInfoGetter); err != nil {
+			klog.V(4).Infof("retrying creating initial namespaces for cluster %s (%s) due to %v", cluster.Name, cluster.Spec.HumanReadableName, err)
+			return false, nil
+		}
+		return true, nil
+	})
+}
+
+// createInitialNamespaces creates the namespaces in the user cluster once its control plane is ready.
+// Namespaces which exist already are left untouched.
+func createInitialNamespaces(endpointContext context.Context, namespaces []string, cluster *kubermaticv1.Cluster, project *kubermaticv1.Project,
+	clusterProvider provider.ClusterProvider, privilegedClusterProvider provider.PrivilegedClusterProvider, userInfoGetter provider.UserInfoGetter) error {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	cluster, err := GetInternalCluster(endpointContext, userInfoGetter, clusterProvider, privilegedClusterProvider, project, project.Name, cluster.Name, &provider.ClusterGetOptions{CheckInitStatus: true})
+	if err != nil {
+		return err
+	}
+
+	client, err := common.GetClusterClient(endpointContext, userInfoGetter, clusterProvider, cluster, project.Name)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range namespaces {
+		namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		if err := client.Create(ctx, namespace); err != nil && !kerrors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create namespace %s: %v", name, err)
+		}
+	}
+	return nil
+}
+
 func getNodeDeploymentDisplayName(nd *apiv1.NodeDeployment) string {
 	if len(nd.Name) != 0 {
 		return " " + nd.Name
@@ -719,25 +1142,122 @@ func convertInternalClusterToExternal(internalCluster *kubermaticv1.Cluster, fil
 			UsePodSecurityPolicyAdmissionPlugin: internalCluster.Spec.UsePodSecurityPolicyAdmissionPlugin,
 			UsePodNodeSelectorAdmissionPlugin:   internalCluster.Spec.UsePodNodeSelectorAdmissionPlugin,
 			AdmissionPlugins:                    internalCluster.Spec.AdmissionPlugins,
+			Description:                         internalCluster.Spec.Description,
+			AdminGroups:                         internalCluster.Spec.AdminGroups,
+			Autoscaler:                          internalCluster.Spec.Autoscaler,
+			AddonVersions:                       internalCluster.Spec.AddonVersions,
+			ExternalDNS:                         internalCluster.Spec.ExternalDNS,
+			EgressAllowlist:                     internalCluster.Spec.EgressAllowlist,
+			Connectivity:                        internalCluster.Spec.Connectivity,
+			DisableNodeSSH:                      internalCluster.Spec.DisableNodeSSH,
+			AlertmanagerConfig:                  internalCluster.Spec.AlertmanagerConfig,
+			MaxPodsPerNode:                      internalCluster.Spec.MaxPodsPerNode,
+			ServiceNodePortRange:                internalCluster.Spec.ServiceNodePortRange,
+			RegistryMirror:                      internalCluster.Spec.RegistryMirror,
+			SchedulerConfig:                     internalCluster.Spec.SchedulerConfig,
+			DNS:                                 internalCluster.Spec.DNS,
+			ExposeStrategy:                      internalCluster.Spec.ExposeStrategy,
+			MetricsServer:                       internalCluster.Spec.MetricsServer,
+			LoadBalancerClass:                   internalCluster.Spec.LoadBalancerClass,
 		},
 		Status: apiv1.ClusterStatus{
-			Version: internalCluster.Spec.Version,
-			URL:     internalCluster.Address.URL,
+			Version:     internalCluster.Spec.Version,
+			URL:         internalCluster.Address.URL,
+			Reconciling: kubermaticv1helper.ClusterReconciling(internalCluster),
 		},
 		Type: apiv1.KubernetesClusterType,
 	}
 
+	if lastReconcileTime := internalCluster.Status.LastReconcileTime; lastReconcileTime != nil {
+		reconcileTime := apiv1.NewTime(lastReconcileTime.Time)
+		cluster.Status.LastReconcileTime = &reconcileTime
+	}
+	if lastReconcileDuration := internalCluster.Status.LastReconcileDuration; lastReconcileDuration != nil {
+		cluster.Status.LastReconcileDuration = lastReconcileDuration.Duration.String()
+	}
+
 	if filterSystemLabels {
-		cluster.Labels = label.FilterLabels(label.ClusterResourceType, internalCluster.Labels)
+		// filter a copy, the labels of the internal cluster must not be modified
+		labels := make(map[string]string, len(internalCluster.Labels))
+		for key, value := range internalCluster.Labels {
+			labels[key] = value
+		}
+		cluster.Labels = label.FilterLabels(label.ClusterResourceType, labels)
 	}
 	if internalCluster.IsOpenshift() {
 		cluster.Type = apiv1.OpenShiftClusterType
 	}
+	if internalCluster.Spec.ClusterNetwork.ProxyMode != "" {
+		cluster.Spec.KubeProxy = &apiv1.KubeProxySettings{Mode: internalCluster.Spec.ClusterNetwork.ProxyMode}
+	}
+	if internalCluster.Spec.ClusterNetwork.IPFamily != "" {
+		cluster.Spec.ClusterNetwork = &apiv1.ClusterNetworkSettings{
+			IPFamily: internalCluster.Spec.ClusterNetwork.IPFamily,
+			Pods:     internalCluster.Spec.ClusterNetwork.Pods.CIDRBlocks,
+			Services: internalCluster.Spec.ClusterNetwork.Services.CIDRBlocks,
+		}
+	}
+	cluster.Spec.ComponentsOverride = convertInternalComponentsOverrideToExternal(internalCluster.Spec.ComponentsOverride)
 
 	return cluster
 }
 
+// convertInternalComponentsOverrideToExternal returns the log levels of the control plane components,
+// the machine-controller resources and the etcd maintenance settings, or nil when none of them is set.
+func convertInternalComponentsOverrideToExternal(settings kubermaticv1.ComponentSettings) *apiv1.ComponentSettings {
+	var etcd *apiv1.EtcdOverride
+	if settings.Etcd.CompactionMode != "" || settings.Etcd.DefragSchedule != "" {
+		etcd = &apiv1.EtcdOverride{
+			CompactionMode: settings.Etcd.CompactionMode,
+			DefragSchedule: settings.Etcd.DefragSchedule,
+		}
+	}
+	if settings.Apiserver.LogLevel == nil && settings.ControllerManager.LogLevel == nil && settings.Scheduler.LogLevel == nil && settings.MachineController.Resources == nil && etcd == nil {
+		return nil
+	}
+
+	componentOverride := func(logLevel *int) *apiv1.ComponentOverride {
+		if logLevel == nil {
+			return nil
+		}
+		return &apiv1.ComponentOverride{LogLevel: logLevel}
+	}
+	return &apiv1.ComponentSettings{
+		Apiserver:         componentOverride(settings.Apiserver.LogLevel),
+		ControllerManager: componentOverride(settings.ControllerManager.LogLevel),
+		Scheduler:         componentOverride(settings.Scheduler.LogLevel),
+		MachineController: convertInternalMachineControllerSettingsToExternal(settings.MachineController),
+		Etcd:              etcd,
+	}
+}
+
+func convertInternalMachineControllerSettingsToExternal(settings kubermaticv1.MachineControllerSettings) *apiv1.MachineControllerOverride {
+	if settings.Resources == nil {
+		return nil
+	}
+
+	quantities := func(list corev1.ResourceList) map[string]string {
+		if len(list) == 0 {
+			return nil
+		}
+		result := map[string]string{}
+		for name, quantity := range list {
+			result[string(name)] = quantity.String()
+		}
+		return result
+	}
+	return &apiv1.MachineControllerOverride{
+		Resources: &apiv1.ResourceRequirements{
+			Requests: quantities(settings.Resources.Requests),
+			Limits:   quantities(settings.Resources.Limits),
+		},
+	}
+}
+
 func ValidateClusterSpec(clusterType kubermaticv1.ClusterType, updateManager common.UpdateManager, body apiv1.CreateClusterSpec) error {
+	if body.Cluster.Spec.Version.Version == nil {
+		return fmt.Errorf("spec.version is required")
+	}
 	if body.Cluster.Spec.Cloud.DatacenterName == "" {
 		return fmt.Errorf("cluster datacenter name is empty")
 	}
@@ -750,16 +1270,18 @@ func ValidateClusterSpec(clusterType kubermaticv1.ClusterType, updateManager com
 	if clusterType != kubermaticv1.ClusterTypeAll && clusterType != apiv1.ToInternalClusterType(body.Cluster.Type) {
 		return fmt.Errorf("disabled cluster type %s", body.Cluster.Type)
 	}
-	if body.Cluster.Spec.Version.Version == nil {
-		return fmt.Errorf("invalid cluster: invalid cloud spec \"Version\" is required but was not specified")
+	if err := validation.ValidateInitialNamespaces(body.InitialNamespaces); err != nil {
+		return err
 	}
-
 	versions, err := updateManager.GetVersions(body.Cluster.Type)
 	if err != nil {
 		return fmt.Errorf("failed to get available cluster versions: %v", err)
 	}
 	for _, availableVersion := range versions {
 		if body.Cluster.Spec.Version.Version.Equal(availableVersion.Version) {
+			if availableVersion.EndOfLife {
+				return fmt.Errorf("version %v has reached end of life", availableVersion.Version)
+			}
 			return nil
 		}
 	}
@@ -767,6 +1289,125 @@ func ValidateClusterSpec(clusterType kubermaticv1.ClusterType, updateManager com
 	return fmt.Errorf("invalid cluster: invalid cloud spec: unsupported version %v", body.Cluster.Spec.Version.Version)
 }
 
+// MinRecommendedNodeCount is the smallest initial node deployment which does not get a creation warning
+const MinRecommendedNodeCount = 2
+
+// ClusterCreationWarnings returns the non-blocking advisories for the cluster which is going to be created.
+// The cluster spec must have been validated with ValidateClusterSpec before.
+func ClusterCreationWarnings(updateManager common.UpdateManager, body apiv1.CreateClusterSpec) ([]string, error) {
+	var warnings []string
+
+	versions, err := updateManager.GetVersions(body.Cluster.Type)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get available cluster versions: %v", err)
+	}
+	for _, availableVersion := range versions {
+		if body.Cluster.Spec.Version.Version.Equal(availableVersion.Version) && availableVersion.Deprecated {
+			warnings = append(warnings, fmt.Sprintf("version %s is deprecated and will be removed soon, consider using a newer version", availableVersion.Version))
+		}
+	}
+
+	if body.NodeDeployment != nil && body.NodeDeployment.Spec.Replicas > 0 && body.NodeDeployment.Spec.Replicas < MinRecommendedNodeCount {
+		warnings = append(warnings, fmt.Sprintf("the initial node deployment has %d node(s), at least %d nodes are recommended to tolerate a node failure", body.NodeDeployment.Spec.Replicas, MinRecommendedNodeCount))
+	}
+
+	return warnings, nil
+}
+
+// validateAuditLogging rejects audit logging settings which explicitly disable audit logging in a datacenter
+// that enforces it. Settings which are not set at all get the audit logging enforced later on.
+func validateAuditLogging(settings *kubermaticv1.AuditLoggingSettings, dcName string, dc *kubermaticv1.Datacenter) error {
+	if dc.Spec.EnforceAuditLogging && settings != nil && !settings.Enabled {
+		return errors.NewBadRequest("audit logging cannot be disabled in datacenter %s", dcName)
+	}
+	return nil
+}
+
+// resolveOpenshiftImagePullSecret replaces the reference to a stored image pull secret with the content of the
+// secret, which has to belong to the project of the cluster.
+func resolveOpenshiftImagePullSecret(ctx context.Context, client ctrlruntimeclient.Client, projectID string, openshift *kubermaticv1.Openshift) error {
+	if openshift == nil || openshift.ImagePullSecretRef == "" {
+		return nil
+	}
+	if openshift.ImagePullSecret != "" {
+		return errors.NewBadRequest("only one of imagePullSecret and imagePullSecretRef can be set")
+	}
+
+	secret := &corev1.Secret{}
+	name := types.NamespacedName{Namespace: resources.KubermaticNamespace, Name: openshift.ImagePullSecretRef}
+	if err := client.Get(ctx, name, secret); err != nil {
+		if kerrors.IsNotFound(err) {
+			return errors.NewBadRequest("image pull secret %q not found in project %s", openshift.ImagePullSecretRef, projectID)
+		}
+		return common.KubernetesErrorToHTTPError(err)
+	}
+	if secret.Labels[kubermaticv1.ProjectIDLabelKey] != projectID {
+		return errors.NewBadRequest("image pull secret %q not found in project %s", openshift.ImagePullSecretRef, projectID)
+	}
+
+	pullSecret := secret.Data[corev1.DockerConfigJsonKey]
+	if len(pullSecret) == 0 {
+		return errors.NewBadRequest("image pull secret %q does not contain the %s key", openshift.ImagePullSecretRef, corev1.DockerConfigJsonKey)
+	}
+
+	openshift.ImagePullSecret = string(pullSecret)
+	openshift.ImagePullSecretRef = ""
+	return nil
+}
+
+// validateEgressAllowlistCNI rejects an egress allowlist for clusters which do not use the canal CNI,
+// as the allowlist is enforced by a Calico policy. Only openshift clusters use a different CNI.
+func validateEgressAllowlistCNI(cluster *kubermaticv1.Cluster) error {
+	if len(cluster.Spec.EgressAllowlist) > 0 && cluster.IsOpenshift() {
+		return errors.NewBadRequest("the egress allowlist requires the canal CNI, which is not used by openshift clusters")
+	}
+	return nil
+}
+
+// AcknowledgeDisruption is the value of the acknowledge query parameter which allows a patch to change the
+// expose strategy of a cluster
+const AcknowledgeDisruption = "disruption"
+
+// validateExposeStrategyChange guards changes of the expose strategy. They change the endpoint of the control
+// plane and disconnect its clients, so they have to be acknowledged explicitly.
+func validateExposeStrategyChange(oldStrategy, newStrategy corev1.ServiceType, acknowledged bool) error {
+	if newStrategy == "" || newStrategy == oldStrategy {
+		return nil
+	}
+	if newStrategy != corev1.ServiceTypeNodePort && newStrategy != corev1.ServiceTypeLoadBalancer {
+		return errors.NewBadRequest("invalid exposeStrategy %q, must be either %s or %s", newStrategy, corev1.ServiceTypeNodePort, corev1.ServiceTypeLoadBalancer)
+	}
+	if !acknowledged {
+		return errors.New(http.StatusConflict, fmt.Sprintf("exposeStrategy change requires ?acknowledge=%s", AcknowledgeDisruption))
+	}
+	return nil
+}
+
+// ValidateSSHKeyAssignment rejects assigning SSH keys to clusters which have the SSH access to their nodes disabled
+func ValidateSSHKeyAssignment(cluster *kubermaticv1.Cluster) error {
+	if cluster.Spec.DisableNodeSSH {
+		return errors.NewBadRequest("the SSH access to the nodes of cluster %s is disabled", cluster.Name)
+	}
+	return nil
+}
+
+// ValidateAddonVersions checks that every pinned addon version is listed in the versions of the addon's config.
+func ValidateAddonVersions(addonConfigProvider provider.AddonConfigProvider, addonVersions map[string]string) error {
+	for _, name := range sets.StringKeySet(addonVersions).List() {
+		addonConfig, err := addonConfigProvider.Get(name)
+		if err != nil {
+			if kerrors.IsNotFound(err) {
+				return fmt.Errorf("unknown addon %q", name)
+			}
+			return fmt.Errorf("failed to get the config of addon %q: %v", name, err)
+		}
+		if version := addonVersions[name]; !sets.NewString(addonConfig.Spec.Versions...).Has(version) {
+			return fmt.Errorf("version %q of addon %q is not available", version, name)
+		}
+	}
+	return nil
+}
+
 func ConvertClusterMetrics(podMetrics *v1beta1.PodMetricsList, nodeMetrics []v1beta1.NodeMetrics, availableNodesResources map[string]corev1.ResourceList, clusterName string) (*apiv1.ClusterMetrics, error) {
 	if podMetrics == nil {
 		return nil, fmt.Errorf("metric list can not be nil")
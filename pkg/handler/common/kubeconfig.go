@@ -55,12 +55,16 @@ const (
 var secureCookie *securecookie.SecureCookie
 
 func GetAdminKubeconfigEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectID, clusterID string, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider) (interface{}, error) {
-	clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
 	cluster, err := GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, projectID, clusterID, nil)
 	if err != nil {
 		return nil, err
 	}
+	return GetClusterAdminKubeconfig(ctx, userInfoGetter, projectID, cluster)
+}
 
+// GetClusterAdminKubeconfig returns the kubeconfig of the given cluster, viewers of the project get a viewer kubeconfig
+func GetClusterAdminKubeconfig(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectID string, cluster *kubermaticv1.Cluster) (interface{}, error) {
+	clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
 	filePrefix := "admin"
 	var adminClientCfg *clientcmdapi.Config
 
@@ -100,6 +104,12 @@ func GetOidcKubeconfigEndpoint(ctx context.Context, userInfoGetter provider.User
 	if err != nil {
 		return nil, err
 	}
+	// the apiserver only accepts OIDC tokens when both the issuer and the client are configured
+	if cluster.Spec.OIDC.IssuerURL == "" || cluster.Spec.OIDC.ClientID == "" {
+		return nil, kcerrors.NewBadRequest("cluster %s has no OIDC settings configured", cluster.Name)
+	}
+
+	// the static admin credentials are dropped below, only the server and its CA are taken over
 	adminClientCfg, err := clusterProvider.GetAdminKubeconfigForCustomerCluster(cluster)
 	if err != nil {
 		return nil, common.KubernetesErrorToHTTPError(err)
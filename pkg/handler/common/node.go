@@ -19,6 +19,8 @@ package common
 import (
 	"fmt"
 
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+
 	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
 	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
 
@@ -67,3 +69,14 @@ func ConvertNodeMetrics(metrics []v1beta1.NodeMetrics, availableResources map[st
 
 	return nodeMetrics, nil
 }
+
+// GetNodeForMachine returns the node of the machine, which is the node the machine references or the node named
+// like the machine. It returns nil when the machine has no node yet.
+func GetNodeForMachine(machine *clusterv1alpha1.Machine, nodes []corev1.Node) *corev1.Node {
+	for i := range nodes {
+		if (machine.Status.NodeRef != nil && nodes[i].UID == machine.Status.NodeRef.UID) || nodes[i].Name == machine.Name {
+			return &nodes[i]
+		}
+	}
+	return nil
+}
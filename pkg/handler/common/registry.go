@@ -0,0 +1,128 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/util/errors"
+)
+
+// registryCheckTimeout is the time the registry mirror has to answer
+const registryCheckTimeout = 10 * time.Second
+
+// internalNetworks are the private, loopback and link-local networks which the registry mirror must not resolve to,
+// so the check can not be used to probe the services next to the API
+var internalNetworks = mustParseCIDRs(
+	"0.0.0.0/8",
+	"10.0.0.0/8",
+	"100.64.0.0/10",
+	"127.0.0.0/8",
+	"169.254.0.0/16",
+	"172.16.0.0/12",
+	"192.168.0.0/16",
+	"::/128",
+	"::1/128",
+	"fc00::/7",
+	"fe80::/10",
+)
+
+// RegistryCheckClient is the client probing the registry mirror. It refuses to connect to internal addresses once
+// the host name is resolved and does not follow redirects, which could point to an internal address. Tests replace
+// it to reach registries served on the loopback address.
+var RegistryCheckClient = &http.Client{
+	Transport: &http.Transport{
+		DialContext: (&net.Dialer{
+			Timeout: registryCheckTimeout,
+			Control: rejectInternalAddress,
+		}).DialContext,
+		TLSHandshakeTimeout: registryCheckTimeout,
+	},
+	CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	},
+}
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
+
+func rejectInternalAddress(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return fmt.Errorf("invalid address %q", address)
+	}
+	for _, network := range internalNetworks {
+		if network.Contains(ip) {
+			return fmt.Errorf("address %s is internal", ip)
+		}
+	}
+	return nil
+}
+
+// ValidateRegistryMirrorReachable checks that the registry mirror answers the requests of the registry API.
+// The registry API responds to its base endpoint either with 200 or, when it requires authentication, with 401.
+// Mirrors on internal addresses are rejected and the response of the mirror is not reported back, so the check
+// can not be used to scan the network of the API.
+func ValidateRegistryMirrorReachable(ctx context.Context, mirror *kubermaticv1.RegistryMirrorSettings) error {
+	if mirror == nil {
+		return nil
+	}
+
+	u, err := url.Parse(mirror.URL)
+	if err != nil {
+		return errors.NewBadRequest("invalid registry mirror URL %q: %v", mirror.URL, err)
+	}
+	baseURL := fmt.Sprintf("%s://%s/v2/", u.Scheme, u.Host)
+
+	ctx, cancel := context.WithTimeout(ctx, registryCheckTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL, nil)
+	if err != nil {
+		return errors.NewBadRequest("invalid registry mirror URL %q: %v", mirror.URL, err)
+	}
+	resp, err := RegistryCheckClient.Do(req)
+	if err != nil {
+		return errors.NewBadRequest("registry mirror %s is unreachable", mirror.URL)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusUnauthorized {
+		return errors.NewBadRequest("registry mirror %s is unreachable", mirror.URL)
+	}
+	return nil
+}
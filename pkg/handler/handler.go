@@ -19,8 +19,11 @@ package handler
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"reflect"
+	"strconv"
+	"strings"
 
 	"k8c.io/kubermatic/v2/pkg/log"
 	"k8c.io/kubermatic/v2/pkg/util/errors"
@@ -28,6 +31,8 @@ import (
 
 const (
 	headerContentType = "Content-Type"
+	headerETag        = "ETag"
+	headerTotalCount  = "X-Total-Count"
 
 	contentTypeJSON = "application/json"
 )
@@ -123,3 +128,78 @@ func SetStatusCreatedHeader(f func(context.Context, http.ResponseWriter, interfa
 		return f(ctx, r, i)
 	}
 }
+
+func SetStatusAcceptedHeader(f func(context.Context, http.ResponseWriter, interface{}) error) func(context.Context, http.ResponseWriter, interface{}) error {
+	return func(ctx context.Context, r http.ResponseWriter, i interface{}) error {
+		r.Header().Set(headerContentType, contentTypeJSON)
+		r.WriteHeader(http.StatusAccepted)
+		return f(ctx, r, i)
+	}
+}
+
+// ETagResponse is a response tagged with the version of the returned object
+type ETagResponse struct {
+	// ETag is the quoted entity tag of the response
+	ETag string
+	// NotModified is set when the client already has the current version of the object,
+	// in that case Response is not sent
+	NotModified bool
+	Response    interface{}
+}
+
+// NewETagResponse returns the response tagged with the given resource version. When the version matches
+// one of the entity tags of the If-None-Match header, the response is marked as not modified.
+func NewETagResponse(response interface{}, resourceVersion, ifNoneMatch string) ETagResponse {
+	etag := fmt.Sprintf("%q", resourceVersion)
+	return ETagResponse{
+		ETag:        etag,
+		NotModified: etagMatches(etag, ifNoneMatch),
+		Response:    response,
+	}
+}
+
+// etagMatches checks whether the If-None-Match header contains the given entity tag
+func etagMatches(etag, ifNoneMatch string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// EncodeJSONWithETag sets the ETag header of an ETagResponse and writes its JSON encoding, or only the
+// status code 304 when the response is not modified. Other responses are written like by EncodeJSON.
+func EncodeJSONWithETag(c context.Context, w http.ResponseWriter, response interface{}) error {
+	etagResponse, ok := response.(ETagResponse)
+	if !ok {
+		return EncodeJSON(c, w, response)
+	}
+
+	w.Header().Set(headerETag, etagResponse.ETag)
+	if etagResponse.NotModified {
+		w.WriteHeader(http.StatusNotModified)
+		return nil
+	}
+	return EncodeJSON(c, w, etagResponse.Response)
+}
+
+// TotalCountResponse is a response containing one page of a list, together with the total number of
+// items of the list
+type TotalCountResponse struct {
+	TotalCount int
+	Response   interface{}
+}
+
+// EncodeJSONWithTotalCount sets the X-Total-Count header of a TotalCountResponse and writes the JSON
+// encoding of its page. Other responses are written like by EncodeJSON.
+func EncodeJSONWithTotalCount(c context.Context, w http.ResponseWriter, response interface{}) error {
+	totalCountResponse, ok := response.(TotalCountResponse)
+	if !ok {
+		return EncodeJSON(c, w, response)
+	}
+
+	w.Header().Set(headerTotalCount, strconv.Itoa(totalCountResponse.TotalCount))
+	return EncodeJSON(c, w, totalCountResponse.Response)
+}
@@ -161,6 +161,10 @@ func (p *FakeExternalClusterProvider) GetVersion(cluster *kubermaticapiv1.Extern
 	return semver.NewSemver(DefaultKubernetesVersion)
 }
 
+func (p *FakeExternalClusterProvider) GetVersionForConfig(cfg *clientcmdapi.Config) (*semver.Semver, error) {
+	return semver.NewSemver(DefaultKubernetesVersion)
+}
+
 func (p *FakeExternalClusterProvider) GetClient(cluster *kubermaticapiv1.ExternalCluster) (ctrlruntimeclient.Client, error) {
 	return p.FakeClient, nil
 }
@@ -201,3 +205,19 @@ func (p *FakeConstraintTemplateProvider) List() (*kubermaticapiv1.ConstraintTemp
 func (p *FakeConstraintTemplateProvider) Get(name string) (*kubermaticapiv1.ConstraintTemplate, error) {
 	return p.Provider.Get(name)
 }
+
+func (p *FakeConstraintTemplateProvider) Create(ct *kubermaticapiv1.ConstraintTemplate) (*kubermaticapiv1.ConstraintTemplate, error) {
+	return p.Provider.Create(ct)
+}
+
+func (p *FakeConstraintTemplateProvider) Update(ct *kubermaticapiv1.ConstraintTemplate) (*kubermaticapiv1.ConstraintTemplate, error) {
+	return p.Provider.Update(ct)
+}
+
+func (p *FakeConstraintTemplateProvider) Delete(ct *kubermaticapiv1.ConstraintTemplate) error {
+	return p.Provider.Delete(ct)
+}
+
+func (p *FakeConstraintTemplateProvider) CompileRego(rego string, libs []string) error {
+	return p.Provider.CompileRego(rego, libs)
+}
@@ -120,6 +120,7 @@ func NewTestRouting(
 		ExternalClusterProvider:               externalClusterProvider,
 		PrivilegedExternalClusterProvider:     privilegedExternalClusterProvider,
 		ConstraintTemplateProvider:            constraintTemplateProvider,
+		SecurityAdvisories:                    test.GenDefaultSecurityAdvisories(),
 	}
 
 	r := handler.NewRouting(routingParams)
@@ -25,6 +25,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"sort"
 	"strings"
 	"testing"
@@ -482,6 +483,10 @@ func (f *fakeUserClusterConnection) GetClient(_ *kubermaticv1.Cluster, _ ...k8cu
 	return f.fakeDynamicClient, nil
 }
 
+func (f *fakeUserClusterConnection) GetClientConfig(_ *kubermaticv1.Cluster, _ ...k8cuserclusterclient.ConfigOption) (*restclient.Config, error) {
+	return &restclient.Config{}, nil
+}
+
 // ClientsSets a simple wrapper that holds fake client sets
 type ClientsSets struct {
 	FakeKubermaticClient *kubermaticfakeclentset.Clientset
@@ -947,6 +952,12 @@ func CompareVersions(t *testing.T, versions, expected []*apiv1.MasterVersion) {
 		if v.Default != expected[i].Default {
 			t.Fatalf("expected flag %v got %v", expected[i].Default, v.Default)
 		}
+		if v.RestrictedByKubeletVersion != expected[i].RestrictedByKubeletVersion {
+			t.Fatalf("expected restricted flag %v got %v", expected[i].RestrictedByKubeletVersion, v.RestrictedByKubeletVersion)
+		}
+		if !reflect.DeepEqual(v.RestrictingMachineDeployments, expected[i].RestrictingMachineDeployments) {
+			t.Fatalf("expected restricting machine deployments %v got %v", expected[i].RestrictingMachineDeployments, v.RestrictingMachineDeployments)
+		}
 	}
 }
 
@@ -1011,6 +1022,23 @@ func GenDefaultVersions() []*version.Version {
 	}
 }
 
+func GenDefaultSecurityAdvisories() []*version.SecurityAdvisory {
+	return []*version.SecurityAdvisory{
+		{
+			ID:               "CVE-2020-8558",
+			Description:      "Node setting allows for neighboring hosts to bypass localhost boundary",
+			URL:              "https://github.com/kubernetes/kubernetes/issues/92315",
+			AffectedVersions: ">= 9.9.0, < 9.9.10",
+		},
+		{
+			ID:               "CVE-2020-8559",
+			Description:      "Privilege escalation from compromised node to cluster",
+			URL:              "https://github.com/kubernetes/kubernetes/issues/92914",
+			AffectedVersions: "< 1.16.13",
+		},
+	}
+}
+
 func GenBlacklistTokenSecret(name string, tokens []byte) *corev1.Secret {
 	return &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
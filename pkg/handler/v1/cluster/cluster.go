@@ -39,6 +39,7 @@ import (
 	kubermaticerrors "k8c.io/kubermatic/v2/pkg/util/errors"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/klog"
 	"k8s.io/metrics/pkg/apis/metrics/v1beta1"
 
@@ -47,7 +48,7 @@ import (
 
 func CreateEndpoint(sshKeyProvider provider.SSHKeyProvider, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, seedsGetter provider.SeedsGetter,
 	initNodeDeploymentFailures *prometheus.CounterVec, eventRecorderProvider provider.EventRecorderProvider, credentialManager provider.PresetProvider,
-	exposeStrategy corev1.ServiceType, userInfoGetter provider.UserInfoGetter, settingsProvider provider.SettingsProvider, updateManager common.UpdateManager) endpoint.Endpoint {
+	exposeStrategy corev1.ServiceType, userInfoGetter provider.UserInfoGetter, settingsProvider provider.SettingsProvider, updateManager common.UpdateManager, addonConfigProvider provider.AddonConfigProvider) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(CreateReq)
 		globalSettings, err := settingsProvider.GetGlobalSettings()
@@ -58,8 +59,15 @@ func CreateEndpoint(sshKeyProvider provider.SSHKeyProvider, projectProvider prov
 		if err != nil {
 			return nil, errors.NewBadRequest(err.Error())
 		}
+		if err := handlercommon.ValidateAddonVersions(addonConfigProvider, req.Body.Cluster.Spec.AddonVersions); err != nil {
+			return nil, errors.NewBadRequest(err.Error())
+		}
+		warnings, err := handlercommon.ClusterCreationWarnings(updateManager, req.Body)
+		if err != nil {
+			return nil, err
+		}
 
-		return handlercommon.CreateEndpoint(ctx, req.ProjectID, req.Body, sshKeyProvider, projectProvider, privilegedProjectProvider, seedsGetter, initNodeDeploymentFailures, eventRecorderProvider, credentialManager, exposeStrategy, userInfoGetter)
+		return handlercommon.CreateEndpoint(ctx, req.ProjectID, req.Body, sshKeyProvider, projectProvider, privilegedProjectProvider, seedsGetter, initNodeDeploymentFailures, eventRecorderProvider, credentialManager, exposeStrategy, userInfoGetter, warnings)
 	}
 }
 
@@ -73,7 +81,7 @@ func GetEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProv
 func PatchEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, seedsGetter provider.SeedsGetter, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(PatchReq)
-		return handlercommon.PatchEndpoint(ctx, userInfoGetter, req.ProjectID, req.ClusterID, req.Patch, seedsGetter, projectProvider, privilegedProjectProvider)
+		return handlercommon.PatchEndpoint(ctx, userInfoGetter, req.ProjectID, req.ClusterID, req.Patch, req.Acknowledge == handlercommon.AcknowledgeDisruption, seedsGetter, projectProvider, privilegedProjectProvider)
 	}
 }
 
@@ -129,163 +137,35 @@ func DeleteEndpoint(sshKeyProvider provider.SSHKeyProvider, privilegedSSHKeyProv
 func GetClusterEventsEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(EventsReq)
-		return handlercommon.GetClusterEventsEndpoint(ctx, userInfoGetter, req.ProjectID, req.ClusterID, req.Type, projectProvider, privilegedProjectProvider)
+		return handlercommon.GetClusterEventsEndpoint(ctx, userInfoGetter, req.ProjectID, req.ClusterID, req.Type, req.Search, fields.Everything(), projectProvider, privilegedProjectProvider)
 	}
 }
 
 func HealthEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
-		req := request.(common.GetClusterReq)
-		return handlercommon.HealthEndpoint(ctx, userInfoGetter, req.ProjectID, req.ClusterID, projectProvider, privilegedProjectProvider)
+		req := request.(HealthReq)
+		return handlercommon.HealthEndpoint(ctx, userInfoGetter, req.ProjectID, req.ClusterID, req.Detailed, projectProvider, privilegedProjectProvider)
 	}
 }
 
 func AssignSSHKeyEndpoint(sshKeyProvider provider.SSHKeyProvider, privilegedSSHKeyProvider provider.PrivilegedSSHKeyProvider, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(AssignSSHKeysReq)
-		clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
-		privilegedClusterProvider := ctx.Value(middleware.PrivilegedClusterProviderContextKey).(provider.PrivilegedClusterProvider)
-		if len(req.KeyID) == 0 {
-			return nil, errors.NewBadRequest("please provide an SSH key")
-		}
-
-		project, err := common.GetProject(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, req.ProjectID, nil)
-		if err != nil {
-			return nil, common.KubernetesErrorToHTTPError(err)
-		}
-		_, err = handlercommon.GetInternalCluster(ctx, userInfoGetter, clusterProvider, privilegedClusterProvider, project, req.ProjectID, req.ClusterID, &provider.ClusterGetOptions{})
-		if err != nil {
-			return nil, common.KubernetesErrorToHTTPError(err)
-		}
-
-		// sanity check, make sure that the key belongs to the project
-		// alternatively we could examine the owner references
-		{
-			projectSSHKeys, err := sshKeyProvider.List(project, nil)
-			if err != nil {
-				return nil, common.KubernetesErrorToHTTPError(err)
-			}
-			found := false
-			for _, projectSSHKey := range projectSSHKeys {
-				if projectSSHKey.Name == req.KeyID {
-					found = true
-					break
-				}
-			}
-			if !found {
-				return nil, fmt.Errorf("the given ssh key %s does not belong to the given project %s (%s)", req.KeyID, project.Spec.Name, project.Name)
-			}
-		}
-
-		sshKey, err := getSSHKey(ctx, userInfoGetter, sshKeyProvider, privilegedSSHKeyProvider, req.ProjectID, req.KeyID)
-		if err != nil {
-			return nil, common.KubernetesErrorToHTTPError(err)
-		}
-
-		apiKey := apiv1.SSHKey{
-			ObjectMeta: apiv1.ObjectMeta{
-				ID:                sshKey.Name,
-				Name:              sshKey.Spec.Name,
-				CreationTimestamp: apiv1.NewTime(sshKey.CreationTimestamp.Time),
-			},
-		}
-
-		if sshKey.IsUsedByCluster(req.ClusterID) {
-			return apiKey, nil
-		}
-		sshKey.AddToCluster(req.ClusterID)
-		if err := handlercommon.UpdateClusterSSHKey(ctx, userInfoGetter, sshKeyProvider, privilegedSSHKeyProvider, sshKey, req.ProjectID); err != nil {
-			return nil, err
-		}
-
-		return apiKey, nil
-	}
-}
-
-func getSSHKey(ctx context.Context, userInfoGetter provider.UserInfoGetter, sshKeyProvider provider.SSHKeyProvider, privilegedSSHKeyProvider provider.PrivilegedSSHKeyProvider, projectID, keyName string) (*kubermaticv1.UserSSHKey, error) {
-	adminUserInfo, err := userInfoGetter(ctx, "")
-	if err != nil {
-		return nil, errors.New(http.StatusInternalServerError, err.Error())
-	}
-	if adminUserInfo.IsAdmin {
-		return privilegedSSHKeyProvider.GetUnsecured(keyName)
-	}
-	userInfo, err := userInfoGetter(ctx, projectID)
-	if err != nil {
-		return nil, errors.New(http.StatusInternalServerError, err.Error())
+		return handlercommon.AssignSSHKeyEndpoint(ctx, userInfoGetter, sshKeyProvider, privilegedSSHKeyProvider, projectProvider, privilegedProjectProvider, req.ProjectID, req.ClusterID, req.KeyID)
 	}
-	return sshKeyProvider.Get(userInfo, keyName)
 }
 
 func ListSSHKeysEndpoint(sshKeyProvider provider.SSHKeyProvider, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(ListSSHKeysReq)
-		clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
-		privilegedClusterProvider := ctx.Value(middleware.PrivilegedClusterProviderContextKey).(provider.PrivilegedClusterProvider)
-
-		project, err := common.GetProject(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, req.ProjectID, nil)
-		if err != nil {
-			return nil, common.KubernetesErrorToHTTPError(err)
-		}
-		_, err = handlercommon.GetInternalCluster(ctx, userInfoGetter, clusterProvider, privilegedClusterProvider, project, req.ProjectID, req.ClusterID, &provider.ClusterGetOptions{})
-		if err != nil {
-			return nil, common.KubernetesErrorToHTTPError(err)
-		}
-		keys, err := sshKeyProvider.List(project, &provider.SSHKeyListOptions{ClusterName: req.ClusterID})
-		if err != nil {
-			return nil, common.KubernetesErrorToHTTPError(err)
-		}
-		apiKeys := common.ConvertInternalSSHKeysToExternal(keys)
-		return apiKeys, nil
+		return handlercommon.ListSSHKeysEndpoint(ctx, userInfoGetter, sshKeyProvider, projectProvider, privilegedProjectProvider, req.ProjectID, req.ClusterID)
 	}
 }
 
 func DetachSSHKeyEndpoint(sshKeyProvider provider.SSHKeyProvider, privilegedSSHKeyProvider provider.PrivilegedSSHKeyProvider, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(DetachSSHKeysReq)
-		clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
-		privilegedClusterProvider := ctx.Value(middleware.PrivilegedClusterProviderContextKey).(provider.PrivilegedClusterProvider)
-
-		project, err := common.GetProject(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, req.ProjectID, nil)
-		if err != nil {
-			return nil, common.KubernetesErrorToHTTPError(err)
-		}
-
-		_, err = handlercommon.GetInternalCluster(ctx, userInfoGetter, clusterProvider, privilegedClusterProvider, project, req.ProjectID, req.ClusterID, &provider.ClusterGetOptions{})
-		if err != nil {
-			return nil, common.KubernetesErrorToHTTPError(err)
-		}
-
-		// sanity check, make sure that the key belongs to the project
-		// alternatively we could examine the owner references
-		{
-			projectSSHKeys, err := sshKeyProvider.List(project, nil)
-			if err != nil {
-				return nil, common.KubernetesErrorToHTTPError(err)
-			}
-
-			found := false
-			for _, projectSSHKey := range projectSSHKeys {
-				if projectSSHKey.Name == req.KeyID {
-					found = true
-					break
-				}
-			}
-			if !found {
-				return nil, errors.NewNotFound("sshkey", req.KeyID)
-			}
-		}
-
-		clusterSSHKey, err := getSSHKey(ctx, userInfoGetter, sshKeyProvider, privilegedSSHKeyProvider, req.ProjectID, req.KeyID)
-		if err != nil {
-			return nil, common.KubernetesErrorToHTTPError(err)
-		}
-
-		clusterSSHKey.RemoveFromCluster(req.ClusterID)
-		if err := handlercommon.UpdateClusterSSHKey(ctx, userInfoGetter, sshKeyProvider, privilegedSSHKeyProvider, clusterSSHKey, req.ProjectID); err != nil {
-			return nil, common.KubernetesErrorToHTTPError(err)
-		}
-		return nil, nil
+		return handlercommon.DetachSSHKeyEndpoint(ctx, userInfoGetter, sshKeyProvider, privilegedSSHKeyProvider, projectProvider, privilegedProjectProvider, req.ProjectID, req.ClusterID, req.KeyID)
 	}
 }
 
@@ -377,6 +257,10 @@ type CreateReq struct {
 
 // Validate validates DeleteEndpoint request
 func (r CreateReq) Validate(clusterType kubermaticv1.ClusterType, updateManager common.UpdateManager) error {
+	if r.Body.Cluster.Spec.Version.Version == nil {
+		return fmt.Errorf("spec.version is required")
+	}
+
 	if len(r.ProjectID) == 0 || len(r.DC) == 0 {
 		return fmt.Errorf("the service account ID and datacenter cannot be empty")
 	}
@@ -392,16 +276,15 @@ func (r CreateReq) Validate(clusterType kubermaticv1.ClusterType, updateManager
 		return fmt.Errorf("disabled cluster type %s", r.Body.Cluster.Type)
 	}
 
-	if r.Body.Cluster.Spec.Version.Version == nil {
-		return fmt.Errorf("invalid cluster: invalid cloud spec \"Version\" is required but was not specified")
-	}
-
 	versions, err := updateManager.GetVersions(r.Body.Cluster.Type)
 	if err != nil {
 		return fmt.Errorf("failed to get available cluster versions: %v", err)
 	}
 	for _, availableVersion := range versions {
 		if r.Body.Cluster.Spec.Version.Version.Equal(availableVersion.Version) {
+			if availableVersion.EndOfLife {
+				return fmt.Errorf("version %v has reached end of life", availableVersion.Version)
+			}
 			return nil
 		}
 	}
@@ -461,6 +344,10 @@ func decodeSSHKeyID(c context.Context, r *http.Request) (string, error) {
 type PatchReq struct {
 	common.GetClusterReq
 
+	// Acknowledge must be set to "disruption" to change the expose strategy of the cluster
+	// in: query
+	Acknowledge string `json:"acknowledge,omitempty"`
+
 	// in: body
 	Patch json.RawMessage
 }
@@ -478,6 +365,7 @@ func DecodePatchReq(c context.Context, r *http.Request) (interface{}, error) {
 	}
 	req.DCReq = dcr.(common.DCReq)
 	req.ClusterID = clusterID
+	req.Acknowledge = r.URL.Query().Get("acknowledge")
 
 	if req.Patch, err = ioutil.ReadAll(r.Body); err != nil {
 		return nil, err
@@ -646,6 +534,35 @@ func DecodeDeleteReq(c context.Context, r *http.Request) (interface{}, error) {
 	return req, nil
 }
 
+// HealthReq defines HTTP request for getClusterHealth endpoint
+// swagger:parameters getClusterHealth
+type HealthReq struct {
+	common.GetClusterReq
+
+	// Detailed returns the details explaining the health of every component
+	// in: query
+	Detailed bool `json:"detailed,omitempty"`
+}
+
+func DecodeHealthReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req HealthReq
+
+	clusterReq, err := common.DecodeGetClusterReq(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.GetClusterReq = clusterReq.(common.GetClusterReq)
+
+	if detailed := r.URL.Query().Get("detailed"); len(detailed) > 0 {
+		req.Detailed, err = strconv.ParseBool(detailed)
+		if err != nil {
+			return nil, errors.NewBadRequest("invalid value for detailed: %v", err)
+		}
+	}
+
+	return req, nil
+}
+
 // EventsReq defines HTTP request for getClusterEvents endpoint
 // swagger:parameters getClusterEvents
 type EventsReq struct {
@@ -653,6 +570,10 @@ type EventsReq struct {
 
 	// in: query
 	Type string `json:"type,omitempty"`
+
+	// Search filters the events by a case-insensitive substring of their message or reason
+	// in: query
+	Search string `json:"search,omitempty"`
 }
 
 func DecodeGetClusterEvents(c context.Context, r *http.Request) (interface{}, error) {
@@ -665,6 +586,7 @@ func DecodeGetClusterEvents(c context.Context, r *http.Request) (interface{}, er
 	clusterReq := clusterReqRaw.(common.GetClusterReq)
 	req.GetClusterReq = clusterReq
 
+	req.Search = r.URL.Query().Get("search")
 	req.Type = r.URL.Query().Get("type")
 	if len(req.Type) > 0 {
 		if req.Type == "warning" || req.Type == "normal" {
@@ -427,6 +427,37 @@ func TestGetMasterKubeconfig(t *testing.T) {
 			ExistingAPIUser:        *test.GenAPIUser("bob", "bob@acme.com"),
 			ExpectedResponseString: `{"error":{"code":403,"message":"forbidden: \"bob@acme.com\" doesn't belong to the given project = foo-ID"}}`,
 		},
+		{
+			Name:         "scenario 5: the kubeconfig is returned before the control plane is ready",
+			HTTPStatus:   http.StatusOK,
+			ProjectToGet: "foo-ID",
+			ClusterToGet: "cluster-foo",
+			ExistingKubermaticObjs: []runtime.Object{
+				/*add projects*/
+				test.GenProject("foo", kubermaticapiv1.ProjectActive, test.DefaultCreationTimestamp()),
+				/*add bindings*/
+				test.GenBinding("foo-ID", "john@acme.com", "owners"),
+
+				/*add users*/
+				test.GenUser("", "john", "john@acme.com"),
+				test.GenCluster("cluster-foo", "cluster-foo", "foo-ID", test.DefaultCreationTimestamp(), func(cluster *kubermaticapiv1.Cluster) {
+					cluster.Status.ExtendedHealth.Apiserver = kubermaticapiv1.HealthStatusProvisioning
+				}),
+			},
+			ExistingObjects: []runtime.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "cluster-cluster-foo",
+						Name:      "admin-kubeconfig",
+					},
+					Data: map[string][]byte{
+						"kubeconfig": []byte(test.GenerateTestKubeconfig("cluster-foo", test.IDToken)),
+					},
+				},
+			},
+			ExistingAPIUser:        *test.GenAPIUser("john", "john@acme.com"),
+			ExpectedResponseString: genToken(test.IDToken),
+		},
 	}
 
 	for _, tc := range testcases {
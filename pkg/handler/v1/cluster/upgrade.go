@@ -78,17 +78,18 @@ func GetUpgradesEndpoint(updateManager common.UpdateManager, projectProvider pro
 
 		upgrades := make([]*apiv1.MasterVersion, 0)
 		for _, v := range versions {
-			isRestricted := false
+			var restrictingMachineDeployments []string
 			if clusterType == apiv1.KubernetesClusterType {
-				isRestricted, err = isRestrictedByKubeletVersions(v, machineDeployments.Items)
+				restrictingMachineDeployments, err = getRestrictingMachineDeployments(v, machineDeployments.Items)
 				if err != nil {
 					return nil, err
 				}
 			}
 
 			upgrades = append(upgrades, &apiv1.MasterVersion{
-				Version:                    v.Version,
-				RestrictedByKubeletVersion: isRestricted,
+				Version:                       v.Version,
+				RestrictedByKubeletVersion:    len(restrictingMachineDeployments) > 0,
+				RestrictingMachineDeployments: restrictingMachineDeployments,
 			})
 		}
 
@@ -96,18 +97,21 @@ func GetUpgradesEndpoint(updateManager common.UpdateManager, projectProvider pro
 	}
 }
 
-func isRestrictedByKubeletVersions(controlPlaneVersion *version.Version, mds []clusterv1alpha1.MachineDeployment) (bool, error) {
+// getRestrictingMachineDeployments returns the names of the machine deployments running kubelets
+// which are not compatible with the given control plane version
+func getRestrictingMachineDeployments(controlPlaneVersion *version.Version, mds []clusterv1alpha1.MachineDeployment) ([]string, error) {
+	var restricting []string
 	for _, md := range mds {
 		kubeletVersion, err := semver.NewVersion(md.Spec.Template.Spec.Versions.Kubelet)
 		if err != nil {
-			return false, err
+			return nil, err
 		}
 
 		if err = nodeupdate.EnsureVersionCompatible(controlPlaneVersion.Version, kubeletVersion); err != nil {
-			return true, nil
+			restricting = append(restricting, md.Name)
 		}
 	}
-	return false, nil
+	return restricting, nil
 }
 
 // NodeUpgradesReq defines HTTP request for getNodeUpgrades
@@ -121,8 +121,9 @@ func TestGetClusterUpgrades(t *testing.T) {
 					Version: semver.MustParse("1.6.1"),
 				},
 				{
-					Version:                    semver.MustParse("1.7.0"),
-					RestrictedByKubeletVersion: true,
+					Version:                       semver.MustParse("1.7.0"),
+					RestrictedByKubeletVersion:    true,
+					RestrictingMachineDeployments: []string{"venus"},
 				},
 			},
 			versions: []*version.Version{
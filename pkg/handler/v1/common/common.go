@@ -39,6 +39,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/client-go/kubernetes"
 	corev1interface "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/portforward"
@@ -311,3 +312,21 @@ func GetClusterClient(ctx context.Context, userInfoGetter provider.UserInfoGette
 	}
 	return clusterProvider.GetClientForCustomerCluster(userInfo, cluster)
 }
+
+// GetClusterClientset returns a clientset for the APIs the client of GetClusterClient does not support, it uses the
+// same permissions as GetClusterClient
+func GetClusterClientset(ctx context.Context, userInfoGetter provider.UserInfoGetter, clusterProvider provider.ClusterProvider, cluster *kubermaticv1.Cluster, projectID string) (kubernetes.Interface, error) {
+	adminUserInfo, err := userInfoGetter(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user information: %v", err)
+	}
+	if adminUserInfo.IsAdmin {
+		return clusterProvider.GetAdminClientsetForCustomerCluster(cluster)
+	}
+
+	userInfo, err := userInfoGetter(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user information: %v", err)
+	}
+	return clusterProvider.GetClientsetForCustomerCluster(userInfo, cluster)
+}
@@ -18,10 +18,13 @@ package common
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/util/sets"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	kubermaticapiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
@@ -44,6 +47,12 @@ func FilterEventsByType(events []kubermaticapiv1.Event, eventType string) []kube
 
 // GetEvents returns events related to an object in a given namespace.
 func GetEvents(ctx context.Context, client ctrlruntimeclient.Client, obj metav1.Object, objNamespace string) ([]kubermaticapiv1.Event, error) {
+	return SearchEvents(ctx, client, obj, objNamespace, "", fields.Everything())
+}
+
+// SearchEvents returns events related to an object in a given namespace whose message or reason contains
+// the search string, ignoring the case, and which match the field selector. Empty search string will return all of them.
+func SearchEvents(ctx context.Context, client ctrlruntimeclient.Client, obj metav1.Object, objNamespace, search string, selector fields.Selector) ([]kubermaticapiv1.Event, error) {
 	events := &corev1.EventList{}
 	listOpts := &ctrlruntimeclient.ListOptions{
 		Namespace:     objNamespace,
@@ -55,9 +64,44 @@ func GetEvents(ctx context.Context, client ctrlruntimeclient.Client, obj metav1.
 
 	kubermaticEvents := make([]kubermaticapiv1.Event, 0)
 	for _, event := range events.Items {
+		if !EventMatchesSearch(event, search) || !EventMatchesFieldSelector(event, selector) {
+			continue
+		}
 		kubermaticEvent := ConvertInternalEventToExternal(event)
 		kubermaticEvents = append(kubermaticEvents, kubermaticEvent)
 	}
 
 	return kubermaticEvents, nil
 }
+
+// supportedEventFields are the event fields which can be used in a field selector.
+var supportedEventFields = sets.NewString("involvedObject.name", "involvedObject.kind")
+
+// ParseEventFieldSelector parses a field selector for events. Only the involvedObject.name and involvedObject.kind
+// fields are supported, the kind is matched against the Kubernetes kind, e.g. Machine.
+func ParseEventFieldSelector(selector string) (fields.Selector, error) {
+	parsed, err := fields.ParseSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+	for _, requirement := range parsed.Requirements() {
+		if !supportedEventFields.Has(requirement.Field) {
+			return nil, fmt.Errorf("unsupported field %q, supported fields are %s", requirement.Field, strings.Join(supportedEventFields.List(), ", "))
+		}
+	}
+	return parsed, nil
+}
+
+// EventMatchesFieldSelector tells whether the object the event is about matches the field selector.
+func EventMatchesFieldSelector(event corev1.Event, selector fields.Selector) bool {
+	return selector.Matches(fields.Set{
+		"involvedObject.name": event.InvolvedObject.Name,
+		"involvedObject.kind": event.InvolvedObject.Kind,
+	})
+}
+
+// EventMatchesSearch tells whether the message or the reason of the event contains the search string, ignoring the case.
+func EventMatchesSearch(event corev1.Event, search string) bool {
+	search = strings.ToLower(search)
+	return strings.Contains(strings.ToLower(event.Message), search) || strings.Contains(strings.ToLower(event.Reason), search)
+}
@@ -75,6 +75,111 @@ func TestFilterEventsByType(t *testing.T) {
 
 }
 
+func TestEventMatchesSearch(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		Name     string
+		Search   string
+		Event    corev1.Event
+		Expected bool
+	}{
+		{
+			Name:     "scenario 1, empty search matches every event",
+			Search:   "",
+			Event:    corev1.Event{Reason: "Started", Message: "Started container"},
+			Expected: true,
+		},
+		{
+			Name:     "scenario 2, search matches the reason ignoring the case",
+			Search:   "failedmount",
+			Event:    corev1.Event{Reason: "FailedMount", Message: "MountVolume.SetUp failed"},
+			Expected: true,
+		},
+		{
+			Name:     "scenario 3, search matches the message ignoring the case",
+			Search:   "SETUP FAILED",
+			Event:    corev1.Event{Reason: "FailedMount", Message: "MountVolume.SetUp failed"},
+			Expected: true,
+		},
+		{
+			Name:     "scenario 4, search matches neither the reason nor the message",
+			Search:   "BackOff",
+			Event:    corev1.Event{Reason: "Started", Message: "Started container"},
+			Expected: false,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			if result := common.EventMatchesSearch(tc.Event, tc.Search); result != tc.Expected {
+				t.Fatalf("expected %v, got %v", tc.Expected, result)
+			}
+		})
+	}
+}
+
+func TestParseEventFieldSelector(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		Name          string
+		FieldSelector string
+		Event         corev1.Event
+		ExpectedMatch bool
+		ExpectedError bool
+	}{
+		{
+			Name:          "scenario 1, empty selector matches every event",
+			FieldSelector: "",
+			Event:         genInvolvedObjectEvent("Machine", "machine-a"),
+			ExpectedMatch: true,
+		},
+		{
+			Name:          "scenario 2, selector matches the involved object name",
+			FieldSelector: "involvedObject.name=machine-a",
+			Event:         genInvolvedObjectEvent("Machine", "machine-a"),
+			ExpectedMatch: true,
+		},
+		{
+			Name:          "scenario 3, selector does not match another involved object",
+			FieldSelector: "involvedObject.kind=Machine,involvedObject.name=machine-b",
+			Event:         genInvolvedObjectEvent("Machine", "machine-a"),
+			ExpectedMatch: false,
+		},
+		{
+			Name:          "scenario 4, selector does not match another kind",
+			FieldSelector: "involvedObject.kind!=Machine",
+			Event:         genInvolvedObjectEvent("Machine", "machine-a"),
+			ExpectedMatch: false,
+		},
+		{
+			Name:          "scenario 5, unsupported fields are rejected",
+			FieldSelector: "involvedObject.name=machine-a,reason=Started",
+			ExpectedError: true,
+		},
+		{
+			Name:          "scenario 6, malformed selectors are rejected",
+			FieldSelector: "involvedObject.name",
+			ExpectedError: true,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			selector, err := common.ParseEventFieldSelector(tc.FieldSelector)
+			if tc.ExpectedError {
+				if err == nil {
+					t.Fatal("expected an error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if match := common.EventMatchesFieldSelector(tc.Event, selector); match != tc.ExpectedMatch {
+				t.Fatalf("expected %v, got %v", tc.ExpectedMatch, match)
+			}
+		})
+	}
+}
+
 // equal tells whether a and b contain the same elements.
 // A nil argument is equivalent to an empty slice.
 func equal(a, b []v1.Event) bool {
@@ -95,3 +200,12 @@ func genEvent(message, eventType string) v1.Event {
 		Message: message,
 	}
 }
+
+func genInvolvedObjectEvent(kind, name string) corev1.Event {
+	return corev1.Event{
+		InvolvedObject: corev1.ObjectReference{
+			Kind: kind,
+			Name: name,
+		},
+	}
+}
@@ -19,13 +19,16 @@ package common
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/Masterminds/semver"
 
 	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
 	kubermaticapiv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/provider"
+	kubermaticerrors "k8c.io/kubermatic/v2/pkg/util/errors"
 	"k8c.io/kubermatic/v2/pkg/validation/nodeupdate"
 
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
@@ -33,32 +36,64 @@ import (
 
 // CheckClusterVersionSkew returns a list of machines and/or machine deployments
 // that are running kubelet at a version incompatible with the cluster's control plane.
+// Every entry names the offending object and its kubelet version, e.g. "deployment md-123 runs 9.9.9".
 func CheckClusterVersionSkew(ctx context.Context, userInfoGetter provider.UserInfoGetter, clusterProvider provider.ClusterProvider, cluster *kubermaticapiv1.Cluster, projectID string) ([]string, error) {
 	client, err := GetClusterClient(ctx, userInfoGetter, clusterProvider, cluster, projectID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create a machine client: %v", err)
 	}
 
-	// get deduplicated list of all used kubelet versions
+	// get all used kubelet versions together with the objects using them
 	kubeletVersions, err := getKubeletVersions(ctx, client)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get the list of kubelet versions used in the cluster: %v", err)
 	}
 
-	// this is where the incompatible versions shall be saved
-	incompatibleVersionsSet := map[string]bool{}
+	return CheckKubeletVersionSkew(cluster.Spec.Version.Semver(), kubeletVersions)
+}
+
+// CheckNodeDeploymentVersionSkew returns a bad request error if the kubelet version of the given node deployment
+// is newer than the cluster's control plane or more than two minor versions behind it.
+// A node deployment without a kubelet version will use the version of the control plane.
+func CheckNodeDeploymentVersionSkew(cluster *kubermaticapiv1.Cluster, nd *apiv1.NodeDeployment) error {
+	ver := strings.TrimSpace(nd.Spec.Template.Versions.Kubelet)
+	if ver == "" {
+		return nil
+	}
+
+	owner := "node deployment"
+	if nd.Name != "" {
+		owner = fmt.Sprintf("deployment %s", nd.Name)
+	}
 
-	clusterVersion := cluster.Spec.Version.Semver()
-	for _, ver := range kubeletVersions {
+	incompatibleKubelets, err := CheckKubeletVersionSkew(cluster.Spec.Version.Semver(), map[string][]string{ver: {owner}})
+	if err != nil {
+		return kubermaticerrors.NewBadRequest(err.Error())
+	}
+	if len(incompatibleKubelets) > 0 {
+		return kubermaticerrors.NewBadRequest("Node deployment contains nodes running incompatible kubelet versions: %s. The kubelet must not be newer than the control plane version %s or more than two minor versions behind it.", strings.Join(incompatibleKubelets, ", "), cluster.Spec.Version.String())
+	}
+	return nil
+}
+
+// CheckKubeletVersionSkew returns a list of the objects running a kubelet version which is incompatible with the
+// given control plane version. The kubelet versions must be mapped to the objects using them, e.g. "deployment md-123".
+func CheckKubeletVersionSkew(clusterVersion *semver.Version, kubeletVersions map[string][]string) ([]string, error) {
+	// this is where the objects running incompatible versions shall be saved
+	var incompatibleList []string
+
+	for ver, owners := range kubeletVersions {
 		kubeletVersion, parseErr := semver.NewVersion(ver)
 		if parseErr != nil {
 			return nil, fmt.Errorf("failed to parse kubelet version: %v", parseErr)
 		}
 
-		if err = nodeupdate.EnsureVersionCompatible(clusterVersion, kubeletVersion); err != nil {
+		if err := nodeupdate.EnsureVersionCompatible(clusterVersion, kubeletVersion); err != nil {
 			// errVersionSkew says it's incompatible
 			if _, ok := err.(nodeupdate.ErrVersionSkew); ok {
-				incompatibleVersionsSet[kubeletVersion.String()] = true
+				for _, owner := range owners {
+					incompatibleList = append(incompatibleList, fmt.Sprintf("%s runs %s", owner, kubeletVersion))
+				}
 				continue
 			}
 
@@ -67,17 +102,13 @@ func CheckClusterVersionSkew(ctx context.Context, userInfoGetter provider.UserIn
 		}
 	}
 
-	// collect the deduplicated map entries into a slice
-	var incompatibleVersionsList []string
-	for ver := range incompatibleVersionsSet {
-		incompatibleVersionsList = append(incompatibleVersionsList, ver)
-	}
-
-	return incompatibleVersionsList, nil
+	sort.Strings(incompatibleList)
+	return incompatibleList, nil
 }
 
-// getKubeletVersions returns the list of all kubelet versions used by a given cluster's Machines and MachineDeployments
-func getKubeletVersions(ctx context.Context, client ctrlruntimeclient.Client) ([]string, error) {
+// getKubeletVersions returns all kubelet versions used by a given cluster's Machines and MachineDeployments,
+// mapped to the objects which use them
+func getKubeletVersions(ctx context.Context, client ctrlruntimeclient.Client) (map[string][]string, error) {
 
 	machineList := &clusterv1alpha1.MachineList{}
 	if err := client.List(ctx, machineList); err != nil {
@@ -89,28 +120,22 @@ func getKubeletVersions(ctx context.Context, client ctrlruntimeclient.Client) ([
 		return nil, KubernetesErrorToHTTPError(err)
 	}
 
-	kubeletVersionsSet := map[string]bool{}
+	kubeletVersions := map[string][]string{}
 
 	// first let's go through the legacy non-MD nodes
 	for _, m := range machineList.Items {
 		// Only list Machines that are not controlled, i.e. by Machine Set.
 		if len(m.ObjectMeta.OwnerReferences) == 0 {
 			ver := strings.TrimSpace(m.Spec.Versions.Kubelet)
-			kubeletVersionsSet[ver] = true
+			kubeletVersions[ver] = append(kubeletVersions[ver], fmt.Sprintf("machine %s", m.Name))
 		}
 	}
 
 	// now the deployments
 	for _, md := range machineDeployments.Items {
 		ver := strings.TrimSpace(md.Spec.Template.Spec.Versions.Kubelet)
-		kubeletVersionsSet[ver] = true
-	}
-
-	// deduplicated list
-	kubeletVersionList := []string{}
-	for ver := range kubeletVersionsSet {
-		kubeletVersionList = append(kubeletVersionList, ver)
+		kubeletVersions[ver] = append(kubeletVersions[ver], fmt.Sprintf("deployment %s", md.Name))
 	}
 
-	return kubeletVersionList, nil
+	return kubeletVersions, nil
 }
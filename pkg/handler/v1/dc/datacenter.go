@@ -670,6 +670,9 @@ func ConvertInternalDCToExternalSpec(dc *kubermaticv1.Datacenter, seedName strin
 		RequiredEmailDomains:     dc.Spec.RequiredEmailDomains,
 		EnforceAuditLogging:      dc.Spec.EnforceAuditLogging,
 		EnforcePodSecurityPolicy: dc.Spec.EnforcePodSecurityPolicy,
+		MaxClusters:              dc.Spec.MaxClusters,
+		NodeNetworks:             dc.Spec.NodeNetworks,
+		LoadBalancerClasses:      dc.Spec.LoadBalancerClasses,
 	}, nil
 }
 
@@ -695,6 +698,9 @@ func convertExternalDCToInternal(datacenter *apiv1.DatacenterSpec) kubermaticv1.
 			RequiredEmailDomains:     datacenter.RequiredEmailDomains,
 			EnforceAuditLogging:      datacenter.EnforceAuditLogging,
 			EnforcePodSecurityPolicy: datacenter.EnforcePodSecurityPolicy,
+			MaxClusters:              datacenter.MaxClusters,
+			NodeNetworks:             datacenter.NodeNetworks,
+			LoadBalancerClasses:      datacenter.LoadBalancerClasses,
 		},
 	}
 }
@@ -102,7 +102,7 @@ func ListNodesForClusterLegacyEndpoint(projectProvider provider.ProjectProvider,
 
 		// Go over all machines first
 		for i := range machineList.Items {
-			node := getNodeForMachine(&machineList.Items[i], nodeList.Items)
+			node := handlercommon.GetNodeForMachine(&machineList.Items[i], nodeList.Items)
 			if node != nil {
 				matchedMachineNodes.Insert(string(node.UID))
 			}
@@ -326,7 +326,7 @@ func findMachineAndNode(ctx context.Context, name string, client ctrlruntimeclie
 	}
 
 	if machine != nil && node == nil {
-		node = getNodeForMachine(machine, nodeList.Items)
+		node = handlercommon.GetNodeForMachine(machine, nodeList.Items)
 	}
 
 	return machine, node, nil
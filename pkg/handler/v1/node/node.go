@@ -131,6 +131,10 @@ func CreateNodeDeployment(sshKeyProvider provider.SSHKeyProvider, projectProvide
 			return nil, fmt.Errorf("error getting dc: %v", err)
 		}
 
+		if err := common.CheckNodeDeploymentVersionSkew(cluster, &req.Body); err != nil {
+			return nil, err
+		}
+
 		nd, err := machineresource.Validate(&req.Body, cluster.Spec.Version.Semver())
 		if err != nil {
 			return nil, k8cerrors.NewBadRequest(fmt.Sprintf("node deployment validation failed: %s", err.Error()))
@@ -449,7 +453,7 @@ func ListNodeDeploymentNodes(projectProvider provider.ProjectProvider, privilege
 
 		var nodesV1 []*apiv1.Node
 		for i := range machines.Items {
-			node := getNodeForMachine(&machines.Items[i], nodeList.Items)
+			node := handlercommon.GetNodeForMachine(&machines.Items[i], nodeList.Items)
 			outNode, err := outputMachine(&machines.Items[i], node, req.HideInitialConditions)
 			if err != nil {
 				return nil, fmt.Errorf("failed to output machine %s: %v", machines.Items[i].Name, err)
@@ -519,7 +523,7 @@ func ListNodeDeploymentMetrics(projectProvider provider.ProjectProvider, privile
 
 		availableResources := make(map[string]corev1.ResourceList)
 		for i := range machines.Items {
-			n := getNodeForMachine(&machines.Items[i], nodeList.Items)
+			n := handlercommon.GetNodeForMachine(&machines.Items[i], nodeList.Items)
 			if n != nil {
 				availableResources[n.Name] = n.Status.Allocatable
 			}
@@ -971,12 +975,3 @@ func parseNodeConditions(node *corev1.Node) (reason string, message string) {
 	}
 	return reason, message
 }
-
-func getNodeForMachine(machine *clusterv1alpha1.Machine, nodes []corev1.Node) *corev1.Node {
-	for _, node := range nodes {
-		if (machine.Status.NodeRef != nil && node.UID == machine.Status.NodeRef.UID) || node.Name == machine.Name {
-			return &node
-		}
-	}
-	return nil
-}
@@ -0,0 +1,123 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/go-kit/kit/endpoint"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	apiv2 "k8c.io/kubermatic/v2/pkg/api/v2"
+	handlercommon "k8c.io/kubermatic/v2/pkg/handler/common"
+	"k8c.io/kubermatic/v2/pkg/handler/middleware"
+	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/util/errors"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// GetAccessEndpoint returns the permissions the requesting user has in the user cluster
+func GetAccessEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(AccessReq)
+		clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
+
+		cluster, err := handlercommon.GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, req.ProjectID, req.ClusterID, &provider.ClusterGetOptions{CheckInitStatus: true})
+		if err != nil {
+			return nil, err
+		}
+
+		client, err := common.GetClusterClient(ctx, userInfoGetter, clusterProvider, cluster, req.ProjectID)
+		if err != nil {
+			return nil, clusterUnreachableToHTTPError(err)
+		}
+
+		review := &authorizationv1.SelfSubjectRulesReview{
+			Spec: authorizationv1.SelfSubjectRulesReviewSpec{
+				Namespace: req.Namespace,
+			},
+		}
+		if err := client.Create(ctx, review); err != nil {
+			return nil, clusterUnreachableToHTTPError(err)
+		}
+
+		return apiv2.ClusterAccess{
+			Namespace:        req.Namespace,
+			ResourceRules:    review.Status.ResourceRules,
+			NonResourceRules: review.Status.NonResourceRules,
+			Incomplete:       review.Status.Incomplete,
+		}, nil
+	}
+}
+
+// clusterUnreachableToHTTPError maps errors returned by the user cluster API to HTTP errors.
+// Errors which are not returned by the API server itself mean that the cluster could not be reached.
+func clusterUnreachableToHTTPError(err error) error {
+	if _, ok := err.(kerrors.APIStatus); ok {
+		return common.KubernetesErrorToHTTPError(err)
+	}
+	return errors.New(http.StatusServiceUnavailable, fmt.Sprintf("cluster is not reachable: %v", err))
+}
+
+// AccessReq defines HTTP request for getClusterAccess endpoint
+// swagger:parameters getClusterAccessV2
+type AccessReq struct {
+	common.ProjectReq
+	// in: path
+	// required: true
+	ClusterID string `json:"cluster_id"`
+	// The namespace the permissions are evaluated in, defaults to "default".
+	// Cluster-wide permissions are always included.
+	// in: query
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// GetSeedCluster returns the SeedCluster object
+func (req AccessReq) GetSeedCluster() apiv1.SeedCluster {
+	return apiv1.SeedCluster{
+		ClusterID: req.ClusterID,
+	}
+}
+
+func DecodeAccessReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req AccessReq
+
+	pr, err := common.DecodeProjectRequest(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.ProjectReq = pr.(common.ProjectReq)
+
+	clusterID, err := common.DecodeClusterID(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.ClusterID = clusterID
+
+	req.Namespace = r.URL.Query().Get("namespace")
+	if req.Namespace == "" {
+		req.Namespace = corev1.NamespaceDefault
+	}
+
+	return req, nil
+}
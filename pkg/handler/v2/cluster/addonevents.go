@@ -0,0 +1,87 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/gorilla/mux"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	handlercommon "k8c.io/kubermatic/v2/pkg/handler/common"
+	"k8c.io/kubermatic/v2/pkg/handler/middleware"
+	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
+	"k8c.io/kubermatic/v2/pkg/provider"
+
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// GetAddonEventsEndpoint returns the events the addon controller recorded while reconciling the given addon.
+// The list is empty when the addon was installed without problems.
+func GetAddonEventsEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(AddonEventsReq)
+		privilegedClusterProvider := ctx.Value(middleware.PrivilegedClusterProviderContextKey).(provider.PrivilegedClusterProvider)
+
+		cluster, err := handlercommon.GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, req.ProjectID, req.ClusterID, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		seedClient := privilegedClusterProvider.GetSeedClusterAdminRuntimeClient()
+		addon := &kubermaticv1.Addon{}
+		if err := seedClient.Get(ctx, types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: req.AddonID}, addon); err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		// events are matched by the kind too, as other objects in the cluster namespace can share the name of the addon
+		selector := fields.SelectorFromSet(fields.Set{
+			"involvedObject.kind": kubermaticv1.AddonKindName,
+			"involvedObject.name": addon.Name,
+		})
+		events, err := common.SearchEvents(ctx, seedClient, addon, addon.Namespace, "", selector)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		return events, nil
+	}
+}
+
+// AddonEventsReq defines HTTP request for getClusterAddonEventsV2 endpoint
+// swagger:parameters getClusterAddonEventsV2
+type AddonEventsReq struct {
+	GetClusterReq
+	// in: path
+	// required: true
+	AddonID string `json:"addon_id"`
+}
+
+func DecodeAddonEventsReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req AddonEventsReq
+
+	clusterReq, err := DecodeGetClusterReq(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.GetClusterReq = clusterReq.(GetClusterReq)
+	req.AddonID = mux.Vars(r)["addon_id"]
+
+	return req, nil
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/handler/test"
+	"k8c.io/kubermatic/v2/pkg/handler/test/hack"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestGetClusterAddonEvents(t *testing.T) {
+	t.Parallel()
+	creationTime := time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC)
+	cluster := test.GenDefaultCluster()
+
+	testcases := []struct {
+		Name                   string
+		ExpectedResponse       string
+		HTTPStatus             int
+		AddonToGet             string
+		ExistingAPIUser        *apiv1.User
+		ExistingKubeObjs       []runtime.Object
+		ExistingKubermaticObjs []runtime.Object
+	}{
+		{
+			Name:             "scenario 1: the events of a failing addon are returned",
+			ExpectedResponse: `[{"name":"dns-failed","creationTimestamp":"0001-01-01T00:00:00Z","message":"failed to deploy the addon","type":"Warning","involvedObject":{"type":"Addon","namespace":"cluster-defClusterID","name":"dns"},"lastTimestamp":"0001-01-01T00:00:00Z","count":1}]`,
+			HTTPStatus:       http.StatusOK,
+			AddonToGet:       "dns",
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExistingKubeObjs: []runtime.Object{
+				genAddonEvent("dns-failed", "dns", cluster),
+				genAddonEvent("canal-failed", "canal", cluster),
+			},
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				cluster,
+				test.GenTestAddon("dns", nil, cluster, creationTime),
+			),
+		},
+		{
+			Name:             "scenario 2: a healthy addon has no events",
+			ExpectedResponse: `[]`,
+			HTTPStatus:       http.StatusOK,
+			AddonToGet:       "canal",
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExistingKubeObjs: []runtime.Object{genAddonEvent("dns-failed", "dns", cluster)},
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				cluster,
+				test.GenTestAddon("canal", nil, cluster, creationTime),
+			),
+		},
+		{
+			Name:             "scenario 3: the addon is not installed",
+			ExpectedResponse: `{"error":{"code":404,"message":"addons.kubermatic.k8s.io \"dns\" not found"}}`,
+			HTTPStatus:       http.StatusNotFound,
+			AddonToGet:       "dns",
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				cluster,
+			),
+		},
+		{
+			Name:             "scenario 4: the user John can not get the addon events of Bob's cluster",
+			ExpectedResponse: `{"error":{"code":403,"message":"forbidden: \"john@acme.com\" doesn't belong to the given project = my-first-project-ID"}}`,
+			HTTPStatus:       http.StatusForbidden,
+			AddonToGet:       "dns",
+			ExistingAPIUser:  test.GenAPIUser("John", "john@acme.com"),
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				cluster,
+				test.GenTestAddon("dns", nil, cluster, creationTime),
+				genUser("John", "john@acme.com", false),
+			),
+		},
+		{
+			Name:             "scenario 5: the admin John can get the addon events of Bob's cluster",
+			ExpectedResponse: `[]`,
+			HTTPStatus:       http.StatusOK,
+			AddonToGet:       "dns",
+			ExistingAPIUser:  test.GenAPIUser("John", "john@acme.com"),
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				cluster,
+				test.GenTestAddon("dns", nil, cluster, creationTime),
+				genUser("John", "john@acme.com", true),
+			),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", fmt.Sprintf("/api/v2/projects/%s/clusters/%s/addons/%s/events", test.GenDefaultProject().Name, test.DefaultClusterID, tc.AddonToGet), nil)
+			res := httptest.NewRecorder()
+			ep, err := test.CreateTestEndpoint(*tc.ExistingAPIUser, tc.ExistingKubeObjs, tc.ExistingKubermaticObjs, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+
+			test.CompareWithResult(t, res, tc.ExpectedResponse)
+		})
+	}
+}
+
+func genAddonEvent(eventName, addonName string, cluster *kubermaticv1.Cluster) *corev1.Event {
+	event := test.GenTestEvent(eventName, corev1.EventTypeWarning, "ReconcilingError", "failed to deploy the addon", kubermaticv1.AddonKindName, "")
+	event.Namespace = cluster.Status.NamespaceName
+	event.InvolvedObject.Namespace = cluster.Status.NamespaceName
+	event.InvolvedObject.Name = addonName
+	return event
+}
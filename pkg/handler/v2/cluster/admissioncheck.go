@@ -0,0 +1,168 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/go-kit/kit/endpoint"
+
+	apiv2 "k8c.io/kubermatic/v2/pkg/api/v2"
+	handlercommon "k8c.io/kubermatic/v2/pkg/handler/common"
+	"k8c.io/kubermatic/v2/pkg/handler/middleware"
+	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/util/errors"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// gatekeeperWebhookName is the name of the validating webhook Gatekeeper enforces its constraints with
+const gatekeeperWebhookName = "validation.gatekeeper.sh"
+
+// gatekeeperDenialRegexp matches the constraint names in the denial messages of the Gatekeeper validating webhook,
+// e.g. `[denied by ns-must-have-gk] you must provide labels: {"gatekeeper"}`
+var gatekeeperDenialRegexp = regexp.MustCompile(`\[(?:denied by )?([^\]\s]+)\]`)
+
+// unauthorizedRegexp matches the messages of the API server when the RBAC rules do not allow the user to write the object,
+// e.g. `configmaps "settings" is forbidden: User "bob@acme.com" cannot create resource "configmaps" in API group ""`
+var unauthorizedRegexp = regexp.MustCompile(`is forbidden: User ".*" cannot `)
+
+// AdmissionCheckEndpoint submits the given object to the API server of the user cluster as a dry-run request.
+// The object is run through the full admission chain, including the Gatekeeper constraints, but never persisted.
+func AdmissionCheckEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(admissionCheckReq)
+		clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
+
+		cluster, err := handlercommon.GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, req.ProjectID, req.ClusterID, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		client, err := common.GetClusterClient(ctx, userInfoGetter, clusterProvider, cluster, req.ProjectID)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		err = dryRunObject(ctx, client, req.object)
+		if err == nil {
+			return &apiv2.AdmissionCheck{Admitted: true}, nil
+		}
+		if meta.IsNoMatchError(err) {
+			return nil, errors.NewBadRequest("unknown object kind: %v", err)
+		}
+		if !isAdmissionDenial(err) {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		return &apiv2.AdmissionCheck{
+			Admitted:    false,
+			Reason:      err.Error(),
+			Constraints: deniedByConstraints(err.Error()),
+		}, nil
+	}
+}
+
+// dryRunObject creates the object with dry-run enabled, an object which already exists is updated instead
+func dryRunObject(ctx context.Context, client ctrlruntimeclient.Client, object *unstructured.Unstructured) error {
+	err := client.Create(ctx, object.DeepCopy(), ctrlruntimeclient.DryRunAll)
+	if !kerrors.IsAlreadyExists(err) {
+		return err
+	}
+
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(object.GroupVersionKind())
+	if err := client.Get(ctx, ctrlruntimeclient.ObjectKey{Namespace: object.GetNamespace(), Name: object.GetName()}, existing); err != nil {
+		return err
+	}
+	updated := object.DeepCopy()
+	updated.SetResourceVersion(existing.GetResourceVersion())
+	return client.Update(ctx, updated, ctrlruntimeclient.DryRunAll)
+}
+
+// isAdmissionDenial checks if the API server rejected the object, either during validation or by an admission plugin.
+// Missing namespaces, conflicts and the user not being authorized to write the object are not reported as denials.
+func isAdmissionDenial(err error) bool {
+	status, ok := err.(kerrors.APIStatus)
+	if !ok {
+		return false
+	}
+	switch status.Status().Code {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return true
+	case http.StatusForbidden:
+		return !unauthorizedRegexp.MatchString(status.Status().Message)
+	}
+	return false
+}
+
+// deniedByConstraints returns the names of the Gatekeeper constraints in the given denial message
+func deniedByConstraints(message string) []string {
+	if !strings.Contains(message, gatekeeperWebhookName) {
+		return nil
+	}
+	var constraints []string
+	for _, match := range gatekeeperDenialRegexp.FindAllStringSubmatch(message, -1) {
+		constraints = append(constraints, match[1])
+	}
+	return constraints
+}
+
+// admissionCheckReq defines HTTP request for checkClusterAdmissionV2 endpoint
+// swagger:parameters checkClusterAdmissionV2
+type admissionCheckReq struct {
+	GetClusterReq
+
+	// The Kubernetes object to check, it must contain apiVersion, kind and metadata.name
+	// in: body
+	// required: true
+	Body map[string]interface{}
+
+	// private field for the decoded object
+	object *unstructured.Unstructured
+}
+
+func DecodeAdmissionCheckReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req admissionCheckReq
+
+	clusterReq, err := DecodeGetClusterReq(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.GetClusterReq = clusterReq.(GetClusterReq)
+
+	if err := json.NewDecoder(r.Body).Decode(&req.Body); err != nil {
+		return nil, errors.NewBadRequest("invalid object: %v", err)
+	}
+
+	req.object = &unstructured.Unstructured{Object: req.Body}
+	if req.object.GetAPIVersion() == "" || req.object.GetKind() == "" {
+		return nil, errors.NewBadRequest("the object must contain apiVersion and kind")
+	}
+	if req.object.GetName() == "" {
+		return nil, errors.NewBadRequest("the object must contain metadata.name")
+	}
+
+	return req, nil
+}
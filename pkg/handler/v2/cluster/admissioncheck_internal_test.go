@@ -0,0 +1,75 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"errors"
+	"testing"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestIsAdmissionDenial(t *testing.T) {
+	configMaps := schema.GroupResource{Resource: "configmaps"}
+
+	testcases := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{
+			name:     "denied by the gatekeeper webhook",
+			err:      kerrors.NewForbidden(configMaps, "settings", errors.New(`admission webhook "validation.gatekeeper.sh" denied the request: [denied by cm-must-have-gk] you must provide labels: {"gatekeeper"}`)),
+			expected: true,
+		},
+		{
+			name:     "invalid object",
+			err:      kerrors.NewInvalid(schema.GroupKind{Kind: "ConfigMap"}, "settings", field.ErrorList{field.Required(field.NewPath("data"), "")}),
+			expected: true,
+		},
+		{
+			name:     "user not authorized to create the object",
+			err:      kerrors.NewForbidden(configMaps, "settings", errors.New(`User "bob@acme.com" cannot create resource "configmaps" in API group "" in the namespace "default"`)),
+			expected: false,
+		},
+		{
+			name:     "namespace not found",
+			err:      kerrors.NewNotFound(schema.GroupResource{Resource: "namespaces"}, "missing"),
+			expected: false,
+		},
+		{
+			name:     "conflict",
+			err:      kerrors.NewConflict(configMaps, "settings", errors.New("the object has been modified")),
+			expected: false,
+		},
+		{
+			name:     "no API status",
+			err:      errors.New("connection refused"),
+			expected: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			if denial := isAdmissionDenial(tc.err); denial != tc.expected {
+				t.Fatalf("expected admission denial %v, got %v for: %v", tc.expected, denial, tc.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"k8c.io/kubermatic/v2/pkg/handler/test"
+	"k8c.io/kubermatic/v2/pkg/handler/test/hack"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestCheckClusterAdmission(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		Name             string
+		Body             string
+		ExistingKubeObjs []runtime.Object
+		ExpectedResponse string
+		HTTPStatus       int
+	}{
+		{
+			Name:             "scenario 1: a new object is admitted",
+			Body:             `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"settings","namespace":"default"},"data":{"key":"value"}}`,
+			ExpectedResponse: `{"admitted":true}`,
+			HTTPStatus:       http.StatusOK,
+		},
+		{
+			Name: "scenario 2: an update of an existing object is admitted",
+			Body: `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"settings","namespace":"default"},"data":{"key":"other"}}`,
+			ExistingKubeObjs: []runtime.Object{
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: "default"},
+					Data:       map[string]string{"key": "value"},
+				},
+			},
+			ExpectedResponse: `{"admitted":true}`,
+			HTTPStatus:       http.StatusOK,
+		},
+		{
+			Name:             "scenario 3: the object must have a kind",
+			Body:             `{"apiVersion":"v1","metadata":{"name":"settings","namespace":"default"}}`,
+			ExpectedResponse: `{"error":{"code":400,"message":"the object must contain apiVersion and kind"}}`,
+			HTTPStatus:       http.StatusBadRequest,
+		},
+		{
+			Name:             "scenario 4: the object must have a name",
+			Body:             `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"namespace":"default"}}`,
+			ExpectedResponse: `{"error":{"code":400,"message":"the object must contain metadata.name"}}`,
+			HTTPStatus:       http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", fmt.Sprintf("/api/v2/projects/%s/clusters/%s/admissioncheck", test.GenDefaultProject().Name, test.GenDefaultCluster().Name), strings.NewReader(tc.Body))
+			res := httptest.NewRecorder()
+			ep, err := test.CreateTestEndpoint(*test.GenDefaultAPIUser(), tc.ExistingKubeObjs, test.GenDefaultKubermaticObjects(test.GenDefaultCluster()), nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+
+			test.CompareWithResult(t, res, tc.ExpectedResponse)
+		})
+	}
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-kit/kit/endpoint"
+
+	apiv2 "k8c.io/kubermatic/v2/pkg/api/v2"
+	handlercommon "k8c.io/kubermatic/v2/pkg/handler/common"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/util/errors"
+	"k8c.io/kubermatic/v2/pkg/version"
+)
+
+// GetSecurityAdvisoriesEndpoint returns the known security advisories affecting the Kubernetes version of the cluster.
+func GetSecurityAdvisoriesEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter, securityAdvisories []*version.SecurityAdvisory) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(GetClusterReq)
+
+		cluster, err := handlercommon.GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, req.ProjectID, req.ClusterID, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		advisories, err := version.SecurityAdvisoriesForVersion(securityAdvisories, cluster.Spec.Version.Semver())
+		if err != nil {
+			return nil, errors.New(http.StatusInternalServerError, err.Error())
+		}
+
+		result := make([]apiv2.SecurityAdvisory, 0, len(advisories))
+		for _, advisory := range advisories {
+			result = append(result, apiv2.SecurityAdvisory{
+				ID:               advisory.ID,
+				Description:      advisory.Description,
+				URL:              advisory.URL,
+				AffectedVersions: advisory.AffectedVersions,
+			})
+		}
+
+		return result, nil
+	}
+}
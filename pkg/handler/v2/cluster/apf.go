@@ -0,0 +1,111 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"sort"
+
+	"github.com/go-kit/kit/endpoint"
+
+	apiv2 "k8c.io/kubermatic/v2/pkg/api/v2"
+	handlercommon "k8c.io/kubermatic/v2/pkg/handler/common"
+	"k8c.io/kubermatic/v2/pkg/handler/middleware"
+	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
+	"k8c.io/kubermatic/v2/pkg/provider"
+
+	flowcontrolv1alpha1 "k8s.io/api/flowcontrol/v1alpha1"
+)
+
+// GetAPFConfigurationEndpoint returns the API priority and fairness configuration of the cluster: the flow schemas
+// the API server classifies the requests with and the priority levels limiting them
+func GetAPFConfigurationEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(GetClusterReq)
+		clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
+
+		cluster, err := handlercommon.GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, req.ProjectID, req.ClusterID, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		client, err := clusterProvider.GetAdminClientForCustomerCluster(cluster)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		flowSchemas := &flowcontrolv1alpha1.FlowSchemaList{}
+		if err := client.List(ctx, flowSchemas); err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		priorityLevels := &flowcontrolv1alpha1.PriorityLevelConfigurationList{}
+		if err := client.List(ctx, priorityLevels); err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		return convertAPFConfigurationToAPI(flowSchemas.Items, priorityLevels.Items), nil
+	}
+}
+
+func convertAPFConfigurationToAPI(flowSchemas []flowcontrolv1alpha1.FlowSchema, priorityLevels []flowcontrolv1alpha1.PriorityLevelConfiguration) *apiv2.APFConfiguration {
+	result := &apiv2.APFConfiguration{
+		FlowSchemas:    []apiv2.APFFlowSchema{},
+		PriorityLevels: []apiv2.APFPriorityLevel{},
+	}
+
+	for _, flowSchema := range flowSchemas {
+		apiFlowSchema := apiv2.APFFlowSchema{
+			Name:               flowSchema.Name,
+			PriorityLevel:      flowSchema.Spec.PriorityLevelConfiguration.Name,
+			MatchingPrecedence: flowSchema.Spec.MatchingPrecedence,
+			Rules:              len(flowSchema.Spec.Rules),
+		}
+		if flowSchema.Spec.DistinguisherMethod != nil {
+			apiFlowSchema.DistinguisherMethod = string(flowSchema.Spec.DistinguisherMethod.Type)
+		}
+		result.FlowSchemas = append(result.FlowSchemas, apiFlowSchema)
+	}
+	// the API server evaluates the flow schemas in this order, the first matching schema classifies the request
+	sort.SliceStable(result.FlowSchemas, func(i, j int) bool {
+		if result.FlowSchemas[i].MatchingPrecedence != result.FlowSchemas[j].MatchingPrecedence {
+			return result.FlowSchemas[i].MatchingPrecedence < result.FlowSchemas[j].MatchingPrecedence
+		}
+		return result.FlowSchemas[i].Name < result.FlowSchemas[j].Name
+	})
+
+	for _, priorityLevel := range priorityLevels {
+		apiPriorityLevel := apiv2.APFPriorityLevel{
+			Name: priorityLevel.Name,
+			Type: string(priorityLevel.Spec.Type),
+		}
+		if limited := priorityLevel.Spec.Limited; limited != nil {
+			apiPriorityLevel.AssuredConcurrencyShares = limited.AssuredConcurrencyShares
+			apiPriorityLevel.LimitResponse = string(limited.LimitResponse.Type)
+			if queuing := limited.LimitResponse.Queuing; queuing != nil {
+				apiPriorityLevel.Queues = queuing.Queues
+				apiPriorityLevel.HandSize = queuing.HandSize
+				apiPriorityLevel.QueueLengthLimit = queuing.QueueLengthLimit
+			}
+		}
+		result.PriorityLevels = append(result.PriorityLevels, apiPriorityLevel)
+	}
+	sort.Slice(result.PriorityLevels, func(i, j int) bool {
+		return result.PriorityLevels[i].Name < result.PriorityLevels[j].Name
+	})
+
+	return result
+}
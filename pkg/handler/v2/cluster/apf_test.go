@@ -0,0 +1,116 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"k8c.io/kubermatic/v2/pkg/handler/test"
+	"k8c.io/kubermatic/v2/pkg/handler/test/hack"
+
+	flowcontrolv1alpha1 "k8s.io/api/flowcontrol/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestGetClusterAPFConfiguration(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		Name             string
+		ExistingKubeObjs []runtime.Object
+		ExpectedResponse string
+	}{
+		{
+			Name:             "scenario 1: a cluster without API priority and fairness objects",
+			ExpectedResponse: `{"flowSchemas":[],"priorityLevels":[]}`,
+		},
+		{
+			Name: "scenario 2: flow schemas are sorted by matching precedence and priority levels by name",
+			ExistingKubeObjs: []runtime.Object{
+				genFlowSchema("workload-low", "workload-low", 9000, flowcontrolv1alpha1.FlowDistinguisherMethodByNamespaceType),
+				genFlowSchema("exempt", "exempt", 1, ""),
+				genPriorityLevel("workload-low", 100, &flowcontrolv1alpha1.QueuingConfiguration{Queues: 128, HandSize: 6, QueueLengthLimit: 50}),
+				genPriorityLevel("catch-all", 5, nil),
+				&flowcontrolv1alpha1.PriorityLevelConfiguration{
+					ObjectMeta: metav1.ObjectMeta{Name: "exempt"},
+					Spec:       flowcontrolv1alpha1.PriorityLevelConfigurationSpec{Type: flowcontrolv1alpha1.PriorityLevelEnablementExempt},
+				},
+			},
+			ExpectedResponse: `{"flowSchemas":[{"name":"exempt","priorityLevel":"exempt","matchingPrecedence":1,"rules":1},{"name":"workload-low","priorityLevel":"workload-low","matchingPrecedence":9000,"distinguisherMethod":"ByNamespace","rules":1}],"priorityLevels":[{"name":"catch-all","type":"Limited","assuredConcurrencyShares":5,"limitResponse":"Reject"},{"name":"exempt","type":"Exempt"},{"name":"workload-low","type":"Limited","assuredConcurrencyShares":100,"limitResponse":"Queue","queues":128,"handSize":6,"queueLengthLimit":50}]}`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", fmt.Sprintf("/api/v2/projects/%s/clusters/%s/apf", test.GenDefaultProject().Name, test.GenDefaultCluster().Name), nil)
+			res := httptest.NewRecorder()
+			ep, err := test.CreateTestEndpoint(*test.GenDefaultAPIUser(), tc.ExistingKubeObjs, test.GenDefaultKubermaticObjects(test.GenDefaultCluster()), nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != http.StatusOK {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", http.StatusOK, res.Code, res.Body.String())
+			}
+
+			test.CompareWithResult(t, res, tc.ExpectedResponse)
+		})
+	}
+}
+
+func genFlowSchema(name, priorityLevel string, matchingPrecedence int32, distinguisherMethod flowcontrolv1alpha1.FlowDistinguisherMethodType) *flowcontrolv1alpha1.FlowSchema {
+	flowSchema := &flowcontrolv1alpha1.FlowSchema{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: flowcontrolv1alpha1.FlowSchemaSpec{
+			PriorityLevelConfiguration: flowcontrolv1alpha1.PriorityLevelConfigurationReference{Name: priorityLevel},
+			MatchingPrecedence:         matchingPrecedence,
+			Rules: []flowcontrolv1alpha1.PolicyRulesWithSubjects{
+				{
+					Subjects: []flowcontrolv1alpha1.Subject{
+						{Kind: flowcontrolv1alpha1.SubjectKindGroup, Group: &flowcontrolv1alpha1.GroupSubject{Name: "system:authenticated"}},
+					},
+				},
+			},
+		},
+	}
+	if distinguisherMethod != "" {
+		flowSchema.Spec.DistinguisherMethod = &flowcontrolv1alpha1.FlowDistinguisherMethod{Type: distinguisherMethod}
+	}
+	return flowSchema
+}
+
+func genPriorityLevel(name string, assuredConcurrencyShares int32, queuing *flowcontrolv1alpha1.QueuingConfiguration) *flowcontrolv1alpha1.PriorityLevelConfiguration {
+	limitResponse := flowcontrolv1alpha1.LimitResponse{Type: flowcontrolv1alpha1.LimitResponseTypeReject}
+	if queuing != nil {
+		limitResponse = flowcontrolv1alpha1.LimitResponse{Type: flowcontrolv1alpha1.LimitResponseTypeQueue, Queuing: queuing}
+	}
+	return &flowcontrolv1alpha1.PriorityLevelConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: flowcontrolv1alpha1.PriorityLevelConfigurationSpec{
+			Type: flowcontrolv1alpha1.PriorityLevelEnablementLimited,
+			Limited: &flowcontrolv1alpha1.LimitedPriorityLevelConfiguration{
+				AssuredConcurrencyShares: assuredConcurrencyShares,
+				LimitResponse:            limitResponse,
+			},
+		},
+	}
+}
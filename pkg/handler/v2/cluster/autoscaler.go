@@ -0,0 +1,128 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"sort"
+	"strconv"
+
+	"github.com/go-kit/kit/endpoint"
+
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	apiv2 "k8c.io/kubermatic/v2/pkg/api/v2"
+	handlercommon "k8c.io/kubermatic/v2/pkg/handler/common"
+	"k8c.io/kubermatic/v2/pkg/handler/middleware"
+	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/clusterautoscaler"
+	"k8c.io/kubermatic/v2/pkg/util/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// maxAutoscalerEvents is the maximum number of cluster-autoscaler events returned
+const maxAutoscalerEvents = 20
+
+// GetAutoscalerEndpoint returns the node groups managed by the cluster-autoscaler and its recent scaling events
+func GetAutoscalerEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(GetClusterReq)
+		clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
+
+		cluster, err := handlercommon.GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, req.ProjectID, req.ClusterID, &provider.ClusterGetOptions{CheckInitStatus: true})
+		if err != nil {
+			return nil, err
+		}
+		if !cluster.ClusterAutoscalerEnabled() {
+			return nil, errors.NewBadRequest("the cluster-autoscaler is not enabled for this cluster")
+		}
+
+		client, err := common.GetClusterClient(ctx, userInfoGetter, clusterProvider, cluster, req.ProjectID)
+		if err != nil {
+			return nil, clusterUnreachableToHTTPError(err)
+		}
+
+		machineDeployments := &clusterv1alpha1.MachineDeploymentList{}
+		if err := client.List(ctx, machineDeployments, ctrlruntimeclient.InNamespace(metav1.NamespaceSystem)); err != nil {
+			return nil, clusterUnreachableToHTTPError(err)
+		}
+
+		events := &corev1.EventList{}
+		if err := client.List(ctx, events); err != nil {
+			return nil, clusterUnreachableToHTTPError(err)
+		}
+
+		return apiv2.ClusterAutoscaler{
+			Enabled:    true,
+			NodeGroups: getAutoscalerNodeGroups(machineDeployments.Items),
+			Events:     getAutoscalerEvents(events.Items),
+		}, nil
+	}
+}
+
+// getAutoscalerNodeGroups returns the machine deployments which have both autoscaler size annotations
+func getAutoscalerNodeGroups(machineDeployments []clusterv1alpha1.MachineDeployment) []apiv2.ClusterAutoscalerNodeGroup {
+	nodeGroups := make([]apiv2.ClusterAutoscalerNodeGroup, 0)
+	for _, md := range machineDeployments {
+		minReplicas, err := strconv.Atoi(md.Annotations[clusterautoscaler.MinSizeAnnotation])
+		if err != nil {
+			continue
+		}
+		maxReplicas, err := strconv.Atoi(md.Annotations[clusterautoscaler.MaxSizeAnnotation])
+		if err != nil {
+			continue
+		}
+
+		nodeGroup := apiv2.ClusterAutoscalerNodeGroup{
+			Name:        md.Name,
+			MinReplicas: minReplicas,
+			MaxReplicas: maxReplicas,
+		}
+		if md.Spec.Replicas != nil {
+			nodeGroup.Replicas = *md.Spec.Replicas
+		}
+		nodeGroups = append(nodeGroups, nodeGroup)
+	}
+	return nodeGroups
+}
+
+// getAutoscalerEvents returns the most recent events emitted by the cluster-autoscaler, newest first
+func getAutoscalerEvents(events []corev1.Event) []apiv1.Event {
+	autoscalerEvents := make([]corev1.Event, 0)
+	for _, event := range events {
+		if event.Source.Component == resources.ClusterAutoscalerDeploymentName {
+			autoscalerEvents = append(autoscalerEvents, event)
+		}
+	}
+	sort.SliceStable(autoscalerEvents, func(i, j int) bool {
+		return autoscalerEvents[j].LastTimestamp.Before(&autoscalerEvents[i].LastTimestamp)
+	})
+	if len(autoscalerEvents) > maxAutoscalerEvents {
+		autoscalerEvents = autoscalerEvents[:maxAutoscalerEvents]
+	}
+
+	apiEvents := make([]apiv1.Event, 0, len(autoscalerEvents))
+	for _, event := range autoscalerEvents {
+		apiEvents = append(apiEvents, common.ConvertInternalEventToExternal(event))
+	}
+	return apiEvents
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/handler/test"
+	"k8c.io/kubermatic/v2/pkg/handler/test/hack"
+	"k8c.io/kubermatic/v2/pkg/resources/clusterautoscaler"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestGetClusterAutoscaler(t *testing.T) {
+	t.Parallel()
+	autoscaledMachineDeployment := test.GenTestMachineDeployment("venus", `{"cloudProvider":"fake"}`, map[string]string{"md-id": "123"}, false)
+	autoscaledMachineDeployment.Annotations = map[string]string{
+		clusterautoscaler.MinSizeAnnotation: "1",
+		clusterautoscaler.MaxSizeAnnotation: "5",
+	}
+	autoscalerCluster := test.GenCluster(test.DefaultClusterID, test.DefaultClusterName, test.GenDefaultProject().Name, test.GenDefaultCluster().CreationTimestamp.Time, func(cluster *kubermaticv1.Cluster) {
+		cluster.Annotations = map[string]string{kubermaticv1.AnnotationNameClusterAutoscalerEnabled: "true"}
+	})
+
+	testcases := []struct {
+		Name                   string
+		ExpectedResponse       string
+		HTTPStatus             int
+		ExistingAPIUser        *apiv1.User
+		ExistingKubeObjs       []runtime.Object
+		ExistingMachineObjs    []runtime.Object
+		ExistingKubermaticObjs []runtime.Object
+	}{
+		{
+			Name:             "scenario 1: get the node groups and events of the cluster-autoscaler",
+			ExpectedResponse: `{"enabled":true,"nodeGroups":[{"name":"venus","minReplicas":1,"maxReplicas":5,"replicas":1}],"events":[{"name":"scale-down","creationTimestamp":"0001-01-01T00:00:00Z","message":"node removed by cluster autoscaler","type":"Normal","involvedObject":{"type":"Node","name":"venus-1"},"lastTimestamp":"2020-10-02T10:00:00Z","count":1},{"name":"scale-up","creationTimestamp":"0001-01-01T00:00:00Z","message":"pod triggered scale-up","type":"Normal","involvedObject":{"type":"Pod","namespace":"default","name":"web-1"},"lastTimestamp":"2020-10-01T10:00:00Z","count":1}]}`,
+			HTTPStatus:       http.StatusOK,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExistingKubeObjs: []runtime.Object{
+				genAutoscalerEvent("scale-up", "default", "Pod", "web-1", "pod triggered scale-up", "cluster-autoscaler", time.Date(2020, 10, 1, 10, 0, 0, 0, time.UTC)),
+				genAutoscalerEvent("scale-down", "default", "Node", "venus-1", "node removed by cluster autoscaler", "cluster-autoscaler", time.Date(2020, 10, 2, 10, 0, 0, 0, time.UTC)),
+				genAutoscalerEvent("pulled", "default", "Pod", "web-1", "image pulled", "kubelet", time.Date(2020, 10, 3, 10, 0, 0, 0, time.UTC)),
+			},
+			ExistingMachineObjs: []runtime.Object{
+				autoscaledMachineDeployment,
+				test.GenTestMachineDeployment("mars", `{"cloudProvider":"fake"}`, map[string]string{"md-id": "345"}, false),
+			},
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(autoscalerCluster),
+		},
+		{
+			Name:                   "scenario 2: the cluster-autoscaler is not enabled",
+			ExpectedResponse:       `{"error":{"code":400,"message":"the cluster-autoscaler is not enabled for this cluster"}}`,
+			HTTPStatus:             http.StatusBadRequest,
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(test.GenDefaultCluster()),
+		},
+		{
+			Name:             "scenario 3: the user John can not get the cluster-autoscaler of Bob's cluster",
+			ExpectedResponse: `{"error":{"code":403,"message":"forbidden: \"john@acme.com\" doesn't belong to the given project = my-first-project-ID"}}`,
+			HTTPStatus:       http.StatusForbidden,
+			ExistingAPIUser:  test.GenAPIUser("John", "john@acme.com"),
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				autoscalerCluster,
+				genUser("John", "john@acme.com", false),
+			),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", fmt.Sprintf("/api/v2/projects/%s/clusters/%s/autoscaler", test.GenDefaultProject().Name, test.DefaultClusterID), nil)
+			res := httptest.NewRecorder()
+			ep, _, err := test.CreateTestEndpointAndGetClients(*tc.ExistingAPIUser, nil, tc.ExistingKubeObjs, tc.ExistingMachineObjs, tc.ExistingKubermaticObjs, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+
+			test.CompareWithResult(t, res, tc.ExpectedResponse)
+		})
+	}
+}
+
+func genAutoscalerEvent(name, namespace, kind, objectName, message, component string, lastTimestamp time.Time) *corev1.Event {
+	involvedObjectNamespace := namespace
+	if kind == "Node" {
+		involvedObjectNamespace = ""
+	}
+	return &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		InvolvedObject: corev1.ObjectReference{
+			Name:      objectName,
+			Namespace: involvedObjectNamespace,
+			Kind:      kind,
+		},
+		Message:       message,
+		Source:        corev1.EventSource{Component: component},
+		Count:         1,
+		Type:          corev1.EventTypeNormal,
+		LastTimestamp: metav1.NewTime(lastTimestamp),
+	}
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-kit/kit/endpoint"
+
+	apiv2 "k8c.io/kubermatic/v2/pkg/api/v2"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	handlercommon "k8c.io/kubermatic/v2/pkg/handler/common"
+	"k8c.io/kubermatic/v2/pkg/handler/middleware"
+	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/util/errors"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// maxBulkDeleteClusters is the maximum number of clusters a single bulk delete request can delete
+const maxBulkDeleteClusters = 100
+
+// BulkDeleteEndpoint deletes the given clusters of the project one after another. A cluster which can not be deleted
+// does not stop the deletion of the others, instead the outcome is reported for every cluster.
+// Every deletion goes through the same authorization as the deletion of a single cluster.
+func BulkDeleteEndpoint(sshKeyProvider provider.SSHKeyProvider, privilegedSSHKeyProvider provider.PrivilegedSSHKeyProvider, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, seedsGetter provider.SeedsGetter, clusterProviderGetter provider.ClusterProviderGetter, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(BulkDeleteReq)
+
+		adminUserInfo, err := userInfoGetter(ctx, "")
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		seeds, err := seedsGetter()
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		result := make([]apiv2.ClusterDeletionStatus, 0, len(req.Body.ClusterIDs))
+		for _, clusterID := range req.Body.ClusterIDs {
+			status := apiv2.ClusterDeletionStatus{ClusterID: clusterID}
+
+			err := func() error {
+				clusterProvider, err := findClusterProvider(seeds, clusterProviderGetter, clusterID)
+				if err != nil {
+					return err
+				}
+				privilegedClusterProvider, ok := clusterProvider.(provider.PrivilegedClusterProvider)
+				if !ok {
+					return errors.New(http.StatusInternalServerError, "the cluster provider can not delete the cluster")
+				}
+
+				// regular users are not allowed to access the clusters of other projects
+				if !adminUserInfo.IsAdmin {
+					cluster := &kubermaticv1.Cluster{}
+					if err := privilegedClusterProvider.GetSeedClusterAdminRuntimeClient().Get(ctx, types.NamespacedName{Name: clusterID}, cluster); err != nil {
+						return common.KubernetesErrorToHTTPError(err)
+					}
+					if cluster.Labels[kubermaticv1.ProjectIDLabelKey] != req.ProjectID {
+						return errors.New(http.StatusForbidden, "the cluster does not belong to the given project")
+					}
+				}
+
+				clusterCtx := context.WithValue(ctx, middleware.ClusterProviderContextKey, clusterProvider)
+				clusterCtx = context.WithValue(clusterCtx, middleware.PrivilegedClusterProviderContextKey, privilegedClusterProvider)
+				_, err = handlercommon.DeleteEndpoint(clusterCtx, userInfoGetter, req.ProjectID, clusterID, req.DeleteVolumes, req.DeleteLoadBalancers, sshKeyProvider, privilegedSSHKeyProvider, projectProvider, privilegedProjectProvider)
+				return err
+			}()
+
+			status.Status = clusterDeletionResult(err)
+			if err != nil {
+				status.Message = err.Error()
+			}
+			result = append(result, status)
+		}
+
+		return result, nil
+	}
+}
+
+// findClusterProvider returns the cluster provider of the seed the cluster with the given ID runs in
+func findClusterProvider(seeds map[string]*kubermaticv1.Seed, clusterProviderGetter provider.ClusterProviderGetter, clusterID string) (provider.ClusterProvider, error) {
+	for _, seed := range seeds {
+		clusterProvider, err := clusterProviderGetter(seed)
+		if err != nil {
+			return nil, errors.New(http.StatusInternalServerError, err.Error())
+		}
+		if clusterProvider.IsCluster(clusterID) {
+			return clusterProvider, nil
+		}
+	}
+	return nil, errors.NewNotFound("cluster", clusterID)
+}
+
+func clusterDeletionResult(err error) apiv2.ClusterDeletionResult {
+	if err == nil {
+		return apiv2.ClusterDeleted
+	}
+	if httpErr, ok := err.(errors.HTTPError); ok {
+		switch httpErr.StatusCode() {
+		case http.StatusNotFound:
+			return apiv2.ClusterDeletionNotFound
+		case http.StatusUnauthorized, http.StatusForbidden:
+			return apiv2.ClusterDeletionForbidden
+		}
+	}
+	return apiv2.ClusterDeletionFailed
+}
+
+// BulkDeleteReq defines HTTP request for deleteClustersV2 endpoint
+// swagger:parameters deleteClustersV2
+type BulkDeleteReq struct {
+	common.ProjectReq
+	// in: header
+	// DeleteVolumes if true all cluster PV's and PVC's will be deleted from the clusters
+	DeleteVolumes bool
+	// in: header
+	// DeleteLoadBalancers if true all load balancers will be deleted from the clusters
+	DeleteLoadBalancers bool
+	// in: body
+	// required: true
+	Body apiv2.ClusterDeletion
+}
+
+func DecodeBulkDeleteReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req BulkDeleteReq
+
+	projectReq, err := common.DecodeProjectRequest(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.ProjectReq = projectReq.(common.ProjectReq)
+
+	if headerValue := r.Header.Get("DeleteVolumes"); len(headerValue) > 0 {
+		req.DeleteVolumes, err = strconv.ParseBool(headerValue)
+		if err != nil {
+			return nil, errors.NewBadRequest("invalid DeleteVolumes header: %v", err)
+		}
+	}
+	if headerValue := r.Header.Get("DeleteLoadBalancers"); len(headerValue) > 0 {
+		req.DeleteLoadBalancers, err = strconv.ParseBool(headerValue)
+		if err != nil {
+			return nil, errors.NewBadRequest("invalid DeleteLoadBalancers header: %v", err)
+		}
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req.Body); err != nil {
+		return nil, errors.NewBadRequest("cannot decode the clusters to delete: %v", err)
+	}
+	if len(req.Body.ClusterIDs) == 0 {
+		return nil, errors.NewBadRequest("clusterIDs must not be empty")
+	}
+
+	// every cluster is deleted only once, in the order it was first listed
+	seen := sets.NewString()
+	clusterIDs := make([]string, 0, len(req.Body.ClusterIDs))
+	for _, clusterID := range req.Body.ClusterIDs {
+		if !seen.Has(clusterID) {
+			seen.Insert(clusterID)
+			clusterIDs = append(clusterIDs, clusterID)
+		}
+	}
+	if len(clusterIDs) > maxBulkDeleteClusters {
+		return nil, errors.NewBadRequest("at most %d clusters can be deleted at once, got %d", maxBulkDeleteClusters, len(clusterIDs))
+	}
+	req.Body.ClusterIDs = clusterIDs
+
+	return req, nil
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/handler/test"
+	"k8c.io/kubermatic/v2/pkg/handler/test/hack"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestBulkDeleteClusters(t *testing.T) {
+	t.Parallel()
+
+	const foreignProjectID = "foreign-project-ID"
+	genClusters := func() []runtime.Object {
+		return []runtime.Object{
+			test.GenCluster("clusterAbcID", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC)),
+			test.GenCluster("clusterForeignID", "clusterForeign", foreignProjectID, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC)),
+		}
+	}
+
+	testcases := []struct {
+		Name                   string
+		Body                   string
+		ExpectedResponse       string
+		HTTPStatus             int
+		ExpectedExisting       []string
+		ExistingKubermaticObjs []runtime.Object
+		ExistingAPIUser        *apiv1.User
+	}{
+		{
+			Name:                   "scenario 1: only the cluster of the project is deleted, the other clusters are reported",
+			Body:                   `{"clusterIDs":["clusterAbcID","clusterForeignID","missingClusterID"]}`,
+			ExpectedResponse:       `[{"clusterID":"clusterAbcID","status":"deleted"},{"clusterID":"clusterForeignID","status":"forbidden","message":"the cluster does not belong to the given project"},{"clusterID":"missingClusterID","status":"not-found","message":"cluster \"missingClusterID\" not found"}]`,
+			HTTPStatus:             http.StatusOK,
+			ExpectedExisting:       []string{"clusterForeignID"},
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(genClusters()...),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		{
+			Name:                   "scenario 2: the admin John can delete Bob's cluster, but not clusters of other projects",
+			Body:                   `{"clusterIDs":["clusterForeignID","clusterAbcID"]}`,
+			ExpectedResponse:       `[{"clusterID":"clusterForeignID","status":"not-found","message":" \"clusterForeignID\" not found"},{"clusterID":"clusterAbcID","status":"deleted"}]`,
+			HTTPStatus:             http.StatusOK,
+			ExpectedExisting:       []string{"clusterForeignID"},
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(append(genClusters(), genUser("John", "john@acme.com", true))...),
+			ExistingAPIUser:        test.GenAPIUser("John", "john@acme.com"),
+		},
+		{
+			Name:                   "scenario 3: the regular user John can not delete Bob's cluster",
+			Body:                   `{"clusterIDs":["clusterAbcID"]}`,
+			ExpectedResponse:       `[{"clusterID":"clusterAbcID","status":"forbidden","message":"forbidden: \"john@acme.com\" doesn't belong to the given project = my-first-project-ID"}]`,
+			HTTPStatus:             http.StatusOK,
+			ExpectedExisting:       []string{"clusterAbcID", "clusterForeignID"},
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(append(genClusters(), genUser("John", "john@acme.com", false))...),
+			ExistingAPIUser:        test.GenAPIUser("John", "john@acme.com"),
+		},
+		{
+			Name:                   "scenario 4: the list of clusters must not be empty",
+			Body:                   `{"clusterIDs":[]}`,
+			ExpectedResponse:       `{"error":{"code":400,"message":"clusterIDs must not be empty"}}`,
+			HTTPStatus:             http.StatusBadRequest,
+			ExpectedExisting:       []string{"clusterAbcID", "clusterForeignID"},
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(genClusters()...),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		{
+			Name:                   "scenario 5: a cluster listed twice is deleted once",
+			Body:                   `{"clusterIDs":["clusterAbcID","missingClusterID","clusterAbcID"]}`,
+			ExpectedResponse:       `[{"clusterID":"clusterAbcID","status":"deleted"},{"clusterID":"missingClusterID","status":"not-found","message":"cluster \"missingClusterID\" not found"}]`,
+			HTTPStatus:             http.StatusOK,
+			ExpectedExisting:       []string{"clusterForeignID"},
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(genClusters()...),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		{
+			Name:                   "scenario 6: at most 100 clusters can be deleted at once",
+			Body:                   genBulkDeleteBody(101),
+			ExpectedResponse:       `{"error":{"code":400,"message":"at most 100 clusters can be deleted at once, got 101"}}`,
+			HTTPStatus:             http.StatusBadRequest,
+			ExpectedExisting:       []string{"clusterAbcID", "clusterForeignID"},
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(genClusters()...),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", fmt.Sprintf("/api/v2/projects/%s/clusters/delete", test.GenDefaultProject().Name), strings.NewReader(tc.Body))
+			res := httptest.NewRecorder()
+			ep, clients, err := test.CreateTestEndpointAndGetClients(*tc.ExistingAPIUser, nil, []runtime.Object{}, nil, tc.ExistingKubermaticObjs, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+			test.CompareWithResult(t, res, tc.ExpectedResponse)
+
+			for _, clusterID := range tc.ExpectedExisting {
+				if err := clients.FakeClient.Get(context.Background(), types.NamespacedName{Name: clusterID}, &kubermaticv1.Cluster{}); err != nil {
+					t.Fatalf("expected cluster %s to still exist: %v", clusterID, err)
+				}
+			}
+		})
+	}
+}
+
+func genBulkDeleteBody(clusters int) string {
+	clusterIDs := make([]string, 0, clusters)
+	for i := 0; i < clusters; i++ {
+		clusterIDs = append(clusterIDs, fmt.Sprintf("%q", fmt.Sprintf("cluster%dID", i)))
+	}
+	return fmt.Sprintf(`{"clusterIDs":[%s]}`, strings.Join(clusterIDs, ","))
+}
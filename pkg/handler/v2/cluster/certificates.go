@@ -0,0 +1,132 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-kit/kit/endpoint"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	apiv2 "k8c.io/kubermatic/v2/pkg/api/v2"
+	handlercommon "k8c.io/kubermatic/v2/pkg/handler/common"
+	"k8c.io/kubermatic/v2/pkg/handler/middleware"
+	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	kubernetesprovider "k8c.io/kubermatic/v2/pkg/provider/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/util/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	certutil "k8s.io/client-go/util/cert"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// clusterCertificates maps the name of a control plane certificate to the secret and key it is stored in
+var clusterCertificates = []struct {
+	name       string
+	secretName string
+	secretKey  string
+}{
+	{name: "ca", secretName: resources.CASecretName, secretKey: resources.CACertSecretKey},
+	{name: "apiserver", secretName: resources.ApiserverTLSSecretName, secretKey: resources.ApiserverTLSCertSecretKey},
+	{name: "etcd", secretName: resources.EtcdTLSCertificateSecretName, secretKey: resources.EtcdTLSCertSecretKey},
+	{name: "apiserver-etcd-client", secretName: resources.ApiserverEtcdClientCertificateSecretName, secretKey: resources.ApiserverEtcdClientCertificateCertSecretKey},
+}
+
+// GetCertificatesEndpoint returns the expiry dates of the control plane certificates of the cluster.
+// Certificates which were not created yet are omitted.
+func GetCertificatesEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(GetClusterReq)
+		privilegedClusterProvider := ctx.Value(middleware.PrivilegedClusterProviderContextKey).(provider.PrivilegedClusterProvider)
+
+		cluster, err := handlercommon.GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, req.ProjectID, req.ClusterID, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		return getClusterCertificates(ctx, privilegedClusterProvider.GetSeedClusterAdminRuntimeClient(), cluster.Status.NamespaceName)
+	}
+}
+
+// getClusterCertificates returns the expiry dates of the certificates stored in the given cluster namespace
+func getClusterCertificates(ctx context.Context, seedClient ctrlruntimeclient.Client, namespace string) ([]apiv2.ClusterCertificate, error) {
+	certificates := make([]apiv2.ClusterCertificate, 0, len(clusterCertificates))
+	for _, certificate := range clusterCertificates {
+		secret := &corev1.Secret{}
+		if err := seedClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: certificate.secretName}, secret); err != nil {
+			if kerrors.IsNotFound(err) {
+				continue
+			}
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		certs, err := certutil.ParseCertsPEM(secret.Data[certificate.secretKey])
+		if err != nil {
+			return nil, errors.New(http.StatusInternalServerError, fmt.Sprintf("failed to parse the %s certificate: %v", certificate.name, err))
+		}
+
+		certificates = append(certificates, apiv2.ClusterCertificate{
+			Name:   certificate.name,
+			Expiry: apiv1.NewTime(certs[0].NotAfter),
+		})
+	}
+
+	return certificates, nil
+}
+
+// clustersWithExpiringCertificates returns the IDs of the given clusters with a control plane certificate which expires
+// within the given duration. The certificate secrets of all clusters of the seed are listed at once, one list call
+// per certificate.
+func clustersWithExpiringCertificates(ctx context.Context, seedClient ctrlruntimeclient.Client, clusters []*apiv1.Cluster, duration time.Duration) (sets.String, error) {
+	namespaces := map[string]string{}
+	for _, cluster := range clusters {
+		namespaces[kubernetesprovider.NamespaceName(cluster.ID)] = cluster.ID
+	}
+
+	deadline := time.Now().Add(duration)
+	expiring := sets.NewString()
+	for _, certificate := range clusterCertificates {
+		secrets := &corev1.SecretList{}
+		if err := seedClient.List(ctx, secrets, ctrlruntimeclient.MatchingFields{"metadata.name": certificate.secretName}); err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		for _, secret := range secrets.Items {
+			clusterID, ok := namespaces[secret.Namespace]
+			if !ok || secret.Name != certificate.secretName || expiring.Has(clusterID) {
+				continue
+			}
+			certs, err := certutil.ParseCertsPEM(secret.Data[certificate.secretKey])
+			if err != nil {
+				return nil, errors.New(http.StatusInternalServerError, fmt.Sprintf("failed to parse the %s certificate of cluster %q: %v", certificate.name, clusterID, err))
+			}
+			if certs[0].NotAfter.Before(deadline) {
+				expiring.Insert(clusterID)
+			}
+		}
+	}
+
+	return expiring, nil
+}
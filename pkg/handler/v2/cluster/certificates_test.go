@@ -0,0 +1,161 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	"k8c.io/kubermatic/v2/pkg/handler/test"
+	"k8c.io/kubermatic/v2/pkg/handler/test/hack"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/certificates/triple"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestGetClusterCertificates(t *testing.T) {
+	t.Parallel()
+	clusterNamespace := test.GenDefaultCluster().Status.NamespaceName
+	testcases := []struct {
+		Name                   string
+		ExpectedResponse       string
+		HTTPStatus             int
+		ProjectToSync          string
+		ClusterToSync          string
+		ExistingAPIUser        *apiv1.User
+		ExistingKubeObjs       []runtime.Object
+		ExistingKubermaticObjs []runtime.Object
+	}{
+		{
+			Name:             "scenario 1: get the expiry dates of the cluster certificates",
+			ExpectedResponse: `[{"name":"ca","expiry":"2030-01-01T00:00:00Z"},{"name":"apiserver","expiry":"2021-06-01T00:00:00Z"},{"name":"etcd","expiry":"2021-07-01T00:00:00Z"},{"name":"apiserver-etcd-client","expiry":"2021-08-01T00:00:00Z"}]`,
+			HTTPStatus:       http.StatusOK,
+			ProjectToSync:    test.GenDefaultProject().Name,
+			ClusterToSync:    test.GenDefaultCluster().Name,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExistingKubeObjs: []runtime.Object{
+				genCertificateSecret(t, clusterNamespace, resources.CASecretName, resources.CACertSecretKey, time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)),
+				genCertificateSecret(t, clusterNamespace, resources.ApiserverTLSSecretName, resources.ApiserverTLSCertSecretKey, time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)),
+				genCertificateSecret(t, clusterNamespace, resources.EtcdTLSCertificateSecretName, resources.EtcdTLSCertSecretKey, time.Date(2021, 7, 1, 0, 0, 0, 0, time.UTC)),
+				genCertificateSecret(t, clusterNamespace, resources.ApiserverEtcdClientCertificateSecretName, resources.ApiserverEtcdClientCertificateCertSecretKey, time.Date(2021, 8, 1, 0, 0, 0, 0, time.UTC)),
+			},
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(test.GenDefaultCluster()),
+		},
+		{
+			Name:             "scenario 2: certificates which were not created yet are omitted",
+			ExpectedResponse: `[{"name":"ca","expiry":"2030-01-01T00:00:00Z"}]`,
+			HTTPStatus:       http.StatusOK,
+			ProjectToSync:    test.GenDefaultProject().Name,
+			ClusterToSync:    test.GenDefaultCluster().Name,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExistingKubeObjs: []runtime.Object{
+				genCertificateSecret(t, clusterNamespace, resources.CASecretName, resources.CACertSecretKey, time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)),
+			},
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(test.GenDefaultCluster()),
+		},
+		{
+			Name:             "scenario 3: the user John can not get the certificates of Bob's cluster",
+			ExpectedResponse: `{"error":{"code":403,"message":"forbidden: \"john@acme.com\" doesn't belong to the given project = my-first-project-ID"}}`,
+			HTTPStatus:       http.StatusForbidden,
+			ProjectToSync:    test.GenDefaultProject().Name,
+			ClusterToSync:    test.GenDefaultCluster().Name,
+			ExistingAPIUser:  test.GenAPIUser("John", "john@acme.com"),
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenDefaultCluster(),
+				genUser("John", "john@acme.com", false),
+			),
+		},
+		{
+			Name:             "scenario 4: the admin John can get the certificates of Bob's cluster",
+			ExpectedResponse: `[{"name":"ca","expiry":"2030-01-01T00:00:00Z"}]`,
+			HTTPStatus:       http.StatusOK,
+			ProjectToSync:    test.GenDefaultProject().Name,
+			ClusterToSync:    test.GenDefaultCluster().Name,
+			ExistingAPIUser:  test.GenAPIUser("John", "john@acme.com"),
+			ExistingKubeObjs: []runtime.Object{
+				genCertificateSecret(t, clusterNamespace, resources.CASecretName, resources.CACertSecretKey, time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)),
+			},
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenDefaultCluster(),
+				genUser("John", "john@acme.com", true),
+			),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", fmt.Sprintf("/api/v2/projects/%s/clusters/%s/certificates", tc.ProjectToSync, tc.ClusterToSync), nil)
+			res := httptest.NewRecorder()
+			ep, err := test.CreateTestEndpoint(*tc.ExistingAPIUser, tc.ExistingKubeObjs, tc.ExistingKubermaticObjs, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+
+			test.CompareWithResult(t, res, tc.ExpectedResponse)
+		})
+	}
+}
+
+func genCertificateSecret(t *testing.T, namespace, name, key string, notAfter time.Time) *corev1.Secret {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate private key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    notAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &privateKey.PublicKey, privateKey)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("failed to parse certificate: %v", err)
+	}
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Data: map[string][]byte{
+			key: triple.EncodeCertPEM(cert),
+		},
+	}
+}
@@ -22,13 +22,17 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-kit/kit/endpoint"
 	"github.com/prometheus/client_golang/prometheus"
 
 	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/handler"
 	handlercommon "k8c.io/kubermatic/v2/pkg/handler/common"
 	"k8c.io/kubermatic/v2/pkg/handler/middleware"
 	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
@@ -36,12 +40,14 @@ import (
 	"k8c.io/kubermatic/v2/pkg/util/errors"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog"
 )
 
 func CreateEndpoint(sshKeyProvider provider.SSHKeyProvider, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, seedsGetter provider.SeedsGetter,
 	initNodeDeploymentFailures *prometheus.CounterVec, eventRecorderProvider provider.EventRecorderProvider, credentialManager provider.PresetProvider,
-	exposeStrategy corev1.ServiceType, userInfoGetter provider.UserInfoGetter, settingsProvider provider.SettingsProvider, updateManager common.UpdateManager) endpoint.Endpoint {
+	exposeStrategy corev1.ServiceType, userInfoGetter provider.UserInfoGetter, settingsProvider provider.SettingsProvider, updateManager common.UpdateManager, addonConfigProvider provider.AddonConfigProvider) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(CreateClusterReq)
 		globalSettings, err := settingsProvider.GetGlobalSettings()
@@ -52,8 +58,20 @@ func CreateEndpoint(sshKeyProvider provider.SSHKeyProvider, projectProvider prov
 		if err != nil {
 			return nil, errors.NewBadRequest(err.Error())
 		}
+		if err := handlercommon.ValidateAddonVersions(addonConfigProvider, req.Body.Cluster.Spec.AddonVersions); err != nil {
+			return nil, errors.NewBadRequest(err.Error())
+		}
+		if req.ValidateRegistry {
+			if err := handlercommon.ValidateRegistryMirrorReachable(ctx, req.Body.Cluster.Spec.RegistryMirror); err != nil {
+				return nil, err
+			}
+		}
+		warnings, err := handlercommon.ClusterCreationWarnings(updateManager, req.Body)
+		if err != nil {
+			return nil, err
+		}
 
-		return handlercommon.CreateEndpoint(ctx, req.ProjectID, req.Body, sshKeyProvider, projectProvider, privilegedProjectProvider, seedsGetter, initNodeDeploymentFailures, eventRecorderProvider, credentialManager, exposeStrategy, userInfoGetter)
+		return handlercommon.CreateEndpoint(ctx, req.ProjectID, req.Body, sshKeyProvider, projectProvider, privilegedProjectProvider, seedsGetter, initNodeDeploymentFailures, eventRecorderProvider, credentialManager, exposeStrategy, userInfoGetter, warnings)
 
 	}
 }
@@ -61,7 +79,7 @@ func CreateEndpoint(sshKeyProvider provider.SSHKeyProvider, projectProvider prov
 // ListEndpoint list clusters for the given project
 func ListEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, seedsGetter provider.SeedsGetter, clusterProviderGetter provider.ClusterProviderGetter, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
-		req := request.(common.GetProjectRq)
+		req := request.(ListClustersReq)
 		allClusters := make([]*apiv1.Cluster, 0)
 
 		seeds, err := seedsGetter()
@@ -80,17 +98,239 @@ func ListEndpoint(projectProvider provider.ProjectProvider, privilegedProjectPro
 			if err != nil {
 				return nil, common.KubernetesErrorToHTTPError(err)
 			}
-			allClusters = append(allClusters, apiClusters...)
+			var expiringClusters sets.String
+			if req.certExpiringWithin != nil {
+				privilegedClusterProvider, ok := clusterProvider.(provider.PrivilegedClusterProvider)
+				if !ok {
+					return nil, errors.New(http.StatusInternalServerError, fmt.Sprintf("the cluster provider of seed %q can not read the certificates of the clusters", seed.Name))
+				}
+				expiringClusters, err = clustersWithExpiringCertificates(ctx, privilegedClusterProvider.GetSeedClusterAdminRuntimeClient(), apiClusters, *req.certExpiringWithin)
+				if err != nil {
+					return nil, err
+				}
+			}
+			for _, apiCluster := range apiClusters {
+				if !req.createdInRange(apiCluster.CreationTimestamp.Time) {
+					continue
+				}
+				if req.labelSelector != nil && !req.labelSelector.Matches(labels.Set(apiCluster.Labels)) {
+					continue
+				}
+				if req.Provider != "" {
+					providerName, err := provider.ClusterCloudProviderName(apiCluster.Spec.Cloud)
+					if err != nil {
+						return nil, errors.New(http.StatusInternalServerError, err.Error())
+					}
+					if providerName != req.Provider {
+						continue
+					}
+				}
+				if expiringClusters != nil && !expiringClusters.Has(apiCluster.ID) {
+					continue
+				}
+				allClusters = append(allClusters, apiCluster)
+			}
+		}
+
+		if req.limit == nil && req.offset == nil {
+			return allClusters, nil
+		}
+
+		sort.SliceStable(allClusters, func(i, j int) bool {
+			ti, tj := allClusters[i].CreationTimestamp.Time, allClusters[j].CreationTimestamp.Time
+			if !ti.Equal(tj) {
+				return ti.Before(tj)
+			}
+			return allClusters[i].Name < allClusters[j].Name
+		})
+
+		return handler.TotalCountResponse{
+			TotalCount: len(allClusters),
+			Response:   req.page(allClusters),
+		}, nil
+	}
+}
+
+// maxListClustersLimit is the maximum number of clusters returned by a single list request
+const maxListClustersLimit = 100
+
+// listClustersProviders are the cloud providers clusters can be filtered by
+var listClustersProviders = sets.NewString(
+	provider.AWSCloudProvider,
+	provider.AlibabaCloudProvider,
+	provider.AzureCloudProvider,
+	provider.BringYourOwnCloudProvider,
+	provider.DigitaloceanCloudProvider,
+	provider.FakeCloudProvider,
+	provider.GCPCloudProvider,
+	provider.HetznerCloudProvider,
+	provider.KubevirtCloudProvider,
+	provider.OpenstackCloudProvider,
+	provider.PacketCloudProvider,
+	provider.VSphereCloudProvider,
+)
+
+// ListClustersReq defines HTTP request for listClusters endpoint
+// swagger:parameters listClustersV2
+type ListClustersReq struct {
+	common.ProjectReq
+	// Only return clusters created at or after the given time, in RFC3339 format
+	// in: query
+	CreatedAfter string `json:"createdAfter,omitempty"`
+	// Only return clusters created before the given time, in RFC3339 format
+	// in: query
+	CreatedBefore string `json:"createdBefore,omitempty"`
+	// Only return clusters with a control plane certificate which expires within the given duration, e.g. "30d" or "12h"
+	// in: query
+	CertExpiringWithin string `json:"certExpiringWithin,omitempty"`
+	// Only return clusters of the given cloud provider, e.g. "openstack" or "aws"
+	// in: query
+	Provider string `json:"provider,omitempty"`
+	// Only return clusters whose labels match the given label selector, e.g. "env=prod,tier!=frontend"
+	// in: query
+	LabelSelector string `json:"labelSelector,omitempty"`
+	// The maximum number of clusters to return, at most 100. When limit or offset is set, the clusters are
+	// sorted by creation time and name, and the total number of clusters is returned in the X-Total-Count header
+	// in: query
+	Limit string `json:"limit,omitempty"`
+	// The number of clusters to skip
+	// in: query
+	Offset string `json:"offset,omitempty"`
+
+	createdAfter       *time.Time
+	createdBefore      *time.Time
+	certExpiringWithin *time.Duration
+	labelSelector      labels.Selector
+	limit              *int
+	offset             *int
+}
+
+// page returns the clusters selected by the limit and offset of the request
+func (req ListClustersReq) page(clusters []*apiv1.Cluster) []*apiv1.Cluster {
+	if req.offset != nil {
+		if *req.offset >= len(clusters) {
+			return []*apiv1.Cluster{}
+		}
+		clusters = clusters[*req.offset:]
+	}
+	if req.limit != nil && *req.limit < len(clusters) {
+		clusters = clusters[:*req.limit]
+	}
+	return clusters
+}
+
+// createdInRange checks if the given creation time is within the time range of the request
+func (req ListClustersReq) createdInRange(creationTime time.Time) bool {
+	if req.createdAfter != nil && creationTime.Before(*req.createdAfter) {
+		return false
+	}
+	if req.createdBefore != nil && !creationTime.Before(*req.createdBefore) {
+		return false
+	}
+	return true
+}
+
+func DecodeListClustersReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req ListClustersReq
+
+	pr, err := common.DecodeProjectRequest(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.ProjectReq = pr.(common.ProjectReq)
+
+	req.CreatedAfter = r.URL.Query().Get("createdAfter")
+	if req.CreatedAfter != "" {
+		createdAfter, err := time.Parse(time.RFC3339, req.CreatedAfter)
+		if err != nil {
+			return nil, errors.NewBadRequest("invalid createdAfter %q, must be a RFC3339 timestamp", req.CreatedAfter)
+		}
+		req.createdAfter = &createdAfter
+	}
+
+	req.CreatedBefore = r.URL.Query().Get("createdBefore")
+	if req.CreatedBefore != "" {
+		createdBefore, err := time.Parse(time.RFC3339, req.CreatedBefore)
+		if err != nil {
+			return nil, errors.NewBadRequest("invalid createdBefore %q, must be a RFC3339 timestamp", req.CreatedBefore)
+		}
+		req.createdBefore = &createdBefore
+	}
+
+	req.CertExpiringWithin = r.URL.Query().Get("certExpiringWithin")
+	if req.CertExpiringWithin != "" {
+		certExpiringWithin, err := parseDays(req.CertExpiringWithin)
+		if err != nil || certExpiringWithin <= 0 {
+			return nil, errors.NewBadRequest("invalid certExpiringWithin %q, must be a positive duration like \"30d\" or \"12h\"", req.CertExpiringWithin)
 		}
+		req.certExpiringWithin = &certExpiringWithin
+	}
+
+	req.Provider = r.URL.Query().Get("provider")
+	if req.Provider != "" && !listClustersProviders.Has(req.Provider) {
+		return nil, errors.NewBadRequest("invalid provider %q, must be one of: %s", req.Provider, strings.Join(listClustersProviders.List(), ", "))
+	}
 
-		return allClusters, nil
+	req.LabelSelector = r.URL.Query().Get("labelSelector")
+	if req.LabelSelector != "" {
+		req.labelSelector, err = labels.Parse(req.LabelSelector)
+		if err != nil {
+			return nil, errors.NewBadRequest("invalid labelSelector %q: %v", req.LabelSelector, err)
+		}
+	}
+
+	req.Limit = r.URL.Query().Get("limit")
+	if req.Limit != "" {
+		limit, err := strconv.Atoi(req.Limit)
+		if err != nil || limit <= 0 {
+			return nil, errors.NewBadRequest("invalid limit %q, must be a positive integer", req.Limit)
+		}
+		if limit > maxListClustersLimit {
+			limit = maxListClustersLimit
+		}
+		req.limit = &limit
 	}
+
+	req.Offset = r.URL.Query().Get("offset")
+	if req.Offset != "" {
+		offset, err := strconv.Atoi(req.Offset)
+		if err != nil || offset < 0 {
+			return nil, errors.NewBadRequest("invalid offset %q, must be a non-negative integer", req.Offset)
+		}
+		req.offset = &offset
+	}
+
+	return req, nil
+}
+
+// parseDays parses a duration like time.ParseDuration, but additionally accepts a number of days like "30d"
+func parseDays(duration string) (time.Duration, error) {
+	if strings.HasSuffix(duration, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(duration, "d"))
+		if err != nil {
+			return 0, err
+		}
+		return time.Duration(days) * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(duration)
 }
 
+// GetEndpoint returns the cluster tagged with its resource version, so that clients can skip
+// downloading a cluster which did not change since their last request
 func GetEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
-		req := request.(GetClusterReq)
-		return handlercommon.GetEndpoint(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, req.ProjectID, req.ClusterID)
+		req := request.(GetReq)
+		cluster, err := handlercommon.GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, req.ProjectID, req.ClusterID, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		apiCluster, err := handlercommon.ConvertClusterToAPIWithStatus(ctx, cluster)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler.NewETagResponse(apiCluster, cluster.ResourceVersion, req.IfNoneMatch), nil
 	}
 }
 
@@ -104,21 +344,59 @@ func DeleteEndpoint(sshKeyProvider provider.SSHKeyProvider, privilegedSSHKeyProv
 func PatchEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, seedsGetter provider.SeedsGetter, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(PatchReq)
-		return handlercommon.PatchEndpoint(ctx, userInfoGetter, req.ProjectID, req.ClusterID, req.Patch, seedsGetter, projectProvider, privilegedProjectProvider)
+		return handlercommon.PatchEndpoint(ctx, userInfoGetter, req.ProjectID, req.ClusterID, req.Patch, req.Acknowledge == handlercommon.AcknowledgeDisruption, seedsGetter, projectProvider, privilegedProjectProvider)
+	}
+}
+
+func UpdateEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, seedsGetter provider.SeedsGetter, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(UpdateReq)
+		return handlercommon.UpdateEndpoint(ctx, userInfoGetter, req.ProjectID, req.ClusterID, req.Body, seedsGetter, projectProvider, privilegedProjectProvider)
 	}
 }
 
 func GetClusterEventsEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(EventsReq)
-		return handlercommon.GetClusterEventsEndpoint(ctx, userInfoGetter, req.ProjectID, req.ClusterID, req.Type, projectProvider, privilegedProjectProvider)
+		fieldSelector, err := common.ParseEventFieldSelector(req.FieldSelector)
+		if err != nil {
+			return nil, errors.NewBadRequest("invalid field selector: %v", err)
+		}
+
+		// the user is authorized once, a watching client then keeps listing
+		// the events of the cluster with the seed client
+		cluster, err := handlercommon.GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, req.ProjectID, req.ClusterID, &provider.ClusterGetOptions{})
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		privilegedClusterProvider := ctx.Value(middleware.PrivilegedClusterProviderContextKey).(provider.PrivilegedClusterProvider)
+		seedClient := privilegedClusterProvider.GetSeedClusterAdminRuntimeClient()
+
+		listEvents := func() ([]apiv1.Event, error) {
+			events, err := handlercommon.ListClusterEvents(ctx, seedClient, cluster, req.Type, req.Search, fieldSelector)
+			if err != nil {
+				return nil, common.KubernetesErrorToHTTPError(err)
+			}
+			return req.recent(events), nil
+		}
+
+		// the first listing is done before the stream is opened so that
+		// errors are returned as a regular response
+		events, err := listEvents()
+		if err != nil {
+			return nil, err
+		}
+		if req.Watch {
+			return &eventStream{list: listEvents}, nil
+		}
+		return events, nil
 	}
 }
 
 func HealthEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
-		req := request.(GetClusterReq)
-		return handlercommon.HealthEndpoint(ctx, userInfoGetter, req.ProjectID, req.ClusterID, projectProvider, privilegedProjectProvider)
+		req := request.(HealthReq)
+		return handlercommon.HealthEndpoint(ctx, userInfoGetter, req.ProjectID, req.ClusterID, req.Detailed, projectProvider, privilegedProjectProvider)
 	}
 }
 
@@ -132,6 +410,53 @@ type EventsReq struct {
 
 	// in: query
 	Type string `json:"type,omitempty"`
+
+	// Search filters the events by a case-insensitive substring of their message or reason
+	// in: query
+	Search string `json:"search,omitempty"`
+
+	// FieldSelector filters the events by the object they are about, e.g. involvedObject.kind=Machine,involvedObject.name=my-machine.
+	// Only the involvedObject.name and involvedObject.kind fields are supported.
+	// in: query
+	FieldSelector string `json:"fieldSelector,omitempty"`
+
+	// Watch streams the events as Server-Sent Events instead of returning the list
+	// in: query
+	Watch bool `json:"watch,omitempty"`
+
+	// Only return events which occurred at or after the given RFC3339 timestamp
+	// in: query
+	Since string `json:"since,omitempty"`
+
+	// The maximum number of events to return. When limit or since is set, the events are sorted by
+	// the time they last occurred, the most recent first
+	// in: query
+	Limit string `json:"limit,omitempty"`
+
+	since *time.Time
+	limit *int
+}
+
+// recent returns the events selected by the since and limit of the request, the most recent first
+func (req EventsReq) recent(events []apiv1.Event) []apiv1.Event {
+	if req.since == nil && req.limit == nil {
+		return events
+	}
+
+	result := make([]apiv1.Event, 0, len(events))
+	for _, event := range events {
+		if req.since != nil && event.LastTimestamp.Time.Before(*req.since) {
+			continue
+		}
+		result = append(result, event)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[j].LastTimestamp.Time.Before(result[i].LastTimestamp.Time)
+	})
+	if req.limit != nil && *req.limit < len(result) {
+		result = result[:*req.limit]
+	}
+	return result
 }
 
 // GetSeedCluster returns the SeedCluster object
@@ -155,14 +480,42 @@ func DecodeGetClusterEvents(c context.Context, r *http.Request) (interface{}, er
 	}
 	req.ClusterID = clusterID
 
+	req.Search = r.URL.Query().Get("search")
 	req.Type = r.URL.Query().Get("type")
-	if len(req.Type) > 0 {
-		if req.Type == "warning" || req.Type == "normal" {
-			return req, nil
-		}
+	if len(req.Type) > 0 && req.Type != "warning" && req.Type != "normal" {
 		return nil, fmt.Errorf("wrong query paramater, unsupported type: %s", req.Type)
 	}
 
+	req.FieldSelector = r.URL.Query().Get("fieldSelector")
+	if _, err := common.ParseEventFieldSelector(req.FieldSelector); err != nil {
+		return nil, errors.NewBadRequest("invalid field selector: %v", err)
+	}
+
+	req.Since = r.URL.Query().Get("since")
+	if req.Since != "" {
+		since, err := time.Parse(time.RFC3339, req.Since)
+		if err != nil {
+			return nil, errors.NewBadRequest("invalid since %q, must be a RFC3339 timestamp", req.Since)
+		}
+		req.since = &since
+	}
+
+	req.Limit = r.URL.Query().Get("limit")
+	if req.Limit != "" {
+		limit, err := strconv.Atoi(req.Limit)
+		if err != nil || limit <= 0 {
+			return nil, errors.NewBadRequest("invalid limit %q, must be a positive integer", req.Limit)
+		}
+		req.limit = &limit
+	}
+
+	if watch := r.URL.Query().Get("watch"); len(watch) > 0 {
+		req.Watch, err = strconv.ParseBool(watch)
+		if err != nil {
+			return nil, errors.NewBadRequest("invalid value for watch: %v", err)
+		}
+	}
+
 	return req, nil
 }
 
@@ -174,6 +527,10 @@ type PatchReq struct {
 	// required: true
 	ClusterID string `json:"cluster_id"`
 
+	// Acknowledge must be set to "disruption" to change the expose strategy of the cluster
+	// in: query
+	Acknowledge string `json:"acknowledge,omitempty"`
+
 	// in: body
 	Patch json.RawMessage
 }
@@ -191,6 +548,7 @@ func DecodePatchReq(c context.Context, r *http.Request) (interface{}, error) {
 		return nil, err
 	}
 	req.ClusterID = clusterID
+	req.Acknowledge = r.URL.Query().Get("acknowledge")
 
 	if req.Patch, err = ioutil.ReadAll(r.Body); err != nil {
 		return nil, err
@@ -206,6 +564,47 @@ func (req PatchReq) GetSeedCluster() apiv1.SeedCluster {
 	}
 }
 
+// UpdateReq defines HTTP request for updateCluster endpoint
+// swagger:parameters updateClusterV2
+type UpdateReq struct {
+	common.ProjectReq
+	// in: path
+	// required: true
+	ClusterID string `json:"cluster_id"`
+
+	// in: body
+	// required: true
+	Body apiv1.Cluster
+}
+
+func DecodeUpdateReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req UpdateReq
+
+	projectReq, err := common.DecodeProjectRequest(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.ProjectReq = projectReq.(common.ProjectReq)
+	clusterID, err := common.DecodeClusterID(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.ClusterID = clusterID
+
+	if err := json.NewDecoder(r.Body).Decode(&req.Body); err != nil {
+		return nil, errors.NewBadRequest("cannot decode cluster: %v", err)
+	}
+
+	return req, nil
+}
+
+// GetSeedCluster returns the SeedCluster object
+func (req UpdateReq) GetSeedCluster() apiv1.SeedCluster {
+	return apiv1.SeedCluster{
+		ClusterID: req.ClusterID,
+	}
+}
+
 // DeleteReq defines HTTP request for deleteCluster endpoint
 // swagger:parameters deleteClusterV2
 type DeleteReq struct {
@@ -265,7 +664,7 @@ func DecodeDeleteReq(c context.Context, r *http.Request) (interface{}, error) {
 }
 
 // GetClusterReq defines HTTP request for getCluster endpoint.
-// swagger:parameters getClusterV2 getClusterHealthV2 getOidcClusterKubeconfigV2 getClusterKubeconfigV2
+// swagger:parameters getOidcClusterKubeconfigV2 getClusterKubeconfigV2 listClusterInstanceTypesV2 getClusterCertificatesV2 getClusterRawV2 getClusterSecurityAdvisoriesV2 getClusterAutoscalerV2 getClusterCredentialReferenceV2 getClusterNetworkUsageV2 listClusterVolumesV2 getClusterComponentVersionsV2 describeClusterV2 getClusterSchedulerConfigV2 getClusterWebhookDiagnosticsV2 getClusterAPFConfigurationV2 getClusterFeatureGatesV2 getClusterControlPlanePDBV2 getClusterControlPlaneRestartsV2 getClusterNodesDensityV2 getClusterDriftV2 listSSHKeysAssignedToClusterV2
 type GetClusterReq struct {
 	common.ProjectReq
 	// in: path
@@ -298,12 +697,66 @@ func (req GetClusterReq) GetSeedCluster() apiv1.SeedCluster {
 	}
 }
 
+// HealthReq defines HTTP request for getClusterHealthV2 endpoint
+// swagger:parameters getClusterHealthV2
+type HealthReq struct {
+	GetClusterReq
+
+	// Detailed returns the details explaining the health of every component
+	// in: query
+	Detailed bool `json:"detailed,omitempty"`
+}
+
+func DecodeHealthReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req HealthReq
+
+	clusterReq, err := DecodeGetClusterReq(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.GetClusterReq = clusterReq.(GetClusterReq)
+
+	if detailed := r.URL.Query().Get("detailed"); len(detailed) > 0 {
+		req.Detailed, err = strconv.ParseBool(detailed)
+		if err != nil {
+			return nil, errors.NewBadRequest("invalid value for detailed: %v", err)
+		}
+	}
+
+	return req, nil
+}
+
+// GetReq defines HTTP request for getClusterV2 endpoint
+// swagger:parameters getClusterV2
+type GetReq struct {
+	GetClusterReq
+	// The ETag of the cluster known to the client, the cluster is only returned when it changed
+	// in: header
+	// name: If-None-Match
+	IfNoneMatch string
+}
+
+func DecodeGetReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req GetReq
+	cr, err := DecodeGetClusterReq(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.GetClusterReq = cr.(GetClusterReq)
+	req.IfNoneMatch = r.Header.Get("If-None-Match")
+
+	return req, nil
+}
+
 // CreateClusterReq defines HTTP request for createCluster
 // swagger:parameters createClusterV2
 type CreateClusterReq struct {
 	common.ProjectReq
 	// in: body
 	Body apiv1.CreateClusterSpec
+	// ValidateRegistry checks that the registry mirror of the cluster is reachable
+	// in: query
+	ValidateRegistry bool `json:"validateRegistry,omitempty"`
 
 	// private field for the seed name. Needed for the cluster provider.
 	seedName string
@@ -333,6 +786,14 @@ func DecodeCreateReq(c context.Context, r *http.Request) (interface{}, error) {
 		req.Body.Cluster.Type = apiv1.KubernetesClusterType
 	}
 
+	if validateRegistry := r.URL.Query().Get("validateRegistry"); validateRegistry != "" {
+		value, err := strconv.ParseBool(validateRegistry)
+		if err != nil {
+			return nil, errors.NewBadRequest("invalid validateRegistry %q, must be a boolean", validateRegistry)
+		}
+		req.ValidateRegistry = value
+	}
+
 	seedName, err := findSeedNameForDatacenter(c, req.Body.Cluster.Spec.Cloud.DatacenterName)
 	if err != nil {
 		return nil, err
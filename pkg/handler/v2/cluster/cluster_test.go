@@ -17,6 +17,7 @@ limitations under the License.
 package cluster_test
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -28,13 +29,18 @@ import (
 	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
 	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	handlercommon "k8c.io/kubermatic/v2/pkg/handler/common"
 	"k8c.io/kubermatic/v2/pkg/handler/test"
 	"k8c.io/kubermatic/v2/pkg/handler/test/hack"
+	"k8c.io/kubermatic/v2/pkg/resources"
 	"k8c.io/kubermatic/v2/pkg/semver"
 
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 func TestCreateClusterEndpoint(t *testing.T) {
@@ -48,13 +54,17 @@ func TestCreateClusterEndpoint(t *testing.T) {
 		ExistingProject        *kubermaticv1.Project
 		ExistingAPIUser        *apiv1.User
 		ExistingKubermaticObjs []runtime.Object
+		ExistingKubeObjs       []runtime.Object
 		RewriteClusterID       bool
+		DatacenterMaxClusters  int
+		DeprecatedVersion      string
+		EndOfLifeVersion       string
 	}{
 		// scenario 1
 		{
 			Name:                   "scenario 1: a cluster with invalid spec is rejected",
 			Body:                   `{"cluster":{"name":"keen-snyder","spec":{"cloud":{"fake":{"token":"dummy_token"},"dc":"fake-dc"}, "version":""}}}`,
-			ExpectedResponse:       `{"error":{"code":400,"message":"invalid cluster: invalid cloud spec \"Version\" is required but was not specified"}}`,
+			ExpectedResponse:       `{"error":{"code":400,"message":"spec.version is required"}}`,
 			HTTPStatus:             http.StatusBadRequest,
 			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
 			ProjectToSync:          test.GenDefaultProject().Name,
@@ -64,7 +74,7 @@ func TestCreateClusterEndpoint(t *testing.T) {
 		{
 			Name:             "scenario 2: cluster is created when valid spec and ssh key are passed",
 			Body:             `{"cluster":{"name":"keen-snyder","spec":{"version":"1.15.0","cloud":{"fake":{"token":"dummy_token"},"dc":"fake-dc"}}}}`,
-			ExpectedResponse: `{"id":"%s","name":"keen-snyder","creationTimestamp":"0001-01-01T00:00:00Z","type":"kubernetes","spec":{"cloud":{"dc":"fake-dc","fake":{}},"version":"1.15.0","oidc":{}},"status":{"version":"1.15.0","url":""}}`,
+			ExpectedResponse: `{"id":"%s","name":"keen-snyder","creationTimestamp":"0001-01-01T00:00:00Z","type":"kubernetes","spec":{"cloud":{"dc":"fake-dc","fake":{}},"version":"1.15.0","oidc":{},"exposeStrategy":"NodePort"},"status":{"version":"1.15.0","url":""}}`,
 			RewriteClusterID: true,
 			HTTPStatus:       http.StatusCreated,
 			ProjectToSync:    test.GenDefaultProject().Name,
@@ -122,7 +132,7 @@ func TestCreateClusterEndpoint(t *testing.T) {
 		{
 			Name:                   "scenario 5: openShift cluster is created",
 			Body:                   `{"cluster":{"name":"keen-snyder","type":"openshift","spec":{"version":"4.1.0","openshift":{"imagePullSecret": "some-secret"},"cloud":{"fake":{"token":"dummy_token"},"dc":"fake-dc"}}}}`,
-			ExpectedResponse:       `{"id":"%s","name":"keen-snyder","creationTimestamp":"0001-01-01T00:00:00Z","type":"openshift","spec":{"cloud":{"dc":"fake-dc","fake":{}},"version":"4.1.0","oidc":{}},"status":{"version":"4.1.0","url":""}}`,
+			ExpectedResponse:       `{"id":"%s","name":"keen-snyder","creationTimestamp":"0001-01-01T00:00:00Z","type":"openshift","spec":{"cloud":{"dc":"fake-dc","fake":{}},"version":"4.1.0","oidc":{},"exposeStrategy":"NodePort"},"status":{"version":"4.1.0","url":""}}`,
 			RewriteClusterID:       true,
 			HTTPStatus:             http.StatusCreated,
 			ProjectToSync:          test.GenDefaultProject().Name,
@@ -133,7 +143,7 @@ func TestCreateClusterEndpoint(t *testing.T) {
 		{
 			Name:                   "scenario 6: openShift cluster is created with existing custom credential",
 			Body:                   `{"cluster":{"name":"keen-snyder","type":"openshift","credential":"fake","spec":{"version":"4.1.0","openshift":{"imagePullSecret": "some-secret"},"cloud":{"fake":{},"dc":"fake-dc"}}}}`,
-			ExpectedResponse:       `{"id":"%s","name":"keen-snyder","creationTimestamp":"0001-01-01T00:00:00Z","type":"openshift","spec":{"cloud":{"dc":"fake-dc","fake":{}},"version":"4.1.0","oidc":{}},"status":{"version":"4.1.0","url":""}}`,
+			ExpectedResponse:       `{"id":"%s","name":"keen-snyder","creationTimestamp":"0001-01-01T00:00:00Z","type":"openshift","spec":{"cloud":{"dc":"fake-dc","fake":{}},"version":"4.1.0","oidc":{},"exposeStrategy":"NodePort"},"status":{"version":"4.1.0","url":""}}`,
 			RewriteClusterID:       true,
 			HTTPStatus:             http.StatusCreated,
 			ProjectToSync:          test.GenDefaultProject().Name,
@@ -182,7 +192,7 @@ func TestCreateClusterEndpoint(t *testing.T) {
 		{
 			Name:             "scenario 10a: create a cluster in email-restricted datacenter, to which the user does have access - legacy single domain restriction with requiredEmailDomains",
 			Body:             `{"cluster":{"name":"keen-snyder","spec":{"version":"1.15.0","cloud":{"fake":{"token":"dummy_token"},"dc":"restricted-fake-dc"}}}}`,
-			ExpectedResponse: `{"id":"%s","name":"keen-snyder","creationTimestamp":"0001-01-01T00:00:00Z","type":"kubernetes","spec":{"cloud":{"dc":"restricted-fake-dc","fake":{}},"version":"1.15.0","oidc":{}},"status":{"version":"1.15.0","url":""}}`,
+			ExpectedResponse: `{"id":"%s","name":"keen-snyder","creationTimestamp":"0001-01-01T00:00:00Z","type":"kubernetes","spec":{"cloud":{"dc":"restricted-fake-dc","fake":{}},"version":"1.15.0","oidc":{},"exposeStrategy":"NodePort"},"status":{"version":"1.15.0","url":""}}`,
 			RewriteClusterID: true,
 			HTTPStatus:       http.StatusCreated,
 			ProjectToSync:    test.GenDefaultProject().Name,
@@ -195,7 +205,7 @@ func TestCreateClusterEndpoint(t *testing.T) {
 		{
 			Name:             "scenario 10b: create a cluster in email-restricted datacenter, to which the user does have access - domain array restriction with `requiredEmailDomains`",
 			Body:             `{"cluster":{"name":"keen-snyder","spec":{"version":"1.15.0","cloud":{"fake":{"token":"dummy_token"},"dc":"restricted-fake-dc2"}}}}`,
-			ExpectedResponse: `{"id":"%s","name":"keen-snyder","creationTimestamp":"0001-01-01T00:00:00Z","type":"kubernetes","spec":{"cloud":{"dc":"restricted-fake-dc2","fake":{}},"version":"1.15.0","oidc":{}},"status":{"version":"1.15.0","url":""}}`,
+			ExpectedResponse: `{"id":"%s","name":"keen-snyder","creationTimestamp":"0001-01-01T00:00:00Z","type":"kubernetes","spec":{"cloud":{"dc":"restricted-fake-dc2","fake":{}},"version":"1.15.0","oidc":{},"exposeStrategy":"NodePort"},"status":{"version":"1.15.0","url":""}}`,
 			RewriteClusterID: true,
 			HTTPStatus:       http.StatusCreated,
 			ProjectToSync:    test.GenDefaultProject().Name,
@@ -208,7 +218,7 @@ func TestCreateClusterEndpoint(t *testing.T) {
 		{
 			Name:             "scenario 11: create a cluster in audit-logging-enforced datacenter, without explicitly enabling audit logging",
 			Body:             `{"cluster":{"name":"keen-snyder","spec":{"version":"1.15.0","cloud":{"fake":{"token":"dummy_token"},"dc":"audited-dc"}}}}`,
-			ExpectedResponse: `{"id":"%s","name":"keen-snyder","creationTimestamp":"0001-01-01T00:00:00Z","type":"kubernetes","spec":{"cloud":{"dc":"audited-dc","fake":{}},"version":"1.15.0","oidc":{},"auditLogging":{"enabled":true}},"status":{"version":"1.15.0","url":""}}`,
+			ExpectedResponse: `{"id":"%s","name":"keen-snyder","creationTimestamp":"0001-01-01T00:00:00Z","type":"kubernetes","spec":{"cloud":{"dc":"audited-dc","fake":{}},"version":"1.15.0","oidc":{},"auditLogging":{"enabled":true},"exposeStrategy":"NodePort"},"status":{"version":"1.15.0","url":""},"enforcedByDatacenter":["spec.auditLogging.enabled"]}`,
 			RewriteClusterID: true,
 			HTTPStatus:       http.StatusCreated,
 			ProjectToSync:    test.GenDefaultProject().Name,
@@ -221,7 +231,7 @@ func TestCreateClusterEndpoint(t *testing.T) {
 		{
 			Name:             "scenario 12: the admin user can create cluster for any project",
 			Body:             `{"cluster":{"name":"keen-snyder","spec":{"version":"1.15.0","cloud":{"fake":{"token":"dummy_token"},"dc":"fake-dc"}}}}`,
-			ExpectedResponse: `{"id":"%s","name":"keen-snyder","creationTimestamp":"0001-01-01T00:00:00Z","type":"kubernetes","spec":{"cloud":{"dc":"fake-dc","fake":{}},"version":"1.15.0","oidc":{}},"status":{"version":"1.15.0","url":""}}`,
+			ExpectedResponse: `{"id":"%s","name":"keen-snyder","creationTimestamp":"0001-01-01T00:00:00Z","type":"kubernetes","spec":{"cloud":{"dc":"fake-dc","fake":{}},"version":"1.15.0","oidc":{},"exposeStrategy":"NodePort"},"status":{"version":"1.15.0","url":""}}`,
 			RewriteClusterID: true,
 			HTTPStatus:       http.StatusCreated,
 			ProjectToSync:    test.GenDefaultProject().Name,
@@ -259,25 +269,1352 @@ func TestCreateClusterEndpoint(t *testing.T) {
 		{
 			Name:                   "scenario 14: a cluster without version",
 			Body:                   `{"cluster":{"name":"keen-snyder","spec":{"cloud":{"fake":{"token":"dummy_token"},"dc":"fake-dc"}}}}`,
-			ExpectedResponse:       `{"error":{"code":400,"message":"invalid cluster: invalid cloud spec \"Version\" is required but was not specified"}}`,
+			ExpectedResponse:       `{"error":{"code":400,"message":"spec.version is required"}}`,
 			HTTPStatus:             http.StatusBadRequest,
 			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
 			ProjectToSync:          test.GenDefaultProject().Name,
 			ExistingAPIUser:        test.GenDefaultAPIUser(),
 		},
+		// scenario 15
+		{
+			Name:                   "scenario 15: cluster is created with a description",
+			Body:                   `{"cluster":{"name":"keen-snyder","spec":{"version":"1.15.0","description":"cluster for the CI pipelines","cloud":{"fake":{"token":"dummy_token"},"dc":"fake-dc"}}}}`,
+			ExpectedResponse:       `{"id":"%s","name":"keen-snyder","creationTimestamp":"0001-01-01T00:00:00Z","type":"kubernetes","spec":{"cloud":{"dc":"fake-dc","fake":{}},"version":"1.15.0","oidc":{},"description":"cluster for the CI pipelines","exposeStrategy":"NodePort"},"status":{"version":"1.15.0","url":""}}`,
+			RewriteClusterID:       true,
+			HTTPStatus:             http.StatusCreated,
+			ProjectToSync:          test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		// scenario 16
+		{
+			Name:                   "scenario 16: a cluster with a too long description is rejected",
+			Body:                   fmt.Sprintf(`{"cluster":{"name":"keen-snyder","spec":{"version":"1.15.0","description":"%s","cloud":{"fake":{"token":"dummy_token"},"dc":"fake-dc"}}}}`, strings.Repeat("a", 256)),
+			ExpectedResponse:       `{"error":{"code":400,"message":"invalid cluster: cluster description must not be longer than 255 characters"}}`,
+			HTTPStatus:             http.StatusBadRequest,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
+			ProjectToSync:          test.GenDefaultProject().Name,
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		// scenario 17
+		{
+			Name:             "scenario 17: a cluster is rejected when the datacenter is at capacity",
+			Body:             `{"cluster":{"name":"keen-snyder","spec":{"version":"1.15.0","cloud":{"fake":{"token":"dummy_token"},"dc":"fake-dc"}}}}`,
+			ExpectedResponse: `{"error":{"code":503,"message":"datacenter fake-dc is at capacity"}}`,
+			HTTPStatus:       http.StatusServiceUnavailable,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenCluster("clusterAbcID", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC), func(cluster *kubermaticv1.Cluster) {
+					cluster.Spec.Cloud.DatacenterName = "fake-dc"
+				}),
+			),
+			DatacenterMaxClusters: 1,
+			ProjectToSync:         test.GenDefaultProject().Name,
+			ExistingAPIUser:       test.GenDefaultAPIUser(),
+		},
+		// scenario 18
+		{
+			Name:             "scenario 18: a cluster is created when the datacenter has capacity left",
+			Body:             `{"cluster":{"name":"keen-snyder","spec":{"version":"1.15.0","cloud":{"fake":{"token":"dummy_token"},"dc":"fake-dc"}}}}`,
+			ExpectedResponse: `{"id":"%s","name":"keen-snyder","creationTimestamp":"0001-01-01T00:00:00Z","type":"kubernetes","spec":{"cloud":{"dc":"fake-dc","fake":{}},"version":"1.15.0","oidc":{},"exposeStrategy":"NodePort"},"status":{"version":"1.15.0","url":""}}`,
+			RewriteClusterID: true,
+			HTTPStatus:       http.StatusCreated,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenCluster("clusterAbcID", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC), func(cluster *kubermaticv1.Cluster) {
+					cluster.Spec.Cloud.DatacenterName = "fake-dc"
+				}),
+			),
+			DatacenterMaxClusters: 2,
+			ProjectToSync:         test.GenDefaultProject().Name,
+			ExistingAPIUser:       test.GenDefaultAPIUser(),
+		},
+		// scenario 19
+		{
+			Name:                   "scenario 19: cluster is created with admin groups",
+			Body:                   `{"cluster":{"name":"keen-snyder","spec":{"version":"1.15.0","adminGroups":["sre","platform-team"],"cloud":{"fake":{"token":"dummy_token"},"dc":"fake-dc"}}}}`,
+			ExpectedResponse:       `{"id":"%s","name":"keen-snyder","creationTimestamp":"0001-01-01T00:00:00Z","type":"kubernetes","spec":{"cloud":{"dc":"fake-dc","fake":{}},"version":"1.15.0","oidc":{},"adminGroups":["sre","platform-team"],"exposeStrategy":"NodePort"},"status":{"version":"1.15.0","url":""}}`,
+			RewriteClusterID:       true,
+			HTTPStatus:             http.StatusCreated,
+			ProjectToSync:          test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		// scenario 20
+		{
+			Name:                   "scenario 20: a cluster with an invalid admin group is rejected",
+			Body:                   `{"cluster":{"name":"keen-snyder","spec":{"version":"1.15.0","adminGroups":["system:masters"],"cloud":{"fake":{"token":"dummy_token"},"dc":"fake-dc"}}}}`,
+			ExpectedResponse:       `{"error":{"code":400,"message":"invalid cluster: invalid admin group \"system:masters\": the \"system:\" prefix is reserved"}}`,
+			HTTPStatus:             http.StatusBadRequest,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
+			ProjectToSync:          test.GenDefaultProject().Name,
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		// scenario 21
+		{
+			Name:                   "scenario 21: cluster is created with the iptables kube-proxy mode",
+			Body:                   `{"cluster":{"name":"keen-snyder","spec":{"version":"1.15.0","kubeProxy":{"mode":"iptables"},"cloud":{"fake":{"token":"dummy_token"},"dc":"fake-dc"}}}}`,
+			ExpectedResponse:       `{"id":"%s","name":"keen-snyder","creationTimestamp":"0001-01-01T00:00:00Z","type":"kubernetes","spec":{"cloud":{"dc":"fake-dc","fake":{}},"version":"1.15.0","oidc":{},"kubeProxy":{"mode":"iptables"},"exposeStrategy":"NodePort"},"status":{"version":"1.15.0","url":""}}`,
+			RewriteClusterID:       true,
+			HTTPStatus:             http.StatusCreated,
+			ProjectToSync:          test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		// scenario 22
+		{
+			Name:                   "scenario 22: the ebpf kube-proxy mode is not supported",
+			Body:                   `{"cluster":{"name":"keen-snyder","spec":{"version":"1.15.0","kubeProxy":{"mode":"ebpf"},"cloud":{"fake":{"token":"dummy_token"},"dc":"fake-dc"}}}}`,
+			ExpectedResponse:       `{"error":{"code":400,"message":"invalid cluster: unsupported kube-proxy mode \"ebpf\", must be one of \"ipvs\" or \"iptables\""}}`,
+			HTTPStatus:             http.StatusBadRequest,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
+			ProjectToSync:          test.GenDefaultProject().Name,
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		// scenario 23
+		{
+			Name:                   "scenario 23: cluster is created with the log level of the apiserver",
+			Body:                   `{"cluster":{"name":"keen-snyder","spec":{"version":"1.15.0","componentsOverride":{"apiserver":{"logLevel":4}},"cloud":{"fake":{"token":"dummy_token"},"dc":"fake-dc"}}}}`,
+			ExpectedResponse:       `{"id":"%s","name":"keen-snyder","creationTimestamp":"0001-01-01T00:00:00Z","type":"kubernetes","spec":{"cloud":{"dc":"fake-dc","fake":{}},"version":"1.15.0","oidc":{},"componentsOverride":{"apiserver":{"logLevel":4}},"exposeStrategy":"NodePort"},"status":{"version":"1.15.0","url":""}}`,
+			RewriteClusterID:       true,
+			HTTPStatus:             http.StatusCreated,
+			ProjectToSync:          test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		// scenario 24
+		{
+			Name:                   "scenario 24: a log level out of range is rejected",
+			Body:                   `{"cluster":{"name":"keen-snyder","spec":{"version":"1.15.0","componentsOverride":{"scheduler":{"logLevel":11}},"cloud":{"fake":{"token":"dummy_token"},"dc":"fake-dc"}}}}`,
+			ExpectedResponse:       `{"error":{"code":400,"message":"invalid cluster: invalid log level 11 for scheduler: must be between 0 and 10"}}`,
+			HTTPStatus:             http.StatusBadRequest,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
+			ProjectToSync:          test.GenDefaultProject().Name,
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		// scenario 25
+		{
+			Name:                   "scenario 25: cluster is created with the cluster-autoscaler enabled",
+			Body:                   `{"cluster":{"name":"keen-snyder","spec":{"version":"1.15.0","autoscaler":{"enabled":true,"scaleDownDelayAfterAdd":"10m"},"cloud":{"fake":{"token":"dummy_token"},"dc":"fake-dc"}}}}`,
+			ExpectedResponse:       `{"id":"%s","name":"keen-snyder","creationTimestamp":"0001-01-01T00:00:00Z","type":"kubernetes","spec":{"cloud":{"dc":"fake-dc","fake":{}},"version":"1.15.0","oidc":{},"autoscaler":{"enabled":true,"scaleDownDelayAfterAdd":"10m"},"exposeStrategy":"NodePort"},"status":{"version":"1.15.0","url":""}}`,
+			RewriteClusterID:       true,
+			HTTPStatus:             http.StatusCreated,
+			ProjectToSync:          test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		// scenario 26
+		{
+			Name:                   "scenario 26: an invalid cluster-autoscaler scale down delay is rejected",
+			Body:                   `{"cluster":{"name":"keen-snyder","spec":{"version":"1.15.0","autoscaler":{"enabled":true,"scaleDownDelayAfterAdd":"-5m"},"cloud":{"fake":{"token":"dummy_token"},"dc":"fake-dc"}}}}`,
+			ExpectedResponse:       `{"error":{"code":400,"message":"invalid cluster: invalid autoscaler scaleDownDelayAfterAdd \"-5m\": must be a positive duration"}}`,
+			HTTPStatus:             http.StatusBadRequest,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
+			ProjectToSync:          test.GenDefaultProject().Name,
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		// scenario 27
+		{
+			Name:                   "scenario 27: cluster is created with a pinned addon version",
+			Body:                   `{"cluster":{"name":"keen-snyder","spec":{"version":"1.15.0","addonVersions":{"canal":"v3.15"},"cloud":{"fake":{"token":"dummy_token"},"dc":"fake-dc"}}}}`,
+			ExpectedResponse:       `{"id":"%s","name":"keen-snyder","creationTimestamp":"0001-01-01T00:00:00Z","type":"kubernetes","spec":{"cloud":{"dc":"fake-dc","fake":{}},"version":"1.15.0","oidc":{},"addonVersions":{"canal":"v3.15"},"exposeStrategy":"NodePort"},"status":{"version":"1.15.0","url":""}}`,
+			RewriteClusterID:       true,
+			HTTPStatus:             http.StatusCreated,
+			ProjectToSync:          test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(genAddonConfig("canal", "v3.14", "v3.15")),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		// scenario 28
+		{
+			Name:                   "scenario 28: a version which is not available for the addon is rejected",
+			Body:                   `{"cluster":{"name":"keen-snyder","spec":{"version":"1.15.0","addonVersions":{"canal":"v3.16"},"cloud":{"fake":{"token":"dummy_token"},"dc":"fake-dc"}}}}`,
+			ExpectedResponse:       `{"error":{"code":400,"message":"version \"v3.16\" of addon \"canal\" is not available"}}`,
+			HTTPStatus:             http.StatusBadRequest,
+			ProjectToSync:          test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(genAddonConfig("canal", "v3.14", "v3.15")),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		// scenario 29
+		{
+			Name:                   "scenario 29: pinning the version of an unknown addon is rejected",
+			Body:                   `{"cluster":{"name":"keen-snyder","spec":{"version":"1.15.0","addonVersions":{"flannel":"v0.12"},"cloud":{"fake":{"token":"dummy_token"},"dc":"fake-dc"}}}}`,
+			ExpectedResponse:       `{"error":{"code":400,"message":"unknown addon \"flannel\""}}`,
+			HTTPStatus:             http.StatusBadRequest,
+			ProjectToSync:          test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(genAddonConfig("canal", "v3.14", "v3.15")),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		// scenario 30
+		{
+			Name:                   "scenario 30: explicitly disabling audit logging in an audit-logging-enforced datacenter is rejected",
+			Body:                   `{"cluster":{"name":"keen-snyder","spec":{"version":"1.15.0","auditLogging":{"enabled":false},"cloud":{"fake":{"token":"dummy_token"},"dc":"audited-dc"}}}}`,
+			ExpectedResponse:       `{"error":{"code":400,"message":"audit logging cannot be disabled in datacenter audited-dc"}}`,
+			HTTPStatus:             http.StatusBadRequest,
+			ProjectToSync:          test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		// scenario 31
+		{
+			Name:                   "scenario 31: cluster is created with external DNS configured",
+			Body:                   `{"cluster":{"name":"keen-snyder","spec":{"version":"1.15.0","externalDNS":{"provider":"aws","zone":"example.com"},"cloud":{"fake":{"token":"dummy_token"},"dc":"fake-dc"}}}}`,
+			ExpectedResponse:       `{"id":"%s","name":"keen-snyder","creationTimestamp":"0001-01-01T00:00:00Z","type":"kubernetes","spec":{"cloud":{"dc":"fake-dc","fake":{}},"version":"1.15.0","oidc":{},"externalDNS":{"provider":"aws","zone":"example.com"},"exposeStrategy":"NodePort"},"status":{"version":"1.15.0","url":""}}`,
+			RewriteClusterID:       true,
+			HTTPStatus:             http.StatusCreated,
+			ProjectToSync:          test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		// scenario 32
+		{
+			Name:                   "scenario 32: an unsupported external DNS provider is rejected",
+			Body:                   `{"cluster":{"name":"keen-snyder","spec":{"version":"1.15.0","externalDNS":{"provider":"foo","zone":"example.com"},"cloud":{"fake":{"token":"dummy_token"},"dc":"fake-dc"}}}}`,
+			ExpectedResponse:       `{"error":{"code":400,"message":"invalid cluster: unsupported external DNS provider \"foo\", must be one of [aws azure cloudflare digitalocean google]"}}`,
+			HTTPStatus:             http.StatusBadRequest,
+			ProjectToSync:          test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		// scenario 33
+		{
+			Name:                   "scenario 33: cluster is created with an egress allowlist",
+			Body:                   `{"cluster":{"name":"keen-snyder","spec":{"version":"1.15.0","egressAllowlist":["10.0.0.0/8","192.168.1.0/24"],"cloud":{"fake":{"token":"dummy_token"},"dc":"fake-dc"}}}}`,
+			ExpectedResponse:       `{"id":"%s","name":"keen-snyder","creationTimestamp":"0001-01-01T00:00:00Z","type":"kubernetes","spec":{"cloud":{"dc":"fake-dc","fake":{}},"version":"1.15.0","oidc":{},"egressAllowlist":["10.0.0.0/8","192.168.1.0/24"],"exposeStrategy":"NodePort"},"status":{"version":"1.15.0","url":""}}`,
+			RewriteClusterID:       true,
+			HTTPStatus:             http.StatusCreated,
+			ProjectToSync:          test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		// scenario 34
+		{
+			Name:                   "scenario 34: a malformed egress allowlist CIDR is rejected",
+			Body:                   `{"cluster":{"name":"keen-snyder","spec":{"version":"1.15.0","egressAllowlist":["10.0.0.0/33"],"cloud":{"fake":{"token":"dummy_token"},"dc":"fake-dc"}}}}`,
+			ExpectedResponse:       `{"error":{"code":400,"message":"invalid cluster: invalid egress allowlist CIDR \"10.0.0.0/33\": invalid CIDR address: 10.0.0.0/33"}}`,
+			HTTPStatus:             http.StatusBadRequest,
+			ProjectToSync:          test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		// scenario 35
+		{
+			Name:                   "scenario 35: an egress allowlist is rejected for openshift clusters",
+			Body:                   `{"cluster":{"name":"keen-snyder","type":"openshift","spec":{"version":"4.1.0","openshift":{"imagePullSecret": "some-secret"},"egressAllowlist":["10.0.0.0/8"],"cloud":{"fake":{"token":"dummy_token"},"dc":"fake-dc"}}}}`,
+			ExpectedResponse:       `{"error":{"code":400,"message":"the egress allowlist requires the canal CNI, which is not used by openshift clusters"}}`,
+			HTTPStatus:             http.StatusBadRequest,
+			ProjectToSync:          test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		// scenario 36
+		{
+			Name:                   "scenario 36: creating a cluster with a deprecated version returns a warning",
+			Body:                   `{"cluster":{"name":"keen-snyder","spec":{"version":"1.15.0","cloud":{"fake":{"token":"dummy_token"},"dc":"fake-dc"}}}}`,
+			ExpectedResponse:       `{"id":"%s","name":"keen-snyder","creationTimestamp":"0001-01-01T00:00:00Z","type":"kubernetes","spec":{"cloud":{"dc":"fake-dc","fake":{}},"version":"1.15.0","oidc":{},"exposeStrategy":"NodePort"},"status":{"version":"1.15.0","url":""},"warnings":["version 1.15.0 is deprecated and will be removed soon, consider using a newer version"]}`,
+			RewriteClusterID:       true,
+			HTTPStatus:             http.StatusCreated,
+			ProjectToSync:          test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+			DeprecatedVersion:      "1.15.0",
+		},
+		// scenario 37
+		{
+			Name:                   "scenario 37: a deprecated version which is not used does not return a warning",
+			Body:                   `{"cluster":{"name":"keen-snyder","spec":{"version":"1.17.0","cloud":{"fake":{"token":"dummy_token"},"dc":"fake-dc"}}}}`,
+			ExpectedResponse:       `{"id":"%s","name":"keen-snyder","creationTimestamp":"0001-01-01T00:00:00Z","type":"kubernetes","spec":{"cloud":{"dc":"fake-dc","fake":{}},"version":"1.17.0","oidc":{},"exposeStrategy":"NodePort"},"status":{"version":"1.17.0","url":""}}`,
+			RewriteClusterID:       true,
+			HTTPStatus:             http.StatusCreated,
+			ProjectToSync:          test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+			DeprecatedVersion:      "1.15.0",
+		},
+		// scenario 38
+		{
+			Name:                   "scenario 38: create a cluster using OpenVPN as connectivity",
+			Body:                   `{"cluster":{"name":"keen-snyder","spec":{"version":"1.15.0","connectivity":"openvpn","cloud":{"fake":{"token":"dummy_token"},"dc":"fake-dc"}}}}`,
+			ExpectedResponse:       `{"id":"%s","name":"keen-snyder","creationTimestamp":"0001-01-01T00:00:00Z","type":"kubernetes","spec":{"cloud":{"dc":"fake-dc","fake":{}},"version":"1.15.0","oidc":{},"connectivity":"openvpn","exposeStrategy":"NodePort"},"status":{"version":"1.15.0","url":""}}`,
+			RewriteClusterID:       true,
+			HTTPStatus:             http.StatusCreated,
+			ProjectToSync:          test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		// scenario 39
+		{
+			Name:                   "scenario 39: konnectivity is rejected until the konnectivity agent is deployed",
+			Body:                   `{"cluster":{"name":"keen-snyder","spec":{"version":"1.18.0","connectivity":"konnectivity","cloud":{"fake":{"token":"dummy_token"},"dc":"fake-dc"}}}}`,
+			ExpectedResponse:       `{"error":{"code":400,"message":"invalid cluster: the konnectivity connectivity is not supported yet, the konnectivity agent is not deployed to the user clusters"}}`,
+			HTTPStatus:             http.StatusBadRequest,
+			ProjectToSync:          test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		// scenario 40
+		{
+			Name:                   "scenario 40: an unknown connectivity is rejected",
+			Body:                   `{"cluster":{"name":"keen-snyder","spec":{"version":"1.17.0","connectivity":"wireguard","cloud":{"fake":{"token":"dummy_token"},"dc":"fake-dc"}}}}`,
+			ExpectedResponse:       `{"error":{"code":400,"message":"invalid cluster: invalid connectivity \"wireguard\", must be one of \"openvpn\" or \"konnectivity\""}}`,
+			HTTPStatus:             http.StatusBadRequest,
+			ProjectToSync:          test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		// scenario 41
+		{
+			Name:                   "scenario 41: create a cluster with disabled node SSH access",
+			Body:                   `{"cluster":{"name":"keen-snyder","spec":{"version":"1.15.0","disableNodeSSH":true,"cloud":{"fake":{"token":"dummy_token"},"dc":"fake-dc"}}}}`,
+			ExpectedResponse:       `{"id":"%s","name":"keen-snyder","creationTimestamp":"0001-01-01T00:00:00Z","type":"kubernetes","spec":{"cloud":{"dc":"fake-dc","fake":{}},"version":"1.15.0","oidc":{},"disableNodeSSH":true,"exposeStrategy":"NodePort"},"status":{"version":"1.15.0","url":""}}`,
+			RewriteClusterID:       true,
+			HTTPStatus:             http.StatusCreated,
+			ProjectToSync:          test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		// scenario 42
+		{
+			Name:                   "scenario 42: the alertmanager config must route to a defined receiver",
+			Body:                   `{"cluster":{"name":"keen-snyder","spec":{"version":"1.15.0","alertmanagerConfig":"route:\n  receiver: team-b\nreceivers:\n- name: team-a\n","cloud":{"fake":{"token":"dummy_token"},"dc":"fake-dc"}}}}`,
+			ExpectedResponse:       `{"error":{"code":400,"message":"invalid cluster: invalid alertmanager config: undefined receiver \"team-b\" used in route"}}`,
+			HTTPStatus:             http.StatusBadRequest,
+			ProjectToSync:          test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		// scenario 43
+		{
+			Name:                   "scenario 43: a dualstack cluster needs network ranges of both IP families",
+			Body:                   `{"cluster":{"name":"keen-snyder","spec":{"version":"1.15.0","clusterNetwork":{"ipFamily":"dualstack","pods":["172.25.0.0/16"],"services":["10.240.16.0/20","fd02::/108"]},"cloud":{"fake":{"token":"dummy_token"},"dc":"fake-dc"}}}}`,
+			ExpectedResponse:       `{"error":{"code":400,"message":"invalid cluster: the pods network ranges must contain exactly one IPv4 and one IPv6 range for the dualstack IP family"}}`,
+			HTTPStatus:             http.StatusBadRequest,
+			ProjectToSync:          test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		// scenario 44
+		{
+			Name:                   "scenario 44: the network range of a node must fit into the pods network range",
+			Body:                   `{"cluster":{"name":"keen-snyder","spec":{"version":"1.15.0","maxPodsPerNode":65,"clusterNetwork":{"ipFamily":"ipv4","pods":["172.25.0.0/25"]},"cloud":{"fake":{"token":"dummy_token"},"dc":"fake-dc"}}}}`,
+			ExpectedResponse:       `{"error":{"code":400,"message":"invalid cluster: invalid maxPodsPerNode 65: each node needs a /24 network range to hold twice as many addresses, which does not fit into the pods network range 172.25.0.0/25"}}`,
+			HTTPStatus:             http.StatusBadRequest,
+			ProjectToSync:          test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		// scenario 45
+		{
+			Name:                   "scenario 45: the registry mirror URL must be an absolute URL",
+			Body:                   `{"cluster":{"name":"keen-snyder","spec":{"version":"1.15.0","registryMirror":{"url":"registry.example.com"},"cloud":{"fake":{"token":"dummy_token"},"dc":"fake-dc"}}}}`,
+			ExpectedResponse:       `{"error":{"code":400,"message":"invalid cluster: invalid registry mirror URL \"registry.example.com\": must be an absolute http or https URL"}}`,
+			HTTPStatus:             http.StatusBadRequest,
+			ProjectToSync:          test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		// scenario 46
+		{
+			Name:                   "scenario 46: cluster is created with the resources of the machine-controller",
+			Body:                   `{"cluster":{"name":"keen-snyder","spec":{"version":"1.15.0","componentsOverride":{"machineController":{"resources":{"requests":{"memory":"512Mi"},"limits":{"memory":"1Gi"}}}},"cloud":{"fake":{"token":"dummy_token"},"dc":"fake-dc"}}}}`,
+			ExpectedResponse:       `{"id":"%s","name":"keen-snyder","creationTimestamp":"0001-01-01T00:00:00Z","type":"kubernetes","spec":{"cloud":{"dc":"fake-dc","fake":{}},"version":"1.15.0","oidc":{},"componentsOverride":{"machineController":{"resources":{"requests":{"memory":"512Mi"},"limits":{"memory":"1Gi"}}}},"exposeStrategy":"NodePort"},"status":{"version":"1.15.0","url":""}}`,
+			RewriteClusterID:       true,
+			HTTPStatus:             http.StatusCreated,
+			ProjectToSync:          test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		// scenario 47
+		{
+			Name:                   "scenario 47: an invalid resource quantity of the machine-controller is rejected",
+			Body:                   `{"cluster":{"name":"keen-snyder","spec":{"version":"1.15.0","componentsOverride":{"machineController":{"resources":{"limits":{"memory":"lots"}}}},"cloud":{"fake":{"token":"dummy_token"},"dc":"fake-dc"}}}}`,
+			ExpectedResponse:       `{"error":{"code":400,"message":"invalid cluster: invalid machineController resource limits: memory \"lots\" is not a valid quantity"}}`,
+			HTTPStatus:             http.StatusBadRequest,
+			ProjectToSync:          test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		// scenario 48
+		{
+			Name:                   "scenario 48: the scheduler configuration must match the Kubernetes version",
+			Body:                   `{"cluster":{"name":"keen-snyder","spec":{"version":"1.15.0","schedulerConfig":"{\"apiVersion\":\"kubescheduler.config.k8s.io/v1beta1\",\"kind\":\"KubeSchedulerConfiguration\"}","cloud":{"fake":{"token":"dummy_token"},"dc":"fake-dc"}}}}`,
+			ExpectedResponse:       `{"error":{"code":400,"message":"invalid cluster: invalid scheduler configuration: apiVersion must be kubescheduler.config.k8s.io/v1alpha1 for Kubernetes 1.15.0, got \"kubescheduler.config.k8s.io/v1beta1\""}}`,
+			HTTPStatus:             http.StatusBadRequest,
+			ProjectToSync:          test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		// scenario 49
+		{
+			Name:                   "scenario 49: openShift cluster is created with a stored image pull secret",
+			Body:                   `{"cluster":{"name":"keen-snyder","type":"openshift","credential":"fake","spec":{"version":"4.1.0","openshift":{"imagePullSecretRef": "pull-secret"},"cloud":{"fake":{},"dc":"fake-dc"}}}}`,
+			ExpectedResponse:       `{"id":"%s","name":"keen-snyder","creationTimestamp":"0001-01-01T00:00:00Z","type":"openshift","spec":{"cloud":{"dc":"fake-dc","fake":{}},"version":"4.1.0","oidc":{},"exposeStrategy":"NodePort"},"status":{"version":"4.1.0","url":""}}`,
+			RewriteClusterID:       true,
+			HTTPStatus:             http.StatusCreated,
+			ProjectToSync:          test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(test.GenDefaultSettings()),
+			ExistingKubeObjs:       []runtime.Object{genPullSecret("pull-secret", test.GenDefaultProject().Name)},
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		// scenario 50
+		{
+			Name:                   "scenario 50: openShift cluster creation fails with a missing stored image pull secret",
+			Body:                   `{"cluster":{"name":"keen-snyder","type":"openshift","credential":"fake","spec":{"version":"4.1.0","openshift":{"imagePullSecretRef": "pull-secret"},"cloud":{"fake":{},"dc":"fake-dc"}}}}`,
+			ExpectedResponse:       `{"error":{"code":400,"message":"image pull secret \"pull-secret\" not found in project my-first-project-ID"}}`,
+			HTTPStatus:             http.StatusBadRequest,
+			ProjectToSync:          test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(test.GenDefaultSettings()),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		// scenario 51
+		{
+			Name:                   "scenario 51: openShift cluster creation fails with an image pull secret of another project",
+			Body:                   `{"cluster":{"name":"keen-snyder","type":"openshift","credential":"fake","spec":{"version":"4.1.0","openshift":{"imagePullSecretRef": "pull-secret"},"cloud":{"fake":{},"dc":"fake-dc"}}}}`,
+			ExpectedResponse:       `{"error":{"code":400,"message":"image pull secret \"pull-secret\" not found in project my-first-project-ID"}}`,
+			HTTPStatus:             http.StatusBadRequest,
+			ProjectToSync:          test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(test.GenDefaultSettings()),
+			ExistingKubeObjs:       []runtime.Object{genPullSecret("pull-secret", "other-project")},
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		// scenario 52
+		{
+			Name:                   "scenario 52: cluster is created with the etcd maintenance settings",
+			Body:                   `{"cluster":{"name":"keen-snyder","spec":{"version":"1.15.0","componentsOverride":{"etcd":{"compactionMode":"revision","defragSchedule":"0 3 * * *"}},"cloud":{"fake":{"token":"dummy_token"},"dc":"fake-dc"}}}}`,
+			ExpectedResponse:       `{"id":"%s","name":"keen-snyder","creationTimestamp":"0001-01-01T00:00:00Z","type":"kubernetes","spec":{"cloud":{"dc":"fake-dc","fake":{}},"version":"1.15.0","oidc":{},"componentsOverride":{"etcd":{"compactionMode":"revision","defragSchedule":"0 3 * * *"}},"exposeStrategy":"NodePort"},"status":{"version":"1.15.0","url":""}}`,
+			RewriteClusterID:       true,
+			HTTPStatus:             http.StatusCreated,
+			ProjectToSync:          test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		// scenario 53
+		{
+			Name:                   "scenario 53: an invalid etcd defrag schedule is rejected",
+			Body:                   `{"cluster":{"name":"keen-snyder","spec":{"version":"1.15.0","componentsOverride":{"etcd":{"defragSchedule":"every night"}},"cloud":{"fake":{"token":"dummy_token"},"dc":"fake-dc"}}}}`,
+			ExpectedResponse:       `{"error":{"code":400,"message":"invalid cluster: invalid etcd defrag schedule \"every night\": Expected exactly 5 fields, found 2: every night"}}`,
+			HTTPStatus:             http.StatusBadRequest,
+			ProjectToSync:          test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		// scenario 54
+		{
+			Name:                   "scenario 54: a service node port range overlapping the kubelet port is rejected",
+			Body:                   `{"cluster":{"name":"keen-snyder","spec":{"version":"1.15.0","serviceNodePortRange":"10250-12767","cloud":{"fake":{"token":"dummy_token"},"dc":"fake-dc"}}}}`,
+			ExpectedResponse:       `{"error":{"code":400,"message":"invalid cluster: invalid service node port range \"10250-12767\": it overlaps the port 10250 of the kubelet"}}`,
+			HTTPStatus:             http.StatusBadRequest,
+			ProjectToSync:          test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		// scenario 55
+		{
+			Name:                   "scenario 55: an initial namespace specified more than once is rejected",
+			Body:                   `{"cluster":{"name":"keen-snyder","spec":{"version":"1.15.0","cloud":{"fake":{"token":"dummy_token"},"dc":"fake-dc"}}},"initialNamespaces":["monitoring","team-a","monitoring"]}`,
+			ExpectedResponse:       `{"error":{"code":400,"message":"initial namespace \"monitoring\" is specified more than once"}}`,
+			HTTPStatus:             http.StatusBadRequest,
+			ProjectToSync:          test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		// scenario 56
+		{
+			Name:                   "scenario 56: a DNS upstream which is not an IP address is rejected",
+			Body:                   `{"cluster":{"name":"keen-snyder","spec":{"version":"1.15.0","dns":{"upstreams":[{"zone":"corp.example.com","address":"dns.corp.example.com"}]},"cloud":{"fake":{"token":"dummy_token"},"dc":"fake-dc"}}}}`,
+			ExpectedResponse:       `{"error":{"code":400,"message":"invalid cluster: invalid DNS upstream address \"dns.corp.example.com\": must be an IP address or IP:port"}}`,
+			HTTPStatus:             http.StatusBadRequest,
+			ProjectToSync:          test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		// scenario 57
+		{
+			Name:                   "scenario 57: creating a cluster with a version which reached end of life is rejected",
+			Body:                   `{"cluster":{"name":"keen-snyder","spec":{"version":"1.15.0","cloud":{"fake":{"token":"dummy_token"},"dc":"fake-dc"}}}}`,
+			ExpectedResponse:       `{"error":{"code":400,"message":"version 1.15.0 has reached end of life"}}`,
+			HTTPStatus:             http.StatusBadRequest,
+			ProjectToSync:          test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+			EndOfLifeVersion:       "1.15.0",
+		},
+		// scenario 58
+		{
+			Name:                   "scenario 58: creating a cluster with a deprecated version is not rejected while another version reached end of life",
+			Body:                   `{"cluster":{"name":"keen-snyder","spec":{"version":"1.15.0","cloud":{"fake":{"token":"dummy_token"},"dc":"fake-dc"}}}}`,
+			ExpectedResponse:       `{"id":"%s","name":"keen-snyder","creationTimestamp":"0001-01-01T00:00:00Z","type":"kubernetes","spec":{"cloud":{"dc":"fake-dc","fake":{}},"version":"1.15.0","oidc":{}},"status":{"version":"1.15.0","url":""},"warnings":["version 1.15.0 is deprecated and will be removed soon, consider using a newer version"]}`,
+			RewriteClusterID:       true,
+			HTTPStatus:             http.StatusCreated,
+			ProjectToSync:          test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+			DeprecatedVersion:      "1.15.0",
+			EndOfLifeVersion:       "1.17.0",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", fmt.Sprintf("/api/v2/projects/%s/clusters", tc.ProjectToSync), strings.NewReader(tc.Body))
+			res := httptest.NewRecorder()
+			var kubermaticObj []runtime.Object
+			if tc.ExistingProject != nil {
+				kubermaticObj = append(kubermaticObj, tc.ExistingProject)
+			}
+			kubermaticObj = append(kubermaticObj, tc.ExistingKubermaticObjs...)
+
+			seedsGetter := func() (map[string]*kubermaticv1.Seed, error) {
+				seed := test.GenTestSeed()
+				dc := seed.Spec.Datacenters["fake-dc"]
+				dc.Spec.MaxClusters = tc.DatacenterMaxClusters
+				seed.Spec.Datacenters["fake-dc"] = dc
+				return map[string]*kubermaticv1.Seed{seed.Name: seed}, nil
+			}
+
+			versions := test.GenDefaultVersions()
+			for _, v := range versions {
+				if v.Version.String() == tc.DeprecatedVersion {
+					v.Deprecated = true
+				}
+				if v.Version.String() == tc.EndOfLifeVersion {
+					v.EndOfLife = true
+				}
+			}
+
+			ep, _, err := test.CreateTestEndpointAndGetClients(*tc.ExistingAPIUser, seedsGetter, tc.ExistingKubeObjs, nil, kubermaticObj, versions, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+
+			expectedResponse := tc.ExpectedResponse
+			// since Cluster.Name is automatically generated by the system just rewrite it.
+			if tc.RewriteClusterID {
+				actualCluster := &apiv1.Cluster{}
+				err = json.Unmarshal(res.Body.Bytes(), actualCluster)
+				if err != nil {
+					t.Fatal(err)
+				}
+				expectedResponse = fmt.Sprintf(tc.ExpectedResponse, actualCluster.ID)
+			}
+
+			test.CompareWithResult(t, res, expectedResponse)
+		})
+	}
+}
+
+func genPullSecret(name, projectID string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: resources.KubermaticNamespace,
+			Labels:    map[string]string{kubermaticv1.ProjectIDLabelKey: projectID},
+		},
+		Data: map[string][]byte{corev1.DockerConfigJsonKey: []byte(`{"auths":{}}`)},
+	}
+}
+
+func TestCreateClusterValidateRegistry(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		Name               string
+		RegistryStatusCode int
+		HTTPStatus         int
+	}{
+		{
+			Name:               "scenario 1: a registry requiring authentication is reachable",
+			RegistryStatusCode: http.StatusUnauthorized,
+			HTTPStatus:         http.StatusCreated,
+		},
+		{
+			Name:               "scenario 2: a server without the registry API is rejected",
+			RegistryStatusCode: http.StatusNotFound,
+			HTTPStatus:         http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			registry := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/v2/" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				w.WriteHeader(tc.RegistryStatusCode)
+			}))
+			defer registry.Close()
+
+			// the registry check refuses to connect to the loopback address of the test registry
+			checkClient := handlercommon.RegistryCheckClient
+			handlercommon.RegistryCheckClient = registry.Client()
+			defer func() { handlercommon.RegistryCheckClient = checkClient }()
+
+			body := fmt.Sprintf(`{"cluster":{"name":"keen-snyder","spec":{"version":"1.15.0","registryMirror":{"url":"%s/mirror"},"cloud":{"fake":{"token":"dummy_token"},"dc":"fake-dc"}}}}`, registry.URL)
+			req := httptest.NewRequest("POST", fmt.Sprintf("/api/v2/projects/%s/clusters?validateRegistry=true", test.GenDefaultProject().Name), strings.NewReader(body))
+			res := httptest.NewRecorder()
+
+			ep, err := test.CreateTestEndpoint(*test.GenDefaultAPIUser(), []runtime.Object{}, test.GenDefaultKubermaticObjects(), test.GenDefaultVersions(), nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+		})
+	}
+}
+
+func TestListClusters(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		Name                   string
+		QueryParams            string
+		ExpectedClusters       []apiv1.Cluster
+		ExpectedTotalCount     string
+		HTTPStatus             int
+		ExistingAPIUser        *apiv1.User
+		ExistingKubeObjs       []runtime.Object
+		ExistingKubermaticObjs []runtime.Object
+	}{
+		// scenario 1
+		{
+			Name: "scenario 1: list clusters that belong to the given project",
+			ExpectedClusters: []apiv1.Cluster{
+				{
+					ObjectMeta: apiv1.ObjectMeta{
+						ID:                "clusterAbcID",
+						Name:              "clusterAbc",
+						CreationTimestamp: apiv1.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC),
+					},
+					Spec: apiv1.ClusterSpec{
+						Cloud: kubermaticv1.CloudSpec{
+							DatacenterName: "FakeDatacenter",
+							Fake:           &kubermaticv1.FakeCloudSpec{},
+						},
+						Version: *semver.NewSemverOrDie("9.9.9"),
+					},
+					Status: apiv1.ClusterStatus{
+						Version: *semver.NewSemverOrDie("9.9.9"),
+						URL:     "https://w225mx4z66.asia-east1-a-1.cloud.kubermatic.io:31885",
+					},
+					Type: "kubernetes",
+				},
+				{
+					ObjectMeta: apiv1.ObjectMeta{
+						ID:                "clusterDefID",
+						Name:              "clusterDef",
+						CreationTimestamp: apiv1.Date(2013, 02, 04, 01, 54, 0, 0, time.UTC),
+					},
+					Spec: apiv1.ClusterSpec{
+						Cloud: kubermaticv1.CloudSpec{
+							DatacenterName: "FakeDatacenter",
+							Fake:           &kubermaticv1.FakeCloudSpec{},
+						},
+						Version: *semver.NewSemverOrDie("9.9.9"),
+					},
+					Status: apiv1.ClusterStatus{
+						Version: *semver.NewSemverOrDie("9.9.9"),
+						URL:     "https://w225mx4z66.asia-east1-a-1.cloud.kubermatic.io:31885",
+					},
+					Type: "kubernetes",
+				},
+				{
+					ObjectMeta: apiv1.ObjectMeta{
+						ID:                "clusterOpenstackID",
+						Name:              "clusterOpenstack",
+						CreationTimestamp: apiv1.Date(2013, 02, 04, 03, 54, 0, 0, time.UTC),
+					},
+					Spec: apiv1.ClusterSpec{
+						Cloud: kubermaticv1.CloudSpec{
+							DatacenterName: "OpenstackDatacenter",
+							Openstack: &kubermaticv1.OpenstackCloudSpec{
+								FloatingIPPool: "floatingIPPool",
+								SubnetID:       "subnetID",
+								Domain:         "domain",
+								Network:        "network",
+								RouterID:       "routerID",
+								SecurityGroups: "securityGroups",
+								Tenant:         "tenant",
+							},
+						},
+						Version: *semver.NewSemverOrDie("9.9.9"),
+					},
+					Status: apiv1.ClusterStatus{
+						Version: *semver.NewSemverOrDie("9.9.9"),
+						URL:     "https://w225mx4z66.asia-east1-a-1.cloud.kubermatic.io:31885",
+					},
+					Type: "kubernetes",
+				},
+			},
+			HTTPStatus: http.StatusOK,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenCluster("clusterAbcID", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC)),
+				test.GenCluster("clusterDefID", "clusterDef", test.GenDefaultProject().Name, time.Date(2013, 02, 04, 01, 54, 0, 0, time.UTC)),
+				test.GenClusterWithOpenstack(test.GenCluster("clusterOpenstackID", "clusterOpenstack", test.GenDefaultProject().Name, time.Date(2013, 02, 04, 03, 54, 0, 0, time.UTC))),
+			),
+			ExistingAPIUser: test.GenDefaultAPIUser(),
+		},
+		// scenario 2
+		{
+			Name: "scenario 2: the admin John can list Bob's clusters",
+			ExpectedClusters: []apiv1.Cluster{
+				{
+					ObjectMeta: apiv1.ObjectMeta{
+						ID:                "clusterAbcID",
+						Name:              "clusterAbc",
+						CreationTimestamp: apiv1.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC),
+					},
+					Spec: apiv1.ClusterSpec{
+						Cloud: kubermaticv1.CloudSpec{
+							DatacenterName: "FakeDatacenter",
+							Fake:           &kubermaticv1.FakeCloudSpec{},
+						},
+						Version: *semver.NewSemverOrDie("9.9.9"),
+					},
+					Status: apiv1.ClusterStatus{
+						Version: *semver.NewSemverOrDie("9.9.9"),
+						URL:     "https://w225mx4z66.asia-east1-a-1.cloud.kubermatic.io:31885",
+					},
+					Type: "kubernetes",
+				},
+				{
+					ObjectMeta: apiv1.ObjectMeta{
+						ID:                "clusterDefID",
+						Name:              "clusterDef",
+						CreationTimestamp: apiv1.Date(2013, 02, 04, 01, 54, 0, 0, time.UTC),
+					},
+					Spec: apiv1.ClusterSpec{
+						Cloud: kubermaticv1.CloudSpec{
+							DatacenterName: "FakeDatacenter",
+							Fake:           &kubermaticv1.FakeCloudSpec{},
+						},
+						Version: *semver.NewSemverOrDie("9.9.9"),
+					},
+					Status: apiv1.ClusterStatus{
+						Version: *semver.NewSemverOrDie("9.9.9"),
+						URL:     "https://w225mx4z66.asia-east1-a-1.cloud.kubermatic.io:31885",
+					},
+					Type: "kubernetes",
+				},
+				{
+					ObjectMeta: apiv1.ObjectMeta{
+						ID:                "clusterOpenstackID",
+						Name:              "clusterOpenstack",
+						CreationTimestamp: apiv1.Date(2013, 02, 04, 03, 54, 0, 0, time.UTC),
+					},
+					Spec: apiv1.ClusterSpec{
+						Cloud: kubermaticv1.CloudSpec{
+							DatacenterName: "OpenstackDatacenter",
+							Openstack: &kubermaticv1.OpenstackCloudSpec{
+								FloatingIPPool: "floatingIPPool",
+								SubnetID:       "subnetID",
+								Domain:         "domain",
+								Network:        "network",
+								RouterID:       "routerID",
+								SecurityGroups: "securityGroups",
+								Tenant:         "tenant",
+							},
+						},
+						Version: *semver.NewSemverOrDie("9.9.9"),
+					},
+					Status: apiv1.ClusterStatus{
+						Version: *semver.NewSemverOrDie("9.9.9"),
+						URL:     "https://w225mx4z66.asia-east1-a-1.cloud.kubermatic.io:31885",
+					},
+					Type: "kubernetes",
+				},
+			},
+			HTTPStatus: http.StatusOK,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				genUser("John", "john@acme.com", true),
+				test.GenCluster("clusterAbcID", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC)),
+				test.GenCluster("clusterDefID", "clusterDef", test.GenDefaultProject().Name, time.Date(2013, 02, 04, 01, 54, 0, 0, time.UTC)),
+				test.GenClusterWithOpenstack(test.GenCluster("clusterOpenstackID", "clusterOpenstack", test.GenDefaultProject().Name, time.Date(2013, 02, 04, 03, 54, 0, 0, time.UTC))),
+			),
+			ExistingAPIUser: test.GenAPIUser("John", "john@acme.com"),
+		},
+		// scenario 3
+		{
+			Name:        "scenario 3: list clusters created within the given time range",
+			QueryParams: "?createdAfter=2013-02-04T00:00:00Z&createdBefore=2013-02-04T03:54:00Z",
+			ExpectedClusters: []apiv1.Cluster{
+				{
+					ObjectMeta: apiv1.ObjectMeta{
+						ID:                "clusterDefID",
+						Name:              "clusterDef",
+						CreationTimestamp: apiv1.Date(2013, 02, 04, 01, 54, 0, 0, time.UTC),
+					},
+					Spec: apiv1.ClusterSpec{
+						Cloud: kubermaticv1.CloudSpec{
+							DatacenterName: "FakeDatacenter",
+							Fake:           &kubermaticv1.FakeCloudSpec{},
+						},
+						Version: *semver.NewSemverOrDie("9.9.9"),
+					},
+					Status: apiv1.ClusterStatus{
+						Version: *semver.NewSemverOrDie("9.9.9"),
+						URL:     "https://w225mx4z66.asia-east1-a-1.cloud.kubermatic.io:31885",
+					},
+					Type: "kubernetes",
+				},
+			},
+			HTTPStatus: http.StatusOK,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenCluster("clusterAbcID", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC)),
+				test.GenCluster("clusterDefID", "clusterDef", test.GenDefaultProject().Name, time.Date(2013, 02, 04, 01, 54, 0, 0, time.UTC)),
+				test.GenClusterWithOpenstack(test.GenCluster("clusterOpenstackID", "clusterOpenstack", test.GenDefaultProject().Name, time.Date(2013, 02, 04, 03, 54, 0, 0, time.UTC))),
+			),
+			ExistingAPIUser: test.GenDefaultAPIUser(),
+		},
+		// scenario 4
+		{
+			Name:        "scenario 4: list clusters with a certificate expiring within the given duration",
+			QueryParams: "?certExpiringWithin=30d",
+			ExpectedClusters: []apiv1.Cluster{
+				{
+					ObjectMeta: apiv1.ObjectMeta{
+						ID:                "clusterAbcID",
+						Name:              "clusterAbc",
+						CreationTimestamp: apiv1.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC),
+					},
+					Spec: apiv1.ClusterSpec{
+						Cloud: kubermaticv1.CloudSpec{
+							DatacenterName: "FakeDatacenter",
+							Fake:           &kubermaticv1.FakeCloudSpec{},
+						},
+						Version: *semver.NewSemverOrDie("9.9.9"),
+					},
+					Status: apiv1.ClusterStatus{
+						Version: *semver.NewSemverOrDie("9.9.9"),
+						URL:     "https://w225mx4z66.asia-east1-a-1.cloud.kubermatic.io:31885",
+					},
+					Type: "kubernetes",
+				},
+			},
+			HTTPStatus: http.StatusOK,
+			ExistingKubeObjs: []runtime.Object{
+				genCertificateSecret(t, "cluster-clusterAbcID", resources.ApiserverTLSSecretName, resources.ApiserverTLSCertSecretKey, time.Now().Add(10*24*time.Hour)),
+				genCertificateSecret(t, "cluster-clusterDefID", resources.ApiserverTLSSecretName, resources.ApiserverTLSCertSecretKey, time.Now().Add(365*24*time.Hour)),
+			},
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenCluster("clusterAbcID", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC)),
+				test.GenCluster("clusterDefID", "clusterDef", test.GenDefaultProject().Name, time.Date(2013, 02, 04, 01, 54, 0, 0, time.UTC)),
+				test.GenClusterWithOpenstack(test.GenCluster("clusterOpenstackID", "clusterOpenstack", test.GenDefaultProject().Name, time.Date(2013, 02, 04, 03, 54, 0, 0, time.UTC))),
+			),
+			ExistingAPIUser: test.GenDefaultAPIUser(),
+		},
+		// scenario 5
+		{
+			Name:        "scenario 5: list a page of the clusters sorted by creation time",
+			QueryParams: "?limit=1&offset=1",
+			ExpectedClusters: []apiv1.Cluster{
+				{
+					ObjectMeta: apiv1.ObjectMeta{
+						ID:                "clusterDefID",
+						Name:              "clusterDef",
+						CreationTimestamp: apiv1.Date(2013, 02, 04, 01, 54, 0, 0, time.UTC),
+					},
+					Spec: apiv1.ClusterSpec{
+						Cloud: kubermaticv1.CloudSpec{
+							DatacenterName: "FakeDatacenter",
+							Fake:           &kubermaticv1.FakeCloudSpec{},
+						},
+						Version: *semver.NewSemverOrDie("9.9.9"),
+					},
+					Status: apiv1.ClusterStatus{
+						Version: *semver.NewSemverOrDie("9.9.9"),
+						URL:     "https://w225mx4z66.asia-east1-a-1.cloud.kubermatic.io:31885",
+					},
+					Type: "kubernetes",
+				},
+			},
+			ExpectedTotalCount: "3",
+			HTTPStatus:         http.StatusOK,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenClusterWithOpenstack(test.GenCluster("clusterOpenstackID", "clusterOpenstack", test.GenDefaultProject().Name, time.Date(2013, 02, 04, 03, 54, 0, 0, time.UTC))),
+				test.GenCluster("clusterDefID", "clusterDef", test.GenDefaultProject().Name, time.Date(2013, 02, 04, 01, 54, 0, 0, time.UTC)),
+				test.GenCluster("clusterAbcID", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC)),
+			),
+			ExistingAPIUser: test.GenDefaultAPIUser(),
+		},
+		// scenario 6
+		{
+			Name:               "scenario 6: an offset beyond the last cluster returns an empty page",
+			QueryParams:        "?offset=5",
+			ExpectedClusters:   []apiv1.Cluster{},
+			ExpectedTotalCount: "2",
+			HTTPStatus:         http.StatusOK,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenCluster("clusterAbcID", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC)),
+				test.GenCluster("clusterDefID", "clusterDef", test.GenDefaultProject().Name, time.Date(2013, 02, 04, 01, 54, 0, 0, time.UTC)),
+			),
+			ExistingAPIUser: test.GenDefaultAPIUser(),
+		},
+		// scenario 7
+		{
+			Name:        "scenario 7: list only openstack clusters",
+			QueryParams: "?provider=openstack",
+			ExpectedClusters: []apiv1.Cluster{
+				{
+					ObjectMeta: apiv1.ObjectMeta{
+						ID:                "clusterOpenstackID",
+						Name:              "clusterOpenstack",
+						CreationTimestamp: apiv1.Date(2013, 02, 04, 03, 54, 0, 0, time.UTC),
+					},
+					Spec: apiv1.ClusterSpec{
+						Cloud: kubermaticv1.CloudSpec{
+							DatacenterName: "OpenstackDatacenter",
+							Openstack: &kubermaticv1.OpenstackCloudSpec{
+								FloatingIPPool: "floatingIPPool",
+								SubnetID:       "subnetID",
+								Domain:         "domain",
+								Network:        "network",
+								RouterID:       "routerID",
+								SecurityGroups: "securityGroups",
+								Tenant:         "tenant",
+							},
+						},
+						Version: *semver.NewSemverOrDie("9.9.9"),
+					},
+					Status: apiv1.ClusterStatus{
+						Version: *semver.NewSemverOrDie("9.9.9"),
+						URL:     "https://w225mx4z66.asia-east1-a-1.cloud.kubermatic.io:31885",
+					},
+					Type: "kubernetes",
+				},
+			},
+			HTTPStatus: http.StatusOK,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenCluster("clusterAbcID", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC)),
+				test.GenClusterWithOpenstack(test.GenCluster("clusterOpenstackID", "clusterOpenstack", test.GenDefaultProject().Name, time.Date(2013, 02, 04, 03, 54, 0, 0, time.UTC))),
+			),
+			ExistingAPIUser: test.GenDefaultAPIUser(),
+		},
+		// scenario 8
+		{
+			Name:        "scenario 8: list only fake clusters",
+			QueryParams: "?provider=fake",
+			ExpectedClusters: []apiv1.Cluster{
+				{
+					ObjectMeta: apiv1.ObjectMeta{
+						ID:                "clusterAbcID",
+						Name:              "clusterAbc",
+						CreationTimestamp: apiv1.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC),
+					},
+					Spec: apiv1.ClusterSpec{
+						Cloud: kubermaticv1.CloudSpec{
+							DatacenterName: "FakeDatacenter",
+							Fake:           &kubermaticv1.FakeCloudSpec{},
+						},
+						Version: *semver.NewSemverOrDie("9.9.9"),
+					},
+					Status: apiv1.ClusterStatus{
+						Version: *semver.NewSemverOrDie("9.9.9"),
+						URL:     "https://w225mx4z66.asia-east1-a-1.cloud.kubermatic.io:31885",
+					},
+					Type: "kubernetes",
+				},
+			},
+			HTTPStatus: http.StatusOK,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenCluster("clusterAbcID", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC)),
+				test.GenClusterWithOpenstack(test.GenCluster("clusterOpenstackID", "clusterOpenstack", test.GenDefaultProject().Name, time.Date(2013, 02, 04, 03, 54, 0, 0, time.UTC))),
+			),
+			ExistingAPIUser: test.GenDefaultAPIUser(),
+		},
+		// scenario 9
+		{
+			Name: "scenario 9: list clusters with their user labels, the labels managed by kubermatic are not returned",
+			ExpectedClusters: []apiv1.Cluster{
+				{
+					ObjectMeta: apiv1.ObjectMeta{
+						ID:                "clusterAbcID",
+						Name:              "clusterAbc",
+						CreationTimestamp: apiv1.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC),
+					},
+					Labels: map[string]string{"team": "platform"},
+					Spec: apiv1.ClusterSpec{
+						Cloud: kubermaticv1.CloudSpec{
+							DatacenterName: "FakeDatacenter",
+							Fake:           &kubermaticv1.FakeCloudSpec{},
+						},
+						Version: *semver.NewSemverOrDie("9.9.9"),
+					},
+					Status: apiv1.ClusterStatus{
+						Version: *semver.NewSemverOrDie("9.9.9"),
+						URL:     "https://w225mx4z66.asia-east1-a-1.cloud.kubermatic.io:31885",
+					},
+					Type: "kubernetes",
+				},
+			},
+			HTTPStatus: http.StatusOK,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenCluster("clusterAbcID", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC), func(cluster *kubermaticv1.Cluster) {
+					cluster.Labels["team"] = "platform"
+					cluster.Labels[kubermaticv1.WorkerNameLabelKey] = "worker-a"
+				}),
+			),
+			ExistingAPIUser: test.GenDefaultAPIUser(),
+		},
+		// scenario 10
+		{
+			Name:        "scenario 10: list clusters matching an equality-based label selector",
+			QueryParams: "?labelSelector=env%3Dprod%2Ctier%21%3Dfrontend",
+			ExpectedClusters: []apiv1.Cluster{
+				genAPIClusterWithLabels("clusterAbcID", "clusterAbc", map[string]string{"env": "prod", "tier": "backend"}),
+			},
+			HTTPStatus: http.StatusOK,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				genClusterWithLabels("clusterAbcID", "clusterAbc", map[string]string{"env": "prod", "tier": "backend"}),
+				genClusterWithLabels("clusterDefID", "clusterDef", map[string]string{"env": "prod", "tier": "frontend"}),
+				genClusterWithLabels("clusterGhiID", "clusterGhi", map[string]string{"env": "staging"}),
+				genClusterWithLabels("clusterJklID", "clusterJkl", map[string]string{"env": "dev"}),
+			),
+			ExistingAPIUser: test.GenDefaultAPIUser(),
+		},
+		// scenario 11
+		{
+			Name:        "scenario 11: list clusters matching a set-based label selector",
+			QueryParams: "?labelSelector=env%20in%20%28prod%2Cstaging%29%2C%21tier",
+			ExpectedClusters: []apiv1.Cluster{
+				genAPIClusterWithLabels("clusterGhiID", "clusterGhi", map[string]string{"env": "staging"}),
+			},
+			HTTPStatus: http.StatusOK,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				genClusterWithLabels("clusterAbcID", "clusterAbc", map[string]string{"env": "prod", "tier": "backend"}),
+				genClusterWithLabels("clusterDefID", "clusterDef", map[string]string{"env": "prod", "tier": "frontend"}),
+				genClusterWithLabels("clusterGhiID", "clusterGhi", map[string]string{"env": "staging"}),
+				genClusterWithLabels("clusterJklID", "clusterJkl", map[string]string{"env": "dev"}),
+			),
+			ExistingAPIUser: test.GenDefaultAPIUser(),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", fmt.Sprintf("/api/v2/projects/%s/clusters%s", test.ProjectName, tc.QueryParams), strings.NewReader(""))
+			res := httptest.NewRecorder()
+			var kubermaticObj []runtime.Object
+			kubermaticObj = append(kubermaticObj, tc.ExistingKubermaticObjs...)
+			ep, err := test.CreateTestEndpoint(*tc.ExistingAPIUser, tc.ExistingKubeObjs, kubermaticObj, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+
+			if totalCount := res.Header().Get("X-Total-Count"); totalCount != tc.ExpectedTotalCount {
+				t.Fatalf("Expected X-Total-Count header %q, got %q", tc.ExpectedTotalCount, totalCount)
+			}
+
+			actualClusters := test.NewClusterV1SliceWrapper{}
+			actualClusters.DecodeOrDie(res.Body, t).Sort()
+
+			wrappedExpectedClusters := test.NewClusterV1SliceWrapper(tc.ExpectedClusters)
+			wrappedExpectedClusters.Sort()
+
+			actualClusters.EqualOrDie(wrappedExpectedClusters, t)
+		})
+	}
+}
+
+func TestListClustersWithInvalidCreationTime(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		Name             string
+		QueryParams      string
+		ExpectedResponse string
+	}{
+		{
+			Name:             "scenario 1: createdAfter must be a RFC3339 timestamp",
+			QueryParams:      "?createdAfter=2013-02-04",
+			ExpectedResponse: `{"error":{"code":400,"message":"invalid createdAfter \"2013-02-04\", must be a RFC3339 timestamp"}}`,
+		},
+		{
+			Name:             "scenario 2: createdBefore must be a RFC3339 timestamp",
+			QueryParams:      "?createdBefore=yesterday",
+			ExpectedResponse: `{"error":{"code":400,"message":"invalid createdBefore \"yesterday\", must be a RFC3339 timestamp"}}`,
+		},
+		{
+			Name:             "scenario 3: certExpiringWithin must be a duration",
+			QueryParams:      "?certExpiringWithin=soon",
+			ExpectedResponse: `{"error":{"code":400,"message":"invalid certExpiringWithin \"soon\", must be a positive duration like \"30d\" or \"12h\""}}`,
+		},
+		{
+			Name:             "scenario 4: certExpiringWithin must be positive",
+			QueryParams:      "?certExpiringWithin=-5d",
+			ExpectedResponse: `{"error":{"code":400,"message":"invalid certExpiringWithin \"-5d\", must be a positive duration like \"30d\" or \"12h\""}}`,
+		},
+		{
+			Name:             "scenario 5: offset must not be negative",
+			QueryParams:      "?offset=-1",
+			ExpectedResponse: `{"error":{"code":400,"message":"invalid offset \"-1\", must be a non-negative integer"}}`,
+		},
+		{
+			Name:             "scenario 6: limit must be a positive integer",
+			QueryParams:      "?limit=all",
+			ExpectedResponse: `{"error":{"code":400,"message":"invalid limit \"all\", must be a positive integer"}}`,
+		},
+		{
+			Name:             "scenario 7: provider must be a known cloud provider",
+			QueryParams:      "?provider=ibm",
+			ExpectedResponse: `{"error":{"code":400,"message":"invalid provider \"ibm\", must be one of: alibaba, aws, azure, bringyourown, digitalocean, fake, gcp, hetzner, kubevirt, openstack, packet, vsphere"}}`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", fmt.Sprintf("/api/v2/projects/%s/clusters%s", test.ProjectName, tc.QueryParams), strings.NewReader(""))
+			res := httptest.NewRecorder()
+			ep, err := test.CreateTestEndpoint(*test.GenDefaultAPIUser(), []runtime.Object{}, test.GenDefaultKubermaticObjects(), nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != http.StatusBadRequest {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", http.StatusBadRequest, res.Code, res.Body.String())
+			}
+
+			test.CompareWithResult(t, res, tc.ExpectedResponse)
+		})
+	}
+}
+
+func TestListClustersWithInvalidLabelSelector(t *testing.T) {
+	t.Parallel()
+	req := httptest.NewRequest("GET", fmt.Sprintf("/api/v2/projects/%s/clusters?labelSelector=env%%20in%%20prod", test.ProjectName), strings.NewReader(""))
+	res := httptest.NewRecorder()
+	ep, err := test.CreateTestEndpoint(*test.GenDefaultAPIUser(), []runtime.Object{}, test.GenDefaultKubermaticObjects(), nil, nil, hack.NewTestRouting)
+	if err != nil {
+		t.Fatalf("failed to create test endpoint due to %v", err)
+	}
+
+	ep.ServeHTTP(res, req)
+
+	if res.Code != http.StatusBadRequest {
+		t.Fatalf("Expected HTTP status code %d, got %d: %s", http.StatusBadRequest, res.Code, res.Body.String())
+	}
+	if expected := `invalid labelSelector \"env in prod\": `; !strings.Contains(res.Body.String(), expected) {
+		t.Fatalf("Expected the response to contain %s, got %s", expected, res.Body.String())
+	}
+}
+
+func TestGetCluster(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		Name                   string
+		Body                   string
+		ExpectedResponse       string
+		HTTPStatus             int
+		ClusterToGet           string
+		ExistingAPIUser        *apiv1.User
+		ExistingKubeObjs       []runtime.Object
+		ExistingKubermaticObjs []runtime.Object
+	}{
+		// scenario 1
+		{
+			Name:             "scenario 1: gets cluster with the given name that belongs to the given project",
+			Body:             ``,
+			ExpectedResponse: `{"id":"defClusterID","name":"defClusterName","creationTimestamp":"2013-02-03T19:54:00Z","type":"kubernetes","spec":{"cloud":{"dc":"FakeDatacenter","fake":{}},"version":"9.9.9","oidc":{}},"status":{"version":"9.9.9","url":"https://w225mx4z66.asia-east1-a-1.cloud.kubermatic.io:31885"}}`,
+			ClusterToGet:     test.GenDefaultCluster().Name,
+			HTTPStatus:       http.StatusOK,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenDefaultCluster(),
+				test.GenCluster("clusterAbcID", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC)),
+			),
+			ExistingAPIUser: test.GenDefaultAPIUser(),
+		},
+		// scenario 2
+		{
+			Name:             "scenario 2: gets cluster for Openstack and no sensitive data (credentials) are returned",
+			Body:             ``,
+			ExpectedResponse: `{"id":"defClusterID","name":"defClusterName","creationTimestamp":"2013-02-03T19:54:00Z","type":"kubernetes","spec":{"cloud":{"dc":"OpenstackDatacenter","openstack":{"floatingIpPool":"floatingIPPool","tenant":"tenant","domain":"domain","network":"network","securityGroups":"securityGroups","routerID":"routerID","subnetID":"subnetID"}},"version":"9.9.9","oidc":{}},"status":{"version":"9.9.9","url":"https://w225mx4z66.asia-east1-a-1.cloud.kubermatic.io:31885"}}`,
+			ClusterToGet:     test.GenDefaultCluster().Name,
+			HTTPStatus:       http.StatusOK,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenClusterWithOpenstack(test.GenDefaultCluster()),
+				test.GenCluster("clusterAbcID", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC)),
+			),
+			ExistingAPIUser: test.GenDefaultAPIUser(),
+		},
+		// scenario 3
+		{
+			Name:             "scenario 3: the admin John can get Bob's cluster",
+			Body:             ``,
+			ExpectedResponse: `{"id":"defClusterID","name":"defClusterName","creationTimestamp":"2013-02-03T19:54:00Z","type":"kubernetes","spec":{"cloud":{"dc":"OpenstackDatacenter","openstack":{"floatingIpPool":"floatingIPPool","tenant":"tenant","domain":"domain","network":"network","securityGroups":"securityGroups","routerID":"routerID","subnetID":"subnetID"}},"version":"9.9.9","oidc":{}},"status":{"version":"9.9.9","url":"https://w225mx4z66.asia-east1-a-1.cloud.kubermatic.io:31885"}}`,
+			ClusterToGet:     test.GenDefaultCluster().Name,
+			HTTPStatus:       http.StatusOK,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				genUser("John", "john@acme.com", true),
+				test.GenClusterWithOpenstack(test.GenDefaultCluster()),
+				test.GenCluster("clusterAbcID", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC)),
+			),
+			ExistingAPIUser: test.GenAPIUser("John", "john@acme.com"),
+		},
+		// scenario 4
+		{
+			Name:             "scenario 4: the regular user John can not get Bob's cluster",
+			Body:             ``,
+			ExpectedResponse: `{"error":{"code":403,"message":"forbidden: \"john@acme.com\" doesn't belong to the given project = my-first-project-ID"}}`,
+			ClusterToGet:     test.GenDefaultCluster().Name,
+			HTTPStatus:       http.StatusForbidden,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				genUser("John", "john@acme.com", false),
+				test.GenClusterWithOpenstack(test.GenDefaultCluster()),
+				test.GenCluster("clusterAbcID", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC)),
+			),
+			ExistingAPIUser: test.GenAPIUser("John", "john@acme.com"),
+		},
+		// scenario 5
+		{
+			Name:             "scenario 5: gets cluster with OIDC settings and the client secret is not returned",
+			Body:             ``,
+			ExpectedResponse: `{"id":"defClusterID","name":"defClusterName","creationTimestamp":"2013-02-03T19:54:00Z","type":"kubernetes","spec":{"cloud":{"dc":"FakeDatacenter","fake":{}},"version":"9.9.9","oidc":{"issuerUrl":"https://dex.acme.com","clientId":"kubernetes","groupsClaim":"groups"}},"status":{"version":"9.9.9","url":"https://w225mx4z66.asia-east1-a-1.cloud.kubermatic.io:31885"}}`,
+			ClusterToGet:     test.GenDefaultCluster().Name,
+			HTTPStatus:       http.StatusOK,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				genClusterWithOIDC(test.GenDefaultCluster()),
+			),
+			ExistingAPIUser: test.GenDefaultAPIUser(),
+		},
+		// scenario 6
+		{
+			Name:             "scenario 6: gets cluster with the completion time of its last successful backup",
+			Body:             ``,
+			ExpectedResponse: `{"id":"defClusterID","name":"defClusterName","creationTimestamp":"2013-02-03T19:54:00Z","type":"kubernetes","spec":{"cloud":{"dc":"FakeDatacenter","fake":{}},"version":"9.9.9","oidc":{}},"status":{"version":"9.9.9","url":"https://w225mx4z66.asia-east1-a-1.cloud.kubermatic.io:31885","lastBackupTime":"2013-02-04T10:20:00Z"}}`,
+			ClusterToGet:     test.GenDefaultCluster().Name,
+			HTTPStatus:       http.StatusOK,
+			ExistingKubeObjs: []runtime.Object{
+				genBackupJob("etcd-backup-defClusterID-1", "defClusterID", true, time.Date(2013, 02, 04, 10, 0, 0, 0, time.UTC)),
+				genBackupJob("etcd-backup-defClusterID-2", "defClusterID", true, time.Date(2013, 02, 04, 10, 20, 0, 0, time.UTC)),
+				genBackupJob("etcd-backup-defClusterID-3", "defClusterID", false, time.Date(2013, 02, 04, 10, 40, 0, 0, time.UTC)),
+				genBackupJob("etcd-backup-clusterAbcID-1", "clusterAbcID", true, time.Date(2013, 02, 04, 11, 0, 0, 0, time.UTC)),
+			},
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenDefaultCluster(),
+			),
+			ExistingAPIUser: test.GenDefaultAPIUser(),
+		},
+		// scenario 7
+		{
+			Name:             "scenario 7: gets cluster whose control plane is still rolling out",
+			Body:             ``,
+			ExpectedResponse: `{"id":"defClusterID","name":"defClusterName","creationTimestamp":"2013-02-03T19:54:00Z","type":"kubernetes","spec":{"cloud":{"dc":"FakeDatacenter","fake":{}},"version":"9.9.9","oidc":{}},"status":{"version":"9.9.9","url":"https://w225mx4z66.asia-east1-a-1.cloud.kubermatic.io:31885","reconciling":true}}`,
+			ClusterToGet:     test.GenDefaultCluster().Name,
+			HTTPStatus:       http.StatusOK,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenCluster(test.DefaultClusterID, test.DefaultClusterName, test.GenDefaultProject().Name, test.GenDefaultCluster().CreationTimestamp.Time, func(cluster *kubermaticv1.Cluster) {
+					cluster.Status.Conditions = []kubermaticv1.ClusterCondition{
+						{Type: kubermaticv1.ClusterConditionSeedResourcesUpToDate, Status: corev1.ConditionFalse},
+					}
+				}),
+			),
+			ExistingAPIUser: test.GenDefaultAPIUser(),
+		},
+		// scenario 8
+		{
+			Name:             "scenario 8: gets cluster with its user labels, the labels managed by kubermatic are not returned",
+			Body:             ``,
+			ExpectedResponse: `{"id":"defClusterID","name":"defClusterName","creationTimestamp":"2013-02-03T19:54:00Z","labels":{"environment":"staging","team":"platform"},"type":"kubernetes","spec":{"cloud":{"dc":"FakeDatacenter","fake":{}},"version":"9.9.9","oidc":{}},"status":{"version":"9.9.9","url":"https://w225mx4z66.asia-east1-a-1.cloud.kubermatic.io:31885"}}`,
+			ClusterToGet:     test.GenDefaultCluster().Name,
+			HTTPStatus:       http.StatusOK,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenCluster(test.DefaultClusterID, test.DefaultClusterName, test.GenDefaultProject().Name, test.GenDefaultCluster().CreationTimestamp.Time, func(cluster *kubermaticv1.Cluster) {
+					cluster.Labels["team"] = "platform"
+					cluster.Labels["environment"] = "staging"
+					cluster.Labels[kubermaticv1.WorkerNameLabelKey] = "worker-a"
+				}),
+			),
+			ExistingAPIUser: test.GenDefaultAPIUser(),
+		},
+		// scenario 9
+		{
+			Name:             "scenario 9: gets cluster with the time and duration of the last reconciliation",
+			Body:             ``,
+			ExpectedResponse: `{"id":"defClusterID","name":"defClusterName","creationTimestamp":"2013-02-03T19:54:00Z","type":"kubernetes","spec":{"cloud":{"dc":"FakeDatacenter","fake":{}},"version":"9.9.9","oidc":{}},"status":{"version":"9.9.9","url":"https://w225mx4z66.asia-east1-a-1.cloud.kubermatic.io:31885","lastReconcileTime":"2013-02-04T08:00:00Z","lastReconcileDuration":"2.5s"}}`,
+			ClusterToGet:     test.GenDefaultCluster().Name,
+			HTTPStatus:       http.StatusOK,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenCluster(test.DefaultClusterID, test.DefaultClusterName, test.GenDefaultProject().Name, test.GenDefaultCluster().CreationTimestamp.Time, func(cluster *kubermaticv1.Cluster) {
+					cluster.Status.LastReconcileTime = &metav1.Time{Time: time.Date(2013, 02, 04, 8, 0, 0, 0, time.UTC)}
+					cluster.Status.LastReconcileDuration = &metav1.Duration{Duration: 2500 * time.Millisecond}
+				}),
+			),
+			ExistingAPIUser: test.GenDefaultAPIUser(),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", fmt.Sprintf("/api/v2/projects/%s/clusters/%s", test.ProjectName, tc.ClusterToGet), strings.NewReader(tc.Body))
+			res := httptest.NewRecorder()
+			var kubermaticObj []runtime.Object
+			kubermaticObj = append(kubermaticObj, tc.ExistingKubermaticObjs...)
+			ep, err := test.CreateTestEndpoint(*tc.ExistingAPIUser, tc.ExistingKubeObjs, kubermaticObj, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+
+			test.CompareWithResult(t, res, tc.ExpectedResponse)
+		})
+	}
+}
+
+func TestGetClusterETag(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		Name             string
+		IfNoneMatch      string
+		ExpectedResponse string
+		HTTPStatus       int
+	}{
+		{
+			Name:             "scenario 1: the cluster is returned with its resource version as ETag",
+			ExpectedResponse: `{"id":"defClusterID","name":"defClusterName","creationTimestamp":"2013-02-03T19:54:00Z","type":"kubernetes","spec":{"cloud":{"dc":"FakeDatacenter","fake":{}},"version":"9.9.9","oidc":{}},"status":{"version":"9.9.9","url":"https://w225mx4z66.asia-east1-a-1.cloud.kubermatic.io:31885"}}`,
+			HTTPStatus:       http.StatusOK,
+		},
+		{
+			Name:             "scenario 2: the cluster is returned when it changed",
+			IfNoneMatch:      `"122"`,
+			ExpectedResponse: `{"id":"defClusterID","name":"defClusterName","creationTimestamp":"2013-02-03T19:54:00Z","type":"kubernetes","spec":{"cloud":{"dc":"FakeDatacenter","fake":{}},"version":"9.9.9","oidc":{}},"status":{"version":"9.9.9","url":"https://w225mx4z66.asia-east1-a-1.cloud.kubermatic.io:31885"}}`,
+			HTTPStatus:       http.StatusOK,
+		},
+		{
+			Name:             "scenario 3: the cluster is not returned when it did not change",
+			IfNoneMatch:      `"122", "123"`,
+			ExpectedResponse: ``,
+			HTTPStatus:       http.StatusNotModified,
+		},
 	}
 
 	for _, tc := range testcases {
 		t.Run(tc.Name, func(t *testing.T) {
-			req := httptest.NewRequest("POST", fmt.Sprintf("/api/v2/projects/%s/clusters", tc.ProjectToSync), strings.NewReader(tc.Body))
-			res := httptest.NewRecorder()
-			var kubermaticObj []runtime.Object
-			if tc.ExistingProject != nil {
-				kubermaticObj = append(kubermaticObj, tc.ExistingProject)
+			req := httptest.NewRequest("GET", fmt.Sprintf("/api/v2/projects/%s/clusters/%s", test.ProjectName, test.GenDefaultCluster().Name), nil)
+			if tc.IfNoneMatch != "" {
+				req.Header.Set("If-None-Match", tc.IfNoneMatch)
 			}
-			kubermaticObj = append(kubermaticObj, tc.ExistingKubermaticObjs...)
-
-			ep, err := test.CreateTestEndpoint(*tc.ExistingAPIUser, []runtime.Object{}, kubermaticObj, test.GenDefaultVersions(), nil, hack.NewTestRouting)
+			res := httptest.NewRecorder()
+			cluster := test.GenDefaultCluster()
+			cluster.ResourceVersion = "123"
+			ep, err := test.CreateTestEndpoint(*test.GenDefaultAPIUser(), nil, test.GenDefaultKubermaticObjects(cluster), nil, nil, hack.NewTestRouting)
 			if err != nil {
 				t.Fatalf("failed to create test endpoint due to %v", err)
 			}
@@ -287,194 +1624,130 @@ func TestCreateClusterEndpoint(t *testing.T) {
 			if res.Code != tc.HTTPStatus {
 				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
 			}
-
-			expectedResponse := tc.ExpectedResponse
-			// since Cluster.Name is automatically generated by the system just rewrite it.
-			if tc.RewriteClusterID {
-				actualCluster := &apiv1.Cluster{}
-				err = json.Unmarshal(res.Body.Bytes(), actualCluster)
-				if err != nil {
-					t.Fatal(err)
-				}
-				expectedResponse = fmt.Sprintf(tc.ExpectedResponse, actualCluster.ID)
+			if etag := res.Header().Get("ETag"); etag != `"123"` {
+				t.Fatalf("Expected ETag header %q, got %q", `"123"`, etag)
 			}
 
-			test.CompareWithResult(t, res, expectedResponse)
+			test.CompareWithResult(t, res, tc.ExpectedResponse)
 		})
 	}
 }
 
-func TestListClusters(t *testing.T) {
+func TestDeleteClusterEndpoint(t *testing.T) {
 	t.Parallel()
 	testcases := []struct {
-		Name                   string
-		ExpectedClusters       []apiv1.Cluster
-		HTTPStatus             int
-		ExistingAPIUser        *apiv1.User
-		ExistingKubermaticObjs []runtime.Object
+		Name                          string
+		Body                          string
+		ExpectedResponse              string
+		HTTPStatus                    int
+		ProjectToSync                 string
+		ClusterToSync                 string
+		ExistingKubermaticObjs        []runtime.Object
+		ExistingAPIUser               *apiv1.User
+		ExpectedListClusterKeysStatus int
 	}{
-		// scenario 1
 		{
-			Name: "scenario 1: list clusters that belong to the given project",
-			ExpectedClusters: []apiv1.Cluster{
-				{
-					ObjectMeta: apiv1.ObjectMeta{
-						ID:                "clusterAbcID",
-						Name:              "clusterAbc",
-						CreationTimestamp: apiv1.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC),
-					},
-					Spec: apiv1.ClusterSpec{
-						Cloud: kubermaticv1.CloudSpec{
-							DatacenterName: "FakeDatacenter",
-							Fake:           &kubermaticv1.FakeCloudSpec{},
-						},
-						Version: *semver.NewSemverOrDie("9.9.9"),
-					},
-					Status: apiv1.ClusterStatus{
-						Version: *semver.NewSemverOrDie("9.9.9"),
-						URL:     "https://w225mx4z66.asia-east1-a-1.cloud.kubermatic.io:31885",
-					},
-					Type: "kubernetes",
-				},
-				{
-					ObjectMeta: apiv1.ObjectMeta{
-						ID:                "clusterDefID",
-						Name:              "clusterDef",
-						CreationTimestamp: apiv1.Date(2013, 02, 04, 01, 54, 0, 0, time.UTC),
-					},
-					Spec: apiv1.ClusterSpec{
-						Cloud: kubermaticv1.CloudSpec{
-							DatacenterName: "FakeDatacenter",
-							Fake:           &kubermaticv1.FakeCloudSpec{},
+			Name:             "scenario 1: tests deletion of a cluster and its dependant resources",
+			Body:             ``,
+			ExpectedResponse: `{}`,
+			HTTPStatus:       http.StatusOK,
+			ProjectToSync:    test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				// add a cluster
+				test.GenCluster("clusterAbcID", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC)),
+				// add ssh keys
+				&kubermaticv1.UserSSHKey{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "key-c08aa5c7abf34504f18552846485267d-yafn",
+						OwnerReferences: []metav1.OwnerReference{
+							{
+								APIVersion: "kubermatic.k8s.io/v1",
+								Kind:       "Project",
+								UID:        "",
+								Name:       test.GenDefaultProject().Name,
+							},
 						},
-						Version: *semver.NewSemverOrDie("9.9.9"),
 					},
-					Status: apiv1.ClusterStatus{
-						Version: *semver.NewSemverOrDie("9.9.9"),
-						URL:     "https://w225mx4z66.asia-east1-a-1.cloud.kubermatic.io:31885",
+					Spec: kubermaticv1.SSHKeySpec{
+						Clusters: []string{"clusterAbcID"},
 					},
-					Type: "kubernetes",
 				},
-				{
-					ObjectMeta: apiv1.ObjectMeta{
-						ID:                "clusterOpenstackID",
-						Name:              "clusterOpenstack",
-						CreationTimestamp: apiv1.Date(2013, 02, 04, 03, 54, 0, 0, time.UTC),
-					},
-					Spec: apiv1.ClusterSpec{
-						Cloud: kubermaticv1.CloudSpec{
-							DatacenterName: "OpenstackDatacenter",
-							Openstack: &kubermaticv1.OpenstackCloudSpec{
-								FloatingIPPool: "floatingIPPool",
-								SubnetID:       "subnetID",
-								Domain:         "domain",
-								Network:        "network",
-								RouterID:       "routerID",
-								SecurityGroups: "securityGroups",
-								Tenant:         "tenant",
+				&kubermaticv1.UserSSHKey{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "key-abc-yafn",
+						OwnerReferences: []metav1.OwnerReference{
+							{
+								APIVersion: "kubermatic.k8s.io/v1",
+								Kind:       "Project",
+								UID:        "",
+								Name:       test.GenDefaultProject().Name,
 							},
 						},
-						Version: *semver.NewSemverOrDie("9.9.9"),
 					},
-					Status: apiv1.ClusterStatus{
-						Version: *semver.NewSemverOrDie("9.9.9"),
-						URL:     "https://w225mx4z66.asia-east1-a-1.cloud.kubermatic.io:31885",
+					Spec: kubermaticv1.SSHKeySpec{
+						Clusters: []string{"clusterAbcID"},
 					},
-					Type: "kubernetes",
 				},
-			},
-			HTTPStatus: http.StatusOK,
-			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
-				test.GenCluster("clusterAbcID", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC)),
-				test.GenCluster("clusterDefID", "clusterDef", test.GenDefaultProject().Name, time.Date(2013, 02, 04, 01, 54, 0, 0, time.UTC)),
-				test.GenClusterWithOpenstack(test.GenCluster("clusterOpenstackID", "clusterOpenstack", test.GenDefaultProject().Name, time.Date(2013, 02, 04, 03, 54, 0, 0, time.UTC))),
 			),
-			ExistingAPIUser: test.GenDefaultAPIUser(),
+			ClusterToSync:                 "clusterAbcID",
+			ExistingAPIUser:               test.GenDefaultAPIUser(),
+			ExpectedListClusterKeysStatus: http.StatusNotFound,
 		},
-		// scenario 2
 		{
-			Name: "scenario 2: the admin John can list Bob's clusters",
-			ExpectedClusters: []apiv1.Cluster{
-				{
-					ObjectMeta: apiv1.ObjectMeta{
-						ID:                "clusterAbcID",
-						Name:              "clusterAbc",
-						CreationTimestamp: apiv1.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC),
-					},
-					Spec: apiv1.ClusterSpec{
-						Cloud: kubermaticv1.CloudSpec{
-							DatacenterName: "FakeDatacenter",
-							Fake:           &kubermaticv1.FakeCloudSpec{},
-						},
-						Version: *semver.NewSemverOrDie("9.9.9"),
-					},
-					Status: apiv1.ClusterStatus{
-						Version: *semver.NewSemverOrDie("9.9.9"),
-						URL:     "https://w225mx4z66.asia-east1-a-1.cloud.kubermatic.io:31885",
-					},
-					Type: "kubernetes",
-				},
-				{
-					ObjectMeta: apiv1.ObjectMeta{
-						ID:                "clusterDefID",
-						Name:              "clusterDef",
-						CreationTimestamp: apiv1.Date(2013, 02, 04, 01, 54, 0, 0, time.UTC),
-					},
-					Spec: apiv1.ClusterSpec{
-						Cloud: kubermaticv1.CloudSpec{
-							DatacenterName: "FakeDatacenter",
-							Fake:           &kubermaticv1.FakeCloudSpec{},
+			Name:             "scenario 2: the admin John can delete Bob's cluster",
+			Body:             ``,
+			ExpectedResponse: `{}`,
+			HTTPStatus:       http.StatusOK,
+			ProjectToSync:    test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				genUser("John", "john@acme.com", true),
+				// add a cluster
+				test.GenCluster("clusterAbcID", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC)),
+				// add ssh keys
+				&kubermaticv1.UserSSHKey{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "key-c08aa5c7abf34504f18552846485267d-yafn",
+						OwnerReferences: []metav1.OwnerReference{
+							{
+								APIVersion: "kubermatic.k8s.io/v1",
+								Kind:       "Project",
+								UID:        "",
+								Name:       test.GenDefaultProject().Name,
+							},
 						},
-						Version: *semver.NewSemverOrDie("9.9.9"),
 					},
-					Status: apiv1.ClusterStatus{
-						Version: *semver.NewSemverOrDie("9.9.9"),
-						URL:     "https://w225mx4z66.asia-east1-a-1.cloud.kubermatic.io:31885",
+					Spec: kubermaticv1.SSHKeySpec{
+						Clusters: []string{"clusterAbcID"},
 					},
-					Type: "kubernetes",
 				},
-				{
-					ObjectMeta: apiv1.ObjectMeta{
-						ID:                "clusterOpenstackID",
-						Name:              "clusterOpenstack",
-						CreationTimestamp: apiv1.Date(2013, 02, 04, 03, 54, 0, 0, time.UTC),
-					},
-					Spec: apiv1.ClusterSpec{
-						Cloud: kubermaticv1.CloudSpec{
-							DatacenterName: "OpenstackDatacenter",
-							Openstack: &kubermaticv1.OpenstackCloudSpec{
-								FloatingIPPool: "floatingIPPool",
-								SubnetID:       "subnetID",
-								Domain:         "domain",
-								Network:        "network",
-								RouterID:       "routerID",
-								SecurityGroups: "securityGroups",
-								Tenant:         "tenant",
+				&kubermaticv1.UserSSHKey{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "key-abc-yafn",
+						OwnerReferences: []metav1.OwnerReference{
+							{
+								APIVersion: "kubermatic.k8s.io/v1",
+								Kind:       "Project",
+								UID:        "",
+								Name:       test.GenDefaultProject().Name,
 							},
 						},
-						Version: *semver.NewSemverOrDie("9.9.9"),
-					},
-					Status: apiv1.ClusterStatus{
-						Version: *semver.NewSemverOrDie("9.9.9"),
-						URL:     "https://w225mx4z66.asia-east1-a-1.cloud.kubermatic.io:31885",
 					},
-					Type: "kubernetes",
+					Spec: kubermaticv1.SSHKeySpec{
+						Clusters: []string{"clusterAbcID"},
+					},
 				},
-			},
-			HTTPStatus: http.StatusOK,
-			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
-				genUser("John", "john@acme.com", true),
-				test.GenCluster("clusterAbcID", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC)),
-				test.GenCluster("clusterDefID", "clusterDef", test.GenDefaultProject().Name, time.Date(2013, 02, 04, 01, 54, 0, 0, time.UTC)),
-				test.GenClusterWithOpenstack(test.GenCluster("clusterOpenstackID", "clusterOpenstack", test.GenDefaultProject().Name, time.Date(2013, 02, 04, 03, 54, 0, 0, time.UTC))),
 			),
-			ExistingAPIUser: test.GenAPIUser("John", "john@acme.com"),
+			ClusterToSync:                 "clusterAbcID",
+			ExistingAPIUser:               test.GenAPIUser("John", "john@acme.com"),
+			ExpectedListClusterKeysStatus: http.StatusNotFound,
 		},
 	}
 
 	for _, tc := range testcases {
 		t.Run(tc.Name, func(t *testing.T) {
-			req := httptest.NewRequest("GET", fmt.Sprintf("/api/v2/projects/%s/clusters", test.ProjectName), strings.NewReader(""))
+
+			// validate if deletion was successful
+			req := httptest.NewRequest("DELETE", fmt.Sprintf("/api/v2/projects/%s/clusters/%s", tc.ProjectToSync, tc.ClusterToSync), strings.NewReader(tc.Body))
 			res := httptest.NewRecorder()
 			var kubermaticObj []runtime.Object
 			kubermaticObj = append(kubermaticObj, tc.ExistingKubermaticObjs...)
@@ -488,98 +1761,399 @@ func TestListClusters(t *testing.T) {
 			if res.Code != tc.HTTPStatus {
 				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
 			}
+			test.CompareWithResult(t, res, tc.ExpectedResponse)
 
-			actualClusters := test.NewClusterV1SliceWrapper{}
-			actualClusters.DecodeOrDie(res.Body, t).Sort()
-
-			wrappedExpectedClusters := test.NewClusterV1SliceWrapper(tc.ExpectedClusters)
-			wrappedExpectedClusters.Sort()
-
-			actualClusters.EqualOrDie(wrappedExpectedClusters, t)
+			// validate if the cluster was deleted
+			req = httptest.NewRequest("GET", fmt.Sprintf("/api/v1/projects/%s/dc/us-central1/clusters/abcd/sshkeys", tc.ProjectToSync), strings.NewReader(tc.Body))
+			res = httptest.NewRecorder()
+			ep.ServeHTTP(res, req)
+			if res.Code != tc.ExpectedListClusterKeysStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.ExpectedListClusterKeysStatus, res.Code, res.Body.String())
+			}
 		})
 	}
 }
 
-func TestGetCluster(t *testing.T) {
+func TestPatchCluster(t *testing.T) {
 	t.Parallel()
+
+	const fakeDC = "fake-dc"
+	cluster := test.GenCluster("keen-snyder", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC))
+	cluster.Spec.Cloud.DatacenterName = "us-central1"
+
 	testcases := []struct {
-		Name                   string
-		Body                   string
-		ExpectedResponse       string
-		HTTPStatus             int
-		ClusterToGet           string
-		ExistingAPIUser        *apiv1.User
-		ExistingKubermaticObjs []runtime.Object
+		Name                      string
+		Body                      string
+		QueryParams               string
+		ExpectedResponse          string
+		HTTPStatus                int
+		cluster                   string
+		project                   string
+		ExistingAPIUser           *apiv1.User
+		ExistingMachines          []*clusterv1alpha1.Machine
+		ExistingKubermaticObjects []runtime.Object
 	}{
 		// scenario 1
 		{
-			Name:             "scenario 1: gets cluster with the given name that belongs to the given project",
-			Body:             ``,
-			ExpectedResponse: `{"id":"defClusterID","name":"defClusterName","creationTimestamp":"2013-02-03T19:54:00Z","type":"kubernetes","spec":{"cloud":{"dc":"FakeDatacenter","fake":{}},"version":"9.9.9","oidc":{}},"status":{"version":"9.9.9","url":"https://w225mx4z66.asia-east1-a-1.cloud.kubermatic.io:31885"}}`,
-			ClusterToGet:     test.GenDefaultCluster().Name,
+			Name:             "scenario 1: update the cluster version",
+			Body:             `{"spec":{"version":"1.2.3"}}`,
+			ExpectedResponse: `{"id":"keen-snyder","name":"clusterAbc","creationTimestamp":"2013-02-03T19:54:00Z","type":"kubernetes","spec":{"cloud":{"dc":"fake-dc","fake":{}},"version":"1.2.3","oidc":{}},"status":{"version":"1.2.3","url":"https://w225mx4z66.asia-east1-a-1.cloud.kubermatic.io:31885"}}`,
+			cluster:          "keen-snyder",
+			HTTPStatus:       http.StatusOK,
+			project:          test.GenDefaultProject().Name,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExistingKubermaticObjects: test.GenDefaultKubermaticObjects(
+				func() *kubermaticv1.Cluster {
+					cluster := test.GenCluster("keen-snyder", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC))
+					cluster.Spec.Cloud.DatacenterName = fakeDC
+					return cluster
+				}()),
+		},
+		// scenario 2
+		{
+			Name:                      "scenario 2: fail on invalid patch json",
+			Body:                      `{"spec":{"cloud":{"dc":"dc1"`,
+			ExpectedResponse:          `{"error":{"code":400,"message":"cannot patch cluster: Invalid JSON Patch"}}`,
+			cluster:                   "keen-snyder",
+			HTTPStatus:                http.StatusBadRequest,
+			project:                   test.GenDefaultProject().Name,
+			ExistingAPIUser:           test.GenDefaultAPIUser(),
+			ExistingKubermaticObjects: test.GenDefaultKubermaticObjects(test.GenCluster("keen-snyder", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC))),
+		},
+		// scenario 3
+		{
+			Name:             "scenario 3: tried to update cluser with older but compatible nodes",
+			Body:             `{"spec":{"version":"9.11.3"}}`, // kubelet is 9.9.9, maximum compatible master is 9.11.x
+			ExpectedResponse: `{"id":"keen-snyder","name":"clusterAbc","creationTimestamp":"2013-02-03T19:54:00Z","type":"kubernetes","spec":{"cloud":{"dc":"fake-dc","fake":{}},"version":"9.11.3","oidc":{}},"status":{"version":"9.11.3","url":"https://w225mx4z66.asia-east1-a-1.cloud.kubermatic.io:31885"}}`,
+			cluster:          "keen-snyder",
+			HTTPStatus:       http.StatusOK,
+			project:          test.GenDefaultProject().Name,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExistingKubermaticObjects: test.GenDefaultKubermaticObjects(
+				func() *kubermaticv1.Cluster {
+					cluster := test.GenCluster("keen-snyder", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC))
+					cluster.Spec.Cloud.DatacenterName = fakeDC
+					return cluster
+				}(),
+			),
+			ExistingMachines: []*clusterv1alpha1.Machine{
+				test.GenTestMachine("venus", `{"cloudProvider":"digitalocean","cloudProviderSpec":{"token":"dummy-token","region":"fra1","size":"2GB"},"operatingSystem":"ubuntu","containerRuntimeInfo":{"name":"docker","version":"1.13"},"operatingSystemSpec":{"distUpgradeOnBoot":true}}`, map[string]string{"md-id": "123", "some-other": "xyz"}, nil),
+				test.GenTestMachine("mars", `{"cloudProvider":"aws","cloudProviderSpec":{"token":"dummy-token","region":"eu-central-1","availabilityZone":"eu-central-1a","vpcId":"vpc-819f62e9","subnetId":"subnet-2bff4f43","instanceType":"t2.micro","diskSize":50}, "containerRuntimeInfo":{"name":"docker","version":"1.12"},"operatingSystem":"ubuntu", "operatingSystemSpec":{"distUpgradeOnBoot":false}}`, map[string]string{"md-id": "123", "some-other": "xyz"}, nil),
+			},
+		},
+		// scenario 4
+		{
+			Name:             "scenario 4: tried to update cluser with old nodes",
+			Body:             `{"spec":{"version":"9.12.3"}}`, // kubelet is 9.9.9, maximum compatible master is 9.11.x
+			ExpectedResponse: `{"error":{"code":400,"message":"Cluster contains nodes running incompatible kubelet versions: machine mars runs 9.9.9, machine venus runs 9.9.9. Upgrade your nodes before you upgrade the cluster."}}`,
+			cluster:          "keen-snyder",
+			HTTPStatus:       http.StatusBadRequest,
+			project:          test.GenDefaultProject().Name,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExistingKubermaticObjects: test.GenDefaultKubermaticObjects(
+				func() *kubermaticv1.Cluster {
+					cluster := test.GenCluster("keen-snyder", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC))
+					cluster.Spec.Cloud.DatacenterName = "us-central1"
+					return cluster
+				}(),
+			),
+			ExistingMachines: []*clusterv1alpha1.Machine{
+				test.GenTestMachine("venus", `{"cloudProvider":"digitalocean","cloudProviderSpec":{"token":"dummy-token","region":"fra1","size":"2GB"},"operatingSystem":"ubuntu","containerRuntimeInfo":{"name":"docker","version":"1.13"},"operatingSystemSpec":{"distUpgradeOnBoot":true}}`, map[string]string{"md-id": "123", "some-other": "xyz"}, nil),
+				test.GenTestMachine("mars", `{"cloudProvider":"aws","cloudProviderSpec":{"token":"dummy-token","region":"eu-central-1","availabilityZone":"eu-central-1a","vpcId":"vpc-819f62e9","subnetId":"subnet-2bff4f43","instanceType":"t2.micro","diskSize":50}, "containerRuntimeInfo":{"name":"docker","version":"1.12"},"operatingSystem":"ubuntu", "operatingSystemSpec":{"distUpgradeOnBoot":false}}`, map[string]string{"md-id": "123", "some-other": "xyz"}, nil),
+			},
+		},
+		// scenario 5
+		{
+			Name:             "scenario 5: tried to downgrade cluser to version older than its nodes",
+			Body:             `{"spec":{"version":"9.8.12"}}`, // kubelet is 9.9.9, cluster cannot be older
+			ExpectedResponse: `{"error":{"code":400,"message":"Cluster contains nodes running incompatible kubelet versions: machine mars runs 9.9.9, machine venus runs 9.9.9. Upgrade your nodes before you upgrade the cluster."}}`,
+			cluster:          "keen-snyder",
+			HTTPStatus:       http.StatusBadRequest,
+			project:          test.GenDefaultProject().Name,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExistingKubermaticObjects: test.GenDefaultKubermaticObjects(
+				func() *kubermaticv1.Cluster {
+					cluster := test.GenCluster("keen-snyder", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC))
+					cluster.Spec.Cloud.DatacenterName = "us-central1"
+					return cluster
+				}(),
+			),
+			ExistingMachines: []*clusterv1alpha1.Machine{
+				test.GenTestMachine("venus", `{"cloudProvider":"digitalocean","cloudProviderSpec":{"token":"dummy-token","region":"fra1","size":"2GB"},"operatingSystem":"ubuntu","containerRuntimeInfo":{"name":"docker","version":"1.13"},"operatingSystemSpec":{"distUpgradeOnBoot":true}}`, map[string]string{"md-id": "123", "some-other": "xyz"}, nil),
+				test.GenTestMachine("mars", `{"cloudProvider":"aws","cloudProviderSpec":{"token":"dummy-token","region":"eu-central-1","availabilityZone":"eu-central-1a","vpcId":"vpc-819f62e9","subnetId":"subnet-2bff4f43","instanceType":"t2.micro","diskSize":50}, "containerRuntimeInfo":{"name":"docker","version":"1.12"},"operatingSystem":"ubuntu", "operatingSystemSpec":{"distUpgradeOnBoot":false}}`, map[string]string{"md-id": "123", "some-other": "xyz"}, nil),
+			},
+		},
+		// scenario 6
+		{
+			Name:             "scenario 6: the admin John can update Bob's cluster version",
+			Body:             `{"spec":{"version":"1.2.3"}}`,
+			ExpectedResponse: `{"id":"keen-snyder","name":"clusterAbc","creationTimestamp":"2013-02-03T19:54:00Z","type":"kubernetes","spec":{"cloud":{"dc":"fake-dc","fake":{}},"version":"1.2.3","oidc":{}},"status":{"version":"1.2.3","url":"https://w225mx4z66.asia-east1-a-1.cloud.kubermatic.io:31885"}}`,
+			cluster:          "keen-snyder",
+			HTTPStatus:       http.StatusOK,
+			project:          test.GenDefaultProject().Name,
+			ExistingAPIUser:  test.GenAPIUser("John", "john@acme.com"),
+			ExistingKubermaticObjects: test.GenDefaultKubermaticObjects(
+				func() *kubermaticv1.Cluster {
+					cluster := test.GenCluster("keen-snyder", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC))
+					cluster.Spec.Cloud.DatacenterName = fakeDC
+					return cluster
+				}(), genUser("John", "john@acme.com", true)),
+		},
+		// scenario 7
+		{
+			Name:             "scenario 7: the regular user John can not update Bob's cluster version",
+			Body:             `{"spec":{"version":"1.2.3"}}`,
+			ExpectedResponse: `{"error":{"code":403,"message":"forbidden: \"john@acme.com\" doesn't belong to the given project = my-first-project-ID"}}`,
+			cluster:          "keen-snyder",
+			HTTPStatus:       http.StatusForbidden,
+			project:          test.GenDefaultProject().Name,
+			ExistingAPIUser:  test.GenAPIUser("John", "john@acme.com"),
+			ExistingKubermaticObjects: test.GenDefaultKubermaticObjects(
+				func() *kubermaticv1.Cluster {
+					cluster := test.GenCluster("keen-snyder", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC))
+					cluster.Spec.Cloud.DatacenterName = fakeDC
+					return cluster
+				}(), genUser("John", "john@acme.com", false)),
+		},
+		// scenario 8
+		{
+			Name:             "scenario 8: update the cluster description",
+			Body:             `{"spec":{"description":"updated description"}}`,
+			ExpectedResponse: `{"id":"keen-snyder","name":"clusterAbc","creationTimestamp":"2013-02-03T19:54:00Z","type":"kubernetes","spec":{"cloud":{"dc":"fake-dc","fake":{}},"version":"9.9.9","oidc":{},"description":"updated description"},"status":{"version":"9.9.9","url":"https://w225mx4z66.asia-east1-a-1.cloud.kubermatic.io:31885"}}`,
+			cluster:          "keen-snyder",
+			HTTPStatus:       http.StatusOK,
+			project:          test.GenDefaultProject().Name,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExistingKubermaticObjects: test.GenDefaultKubermaticObjects(
+				func() *kubermaticv1.Cluster {
+					cluster := test.GenCluster("keen-snyder", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC))
+					cluster.Spec.Cloud.DatacenterName = fakeDC
+					cluster.Spec.Description = "initial description"
+					return cluster
+				}()),
+		},
+		// scenario 9
+		{
+			Name:             "scenario 9: fail to update the cluster with a too long description",
+			Body:             fmt.Sprintf(`{"spec":{"description":"%s"}}`, strings.Repeat("a", 256)),
+			ExpectedResponse: `{"error":{"code":400,"message":"invalid cluster: cluster description must not be longer than 255 characters"}}`,
+			cluster:          "keen-snyder",
+			HTTPStatus:       http.StatusBadRequest,
+			project:          test.GenDefaultProject().Name,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExistingKubermaticObjects: test.GenDefaultKubermaticObjects(
+				func() *kubermaticv1.Cluster {
+					cluster := test.GenCluster("keen-snyder", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC))
+					cluster.Spec.Cloud.DatacenterName = fakeDC
+					return cluster
+				}()),
+		},
+		// scenario 10
+		{
+			Name:             "scenario 10: changing the service node port range returns a warning",
+			Body:             `{"spec":{"serviceNodePortRange":"20000-22767"}}`,
+			ExpectedResponse: `{"id":"keen-snyder","name":"clusterAbc","creationTimestamp":"2013-02-03T19:54:00Z","type":"kubernetes","spec":{"cloud":{"dc":"fake-dc","fake":{}},"version":"9.9.9","oidc":{},"serviceNodePortRange":"20000-22767"},"status":{"version":"9.9.9","url":"https://w225mx4z66.asia-east1-a-1.cloud.kubermatic.io:31885"},"warnings":["the service node port range has changed, existing NodePort services with ports outside of the new range may stop working"]}`,
+			cluster:          "keen-snyder",
+			HTTPStatus:       http.StatusOK,
+			project:          test.GenDefaultProject().Name,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExistingKubermaticObjects: test.GenDefaultKubermaticObjects(
+				func() *kubermaticv1.Cluster {
+					cluster := test.GenCluster("keen-snyder", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC))
+					cluster.Spec.Cloud.DatacenterName = fakeDC
+					return cluster
+				}()),
+		},
+		// scenario 11
+		{
+			Name:             "scenario 11: fail to update the cluster with an invalid service node port range",
+			Body:             `{"spec":{"serviceNodePortRange":"32767-30000"}}`,
+			ExpectedResponse: `{"error":{"code":400,"message":"invalid cluster: invalid service node port range \"32767-30000\": the first port must not be greater than the last port"}}`,
+			cluster:          "keen-snyder",
+			HTTPStatus:       http.StatusBadRequest,
+			project:          test.GenDefaultProject().Name,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExistingKubermaticObjects: test.GenDefaultKubermaticObjects(
+				func() *kubermaticv1.Cluster {
+					cluster := test.GenCluster("keen-snyder", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC))
+					cluster.Spec.Cloud.DatacenterName = fakeDC
+					return cluster
+				}()),
+		},
+		// scenario 12
+		{
+			Name:             "scenario 12: fail to change the expose strategy without acknowledging the disruption",
+			Body:             `{"spec":{"exposeStrategy":"LoadBalancer"}}`,
+			ExpectedResponse: `{"error":{"code":409,"message":"exposeStrategy change requires ?acknowledge=disruption"}}`,
+			cluster:          "keen-snyder",
+			HTTPStatus:       http.StatusConflict,
+			project:          test.GenDefaultProject().Name,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExistingKubermaticObjects: test.GenDefaultKubermaticObjects(
+				func() *kubermaticv1.Cluster {
+					cluster := test.GenCluster("keen-snyder", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC))
+					cluster.Spec.Cloud.DatacenterName = fakeDC
+					cluster.Spec.ExposeStrategy = corev1.ServiceTypeNodePort
+					return cluster
+				}()),
+		},
+		// scenario 13
+		{
+			Name:             "scenario 13: change the expose strategy, the URL is kept until the new endpoint is ready",
+			Body:             `{"spec":{"exposeStrategy":"LoadBalancer"}}`,
+			QueryParams:      "?acknowledge=disruption",
+			ExpectedResponse: `{"id":"keen-snyder","name":"clusterAbc","creationTimestamp":"2013-02-03T19:54:00Z","type":"kubernetes","spec":{"cloud":{"dc":"fake-dc","fake":{}},"version":"9.9.9","oidc":{},"exposeStrategy":"LoadBalancer"},"status":{"version":"9.9.9","url":"https://w225mx4z66.asia-east1-a-1.cloud.kubermatic.io:31885"}}`,
+			cluster:          "keen-snyder",
+			HTTPStatus:       http.StatusOK,
+			project:          test.GenDefaultProject().Name,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExistingKubermaticObjects: test.GenDefaultKubermaticObjects(
+				func() *kubermaticv1.Cluster {
+					cluster := test.GenCluster("keen-snyder", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC))
+					cluster.Spec.Cloud.DatacenterName = fakeDC
+					cluster.Spec.ExposeStrategy = corev1.ServiceTypeNodePort
+					return cluster
+				}()),
+		},
+		// scenario 14
+		{
+			Name:             "scenario 14: fail to change the expose strategy to an unsupported one",
+			Body:             `{"spec":{"exposeStrategy":"ClusterIP"}}`,
+			QueryParams:      "?acknowledge=disruption",
+			ExpectedResponse: `{"error":{"code":400,"message":"invalid exposeStrategy \"ClusterIP\", must be either NodePort or LoadBalancer"}}`,
+			cluster:          "keen-snyder",
+			HTTPStatus:       http.StatusBadRequest,
+			project:          test.GenDefaultProject().Name,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExistingKubermaticObjects: test.GenDefaultKubermaticObjects(
+				func() *kubermaticv1.Cluster {
+					cluster := test.GenCluster("keen-snyder", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC))
+					cluster.Spec.Cloud.DatacenterName = fakeDC
+					cluster.Spec.ExposeStrategy = corev1.ServiceTypeNodePort
+					return cluster
+				}()),
+		},
+		// scenario 15
+		{
+			Name:             "scenario 15: fail to disable audit logging in an audit-logging-enforced datacenter",
+			Body:             `{"spec":{"auditLogging":{"enabled":false}}}`,
+			ExpectedResponse: `{"error":{"code":400,"message":"audit logging cannot be disabled in datacenter audited-dc"}}`,
+			cluster:          "keen-snyder",
+			HTTPStatus:       http.StatusBadRequest,
+			project:          test.GenDefaultProject().Name,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExistingKubermaticObjects: test.GenDefaultKubermaticObjects(
+				func() *kubermaticv1.Cluster {
+					cluster := test.GenCluster("keen-snyder", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC))
+					cluster.Spec.Cloud.DatacenterName = "audited-dc"
+					cluster.Spec.AuditLogging = &kubermaticv1.AuditLoggingSettings{Enabled: true}
+					return cluster
+				}()),
+		},
+		// scenario 16
+		{
+			Name:             "scenario 16: disable audit logging in a datacenter which does not enforce it",
+			Body:             `{"spec":{"auditLogging":{"enabled":false}}}`,
+			ExpectedResponse: `{"id":"keen-snyder","name":"clusterAbc","creationTimestamp":"2013-02-03T19:54:00Z","type":"kubernetes","spec":{"cloud":{"dc":"fake-dc","fake":{}},"version":"9.9.9","oidc":{},"auditLogging":{}},"status":{"version":"9.9.9","url":"https://w225mx4z66.asia-east1-a-1.cloud.kubermatic.io:31885"}}`,
+			cluster:          "keen-snyder",
 			HTTPStatus:       http.StatusOK,
-			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
-				test.GenDefaultCluster(),
-				test.GenCluster("clusterAbcID", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC)),
-			),
-			ExistingAPIUser: test.GenDefaultAPIUser(),
+			project:          test.GenDefaultProject().Name,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExistingKubermaticObjects: test.GenDefaultKubermaticObjects(
+				func() *kubermaticv1.Cluster {
+					cluster := test.GenCluster("keen-snyder", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC))
+					cluster.Spec.Cloud.DatacenterName = fakeDC
+					cluster.Spec.AuditLogging = &kubermaticv1.AuditLoggingSettings{Enabled: true}
+					return cluster
+				}()),
 		},
-		// scenario 2
+		// scenario 17
 		{
-			Name:             "scenario 2: gets cluster for Openstack and no sensitive data (credentials) are returned",
-			Body:             ``,
-			ExpectedResponse: `{"id":"defClusterID","name":"defClusterName","creationTimestamp":"2013-02-03T19:54:00Z","type":"kubernetes","spec":{"cloud":{"dc":"OpenstackDatacenter","openstack":{"floatingIpPool":"floatingIPPool","tenant":"tenant","domain":"domain","network":"network","securityGroups":"securityGroups","routerID":"routerID","subnetID":"subnetID"}},"version":"9.9.9","oidc":{}},"status":{"version":"9.9.9","url":"https://w225mx4z66.asia-east1-a-1.cloud.kubermatic.io:31885"}}`,
-			ClusterToGet:     test.GenDefaultCluster().Name,
+			Name:             "scenario 17: enable audit logging in a datacenter which does not enforce it",
+			Body:             `{"spec":{"auditLogging":{"enabled":true}}}`,
+			ExpectedResponse: `{"id":"keen-snyder","name":"clusterAbc","creationTimestamp":"2013-02-03T19:54:00Z","type":"kubernetes","spec":{"cloud":{"dc":"fake-dc","fake":{}},"version":"9.9.9","oidc":{},"auditLogging":{"enabled":true}},"status":{"version":"9.9.9","url":"https://w225mx4z66.asia-east1-a-1.cloud.kubermatic.io:31885"}}`,
+			cluster:          "keen-snyder",
 			HTTPStatus:       http.StatusOK,
-			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
-				test.GenClusterWithOpenstack(test.GenDefaultCluster()),
-				test.GenCluster("clusterAbcID", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC)),
-			),
-			ExistingAPIUser: test.GenDefaultAPIUser(),
+			project:          test.GenDefaultProject().Name,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExistingKubermaticObjects: test.GenDefaultKubermaticObjects(
+				func() *kubermaticv1.Cluster {
+					cluster := test.GenCluster("keen-snyder", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC))
+					cluster.Spec.Cloud.DatacenterName = fakeDC
+					return cluster
+				}()),
 		},
-		// scenario 3
+		// scenario 18
 		{
-			Name:             "scenario 3: the admin John can get Bob's cluster",
-			Body:             ``,
-			ExpectedResponse: `{"id":"defClusterID","name":"defClusterName","creationTimestamp":"2013-02-03T19:54:00Z","type":"kubernetes","spec":{"cloud":{"dc":"OpenstackDatacenter","openstack":{"floatingIpPool":"floatingIPPool","tenant":"tenant","domain":"domain","network":"network","securityGroups":"securityGroups","routerID":"routerID","subnetID":"subnetID"}},"version":"9.9.9","oidc":{}},"status":{"version":"9.9.9","url":"https://w225mx4z66.asia-east1-a-1.cloud.kubermatic.io:31885"}}`,
-			ClusterToGet:     test.GenDefaultCluster().Name,
+			Name:             "scenario 18: audit logging stays enabled when patching a cluster in an audit-logging-enforced datacenter",
+			Body:             `{"spec":{"auditLogging":null}}`,
+			ExpectedResponse: `{"id":"keen-snyder","name":"clusterAbc","creationTimestamp":"2013-02-03T19:54:00Z","type":"kubernetes","spec":{"cloud":{"dc":"audited-dc","fake":{}},"version":"9.9.9","oidc":{},"auditLogging":{"enabled":true}},"status":{"version":"9.9.9","url":"https://w225mx4z66.asia-east1-a-1.cloud.kubermatic.io:31885"}}`,
+			cluster:          "keen-snyder",
 			HTTPStatus:       http.StatusOK,
-			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
-				genUser("John", "john@acme.com", true),
-				test.GenClusterWithOpenstack(test.GenDefaultCluster()),
-				test.GenCluster("clusterAbcID", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC)),
-			),
-			ExistingAPIUser: test.GenAPIUser("John", "john@acme.com"),
+			project:          test.GenDefaultProject().Name,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExistingKubermaticObjects: test.GenDefaultKubermaticObjects(
+				func() *kubermaticv1.Cluster {
+					cluster := test.GenCluster("keen-snyder", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC))
+					cluster.Spec.Cloud.DatacenterName = "audited-dc"
+					cluster.Spec.AuditLogging = &kubermaticv1.AuditLoggingSettings{Enabled: true}
+					return cluster
+				}()),
 		},
-		// scenario 4
+		// scenario 19
 		{
-			Name:             "scenario 4: the regular user John can not get Bob's cluster",
-			Body:             ``,
-			ExpectedResponse: `{"error":{"code":403,"message":"forbidden: \"john@acme.com\" doesn't belong to the given project = my-first-project-ID"}}`,
-			ClusterToGet:     test.GenDefaultCluster().Name,
-			HTTPStatus:       http.StatusForbidden,
-			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
-				genUser("John", "john@acme.com", false),
-				test.GenClusterWithOpenstack(test.GenDefaultCluster()),
-				test.GenCluster("clusterAbcID", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC)),
-			),
-			ExistingAPIUser: test.GenAPIUser("John", "john@acme.com"),
+			Name:             "scenario 19: fail to move the cluster into an email-restricted datacenter, to which the user does not have access",
+			Body:             `{"spec":{"cloud":{"dc":"restricted-fake-dc"}}}`,
+			ExpectedResponse: `{"error":{"code":404,"message":"datacenter \"restricted-fake-dc\" not found"}}`,
+			cluster:          "keen-snyder",
+			HTTPStatus:       http.StatusNotFound,
+			project:          test.GenDefaultProject().Name,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExistingKubermaticObjects: test.GenDefaultKubermaticObjects(
+				func() *kubermaticv1.Cluster {
+					cluster := test.GenCluster("keen-snyder", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC))
+					cluster.Spec.Cloud.DatacenterName = fakeDC
+					return cluster
+				}()),
+		},
+		// scenario 20
+		{
+			Name:             "scenario 20: moving the cluster into an email-restricted datacenter, to which the user does have access, is still rejected",
+			Body:             `{"spec":{"cloud":{"dc":"restricted-fake-dc"}}}`,
+			ExpectedResponse: `{"error":{"code":400,"message":"invalid cluster: changing the datacenter is not allowed"}}`,
+			cluster:          "keen-snyder",
+			HTTPStatus:       http.StatusBadRequest,
+			project:          test.GenDefaultProject().Name,
+			ExistingAPIUser:  test.GenAPIUser(test.UserName2, test.UserEmail2),
+			ExistingKubermaticObjects: test.GenDefaultKubermaticObjects(
+				test.GenUser(test.UserID2, test.UserName2, test.UserEmail2),
+				test.GenBinding(test.GenDefaultProject().Name, test.UserEmail2, "editors"),
+				func() *kubermaticv1.Cluster {
+					cluster := test.GenCluster("keen-snyder", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC))
+					cluster.Spec.Cloud.DatacenterName = fakeDC
+					return cluster
+				}()),
 		},
 	}
 
 	for _, tc := range testcases {
 		t.Run(tc.Name, func(t *testing.T) {
-			req := httptest.NewRequest("GET", fmt.Sprintf("/api/v2/projects/%s/clusters/%s", test.ProjectName, tc.ClusterToGet), strings.NewReader(tc.Body))
+			var machineObj []runtime.Object
+			for _, existingMachine := range tc.ExistingMachines {
+				machineObj = append(machineObj, existingMachine)
+			}
+			// test data
+			req := httptest.NewRequest(http.MethodPatch, fmt.Sprintf("/api/v2/projects/%s/clusters/%s%s", tc.project, tc.cluster, tc.QueryParams), strings.NewReader(tc.Body))
 			res := httptest.NewRecorder()
-			var kubermaticObj []runtime.Object
-			kubermaticObj = append(kubermaticObj, tc.ExistingKubermaticObjs...)
-			ep, err := test.CreateTestEndpoint(*tc.ExistingAPIUser, []runtime.Object{}, kubermaticObj, nil, nil, hack.NewTestRouting)
+			ep, _, err := test.CreateTestEndpointAndGetClients(*tc.ExistingAPIUser, nil, []runtime.Object{}, machineObj, tc.ExistingKubermaticObjects, nil, nil, hack.NewTestRouting)
 			if err != nil {
 				t.Fatalf("failed to create test endpoint due to %v", err)
 			}
 
+			// act
 			ep.ServeHTTP(res, req)
 
+			// validate
 			if res.Code != tc.HTTPStatus {
 				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
 			}
@@ -589,125 +2163,45 @@ func TestGetCluster(t *testing.T) {
 	}
 }
 
-func TestDeleteClusterEndpoint(t *testing.T) {
+func TestPatchClusterImagePullSecretRef(t *testing.T) {
 	t.Parallel()
+
+	genOpenshiftCluster := func() *kubermaticv1.Cluster {
+		cluster := test.GenCluster("keen-snyder", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC))
+		cluster.Annotations = map[string]string{"kubermatic.io/openshift": "true"}
+		cluster.Spec.Cloud.DatacenterName = "fake-dc"
+		cluster.Spec.Openshift = &kubermaticv1.Openshift{ImagePullSecret: `{"auths":{"old":{}}}`}
+		return cluster
+	}
+
 	testcases := []struct {
-		Name                          string
-		Body                          string
-		ExpectedResponse              string
-		HTTPStatus                    int
-		ProjectToSync                 string
-		ClusterToSync                 string
-		ExistingKubermaticObjs        []runtime.Object
-		ExistingAPIUser               *apiv1.User
-		ExpectedListClusterKeysStatus int
+		Name                    string
+		ExpectedResponse        string
+		HTTPStatus              int
+		ExpectedImagePullSecret string
+		ExistingKubeObjs        []runtime.Object
 	}{
 		{
-			Name:             "scenario 1: tests deletion of a cluster and its dependant resources",
-			Body:             ``,
-			ExpectedResponse: `{}`,
-			HTTPStatus:       http.StatusOK,
-			ProjectToSync:    test.GenDefaultProject().Name,
-			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
-				// add a cluster
-				test.GenCluster("clusterAbcID", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC)),
-				// add ssh keys
-				&kubermaticv1.UserSSHKey{
-					ObjectMeta: metav1.ObjectMeta{
-						Name: "key-c08aa5c7abf34504f18552846485267d-yafn",
-						OwnerReferences: []metav1.OwnerReference{
-							{
-								APIVersion: "kubermatic.k8s.io/v1",
-								Kind:       "Project",
-								UID:        "",
-								Name:       test.GenDefaultProject().Name,
-							},
-						},
-					},
-					Spec: kubermaticv1.SSHKeySpec{
-						Clusters: []string{"clusterAbcID"},
-					},
-				},
-				&kubermaticv1.UserSSHKey{
-					ObjectMeta: metav1.ObjectMeta{
-						Name: "key-abc-yafn",
-						OwnerReferences: []metav1.OwnerReference{
-							{
-								APIVersion: "kubermatic.k8s.io/v1",
-								Kind:       "Project",
-								UID:        "",
-								Name:       test.GenDefaultProject().Name,
-							},
-						},
-					},
-					Spec: kubermaticv1.SSHKeySpec{
-						Clusters: []string{"clusterAbcID"},
-					},
-				},
-			),
-			ClusterToSync:                 "clusterAbcID",
-			ExistingAPIUser:               test.GenDefaultAPIUser(),
-			ExpectedListClusterKeysStatus: http.StatusNotFound,
+			Name:                    "scenario 1: the stored image pull secret replaces the image pull secret of the cluster",
+			HTTPStatus:              http.StatusOK,
+			ExpectedImagePullSecret: `{"auths":{}}`,
+			ExistingKubeObjs:        []runtime.Object{genPullSecret("pull-secret", test.GenDefaultProject().Name)},
 		},
 		{
-			Name:             "scenario 2: the admin John can delete Bob's cluster",
-			Body:             ``,
-			ExpectedResponse: `{}`,
-			HTTPStatus:       http.StatusOK,
-			ProjectToSync:    test.GenDefaultProject().Name,
-			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
-				genUser("John", "john@acme.com", true),
-				// add a cluster
-				test.GenCluster("clusterAbcID", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC)),
-				// add ssh keys
-				&kubermaticv1.UserSSHKey{
-					ObjectMeta: metav1.ObjectMeta{
-						Name: "key-c08aa5c7abf34504f18552846485267d-yafn",
-						OwnerReferences: []metav1.OwnerReference{
-							{
-								APIVersion: "kubermatic.k8s.io/v1",
-								Kind:       "Project",
-								UID:        "",
-								Name:       test.GenDefaultProject().Name,
-							},
-						},
-					},
-					Spec: kubermaticv1.SSHKeySpec{
-						Clusters: []string{"clusterAbcID"},
-					},
-				},
-				&kubermaticv1.UserSSHKey{
-					ObjectMeta: metav1.ObjectMeta{
-						Name: "key-abc-yafn",
-						OwnerReferences: []metav1.OwnerReference{
-							{
-								APIVersion: "kubermatic.k8s.io/v1",
-								Kind:       "Project",
-								UID:        "",
-								Name:       test.GenDefaultProject().Name,
-							},
-						},
-					},
-					Spec: kubermaticv1.SSHKeySpec{
-						Clusters: []string{"clusterAbcID"},
-					},
-				},
-			),
-			ClusterToSync:                 "clusterAbcID",
-			ExistingAPIUser:               test.GenAPIUser("John", "john@acme.com"),
-			ExpectedListClusterKeysStatus: http.StatusNotFound,
+			Name:                    "scenario 2: an image pull secret of another project is rejected",
+			ExpectedResponse:        `{"error":{"code":400,"message":"image pull secret \"pull-secret\" not found in project my-first-project-ID"}}`,
+			HTTPStatus:              http.StatusBadRequest,
+			ExpectedImagePullSecret: `{"auths":{"old":{}}}`,
+			ExistingKubeObjs:        []runtime.Object{genPullSecret("pull-secret", "other-project")},
 		},
 	}
 
 	for _, tc := range testcases {
 		t.Run(tc.Name, func(t *testing.T) {
-
-			// validate if deletion was successful
-			req := httptest.NewRequest("DELETE", fmt.Sprintf("/api/v2/projects/%s/clusters/%s", tc.ProjectToSync, tc.ClusterToSync), strings.NewReader(tc.Body))
+			body := `{"spec":{"openshift":{"imagePullSecretRef":"pull-secret"}}}`
+			req := httptest.NewRequest(http.MethodPatch, fmt.Sprintf("/api/v2/projects/%s/clusters/keen-snyder", test.GenDefaultProject().Name), strings.NewReader(body))
 			res := httptest.NewRecorder()
-			var kubermaticObj []runtime.Object
-			kubermaticObj = append(kubermaticObj, tc.ExistingKubermaticObjs...)
-			ep, err := test.CreateTestEndpoint(*tc.ExistingAPIUser, []runtime.Object{}, kubermaticObj, nil, nil, hack.NewTestRouting)
+			ep, clients, err := test.CreateTestEndpointAndGetClients(*test.GenDefaultAPIUser(), nil, tc.ExistingKubeObjs, nil, test.GenDefaultKubermaticObjects(genOpenshiftCluster()), nil, nil, hack.NewTestRouting)
 			if err != nil {
 				t.Fatalf("failed to create test endpoint due to %v", err)
 			}
@@ -717,25 +2211,28 @@ func TestDeleteClusterEndpoint(t *testing.T) {
 			if res.Code != tc.HTTPStatus {
 				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
 			}
-			test.CompareWithResult(t, res, tc.ExpectedResponse)
+			if tc.ExpectedResponse != "" {
+				test.CompareWithResult(t, res, tc.ExpectedResponse)
+			}
 
-			// validate if the cluster was deleted
-			req = httptest.NewRequest("GET", fmt.Sprintf("/api/v1/projects/%s/dc/us-central1/clusters/abcd/sshkeys", tc.ProjectToSync), strings.NewReader(tc.Body))
-			res = httptest.NewRecorder()
-			ep.ServeHTTP(res, req)
-			if res.Code != tc.ExpectedListClusterKeysStatus {
-				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.ExpectedListClusterKeysStatus, res.Code, res.Body.String())
+			cluster := &kubermaticv1.Cluster{}
+			if err := clients.FakeClient.Get(context.Background(), types.NamespacedName{Name: "keen-snyder"}, cluster); err != nil {
+				t.Fatalf("failed to get the cluster: %v", err)
+			}
+			if cluster.Spec.Openshift == nil || cluster.Spec.Openshift.ImagePullSecret != tc.ExpectedImagePullSecret {
+				t.Fatalf("Expected the image pull secret %s, got %+v", tc.ExpectedImagePullSecret, cluster.Spec.Openshift)
+			}
+			if cluster.Spec.Openshift.ImagePullSecretRef != "" {
+				t.Fatalf("Expected the image pull secret reference to be resolved, got %q", cluster.Spec.Openshift.ImagePullSecretRef)
 			}
 		})
 	}
 }
 
-func TestPatchCluster(t *testing.T) {
+func TestUpdateCluster(t *testing.T) {
 	t.Parallel()
 
 	const fakeDC = "fake-dc"
-	cluster := test.GenCluster("keen-snyder", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC))
-	cluster.Spec.Cloud.DatacenterName = "us-central1"
 
 	testcases := []struct {
 		Name                      string
@@ -750,9 +2247,9 @@ func TestPatchCluster(t *testing.T) {
 	}{
 		// scenario 1
 		{
-			Name:             "scenario 1: update the cluster version",
-			Body:             `{"spec":{"version":"1.2.3"}}`,
-			ExpectedResponse: `{"id":"keen-snyder","name":"clusterAbc","creationTimestamp":"2013-02-03T19:54:00Z","type":"kubernetes","spec":{"cloud":{"dc":"fake-dc","fake":{}},"version":"1.2.3","oidc":{}},"status":{"version":"1.2.3","url":"https://w225mx4z66.asia-east1-a-1.cloud.kubermatic.io:31885"}}`,
+			Name:             "scenario 1: replace the cluster spec",
+			Body:             `{"name":"clusterAbc","spec":{"cloud":{"dc":"fake-dc","fake":{}},"version":"1.2.3","description":"replaced description"}}`,
+			ExpectedResponse: `{"id":"keen-snyder","name":"clusterAbc","creationTimestamp":"2013-02-03T19:54:00Z","type":"kubernetes","spec":{"cloud":{"dc":"fake-dc","fake":{}},"version":"1.2.3","oidc":{},"description":"replaced description"},"status":{"version":"1.2.3","url":"https://w225mx4z66.asia-east1-a-1.cloud.kubermatic.io:31885"}}`,
 			cluster:          "keen-snyder",
 			HTTPStatus:       http.StatusOK,
 			project:          test.GenDefaultProject().Name,
@@ -764,21 +2261,26 @@ func TestPatchCluster(t *testing.T) {
 					return cluster
 				}()),
 		},
-		// scenario 2
-		{
-			Name:                      "scenario 2: fail on invalid patch json",
-			Body:                      `{"spec":{"cloud":{"dc":"dc1"`,
-			ExpectedResponse:          `{"error":{"code":400,"message":"cannot patch cluster: Invalid JSON Patch"}}`,
-			cluster:                   "keen-snyder",
-			HTTPStatus:                http.StatusBadRequest,
-			project:                   test.GenDefaultProject().Name,
-			ExistingAPIUser:           test.GenDefaultAPIUser(),
-			ExistingKubermaticObjects: test.GenDefaultKubermaticObjects(test.GenCluster("keen-snyder", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC))),
-		},
+		// scenario 2
+		{
+			Name:             "scenario 2: fail on invalid cluster json",
+			Body:             `{"spec":{"cloud":{"dc":"dc1"`,
+			ExpectedResponse: `{"error":{"code":400,"message":"cannot decode cluster: unexpected EOF"}}`,
+			cluster:          "keen-snyder",
+			HTTPStatus:       http.StatusBadRequest,
+			project:          test.GenDefaultProject().Name,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExistingKubermaticObjects: test.GenDefaultKubermaticObjects(
+				func() *kubermaticv1.Cluster {
+					cluster := test.GenCluster("keen-snyder", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC))
+					cluster.Spec.Cloud.DatacenterName = fakeDC
+					return cluster
+				}()),
+		},
 		// scenario 3
 		{
-			Name:             "scenario 3: tried to update cluser with older but compatible nodes",
-			Body:             `{"spec":{"version":"9.11.3"}}`, // kubelet is 9.9.9, maximum compatible master is 9.11.x
+			Name:             "scenario 3: update the cluster with older but compatible nodes",
+			Body:             `{"name":"clusterAbc","spec":{"cloud":{"dc":"fake-dc","fake":{}},"version":"9.11.3"}}`, // kubelet is 9.9.9, maximum compatible master is 9.11.x
 			ExpectedResponse: `{"id":"keen-snyder","name":"clusterAbc","creationTimestamp":"2013-02-03T19:54:00Z","type":"kubernetes","spec":{"cloud":{"dc":"fake-dc","fake":{}},"version":"9.11.3","oidc":{}},"status":{"version":"9.11.3","url":"https://w225mx4z66.asia-east1-a-1.cloud.kubermatic.io:31885"}}`,
 			cluster:          "keen-snyder",
 			HTTPStatus:       http.StatusOK,
@@ -789,8 +2291,7 @@ func TestPatchCluster(t *testing.T) {
 					cluster := test.GenCluster("keen-snyder", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC))
 					cluster.Spec.Cloud.DatacenterName = fakeDC
 					return cluster
-				}(),
-			),
+				}()),
 			ExistingMachines: []*clusterv1alpha1.Machine{
 				test.GenTestMachine("venus", `{"cloudProvider":"digitalocean","cloudProviderSpec":{"token":"dummy-token","region":"fra1","size":"2GB"},"operatingSystem":"ubuntu","containerRuntimeInfo":{"name":"docker","version":"1.13"},"operatingSystemSpec":{"distUpgradeOnBoot":true}}`, map[string]string{"md-id": "123", "some-other": "xyz"}, nil),
 				test.GenTestMachine("mars", `{"cloudProvider":"aws","cloudProviderSpec":{"token":"dummy-token","region":"eu-central-1","availabilityZone":"eu-central-1a","vpcId":"vpc-819f62e9","subnetId":"subnet-2bff4f43","instanceType":"t2.micro","diskSize":50}, "containerRuntimeInfo":{"name":"docker","version":"1.12"},"operatingSystem":"ubuntu", "operatingSystemSpec":{"distUpgradeOnBoot":false}}`, map[string]string{"md-id": "123", "some-other": "xyz"}, nil),
@@ -798,9 +2299,9 @@ func TestPatchCluster(t *testing.T) {
 		},
 		// scenario 4
 		{
-			Name:             "scenario 4: tried to update cluser with old nodes",
-			Body:             `{"spec":{"version":"9.12.3"}}`, // kubelet is 9.9.9, maximum compatible master is 9.11.x
-			ExpectedResponse: `{"error":{"code":400,"message":"Cluster contains nodes running the following incompatible kubelet versions: [9.9.9]. Upgrade your nodes before you upgrade the cluster."}}`,
+			Name:             "scenario 4: fail to update the cluster with old nodes",
+			Body:             `{"name":"clusterAbc","spec":{"cloud":{"dc":"fake-dc","fake":{}},"version":"9.12.3"}}`, // kubelet is 9.9.9, maximum compatible master is 9.11.x
+			ExpectedResponse: `{"error":{"code":400,"message":"Cluster contains nodes running incompatible kubelet versions: machine mars runs 9.9.9, machine venus runs 9.9.9. Upgrade your nodes before you upgrade the cluster."}}`,
 			cluster:          "keen-snyder",
 			HTTPStatus:       http.StatusBadRequest,
 			project:          test.GenDefaultProject().Name,
@@ -808,10 +2309,9 @@ func TestPatchCluster(t *testing.T) {
 			ExistingKubermaticObjects: test.GenDefaultKubermaticObjects(
 				func() *kubermaticv1.Cluster {
 					cluster := test.GenCluster("keen-snyder", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC))
-					cluster.Spec.Cloud.DatacenterName = "us-central1"
+					cluster.Spec.Cloud.DatacenterName = fakeDC
 					return cluster
-				}(),
-			),
+				}()),
 			ExistingMachines: []*clusterv1alpha1.Machine{
 				test.GenTestMachine("venus", `{"cloudProvider":"digitalocean","cloudProviderSpec":{"token":"dummy-token","region":"fra1","size":"2GB"},"operatingSystem":"ubuntu","containerRuntimeInfo":{"name":"docker","version":"1.13"},"operatingSystemSpec":{"distUpgradeOnBoot":true}}`, map[string]string{"md-id": "123", "some-other": "xyz"}, nil),
 				test.GenTestMachine("mars", `{"cloudProvider":"aws","cloudProviderSpec":{"token":"dummy-token","region":"eu-central-1","availabilityZone":"eu-central-1a","vpcId":"vpc-819f62e9","subnetId":"subnet-2bff4f43","instanceType":"t2.micro","diskSize":50}, "containerRuntimeInfo":{"name":"docker","version":"1.12"},"operatingSystem":"ubuntu", "operatingSystemSpec":{"distUpgradeOnBoot":false}}`, map[string]string{"md-id": "123", "some-other": "xyz"}, nil),
@@ -819,9 +2319,9 @@ func TestPatchCluster(t *testing.T) {
 		},
 		// scenario 5
 		{
-			Name:             "scenario 5: tried to downgrade cluser to version older than its nodes",
-			Body:             `{"spec":{"version":"9.8.12"}}`, // kubelet is 9.9.9, cluster cannot be older
-			ExpectedResponse: `{"error":{"code":400,"message":"Cluster contains nodes running the following incompatible kubelet versions: [9.9.9]. Upgrade your nodes before you upgrade the cluster."}}`,
+			Name:             "scenario 5: fail to change the cloud provider",
+			Body:             `{"name":"clusterAbc","spec":{"cloud":{"dc":"fake-dc","openstack":{"tenant":"tenant"}},"version":"9.9.9"}}`,
+			ExpectedResponse: `{"error":{"code":400,"message":"changing the cloud provider from \"fake\" to \"openstack\" is not allowed"}}`,
 			cluster:          "keen-snyder",
 			HTTPStatus:       http.StatusBadRequest,
 			project:          test.GenDefaultProject().Name,
@@ -829,35 +2329,30 @@ func TestPatchCluster(t *testing.T) {
 			ExistingKubermaticObjects: test.GenDefaultKubermaticObjects(
 				func() *kubermaticv1.Cluster {
 					cluster := test.GenCluster("keen-snyder", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC))
-					cluster.Spec.Cloud.DatacenterName = "us-central1"
+					cluster.Spec.Cloud.DatacenterName = fakeDC
 					return cluster
-				}(),
-			),
-			ExistingMachines: []*clusterv1alpha1.Machine{
-				test.GenTestMachine("venus", `{"cloudProvider":"digitalocean","cloudProviderSpec":{"token":"dummy-token","region":"fra1","size":"2GB"},"operatingSystem":"ubuntu","containerRuntimeInfo":{"name":"docker","version":"1.13"},"operatingSystemSpec":{"distUpgradeOnBoot":true}}`, map[string]string{"md-id": "123", "some-other": "xyz"}, nil),
-				test.GenTestMachine("mars", `{"cloudProvider":"aws","cloudProviderSpec":{"token":"dummy-token","region":"eu-central-1","availabilityZone":"eu-central-1a","vpcId":"vpc-819f62e9","subnetId":"subnet-2bff4f43","instanceType":"t2.micro","diskSize":50}, "containerRuntimeInfo":{"name":"docker","version":"1.12"},"operatingSystem":"ubuntu", "operatingSystemSpec":{"distUpgradeOnBoot":false}}`, map[string]string{"md-id": "123", "some-other": "xyz"}, nil),
-			},
+				}()),
 		},
 		// scenario 6
 		{
-			Name:             "scenario 6: the admin John can update Bob's cluster version",
-			Body:             `{"spec":{"version":"1.2.3"}}`,
-			ExpectedResponse: `{"id":"keen-snyder","name":"clusterAbc","creationTimestamp":"2013-02-03T19:54:00Z","type":"kubernetes","spec":{"cloud":{"dc":"fake-dc","fake":{}},"version":"1.2.3","oidc":{}},"status":{"version":"1.2.3","url":"https://w225mx4z66.asia-east1-a-1.cloud.kubermatic.io:31885"}}`,
+			Name:             "scenario 6: fail when the cluster ID of the body does not match the path",
+			Body:             `{"id":"other-cluster","name":"clusterAbc","spec":{"cloud":{"dc":"fake-dc","fake":{}},"version":"9.9.9"}}`,
+			ExpectedResponse: `{"error":{"code":400,"message":"the cluster ID \"other-cluster\" of the body does not match the cluster ID \"keen-snyder\" of the path"}}`,
 			cluster:          "keen-snyder",
-			HTTPStatus:       http.StatusOK,
+			HTTPStatus:       http.StatusBadRequest,
 			project:          test.GenDefaultProject().Name,
-			ExistingAPIUser:  test.GenAPIUser("John", "john@acme.com"),
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
 			ExistingKubermaticObjects: test.GenDefaultKubermaticObjects(
 				func() *kubermaticv1.Cluster {
 					cluster := test.GenCluster("keen-snyder", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC))
 					cluster.Spec.Cloud.DatacenterName = fakeDC
 					return cluster
-				}(), genUser("John", "john@acme.com", true)),
+				}()),
 		},
 		// scenario 7
 		{
-			Name:             "scenario 7: the regular user John can not update Bob's cluster version",
-			Body:             `{"spec":{"version":"1.2.3"}}`,
+			Name:             "scenario 7: the regular user John can not update Bob's cluster",
+			Body:             `{"name":"clusterAbc","spec":{"cloud":{"dc":"fake-dc","fake":{}},"version":"1.2.3"}}`,
 			ExpectedResponse: `{"error":{"code":403,"message":"forbidden: \"john@acme.com\" doesn't belong to the given project = my-first-project-ID"}}`,
 			cluster:          "keen-snyder",
 			HTTPStatus:       http.StatusForbidden,
@@ -878,18 +2373,15 @@ func TestPatchCluster(t *testing.T) {
 			for _, existingMachine := range tc.ExistingMachines {
 				machineObj = append(machineObj, existingMachine)
 			}
-			// test data
-			req := httptest.NewRequest(http.MethodPatch, fmt.Sprintf("/api/v2/projects/%s/clusters/%s", tc.project, tc.cluster), strings.NewReader(tc.Body))
+			req := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/api/v2/projects/%s/clusters/%s", tc.project, tc.cluster), strings.NewReader(tc.Body))
 			res := httptest.NewRecorder()
 			ep, _, err := test.CreateTestEndpointAndGetClients(*tc.ExistingAPIUser, nil, []runtime.Object{}, machineObj, tc.ExistingKubermaticObjects, nil, nil, hack.NewTestRouting)
 			if err != nil {
 				t.Fatalf("failed to create test endpoint due to %v", err)
 			}
 
-			// act
 			ep.ServeHTTP(res, req)
 
-			// validate
 			if res.Code != tc.HTTPStatus {
 				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
 			}
@@ -987,6 +2479,142 @@ func TestGetClusterEventsEndpoint(t *testing.T) {
 			},
 			ExpectedResult: `{"error":{"code":403,"message":"forbidden: \"john@acme.com\" doesn't belong to the given project = my-first-project-ID"}}`,
 		},
+		// scenario 6
+		{
+			Name:                   "scenario 6: list events whose reason matches the search",
+			QueryParams:            "?search=kill",
+			HTTPStatus:             http.StatusOK,
+			ClusterIDToSync:        test.GenDefaultCluster().Name,
+			ProjectIDToSync:        test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(test.GenDefaultCluster()),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+			ExistingEvents: []*corev1.Event{
+				test.GenTestEvent("event-1", corev1.EventTypeNormal, "Started", "message started", "Cluster", "venus-1-machine"),
+				test.GenTestEvent("event-2", corev1.EventTypeWarning, "Killed", "container stopped", "Cluster", "venus-1-machine"),
+			},
+			ExpectedResult: `[{"name":"event-2","creationTimestamp":"0001-01-01T00:00:00Z","message":"container stopped","type":"Warning","involvedObject":{"type":"Cluster","namespace":"kube-system","name":"testMachine"},"lastTimestamp":"0001-01-01T00:00:00Z","count":1}]`,
+		},
+		// scenario 7
+		{
+			Name:                   "scenario 7: list normal events whose message matches the search",
+			QueryParams:            "?type=normal&search=MESSAGE",
+			HTTPStatus:             http.StatusOK,
+			ClusterIDToSync:        test.GenDefaultCluster().Name,
+			ProjectIDToSync:        test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(test.GenDefaultCluster()),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+			ExistingEvents: []*corev1.Event{
+				test.GenTestEvent("event-1", corev1.EventTypeNormal, "Started", "message started", "Cluster", "venus-1-machine"),
+				test.GenTestEvent("event-2", corev1.EventTypeWarning, "Killed", "message killed", "Cluster", "venus-1-machine"),
+				test.GenTestEvent("event-3", corev1.EventTypeNormal, "Pulled", "image pulled", "Cluster", "venus-1-machine"),
+			},
+			ExpectedResult: `[{"name":"event-1","creationTimestamp":"0001-01-01T00:00:00Z","message":"message started","type":"Normal","involvedObject":{"type":"Cluster","namespace":"kube-system","name":"testMachine"},"lastTimestamp":"0001-01-01T00:00:00Z","count":1}]`,
+		},
+		// scenario 8
+		{
+			Name:                   "scenario 8: list the events of a single involved object",
+			QueryParams:            "?fieldSelector=involvedObject.name=machine-b",
+			HTTPStatus:             http.StatusOK,
+			ClusterIDToSync:        test.GenDefaultCluster().Name,
+			ProjectIDToSync:        test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(test.GenDefaultCluster()),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+			ExistingEvents: []*corev1.Event{
+				genInvolvedObjectEvent("event-1", "Machine", "machine-a"),
+				genInvolvedObjectEvent("event-2", "Machine", "machine-b"),
+				genInvolvedObjectEvent("event-3", "Node", "machine-b"),
+			},
+			ExpectedResult: `[{"name":"event-2","creationTimestamp":"0001-01-01T00:00:00Z","message":"message started","type":"Normal","involvedObject":{"type":"Node","namespace":"kube-system","name":"machine-b"},"lastTimestamp":"0001-01-01T00:00:00Z","count":1},{"name":"event-3","creationTimestamp":"0001-01-01T00:00:00Z","message":"message started","type":"Normal","involvedObject":{"type":"Node","namespace":"kube-system","name":"machine-b"},"lastTimestamp":"0001-01-01T00:00:00Z","count":1}]`,
+		},
+		// scenario 9
+		{
+			Name:                   "scenario 9: list the events of an involved object of a given kind",
+			QueryParams:            "?fieldSelector=involvedObject.kind=Machine,involvedObject.name=machine-b",
+			HTTPStatus:             http.StatusOK,
+			ClusterIDToSync:        test.GenDefaultCluster().Name,
+			ProjectIDToSync:        test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(test.GenDefaultCluster()),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+			ExistingEvents: []*corev1.Event{
+				genInvolvedObjectEvent("event-1", "Machine", "machine-a"),
+				genInvolvedObjectEvent("event-2", "Machine", "machine-b"),
+				genInvolvedObjectEvent("event-3", "Node", "machine-b"),
+			},
+			ExpectedResult: `[{"name":"event-2","creationTimestamp":"0001-01-01T00:00:00Z","message":"message started","type":"Normal","involvedObject":{"type":"Node","namespace":"kube-system","name":"machine-b"},"lastTimestamp":"0001-01-01T00:00:00Z","count":1}]`,
+		},
+		// scenario 10
+		{
+			Name:                   "scenario 10: the field selector can only select by the involved object",
+			QueryParams:            "?fieldSelector=reason=Started",
+			HTTPStatus:             http.StatusBadRequest,
+			ClusterIDToSync:        test.GenDefaultCluster().Name,
+			ProjectIDToSync:        test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(test.GenDefaultCluster()),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+			ExistingEvents: []*corev1.Event{
+				genInvolvedObjectEvent("event-1", "Machine", "machine-a"),
+			},
+			ExpectedResult: `{"error":{"code":400,"message":"invalid field selector: unsupported field \"reason\", supported fields are involvedObject.kind, involvedObject.name"}}`,
+		},
+		// scenario 11
+		{
+			Name:                   "scenario 11: list the events since a given time, the most recent first",
+			QueryParams:            "?since=2013-02-03T11:00:00Z",
+			HTTPStatus:             http.StatusOK,
+			ClusterIDToSync:        test.GenDefaultCluster().Name,
+			ProjectIDToSync:        test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(test.GenDefaultCluster()),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+			ExistingEvents: []*corev1.Event{
+				genEventAt("event-1", time.Date(2013, 02, 03, 12, 0, 0, 0, time.UTC)),
+				genEventAt("event-2", time.Date(2013, 02, 03, 10, 0, 0, 0, time.UTC)),
+				genEventAt("event-3", time.Date(2013, 02, 03, 11, 0, 0, 0, time.UTC)),
+			},
+			ExpectedResult: `[{"name":"event-1","creationTimestamp":"0001-01-01T00:00:00Z","message":"message started","type":"Normal","involvedObject":{"type":"Cluster","namespace":"kube-system","name":"testMachine"},"lastTimestamp":"2013-02-03T12:00:00Z","count":1},{"name":"event-3","creationTimestamp":"0001-01-01T00:00:00Z","message":"message started","type":"Normal","involvedObject":{"type":"Cluster","namespace":"kube-system","name":"testMachine"},"lastTimestamp":"2013-02-03T11:00:00Z","count":1}]`,
+		},
+		// scenario 12
+		{
+			Name:                   "scenario 12: list the most recent events up to the limit",
+			QueryParams:            "?limit=2",
+			HTTPStatus:             http.StatusOK,
+			ClusterIDToSync:        test.GenDefaultCluster().Name,
+			ProjectIDToSync:        test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(test.GenDefaultCluster()),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+			ExistingEvents: []*corev1.Event{
+				genEventAt("event-1", time.Date(2013, 02, 03, 12, 0, 0, 0, time.UTC)),
+				genEventAt("event-2", time.Date(2013, 02, 03, 10, 0, 0, 0, time.UTC)),
+				genEventAt("event-3", time.Date(2013, 02, 03, 11, 0, 0, 0, time.UTC)),
+			},
+			ExpectedResult: `[{"name":"event-1","creationTimestamp":"0001-01-01T00:00:00Z","message":"message started","type":"Normal","involvedObject":{"type":"Cluster","namespace":"kube-system","name":"testMachine"},"lastTimestamp":"2013-02-03T12:00:00Z","count":1},{"name":"event-3","creationTimestamp":"0001-01-01T00:00:00Z","message":"message started","type":"Normal","involvedObject":{"type":"Cluster","namespace":"kube-system","name":"testMachine"},"lastTimestamp":"2013-02-03T11:00:00Z","count":1}]`,
+		},
+		// scenario 13
+		{
+			Name:                   "scenario 13: the limit applies to the events since the given time",
+			QueryParams:            "?since=2013-02-03T10:30:00Z&limit=1",
+			HTTPStatus:             http.StatusOK,
+			ClusterIDToSync:        test.GenDefaultCluster().Name,
+			ProjectIDToSync:        test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(test.GenDefaultCluster()),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+			ExistingEvents: []*corev1.Event{
+				genEventAt("event-1", time.Date(2013, 02, 03, 12, 0, 0, 0, time.UTC)),
+				genEventAt("event-2", time.Date(2013, 02, 03, 10, 0, 0, 0, time.UTC)),
+				genEventAt("event-3", time.Date(2013, 02, 03, 11, 0, 0, 0, time.UTC)),
+			},
+			ExpectedResult: `[{"name":"event-1","creationTimestamp":"0001-01-01T00:00:00Z","message":"message started","type":"Normal","involvedObject":{"type":"Cluster","namespace":"kube-system","name":"testMachine"},"lastTimestamp":"2013-02-03T12:00:00Z","count":1}]`,
+		},
+		// scenario 14
+		{
+			Name:                   "scenario 14: fail on an invalid since",
+			QueryParams:            "?since=yesterday",
+			HTTPStatus:             http.StatusBadRequest,
+			ClusterIDToSync:        test.GenDefaultCluster().Name,
+			ProjectIDToSync:        test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(test.GenDefaultCluster()),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+			ExpectedResult:         `{"error":{"code":400,"message":"invalid since \"yesterday\", must be a RFC3339 timestamp"}}`,
+		},
 	}
 
 	for _, tc := range testcases {
@@ -1017,6 +2645,18 @@ func TestGetClusterEventsEndpoint(t *testing.T) {
 	}
 }
 
+func genEventAt(eventName string, lastTimestamp time.Time) *corev1.Event {
+	event := test.GenTestEvent(eventName, corev1.EventTypeNormal, "Started", "message started", "Cluster", "")
+	event.LastTimestamp = metav1.NewTime(lastTimestamp)
+	return event
+}
+
+func genInvolvedObjectEvent(eventName, kind, objectName string) *corev1.Event {
+	event := test.GenTestEvent(eventName, corev1.EventTypeNormal, "Started", "message started", kind, "")
+	event.InvolvedObject.Name = objectName
+	return event
+}
+
 func TestGetClusterHealth(t *testing.T) {
 	t.Parallel()
 	testcases := []struct {
@@ -1024,16 +2664,19 @@ func TestGetClusterHealth(t *testing.T) {
 		Body                   string
 		ExpectedResponse       string
 		HTTPStatus             int
+		Detailed               bool
 		ClusterToGet           string
 		ProjectToSync          string
 		ExistingAPIUser        *apiv1.User
+		ExistingKubeObjs       []runtime.Object
 		ExistingKubermaticObjs []runtime.Object
 	}{
 		// scenario 1
 		{
 			Name:             "scenario 1: get existing cluster health status",
 			Body:             ``,
-			ExpectedResponse: `{"apiserver":1,"scheduler":0,"controller":1,"machineController":0,"etcd":1,"cloudProviderInfrastructure":1,"userClusterControllerManager":1}`,
+			ExpectedResponse: `{"apiserver":1,"scheduler":0,"controller":1,"machineController":0,"etcd":1,"cloudProviderInfrastructure":1,"userClusterControllerManager":1,"status":"Degraded","details":{"apiserver":{"reason":"the component is running","replicas":{"desired":1,"ready":0}},"cloudProviderInfrastructure":{"reason":"the component is running"},"controller":{"reason":"the component is running"},"etcd":{"reason":"the component is running"},"machineController":{"reason":"the machine-controller deployment has no ready replicas"},"scheduler":{"reason":"the scheduler deployment has no ready replicas"},"userClusterControllerManager":{"reason":"the component is running"}}}`,
+			Detailed:         true,
 			HTTPStatus:       http.StatusOK,
 			ClusterToGet:     "keen-snyder",
 			ProjectToSync:    test.GenDefaultProject().Name,
@@ -1060,9 +2703,9 @@ func TestGetClusterHealth(t *testing.T) {
 		},
 		// scenario 2
 		{
-			Name:             "scenario 2: the admin Bob can get John's cluster health status",
+			Name:             "scenario 2: the admin Bob can get John's cluster health status, the details are only returned on request",
 			Body:             ``,
-			ExpectedResponse: `{"apiserver":1,"scheduler":0,"controller":1,"machineController":0,"etcd":1,"cloudProviderInfrastructure":1,"userClusterControllerManager":1}`,
+			ExpectedResponse: `{"apiserver":1,"scheduler":0,"controller":1,"machineController":0,"etcd":1,"cloudProviderInfrastructure":1,"userClusterControllerManager":1,"status":"Degraded"}`,
 			HTTPStatus:       http.StatusOK,
 			ClusterToGet:     "keen-snyder",
 			ProjectToSync:    test.GenDefaultProject().Name,
@@ -1120,15 +2763,145 @@ func TestGetClusterHealth(t *testing.T) {
 			),
 			ExistingAPIUser: test.GenAPIUser("John", "john@acme.com"),
 		},
+		// scenario 4
+		{
+			Name:             "scenario 4: the reason of a component which is down includes the message of the failing condition",
+			Body:             ``,
+			ExpectedResponse: `{"apiserver":1,"scheduler":0,"controller":1,"machineController":1,"etcd":1,"cloudProviderInfrastructure":1,"userClusterControllerManager":1,"status":"Degraded","details":{"apiserver":{"reason":"the component is running","lastTransitionTime":"2013-02-03T20:00:00Z","replicas":{"desired":1,"ready":0}},"cloudProviderInfrastructure":{"reason":"the component is running"},"controller":{"reason":"the component is running","lastTransitionTime":"2013-02-03T20:00:00Z"},"etcd":{"reason":"the component is running"},"machineController":{"reason":"the component is running","lastTransitionTime":"2013-02-03T20:00:00Z"},"scheduler":{"reason":"the scheduler deployment has no ready replicas: failed to reconcile the scheduler deployment","lastTransitionTime":"2013-02-03T20:00:00Z"},"userClusterControllerManager":{"reason":"the component is running","lastTransitionTime":"2013-02-03T20:00:00Z"}}}`,
+			Detailed:         true,
+			HTTPStatus:       http.StatusOK,
+			ClusterToGet:     "keen-snyder",
+			ProjectToSync:    test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				func() *kubermaticv1.Cluster {
+					cluster := test.GenCluster("keen-snyder", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC))
+					cluster.Status.ExtendedHealth = kubermaticv1.ExtendedClusterHealth{
+						Apiserver:                    kubermaticv1.HealthStatusUp,
+						Scheduler:                    kubermaticv1.HealthStatusDown,
+						Controller:                   kubermaticv1.HealthStatusUp,
+						MachineController:            kubermaticv1.HealthStatusUp,
+						Etcd:                         kubermaticv1.HealthStatusUp,
+						CloudProviderInfrastructure:  kubermaticv1.HealthStatusUp,
+						UserClusterControllerManager: kubermaticv1.HealthStatusUp,
+					}
+					cluster.Status.Conditions = []kubermaticv1.ClusterCondition{
+						{
+							Type:               kubermaticv1.ClusterConditionClusterControllerReconcilingSuccess,
+							Status:             corev1.ConditionFalse,
+							LastTransitionTime: metav1.NewTime(time.Date(2013, 02, 03, 20, 0, 0, 0, time.UTC)),
+							Message:            "failed to reconcile the scheduler deployment",
+						},
+					}
+					return cluster
+				}(),
+			),
+			ExistingAPIUser: test.GenDefaultAPIUser(),
+		},
+		// scenario 5
+		{
+			Name:             "scenario 5: the cluster is healthy when all components are up",
+			Body:             ``,
+			ExpectedResponse: `{"apiserver":1,"scheduler":1,"controller":1,"machineController":1,"etcd":1,"cloudProviderInfrastructure":1,"userClusterControllerManager":1,"status":"Healthy","details":{"apiserver":{"reason":"the component is running","replicas":{"desired":1,"ready":0}},"cloudProviderInfrastructure":{"reason":"the component is running"},"controller":{"reason":"the component is running"},"etcd":{"reason":"the component is running"},"machineController":{"reason":"the component is running"},"scheduler":{"reason":"the component is running"},"userClusterControllerManager":{"reason":"the component is running"}}}`,
+			Detailed:         true,
+			HTTPStatus:       http.StatusOK,
+			ClusterToGet:     "keen-snyder",
+			ProjectToSync:    test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				func() *kubermaticv1.Cluster {
+					cluster := test.GenCluster("keen-snyder", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC))
+					cluster.Status.ExtendedHealth = kubermaticv1.ExtendedClusterHealth{
+						Apiserver:                    kubermaticv1.HealthStatusUp,
+						Scheduler:                    kubermaticv1.HealthStatusUp,
+						Controller:                   kubermaticv1.HealthStatusUp,
+						MachineController:            kubermaticv1.HealthStatusUp,
+						Etcd:                         kubermaticv1.HealthStatusUp,
+						CloudProviderInfrastructure:  kubermaticv1.HealthStatusUp,
+						UserClusterControllerManager: kubermaticv1.HealthStatusUp,
+					}
+					return cluster
+				}(),
+			),
+			ExistingAPIUser: test.GenDefaultAPIUser(),
+		},
+		// scenario 6
+		{
+			Name:             "scenario 6: the cluster is unhealthy when etcd is down",
+			Body:             ``,
+			ExpectedResponse: `{"apiserver":1,"scheduler":0,"controller":1,"machineController":1,"etcd":0,"cloudProviderInfrastructure":1,"userClusterControllerManager":1,"status":"Unhealthy","details":{"apiserver":{"reason":"the component is running","replicas":{"desired":1,"ready":0}},"cloudProviderInfrastructure":{"reason":"the component is running"},"controller":{"reason":"the component is running"},"etcd":{"reason":"the etcd statefulset has less than 2 ready members"},"machineController":{"reason":"the component is running"},"scheduler":{"reason":"the scheduler deployment has no ready replicas"},"userClusterControllerManager":{"reason":"the component is running"}}}`,
+			Detailed:         true,
+			HTTPStatus:       http.StatusOK,
+			ClusterToGet:     "keen-snyder",
+			ProjectToSync:    test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				func() *kubermaticv1.Cluster {
+					cluster := test.GenCluster("keen-snyder", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC))
+					cluster.Status.ExtendedHealth = kubermaticv1.ExtendedClusterHealth{
+						Apiserver:                    kubermaticv1.HealthStatusUp,
+						Scheduler:                    kubermaticv1.HealthStatusDown,
+						Controller:                   kubermaticv1.HealthStatusUp,
+						MachineController:            kubermaticv1.HealthStatusUp,
+						Etcd:                         kubermaticv1.HealthStatusDown,
+						CloudProviderInfrastructure:  kubermaticv1.HealthStatusUp,
+						UserClusterControllerManager: kubermaticv1.HealthStatusUp,
+					}
+					return cluster
+				}(),
+			),
+			ExistingAPIUser: test.GenDefaultAPIUser(),
+		},
+		// scenario 7
+		{
+			Name:             "scenario 7: the configured and the ready apiserver replicas are returned",
+			Body:             ``,
+			ExpectedResponse: `{"apiserver":1,"scheduler":1,"controller":1,"machineController":1,"etcd":1,"cloudProviderInfrastructure":1,"userClusterControllerManager":1,"status":"Healthy","details":{"apiserver":{"reason":"the component is running","replicas":{"desired":3,"ready":2}},"cloudProviderInfrastructure":{"reason":"the component is running"},"controller":{"reason":"the component is running"},"etcd":{"reason":"the component is running"},"machineController":{"reason":"the component is running"},"scheduler":{"reason":"the component is running"},"userClusterControllerManager":{"reason":"the component is running"}}}`,
+			Detailed:         true,
+			HTTPStatus:       http.StatusOK,
+			ClusterToGet:     "keen-snyder",
+			ProjectToSync:    test.GenDefaultProject().Name,
+			ExistingKubeObjs: []runtime.Object{
+				&appsv1.Deployment{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      resources.ApiserverDeploymentName,
+						Namespace: "cluster-keen-snyder",
+					},
+					Status: appsv1.DeploymentStatus{
+						Replicas:      3,
+						ReadyReplicas: 2,
+					},
+				},
+			},
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				func() *kubermaticv1.Cluster {
+					cluster := test.GenCluster("keen-snyder", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC))
+					replicas := int32(3)
+					cluster.Spec.ComponentsOverride.Apiserver.Replicas = &replicas
+					cluster.Status.ExtendedHealth = kubermaticv1.ExtendedClusterHealth{
+						Apiserver:                    kubermaticv1.HealthStatusUp,
+						Scheduler:                    kubermaticv1.HealthStatusUp,
+						Controller:                   kubermaticv1.HealthStatusUp,
+						MachineController:            kubermaticv1.HealthStatusUp,
+						Etcd:                         kubermaticv1.HealthStatusUp,
+						CloudProviderInfrastructure:  kubermaticv1.HealthStatusUp,
+						UserClusterControllerManager: kubermaticv1.HealthStatusUp,
+					}
+					return cluster
+				}(),
+			),
+			ExistingAPIUser: test.GenDefaultAPIUser(),
+		},
 	}
 
 	for _, tc := range testcases {
 		t.Run(tc.Name, func(t *testing.T) {
-			req := httptest.NewRequest("GET", fmt.Sprintf("/api/v2/projects/%s/clusters/%s/health", tc.ProjectToSync, tc.ClusterToGet), strings.NewReader(tc.Body))
+			url := fmt.Sprintf("/api/v2/projects/%s/clusters/%s/health", tc.ProjectToSync, tc.ClusterToGet)
+			if tc.Detailed {
+				url += "?detailed=true"
+			}
+			req := httptest.NewRequest("GET", url, strings.NewReader(tc.Body))
 			res := httptest.NewRecorder()
 			var kubermaticObj []runtime.Object
 			kubermaticObj = append(kubermaticObj, tc.ExistingKubermaticObjs...)
-			ep, err := test.CreateTestEndpoint(*tc.ExistingAPIUser, []runtime.Object{}, kubermaticObj, nil, nil, hack.NewTestRouting)
+			ep, err := test.CreateTestEndpoint(*tc.ExistingAPIUser, tc.ExistingKubeObjs, kubermaticObj, nil, nil, hack.NewTestRouting)
 			if err != nil {
 				t.Fatalf("failed to create test endpoint due to %v", err)
 			}
@@ -1149,3 +2922,75 @@ func genUser(name, email string, isAdmin bool) *kubermaticv1.User {
 	user.Spec.IsAdmin = isAdmin
 	return user
 }
+
+func genBackupJob(name, clusterName string, succeeded bool, completionTime time.Time) *batchv1.Job {
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: metav1.NamespaceSystem,
+			Labels:    resources.AppClusterLabels(resources.EtcdBackupJobAppName, clusterName, nil),
+		},
+		Status: batchv1.JobStatus{
+			Failed: 1,
+		},
+	}
+	if succeeded {
+		job.Status = batchv1.JobStatus{
+			Succeeded:      1,
+			CompletionTime: &metav1.Time{Time: completionTime},
+		}
+	}
+	return job
+}
+
+func genClusterWithLabels(id, name string, labels map[string]string) *kubermaticv1.Cluster {
+	return test.GenCluster(id, name, test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC), func(cluster *kubermaticv1.Cluster) {
+		for key, value := range labels {
+			cluster.Labels[key] = value
+		}
+	})
+}
+
+func genAPIClusterWithLabels(id, name string, labels map[string]string) apiv1.Cluster {
+	return apiv1.Cluster{
+		ObjectMeta: apiv1.ObjectMeta{
+			ID:                id,
+			Name:              name,
+			CreationTimestamp: apiv1.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC),
+		},
+		Labels: labels,
+		Spec: apiv1.ClusterSpec{
+			Cloud: kubermaticv1.CloudSpec{
+				DatacenterName: "FakeDatacenter",
+				Fake:           &kubermaticv1.FakeCloudSpec{},
+			},
+			Version: *semver.NewSemverOrDie("9.9.9"),
+		},
+		Status: apiv1.ClusterStatus{
+			Version: *semver.NewSemverOrDie("9.9.9"),
+			URL:     "https://w225mx4z66.asia-east1-a-1.cloud.kubermatic.io:31885",
+		},
+		Type: "kubernetes",
+	}
+}
+
+func genClusterWithOIDC(cluster *kubermaticv1.Cluster) *kubermaticv1.Cluster {
+	cluster.Spec.OIDC = kubermaticv1.OIDCSettings{
+		IssuerURL:    "https://dex.acme.com",
+		ClientID:     "kubernetes",
+		ClientSecret: "very-secret",
+		GroupsClaim:  "groups",
+	}
+	return cluster
+}
+
+func genAddonConfig(name string, versions ...string) *kubermaticv1.AddonConfig {
+	return &kubermaticv1.AddonConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: kubermaticv1.AddonConfigSpec{
+			Versions: versions,
+		},
+	}
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-kit/kit/endpoint"
+
+	apiv2 "k8c.io/kubermatic/v2/pkg/api/v2"
+	handlercommon "k8c.io/kubermatic/v2/pkg/handler/common"
+	"k8c.io/kubermatic/v2/pkg/handler/middleware"
+	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/resources"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// clusterComponents are the control plane components whose images are returned. The container running
+// a component is named after its Deployment, or StatefulSet in the case of etcd.
+var clusterComponents = []struct {
+	name        string
+	statefulSet bool
+}{
+	{name: resources.ApiserverDeploymentName},
+	{name: resources.ControllerManagerDeploymentName},
+	{name: resources.SchedulerDeploymentName},
+	{name: resources.EtcdStatefulSetName, statefulSet: true},
+	{name: resources.MachineControllerDeploymentName},
+}
+
+// GetComponentVersionsEndpoint returns the images of the control plane components of the cluster.
+// Components which were not deployed yet are omitted.
+func GetComponentVersionsEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(GetClusterReq)
+		privilegedClusterProvider := ctx.Value(middleware.PrivilegedClusterProviderContextKey).(provider.PrivilegedClusterProvider)
+
+		cluster, err := handlercommon.GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, req.ProjectID, req.ClusterID, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		seedClient := privilegedClusterProvider.GetSeedClusterAdminRuntimeClient()
+		versions := make([]apiv2.ClusterComponentVersion, 0, len(clusterComponents))
+		for _, component := range clusterComponents {
+			podSpec, err := componentPodSpec(ctx, seedClient, cluster.Status.NamespaceName, component.name, component.statefulSet)
+			if err != nil {
+				if kerrors.IsNotFound(err) {
+					continue
+				}
+				return nil, common.KubernetesErrorToHTTPError(err)
+			}
+
+			image := componentImage(podSpec, component.name)
+			if image == "" {
+				continue
+			}
+			versions = append(versions, apiv2.ClusterComponentVersion{
+				Name:    component.name,
+				Image:   image,
+				Version: imageVersion(image),
+			})
+		}
+
+		return versions, nil
+	}
+}
+
+// componentPodSpec returns the pod template of the Deployment or StatefulSet of a control plane component
+func componentPodSpec(ctx context.Context, client ctrlruntimeclient.Client, namespace, name string, statefulSet bool) (*corev1.PodSpec, error) {
+	key := types.NamespacedName{Namespace: namespace, Name: name}
+	if statefulSet {
+		set := &appsv1.StatefulSet{}
+		if err := client.Get(ctx, key, set); err != nil {
+			return nil, err
+		}
+		return &set.Spec.Template.Spec, nil
+	}
+	deployment := &appsv1.Deployment{}
+	if err := client.Get(ctx, key, deployment); err != nil {
+		return nil, err
+	}
+	return &deployment.Spec.Template.Spec, nil
+}
+
+// componentImage returns the image of the container named after the component, sidecars are ignored
+func componentImage(podSpec *corev1.PodSpec, name string) string {
+	for _, container := range podSpec.Containers {
+		if container.Name == name {
+			return container.Image
+		}
+	}
+	return ""
+}
+
+// imageVersion returns the digest or tag of an image reference
+func imageVersion(image string) string {
+	if i := strings.LastIndex(image, "@"); i >= 0 {
+		return image[i+1:]
+	}
+	// a colon before the last slash separates the port of the registry
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		return image[i+1:]
+	}
+	return ""
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	"k8c.io/kubermatic/v2/pkg/handler/test"
+	"k8c.io/kubermatic/v2/pkg/handler/test/hack"
+	"k8c.io/kubermatic/v2/pkg/resources"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestGetClusterComponentVersions(t *testing.T) {
+	t.Parallel()
+	clusterNamespace := test.GenDefaultCluster().Status.NamespaceName
+	testcases := []struct {
+		Name                   string
+		ExpectedResponse       string
+		HTTPStatus             int
+		ProjectToSync          string
+		ClusterToSync          string
+		ExistingAPIUser        *apiv1.User
+		ExistingKubeObjs       []runtime.Object
+		ExistingKubermaticObjs []runtime.Object
+	}{
+		{
+			Name:             "scenario 1: get the images of the control plane components",
+			ExpectedResponse: `[{"name":"apiserver","image":"k8s.gcr.io/kube-apiserver:v1.18.8","version":"v1.18.8"},{"name":"controller-manager","image":"k8s.gcr.io/kube-controller-manager:v1.18.8","version":"v1.18.8"},{"name":"scheduler","image":"k8s.gcr.io/kube-scheduler:v1.18.8","version":"v1.18.8"},{"name":"etcd","image":"gcr.io/etcd-development/etcd:v3.4.3","version":"v3.4.3"},{"name":"machine-controller","image":"registry.local:5000/kubermatic/machine-controller@sha256:abc123","version":"sha256:abc123"}]`,
+			HTTPStatus:       http.StatusOK,
+			ProjectToSync:    test.GenDefaultProject().Name,
+			ClusterToSync:    test.GenDefaultCluster().Name,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExistingKubeObjs: []runtime.Object{
+				genComponentDeployment(clusterNamespace, resources.ApiserverDeploymentName, "k8s.gcr.io/kube-apiserver:v1.18.8"),
+				genComponentDeployment(clusterNamespace, resources.ControllerManagerDeploymentName, "k8s.gcr.io/kube-controller-manager:v1.18.8"),
+				genComponentDeployment(clusterNamespace, resources.SchedulerDeploymentName, "k8s.gcr.io/kube-scheduler:v1.18.8"),
+				genComponentStatefulSet(clusterNamespace, resources.EtcdStatefulSetName, "gcr.io/etcd-development/etcd:v3.4.3"),
+				genComponentDeployment(clusterNamespace, resources.MachineControllerDeploymentName, "registry.local:5000/kubermatic/machine-controller@sha256:abc123"),
+			},
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(test.GenDefaultCluster()),
+		},
+		{
+			Name:             "scenario 2: components which were not deployed yet are omitted",
+			ExpectedResponse: `[{"name":"apiserver","image":"k8s.gcr.io/kube-apiserver:v1.18.8","version":"v1.18.8"}]`,
+			HTTPStatus:       http.StatusOK,
+			ProjectToSync:    test.GenDefaultProject().Name,
+			ClusterToSync:    test.GenDefaultCluster().Name,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExistingKubeObjs: []runtime.Object{
+				genComponentDeployment(clusterNamespace, resources.ApiserverDeploymentName, "k8s.gcr.io/kube-apiserver:v1.18.8"),
+			},
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(test.GenDefaultCluster()),
+		},
+		{
+			Name:             "scenario 3: the user John can not get the component versions of Bob's cluster",
+			ExpectedResponse: `{"error":{"code":403,"message":"forbidden: \"john@acme.com\" doesn't belong to the given project = my-first-project-ID"}}`,
+			HTTPStatus:       http.StatusForbidden,
+			ProjectToSync:    test.GenDefaultProject().Name,
+			ClusterToSync:    test.GenDefaultCluster().Name,
+			ExistingAPIUser:  test.GenAPIUser("John", "john@acme.com"),
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenDefaultCluster(),
+				genUser("John", "john@acme.com", false),
+			),
+		},
+		{
+			Name:             "scenario 4: the admin John can get the component versions of Bob's cluster",
+			ExpectedResponse: `[{"name":"apiserver","image":"k8s.gcr.io/kube-apiserver:v1.18.8","version":"v1.18.8"}]`,
+			HTTPStatus:       http.StatusOK,
+			ProjectToSync:    test.GenDefaultProject().Name,
+			ClusterToSync:    test.GenDefaultCluster().Name,
+			ExistingAPIUser:  test.GenAPIUser("John", "john@acme.com"),
+			ExistingKubeObjs: []runtime.Object{
+				genComponentDeployment(clusterNamespace, resources.ApiserverDeploymentName, "k8s.gcr.io/kube-apiserver:v1.18.8"),
+			},
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenDefaultCluster(),
+				genUser("John", "john@acme.com", true),
+			),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", fmt.Sprintf("/api/v2/projects/%s/clusters/%s/componentversions", tc.ProjectToSync, tc.ClusterToSync), nil)
+			res := httptest.NewRecorder()
+			ep, err := test.CreateTestEndpoint(*tc.ExistingAPIUser, tc.ExistingKubeObjs, tc.ExistingKubermaticObjs, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+
+			test.CompareWithResult(t, res, tc.ExpectedResponse)
+		})
+	}
+}
+
+func genComponentPodTemplate(name, image string) corev1.PodTemplateSpec {
+	return corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: name, Image: image},
+				{Name: "dns-resolver", Image: "coredns/coredns:1.3.1"},
+			},
+		},
+	}
+}
+
+func genComponentDeployment(namespace, name, image string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Template: genComponentPodTemplate(name, image),
+		},
+	}
+}
+
+func genComponentStatefulSet(namespace, name, image string) *appsv1.StatefulSet {
+	return &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Template: genComponentPodTemplate(name, image),
+		},
+	}
+}
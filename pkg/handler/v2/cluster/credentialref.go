@@ -0,0 +1,91 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/go-kit/kit/endpoint"
+
+	providerconfig "github.com/kubermatic/machine-controller/pkg/providerconfig/types"
+	apiv2 "k8c.io/kubermatic/v2/pkg/api/v2"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	handlercommon "k8c.io/kubermatic/v2/pkg/handler/common"
+	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/util/errors"
+)
+
+// GetCredentialReferenceEndpoint returns the name and namespace of the seed secret holding the cloud provider
+// credentials of the cluster. The secret content is never returned. Only admins are allowed to use this endpoint.
+func GetCredentialReferenceEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(GetClusterReq)
+
+		adminUserInfo, err := userInfoGetter(ctx, "")
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		if !adminUserInfo.IsAdmin {
+			return nil, errors.New(http.StatusForbidden, fmt.Sprintf("forbidden: \"%s\" doesn't have admin rights", adminUserInfo.Email))
+		}
+
+		cluster, err := handlercommon.GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, req.ProjectID, req.ClusterID, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		ref := credentialsReference(cluster.Spec.Cloud)
+		if ref == nil || ref.Name == "" {
+			return nil, errors.New(http.StatusNotFound, fmt.Sprintf("cluster %q does not reference a credential secret", cluster.Name))
+		}
+
+		return &apiv2.ClusterCredentialReference{
+			Name:      ref.Name,
+			Namespace: ref.Namespace,
+		}, nil
+	}
+}
+
+// credentialsReference returns the credentials reference of the cloud provider the cluster runs on
+func credentialsReference(cloud kubermaticv1.CloudSpec) *providerconfig.GlobalSecretKeySelector {
+	switch {
+	case cloud.AWS != nil:
+		return cloud.AWS.CredentialsReference
+	case cloud.Azure != nil:
+		return cloud.Azure.CredentialsReference
+	case cloud.Digitalocean != nil:
+		return cloud.Digitalocean.CredentialsReference
+	case cloud.GCP != nil:
+		return cloud.GCP.CredentialsReference
+	case cloud.Hetzner != nil:
+		return cloud.Hetzner.CredentialsReference
+	case cloud.Openstack != nil:
+		return cloud.Openstack.CredentialsReference
+	case cloud.Packet != nil:
+		return cloud.Packet.CredentialsReference
+	case cloud.Kubevirt != nil:
+		return cloud.Kubevirt.CredentialsReference
+	case cloud.VSphere != nil:
+		return cloud.VSphere.CredentialsReference
+	case cloud.Alibaba != nil:
+		return cloud.Alibaba.CredentialsReference
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	providerconfig "github.com/kubermatic/machine-controller/pkg/providerconfig/types"
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/handler/test"
+	"k8c.io/kubermatic/v2/pkg/handler/test/hack"
+	"k8c.io/kubermatic/v2/pkg/resources"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestGetClusterCredentialReference(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		Name                   string
+		HTTPStatus             int
+		ExpectedResponse       string
+		ProjectToSync          string
+		ClusterToSync          string
+		ExistingAPIUser        *apiv1.User
+		ExistingKubermaticObjs []runtime.Object
+	}{
+		{
+			Name:             "scenario 1: the admin John can get the credential secret of Bob's cluster",
+			HTTPStatus:       http.StatusOK,
+			ExpectedResponse: `{"name":"credential-hetzner-defClusterID","namespace":"kubermatic"}`,
+			ProjectToSync:    test.GenDefaultProject().Name,
+			ClusterToSync:    test.GenDefaultCluster().Name,
+			ExistingAPIUser:  test.GenAPIUser("John", "john@acme.com"),
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				genClusterWithCredentialReference(test.GenDefaultCluster()),
+				genUser("John", "john@acme.com", true),
+			),
+		},
+		{
+			Name:             "scenario 2: the project owner Bob can not get the credential secret of the cluster",
+			HTTPStatus:       http.StatusForbidden,
+			ExpectedResponse: `{"error":{"code":403,"message":"forbidden: \"bob@acme.com\" doesn't have admin rights"}}`,
+			ProjectToSync:    test.GenDefaultProject().Name,
+			ClusterToSync:    test.GenDefaultCluster().Name,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				genClusterWithCredentialReference(test.GenDefaultCluster()),
+			),
+		},
+		{
+			Name:             "scenario 3: a cluster without a credential secret",
+			HTTPStatus:       http.StatusNotFound,
+			ExpectedResponse: `{"error":{"code":404,"message":"cluster \"defClusterID\" does not reference a credential secret"}}`,
+			ProjectToSync:    test.GenDefaultProject().Name,
+			ClusterToSync:    test.GenDefaultCluster().Name,
+			ExistingAPIUser:  test.GenAPIUser("John", "john@acme.com"),
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenDefaultCluster(),
+				genUser("John", "john@acme.com", true),
+			),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", fmt.Sprintf("/api/v2/projects/%s/clusters/%s/credentialref", tc.ProjectToSync, tc.ClusterToSync), nil)
+			res := httptest.NewRecorder()
+			ep, err := test.CreateTestEndpoint(*tc.ExistingAPIUser, []runtime.Object{}, tc.ExistingKubermaticObjs, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+
+			test.CompareWithResult(t, res, tc.ExpectedResponse)
+		})
+	}
+}
+
+func genClusterWithCredentialReference(cluster *kubermaticv1.Cluster) *kubermaticv1.Cluster {
+	cluster.Spec.Cloud = kubermaticv1.CloudSpec{
+		DatacenterName: cluster.Spec.Cloud.DatacenterName,
+		Hetzner: &kubermaticv1.HetznerCloudSpec{
+			CredentialsReference: &providerconfig.GlobalSecretKeySelector{
+				ObjectReference: corev1.ObjectReference{
+					Name:      cluster.GetSecretName(),
+					Namespace: resources.KubermaticNamespace,
+				},
+			},
+		},
+	}
+	return cluster
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"sort"
+
+	"github.com/go-kit/kit/endpoint"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	apiv2 "k8c.io/kubermatic/v2/pkg/api/v2"
+	handlercommon "k8c.io/kubermatic/v2/pkg/handler/common"
+	"k8c.io/kubermatic/v2/pkg/handler/middleware"
+	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
+	"k8c.io/kubermatic/v2/pkg/provider"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// GetNodesDensityEndpoint returns the number of pods and the CPU and memory requested on each node of the cluster,
+// compared to what the node can run
+func GetNodesDensityEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(GetClusterReq)
+		clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
+
+		cluster, err := handlercommon.GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, req.ProjectID, req.ClusterID, &provider.ClusterGetOptions{CheckInitStatus: true})
+		if err != nil {
+			return nil, err
+		}
+
+		client, err := common.GetClusterClient(ctx, userInfoGetter, clusterProvider, cluster, req.ProjectID)
+		if err != nil {
+			return nil, clusterUnreachableToHTTPError(err)
+		}
+
+		nodes := &corev1.NodeList{}
+		if err := client.List(ctx, nodes); err != nil {
+			return nil, clusterUnreachableToHTTPError(err)
+		}
+		pods := &corev1.PodList{}
+		if err := client.List(ctx, pods); err != nil {
+			return nil, clusterUnreachableToHTTPError(err)
+		}
+
+		nodePods := map[string][]corev1.Pod{}
+		for _, pod := range pods.Items {
+			if pod.Spec.NodeName == "" || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+				continue
+			}
+			nodePods[pod.Spec.NodeName] = append(nodePods[pod.Spec.NodeName], pod)
+		}
+
+		result := make([]apiv2.NodeDensity, 0, len(nodes.Items))
+		for _, node := range nodes.Items {
+			result = append(result, nodeDensity(node, nodePods[node.Name]))
+		}
+		sort.Slice(result, func(i, j int) bool {
+			return result[i].Name < result[j].Name
+		})
+
+		return result, nil
+	}
+}
+
+func nodeDensity(node corev1.Node, pods []corev1.Pod) apiv2.NodeDensity {
+	cpu := resource.NewMilliQuantity(0, resource.DecimalSI)
+	memory := resource.NewQuantity(0, resource.BinarySI)
+	for _, pod := range pods {
+		requests := podRequests(pod)
+		cpu.Add(*requests.Cpu())
+		memory.Add(*requests.Memory())
+	}
+
+	return apiv2.NodeDensity{
+		Name:    node.Name,
+		Pods:    len(pods),
+		MaxPods: node.Status.Allocatable.Pods().Value(),
+		Requested: apiv1.NodeResources{
+			CPU:    cpu.String(),
+			Memory: memory.String(),
+		},
+		Allocatable: apiv1.NodeResources{
+			CPU:    node.Status.Allocatable.Cpu().String(),
+			Memory: node.Status.Allocatable.Memory().String(),
+		},
+	}
+}
+
+// podRequests returns the resources requested by the pod the way the scheduler computes them: the sum of
+// the requests of the containers, or the largest request of an init container if it is higher
+func podRequests(pod corev1.Pod) corev1.ResourceList {
+	requests := corev1.ResourceList{}
+	for _, container := range pod.Spec.Containers {
+		for name, quantity := range container.Resources.Requests {
+			if total, ok := requests[name]; ok {
+				total.Add(quantity)
+				requests[name] = total
+			} else {
+				requests[name] = quantity.DeepCopy()
+			}
+		}
+	}
+	for _, container := range pod.Spec.InitContainers {
+		for name, quantity := range container.Resources.Requests {
+			if total, ok := requests[name]; !ok || quantity.Cmp(total) > 0 {
+				requests[name] = quantity.DeepCopy()
+			}
+		}
+	}
+	return requests
+}
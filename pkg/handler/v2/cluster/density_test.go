@@ -0,0 +1,179 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/handler/test"
+	"k8c.io/kubermatic/v2/pkg/handler/test/hack"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestGetClusterNodesDensity(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		Name                   string
+		ExpectedResponse       string
+		HTTPStatus             int
+		ProjectToSync          string
+		ClusterToSync          string
+		ExistingAPIUser        *apiv1.User
+		ExistingKubeObjs       []runtime.Object
+		ExistingKubermaticObjs []runtime.Object
+	}{
+		{
+			Name:             "scenario 1: get the pod counts and the requested resources of the nodes",
+			ExpectedResponse: `[{"name":"node-a","pods":2,"maxPods":110,"requested":{"cpu":"750m","memory":"384Mi"},"allocatable":{"cpu":"2","memory":"4Gi"}},{"name":"node-b","pods":1,"maxPods":4,"requested":{"cpu":"1","memory":"512Mi"},"allocatable":{"cpu":"4","memory":"8Gi"}}]`,
+			HTTPStatus:       http.StatusOK,
+			ProjectToSync:    test.GenDefaultProject().Name,
+			ClusterToSync:    test.GenDefaultCluster().Name,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExistingKubeObjs: []runtime.Object{
+				genDensityNode("node-a", "2", "4Gi", "110"),
+				genDensityNode("node-b", "4", "8Gi", "4"),
+				genDensityPod("web-1", "node-a", corev1.PodRunning, "500m", "128Mi", ""),
+				genDensityPod("web-2", "node-a", corev1.PodRunning, "250m", "256Mi", ""),
+				// the init container requests more than the containers of the pod
+				genDensityPod("db-0", "node-b", corev1.PodRunning, "500m", "512Mi", "1"),
+				// finished and unscheduled pods are not counted
+				genDensityPod("job-1", "node-b", corev1.PodSucceeded, "2", "1Gi", ""),
+				genDensityPod("pending-1", "", corev1.PodPending, "2", "1Gi", ""),
+			},
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(test.GenDefaultCluster()),
+		},
+		{
+			Name:             "scenario 2: a node without pods",
+			ExpectedResponse: `[{"name":"node-a","pods":0,"maxPods":110,"requested":{"cpu":"0","memory":"0"},"allocatable":{"cpu":"2","memory":"4Gi"}}]`,
+			HTTPStatus:       http.StatusOK,
+			ProjectToSync:    test.GenDefaultProject().Name,
+			ClusterToSync:    test.GenDefaultCluster().Name,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExistingKubeObjs: []runtime.Object{
+				genDensityNode("node-a", "2", "4Gi", "110"),
+			},
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(test.GenDefaultCluster()),
+		},
+		{
+			Name:             "scenario 3: the nodes can not be inspected before the cluster is ready",
+			ExpectedResponse: `{"error":{"code":503,"message":"Cluster components are not ready yet"}}`,
+			HTTPStatus:       http.StatusServiceUnavailable,
+			ProjectToSync:    test.GenDefaultProject().Name,
+			ClusterToSync:    test.GenDefaultCluster().Name,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenCluster(test.DefaultClusterID, test.DefaultClusterName, test.GenDefaultProject().Name, test.GenDefaultCluster().CreationTimestamp.Time, func(cluster *kubermaticv1.Cluster) {
+					cluster.Status.ExtendedHealth.Apiserver = kubermaticv1.HealthStatusDown
+				}),
+			),
+		},
+		{
+			Name:             "scenario 4: the user John can not inspect the nodes of Bob's cluster",
+			ExpectedResponse: `{"error":{"code":403,"message":"forbidden: \"john@acme.com\" doesn't belong to the given project = my-first-project-ID"}}`,
+			HTTPStatus:       http.StatusForbidden,
+			ProjectToSync:    test.GenDefaultProject().Name,
+			ClusterToSync:    test.GenDefaultCluster().Name,
+			ExistingAPIUser:  test.GenAPIUser("John", "john@acme.com"),
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenDefaultCluster(),
+				genUser("John", "john@acme.com", false),
+			),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", fmt.Sprintf("/api/v2/projects/%s/clusters/%s/nodes/density", tc.ProjectToSync, tc.ClusterToSync), nil)
+			res := httptest.NewRecorder()
+			ep, err := test.CreateTestEndpoint(*tc.ExistingAPIUser, tc.ExistingKubeObjs, tc.ExistingKubermaticObjs, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+
+			test.CompareWithResult(t, res, tc.ExpectedResponse)
+		})
+	}
+}
+
+func genDensityNode(name, cpu, memory, pods string) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse(cpu),
+				corev1.ResourceMemory: resource.MustParse(memory),
+				corev1.ResourcePods:   resource.MustParse(pods),
+			},
+		},
+	}
+}
+
+func genDensityPod(name, nodeName string, phase corev1.PodPhase, cpu, memory, initCPU string) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: metav1.NamespaceDefault,
+		},
+		Spec: corev1.PodSpec{
+			NodeName: nodeName,
+			Containers: []corev1.Container{
+				{
+					Name: "main",
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse(cpu),
+							corev1.ResourceMemory: resource.MustParse(memory),
+						},
+					},
+				},
+			},
+		},
+		Status: corev1.PodStatus{
+			Phase: phase,
+		},
+	}
+	if initCPU != "" {
+		pod.Spec.InitContainers = []corev1.Container{
+			{
+				Name: "init",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse(initCPU),
+					},
+				},
+			},
+		}
+	}
+	return pod
+}
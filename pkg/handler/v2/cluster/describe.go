@@ -0,0 +1,127 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"sort"
+
+	"github.com/go-kit/kit/endpoint"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	apiv2 "k8c.io/kubermatic/v2/pkg/api/v2"
+	handlercommon "k8c.io/kubermatic/v2/pkg/handler/common"
+	"k8c.io/kubermatic/v2/pkg/handler/middleware"
+	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
+	"k8c.io/kubermatic/v2/pkg/provider"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
+)
+
+// maxDescribeEvents is the maximum number of events returned by the describe endpoint
+const maxDescribeEvents = 10
+
+// DescribeEndpoint returns the cluster together with its health, node summary and recent events.
+// Only a failure to get the cluster itself fails the request, the other sections are left empty
+// and their errors are reported in the response.
+func DescribeEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(GetClusterReq)
+
+		cluster, err := handlercommon.GetEndpoint(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, req.ProjectID, req.ClusterID)
+		if err != nil {
+			return nil, err
+		}
+
+		description := &apiv2.ClusterDescription{
+			Cluster: cluster.(*apiv1.Cluster),
+		}
+		addError := func(section string, err error) {
+			if description.Errors == nil {
+				description.Errors = map[string]string{}
+			}
+			description.Errors[section] = err.Error()
+		}
+
+		health, err := handlercommon.HealthEndpoint(ctx, userInfoGetter, req.ProjectID, req.ClusterID, true, projectProvider, privilegedProjectProvider)
+		if err != nil {
+			addError("health", err)
+		} else {
+			clusterHealth := health.(apiv1.ClusterHealth)
+			description.Health = &clusterHealth
+		}
+
+		events, err := handlercommon.GetClusterEventsEndpoint(ctx, userInfoGetter, req.ProjectID, req.ClusterID, "", "", fields.Everything(), projectProvider, privilegedProjectProvider)
+		if err != nil {
+			addError("events", err)
+		} else {
+			description.Events = getRecentEvents(events.([]apiv1.Event))
+		}
+
+		description.Nodes, err = getNodeSummary(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, req.ProjectID, req.ClusterID)
+		if err != nil {
+			addError("nodes", err)
+		}
+
+		return description, nil
+	}
+}
+
+// getRecentEvents returns the most recent events, newest first
+func getRecentEvents(events []apiv1.Event) []apiv1.Event {
+	recentEvents := make([]apiv1.Event, len(events))
+	copy(recentEvents, events)
+	sort.SliceStable(recentEvents, func(i, j int) bool {
+		return recentEvents[j].LastTimestamp.Before(recentEvents[i].LastTimestamp)
+	})
+	if len(recentEvents) > maxDescribeEvents {
+		recentEvents = recentEvents[:maxDescribeEvents]
+	}
+	return recentEvents
+}
+
+// getNodeSummary counts the nodes of the cluster, it fails when the cluster is not reachable
+func getNodeSummary(ctx context.Context, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter, projectID, clusterID string) (*apiv2.ClusterNodeSummary, error) {
+	clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
+
+	cluster, err := handlercommon.GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, projectID, clusterID, &provider.ClusterGetOptions{CheckInitStatus: true})
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := common.GetClusterClient(ctx, userInfoGetter, clusterProvider, cluster, projectID)
+	if err != nil {
+		return nil, clusterUnreachableToHTTPError(err)
+	}
+
+	nodes := &corev1.NodeList{}
+	if err := client.List(ctx, nodes); err != nil {
+		return nil, clusterUnreachableToHTTPError(err)
+	}
+
+	summary := &apiv2.ClusterNodeSummary{Total: len(nodes.Items)}
+	for _, node := range nodes.Items {
+		for _, condition := range node.Status.Conditions {
+			if condition.Type == corev1.NodeReady && condition.Status == corev1.ConditionTrue {
+				summary.Ready++
+				break
+			}
+		}
+	}
+	return summary, nil
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/handler/test"
+	"k8c.io/kubermatic/v2/pkg/handler/test/hack"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestDescribeCluster(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		Name                   string
+		ExpectedResponse       string
+		HTTPStatus             int
+		ProjectToSync          string
+		ClusterToSync          string
+		ExistingAPIUser        *apiv1.User
+		ExistingKubeObjs       []runtime.Object
+		ExistingKubermaticObjs []runtime.Object
+	}{
+		{
+			Name:             "scenario 1: describe the cluster",
+			ExpectedResponse: `{"cluster":{"id":"defClusterID","name":"defClusterName","creationTimestamp":"2013-02-03T19:54:00Z","type":"kubernetes","spec":{"cloud":{"dc":"FakeDatacenter","fake":{}},"version":"9.9.9","oidc":{}},"status":{"version":"9.9.9","url":"https://w225mx4z66.asia-east1-a-1.cloud.kubermatic.io:31885"}},"health":{"apiserver":1,"scheduler":1,"controller":1,"machineController":1,"etcd":1,"cloudProviderInfrastructure":1,"userClusterControllerManager":1,"status":"Healthy","details":{"apiserver":{"reason":"the component is running","replicas":{"desired":1,"ready":0}},"cloudProviderInfrastructure":{"reason":"the component is running"},"controller":{"reason":"the component is running"},"etcd":{"reason":"the component is running"},"machineController":{"reason":"the component is running"},"scheduler":{"reason":"the component is running"},"userClusterControllerManager":{"reason":"the component is running"}}},"nodes":{"total":2,"ready":1},"events":[{"name":"event-2","creationTimestamp":"0001-01-01T00:00:00Z","message":"message killed","type":"Warning","involvedObject":{"type":"Cluster","namespace":"kube-system","name":"testMachine"},"lastTimestamp":"2013-02-03T20:54:00Z","count":1},{"name":"event-1","creationTimestamp":"0001-01-01T00:00:00Z","message":"message started","type":"Normal","involvedObject":{"type":"Cluster","namespace":"kube-system","name":"testMachine"},"lastTimestamp":"2013-02-03T19:54:00Z","count":1}]}`,
+			HTTPStatus:       http.StatusOK,
+			ProjectToSync:    test.GenDefaultProject().Name,
+			ClusterToSync:    test.GenDefaultCluster().Name,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExistingKubeObjs: []runtime.Object{
+				genReadyConditionNode("node-1", corev1.ConditionTrue),
+				genReadyConditionNode("node-2", corev1.ConditionFalse),
+				genEvent("event-1", corev1.EventTypeNormal, "Started", "message started", time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC)),
+				genEvent("event-2", corev1.EventTypeWarning, "Killed", "message killed", time.Date(2013, 02, 03, 20, 54, 0, 0, time.UTC)),
+			},
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(test.GenDefaultCluster()),
+		},
+		{
+			Name:             "scenario 2: the nodes of an unreachable cluster are reported as an error",
+			ExpectedResponse: `{"cluster":{"id":"defClusterID","name":"defClusterName","creationTimestamp":"2013-02-03T19:54:00Z","type":"kubernetes","spec":{"cloud":{"dc":"FakeDatacenter","fake":{}},"version":"9.9.9","oidc":{}},"status":{"version":"9.9.9","url":"https://w225mx4z66.asia-east1-a-1.cloud.kubermatic.io:31885"}},"health":{"apiserver":0,"scheduler":1,"controller":1,"machineController":1,"etcd":1,"cloudProviderInfrastructure":1,"userClusterControllerManager":1,"status":"Unhealthy","details":{"apiserver":{"reason":"the apiserver deployment has no ready replicas","replicas":{"desired":1,"ready":0}},"cloudProviderInfrastructure":{"reason":"the component is running"},"controller":{"reason":"the component is running"},"etcd":{"reason":"the component is running"},"machineController":{"reason":"the component is running"},"scheduler":{"reason":"the component is running"},"userClusterControllerManager":{"reason":"the component is running"}}},"nodes":null,"events":[],"errors":{"nodes":"Cluster components are not ready yet"}}`,
+			HTTPStatus:       http.StatusOK,
+			ProjectToSync:    test.GenDefaultProject().Name,
+			ClusterToSync:    test.GenDefaultCluster().Name,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenCluster(test.DefaultClusterID, test.DefaultClusterName, test.GenDefaultProject().Name, test.GenDefaultCluster().CreationTimestamp.Time, func(cluster *kubermaticv1.Cluster) {
+					cluster.Status.ExtendedHealth.Apiserver = kubermaticv1.HealthStatusDown
+				}),
+			),
+		},
+		{
+			Name:             "scenario 3: the user John can not describe Bob's cluster",
+			ExpectedResponse: `{"error":{"code":403,"message":"forbidden: \"john@acme.com\" doesn't belong to the given project = my-first-project-ID"}}`,
+			HTTPStatus:       http.StatusForbidden,
+			ProjectToSync:    test.GenDefaultProject().Name,
+			ClusterToSync:    test.GenDefaultCluster().Name,
+			ExistingAPIUser:  test.GenAPIUser("John", "john@acme.com"),
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenDefaultCluster(),
+				genUser("John", "john@acme.com", false),
+			),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", fmt.Sprintf("/api/v2/projects/%s/clusters/%s/describe", tc.ProjectToSync, tc.ClusterToSync), nil)
+			res := httptest.NewRecorder()
+			ep, err := test.CreateTestEndpoint(*tc.ExistingAPIUser, tc.ExistingKubeObjs, tc.ExistingKubermaticObjs, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+
+			test.CompareWithResult(t, res, tc.ExpectedResponse)
+		})
+	}
+}
+
+func genReadyConditionNode(name string, ready corev1.ConditionStatus) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{
+				{
+					Type:   corev1.NodeReady,
+					Status: ready,
+				},
+			},
+		},
+	}
+}
+
+func genEvent(name, eventType, reason, message string, lastTimestamp time.Time) *corev1.Event {
+	event := test.GenTestEvent(name, eventType, reason, message, "Cluster", "venus-1-machine")
+	event.LastTimestamp = metav1.NewTime(lastTimestamp)
+	return event
+}
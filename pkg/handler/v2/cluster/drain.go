@@ -0,0 +1,297 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+
+	apiv2 "k8c.io/kubermatic/v2/pkg/api/v2"
+	handlercommon "k8c.io/kubermatic/v2/pkg/handler/common"
+	"k8c.io/kubermatic/v2/pkg/handler/middleware"
+	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
+	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+	"k8c.io/kubermatic/v2/pkg/provider"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// nodeDrainInterval is the interval in which the eviction of the remaining pods is retried
+	nodeDrainInterval = 10 * time.Second
+	// nodeDrainTimeout is the time after which the eviction of the remaining pods is given up
+	nodeDrainTimeout = 30 * time.Minute
+)
+
+// nodeDrains are the nodes whose pods are being evicted, keyed by cluster and node name
+var nodeDrains = &drains{nodes: sets.NewString()}
+
+type drains struct {
+	lock  sync.Mutex
+	nodes sets.String
+}
+
+// start returns false if the node is already being drained
+func (d *drains) start(key string) bool {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if d.nodes.Has(key) {
+		return false
+	}
+	d.nodes.Insert(key)
+	return true
+}
+
+func (d *drains) done(key string) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.nodes.Delete(key)
+}
+
+// DrainNodeEndpoint cordons the node and starts evicting its pods in the background. The pods are evicted through
+// the eviction API, so the PodDisruptionBudgets are respected and the pods whose eviction is refused are retried
+// until the drain is completed. Draining a node again resumes the eviction of its remaining pods, e.g. after the
+// node was cordoned by the user or a previous drain timed out, unless its pods are still being evicted.
+func DrainNodeEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(NodeReq)
+		clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
+
+		cluster, err := handlercommon.GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, req.ProjectID, req.ClusterID, &provider.ClusterGetOptions{CheckInitStatus: true})
+		if err != nil {
+			return nil, err
+		}
+		client, err := common.GetClusterClient(ctx, userInfoGetter, clusterProvider, cluster, req.ProjectID)
+		if err != nil {
+			return nil, clusterUnreachableToHTTPError(err)
+		}
+
+		node := &corev1.Node{}
+		if err := client.Get(ctx, types.NamespacedName{Name: req.NodeID}, node); err != nil {
+			return nil, clusterUnreachableToHTTPError(err)
+		}
+
+		key := fmt.Sprintf("%s/%s", cluster.Name, node.Name)
+		status, err := drainNode(ctx, client, node, func() error {
+			// the pods of the node are still being evicted
+			if !nodeDrains.start(key) {
+				return nil
+			}
+			clientset, err := common.GetClusterClientset(ctx, userInfoGetter, clusterProvider, cluster, req.ProjectID)
+			if err != nil {
+				nodeDrains.done(key)
+				return err
+			}
+
+			nodeName := node.Name
+			go func() {
+				defer utilruntime.HandleCrash()
+				defer nodeDrains.done(key)
+				evictNodePods(client, clientset, nodeName)
+			}()
+			return nil
+		})
+		if err != nil {
+			return nil, clusterUnreachableToHTTPError(err)
+		}
+
+		return status, nil
+	}
+}
+
+// drainNode cordons the node unless it is cordoned already and calls startEviction as long as pods are left on the node
+func drainNode(ctx context.Context, client ctrlruntimeclient.Client, node *corev1.Node, startEviction func() error) (*apiv2.NodeDrainStatus, error) {
+	if !node.Spec.Unschedulable {
+		oldNode := node.DeepCopy()
+		node.Spec.Unschedulable = true
+		if err := client.Patch(ctx, node, ctrlruntimeclient.MergeFrom(oldNode)); err != nil {
+			return nil, err
+		}
+	}
+
+	status, err := nodeDrainStatus(ctx, client, node)
+	if err != nil {
+		return nil, err
+	}
+	if !status.Completed {
+		if err := startEviction(); err != nil {
+			return nil, err
+		}
+	}
+	return status, nil
+}
+
+// GetNodeDrainEndpoint returns the progress of draining the node
+func GetNodeDrainEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(NodeReq)
+		clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
+
+		cluster, err := handlercommon.GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, req.ProjectID, req.ClusterID, &provider.ClusterGetOptions{CheckInitStatus: true})
+		if err != nil {
+			return nil, err
+		}
+		client, err := common.GetClusterClient(ctx, userInfoGetter, clusterProvider, cluster, req.ProjectID)
+		if err != nil {
+			return nil, clusterUnreachableToHTTPError(err)
+		}
+
+		node := &corev1.Node{}
+		if err := client.Get(ctx, types.NamespacedName{Name: req.NodeID}, node); err != nil {
+			return nil, clusterUnreachableToHTTPError(err)
+		}
+
+		status, err := nodeDrainStatus(ctx, client, node)
+		if err != nil {
+			return nil, clusterUnreachableToHTTPError(err)
+		}
+		return status, nil
+	}
+}
+
+// nodeDrainStatus returns the drain progress of the node
+func nodeDrainStatus(ctx context.Context, client ctrlruntimeclient.Client, node *corev1.Node) (*apiv2.NodeDrainStatus, error) {
+	pods, err := drainablePods(ctx, client, node.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &apiv2.NodeDrainStatus{
+		Cordoned:      node.Spec.Unschedulable,
+		RemainingPods: make([]string, 0, len(pods)),
+	}
+	for _, pod := range pods {
+		status.RemainingPods = append(status.RemainingPods, fmt.Sprintf("%s/%s", pod.Namespace, pod.Name))
+	}
+	status.Completed = status.Cordoned && len(pods) == 0
+	return status, nil
+}
+
+// drainablePods returns the pods running on the node which have to be evicted. Like kubectl drain,
+// DaemonSet pods and mirror pods are ignored, as they would be recreated on the node right away.
+func drainablePods(ctx context.Context, client ctrlruntimeclient.Client, nodeName string) ([]corev1.Pod, error) {
+	pods := &corev1.PodList{}
+	if err := client.List(ctx, pods, ctrlruntimeclient.MatchingFields{"spec.nodeName": nodeName}); err != nil {
+		return nil, err
+	}
+
+	var result []corev1.Pod
+	for _, pod := range pods.Items {
+		if pod.Spec.NodeName != nodeName {
+			continue
+		}
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		if _, ok := pod.Annotations[corev1.MirrorPodAnnotationKey]; ok {
+			continue
+		}
+		if owner := metav1.GetControllerOf(&pod); owner != nil && owner.Kind == "DaemonSet" {
+			continue
+		}
+		result = append(result, pod)
+	}
+	return result, nil
+}
+
+// evictNodePods retries evicting the pods of the node until all of them are gone or the timeout is reached
+func evictNodePods(client ctrlruntimeclient.Client, clientset kubernetes.Interface, nodeName string) {
+	log := kubermaticlog.Logger.With("node", nodeName)
+	ctx, cancel := context.WithTimeout(context.Background(), nodeDrainTimeout)
+	defer cancel()
+
+	err := wait.PollImmediateUntil(nodeDrainInterval, func() (bool, error) {
+		remaining, err := evictPods(ctx, client, clientset, nodeName)
+		if err != nil {
+			log.Debugw("Failed to evict the pods of the node", zap.Error(err))
+			return false, nil
+		}
+		return remaining == 0, nil
+	}, ctx.Done())
+	if err != nil {
+		log.Infow("Gave up draining the node", zap.Error(err))
+	}
+}
+
+// evictPods evicts the pods of the node and returns the number of pods which could not be evicted yet.
+// The eviction of a pod is refused with 429 as long as one of its PodDisruptionBudgets does not allow a disruption.
+func evictPods(ctx context.Context, client ctrlruntimeclient.Client, clientset kubernetes.Interface, nodeName string) (int, error) {
+	pods, err := drainablePods(ctx, client, nodeName)
+	if err != nil {
+		return 0, err
+	}
+
+	remaining := 0
+	for _, pod := range pods {
+		eviction := &policyv1beta1.Eviction{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: pod.Namespace,
+				Name:      pod.Name,
+			},
+		}
+		err := clientset.PolicyV1beta1().Evictions(pod.Namespace).Evict(ctx, eviction)
+		switch {
+		case err == nil, kerrors.IsNotFound(err):
+		case kerrors.IsTooManyRequests(err):
+			remaining++
+		default:
+			return 0, err
+		}
+	}
+	return remaining, nil
+}
+
+// NodeReq defines HTTP request for the node drain endpoints
+// swagger:parameters drainClusterNodeV2 getClusterNodeDrainV2
+type NodeReq struct {
+	GetClusterReq
+	// in: path
+	// required: true
+	NodeID string `json:"node_id"`
+}
+
+func DecodeNodeReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req NodeReq
+
+	clusterReq, err := DecodeGetClusterReq(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.GetClusterReq = clusterReq.(GetClusterReq)
+	req.NodeID = mux.Vars(r)["node_id"]
+	if req.NodeID == "" {
+		return nil, fmt.Errorf("'node_id' parameter is required but was not provided")
+	}
+
+	return req, nil
+}
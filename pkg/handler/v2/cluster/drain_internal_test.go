@@ -0,0 +1,143 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestEvictPods(t *testing.T) {
+	pods := []runtime.Object{
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: metav1.NamespaceDefault},
+			Spec:       corev1.PodSpec{NodeName: "worker-1"},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "db-1", Namespace: metav1.NamespaceDefault},
+			Spec:       corev1.PodSpec{NodeName: "worker-1"},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "web-2", Namespace: metav1.NamespaceDefault},
+			Spec:       corev1.PodSpec{NodeName: "worker-2"},
+		},
+	}
+
+	evicted := sets.NewString()
+	clientset := fake.NewSimpleClientset()
+	clientset.PrependReactor("create", "pods", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "eviction" {
+			return false, nil, nil
+		}
+		eviction := action.(clienttesting.CreateAction).GetObject().(*policyv1beta1.Eviction)
+		// the PodDisruptionBudget of the database does not allow a disruption
+		if eviction.Name == "db-1" {
+			return true, nil, kerrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 0)
+		}
+		evicted.Insert(eviction.Name)
+		return true, nil, nil
+	})
+
+	remaining, err := evictPods(context.Background(), fakectrlruntimeclient.NewFakeClient(pods...), clientset, "worker-1")
+	if err != nil {
+		t.Fatalf("failed to evict the pods: %v", err)
+	}
+	if remaining != 1 {
+		t.Errorf("expected 1 remaining pod, got %d", remaining)
+	}
+	if !evicted.Equal(sets.NewString("web-1")) {
+		t.Errorf("expected only web-1 to be evicted, got %v", evicted.List())
+	}
+}
+
+func TestDrainNode(t *testing.T) {
+	genNode := func(cordoned bool) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "worker-1"},
+			Spec:       corev1.NodeSpec{Unschedulable: cordoned},
+		}
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: metav1.NamespaceDefault},
+		Spec:       corev1.PodSpec{NodeName: "worker-1"},
+	}
+
+	testcases := []struct {
+		name             string
+		node             *corev1.Node
+		pods             []runtime.Object
+		expectedEviction bool
+	}{
+		{
+			name:             "scenario 1: the node is cordoned and its pods are evicted",
+			node:             genNode(false),
+			pods:             []runtime.Object{pod.DeepCopy()},
+			expectedEviction: true,
+		},
+		{
+			name:             "scenario 2: the pods of a node which is already cordoned are evicted",
+			node:             genNode(true),
+			pods:             []runtime.Object{pod.DeepCopy()},
+			expectedEviction: true,
+		},
+		{
+			name:             "scenario 3: no eviction is started for a node without pods",
+			node:             genNode(true),
+			expectedEviction: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := fakectrlruntimeclient.NewFakeClient(append(tc.pods, tc.node.DeepCopy())...)
+
+			evictionStarted := false
+			status, err := drainNode(context.Background(), client, tc.node, func() error {
+				evictionStarted = true
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("failed to drain the node: %v", err)
+			}
+			if evictionStarted != tc.expectedEviction {
+				t.Errorf("expected the eviction to be started: %v, got %v", tc.expectedEviction, evictionStarted)
+			}
+			if status.Completed == tc.expectedEviction {
+				t.Errorf("expected the drain to be completed: %v, got %v", !tc.expectedEviction, status.Completed)
+			}
+
+			node := &corev1.Node{}
+			if err := client.Get(context.Background(), types.NamespacedName{Name: "worker-1"}, node); err != nil {
+				t.Fatalf("failed to get the node: %v", err)
+			}
+			if !node.Spec.Unschedulable {
+				t.Error("expected the node to be cordoned")
+			}
+		})
+	}
+}
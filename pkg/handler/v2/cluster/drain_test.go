@@ -0,0 +1,186 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	"k8c.io/kubermatic/v2/pkg/handler/test"
+	"k8c.io/kubermatic/v2/pkg/handler/test/hack"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestDrainNode(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		Name                   string
+		Method                 string
+		NodeToDrain            string
+		ExpectedResponse       string
+		HTTPStatus             int
+		ExistingAPIUser        *apiv1.User
+		ExistingKubeObjs       []runtime.Object
+		ExistingKubermaticObjs []runtime.Object
+	}{
+		{
+			Name:             "scenario 1: draining a node cordons it and returns the pods which are evicted",
+			Method:           http.MethodPost,
+			NodeToDrain:      "worker-1",
+			ExpectedResponse: `{"cordoned":true,"remainingPods":["default/web-1"],"completed":false}`,
+			HTTPStatus:       http.StatusAccepted,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExistingKubeObjs: []runtime.Object{
+				genNode("worker-1", false),
+				genNode("worker-2", false),
+				genNodePod("web-1", "worker-1", corev1.PodRunning, ""),
+				genNodePod("web-2", "worker-2", corev1.PodRunning, ""),
+				genNodePod("job-1", "worker-1", corev1.PodSucceeded, ""),
+				genNodePod("node-exporter-1", "worker-1", corev1.PodRunning, "DaemonSet"),
+			},
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(test.GenDefaultCluster()),
+		},
+		{
+			Name:             "scenario 2: draining a node without evictable pods completes right away",
+			Method:           http.MethodPost,
+			NodeToDrain:      "worker-1",
+			ExpectedResponse: `{"cordoned":true,"remainingPods":[],"completed":true}`,
+			HTTPStatus:       http.StatusAccepted,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExistingKubeObjs: []runtime.Object{
+				genNode("worker-1", false),
+				genNodePod("node-exporter-1", "worker-1", corev1.PodRunning, "DaemonSet"),
+			},
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(test.GenDefaultCluster()),
+		},
+		{
+			Name:                   "scenario 3: draining a node which does not exist returns 404",
+			Method:                 http.MethodPost,
+			NodeToDrain:            "worker-3",
+			ExpectedResponse:       `{"error":{"code":404,"message":"nodes \"worker-3\" not found"}}`,
+			HTTPStatus:             http.StatusNotFound,
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+			ExistingKubeObjs:       []runtime.Object{genNode("worker-1", false)},
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(test.GenDefaultCluster()),
+		},
+		{
+			Name:             "scenario 4: get the drain progress of a cordoned node",
+			Method:           http.MethodGet,
+			NodeToDrain:      "worker-1",
+			ExpectedResponse: `{"cordoned":true,"remainingPods":["default/web-1"],"completed":false}`,
+			HTTPStatus:       http.StatusOK,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExistingKubeObjs: []runtime.Object{
+				genNode("worker-1", true),
+				genNodePod("web-1", "worker-1", corev1.PodRunning, ""),
+			},
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(test.GenDefaultCluster()),
+		},
+		{
+			Name:                   "scenario 5: a node which is not cordoned is not being drained",
+			Method:                 http.MethodGet,
+			NodeToDrain:            "worker-1",
+			ExpectedResponse:       `{"cordoned":false,"remainingPods":[],"completed":false}`,
+			HTTPStatus:             http.StatusOK,
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+			ExistingKubeObjs:       []runtime.Object{genNode("worker-1", false)},
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(test.GenDefaultCluster()),
+		},
+		{
+			Name:             "scenario 7: draining a node which is already cordoned returns its progress",
+			Method:           http.MethodPost,
+			NodeToDrain:      "worker-1",
+			ExpectedResponse: `{"cordoned":true,"remainingPods":["default/web-1"],"completed":false}`,
+			HTTPStatus:       http.StatusAccepted,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExistingKubeObjs: []runtime.Object{
+				genNode("worker-1", true),
+				genNodePod("web-1", "worker-1", corev1.PodRunning, ""),
+			},
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(test.GenDefaultCluster()),
+		},
+		{
+			Name:             "scenario 6: the user John can not drain the nodes of Bob's cluster",
+			Method:           http.MethodPost,
+			NodeToDrain:      "worker-1",
+			ExpectedResponse: `{"error":{"code":403,"message":"forbidden: \"john@acme.com\" doesn't belong to the given project = my-first-project-ID"}}`,
+			HTTPStatus:       http.StatusForbidden,
+			ExistingAPIUser:  test.GenAPIUser("John", "john@acme.com"),
+			ExistingKubeObjs: []runtime.Object{genNode("worker-1", false)},
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenDefaultCluster(),
+				genUser("John", "john@acme.com", false),
+			),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.Method, fmt.Sprintf("/api/v2/projects/%s/clusters/%s/nodes/%s/drain", test.GenDefaultProject().Name, test.GenDefaultCluster().Name, tc.NodeToDrain), nil)
+			res := httptest.NewRecorder()
+			ep, err := test.CreateTestEndpoint(*tc.ExistingAPIUser, tc.ExistingKubeObjs, tc.ExistingKubermaticObjs, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+
+			test.CompareWithResult(t, res, tc.ExpectedResponse)
+		})
+	}
+}
+
+func genNode(name string, unschedulable bool) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: corev1.NodeSpec{
+			Unschedulable: unschedulable,
+		},
+	}
+}
+
+func genNodePod(name, nodeName string, phase corev1.PodPhase, ownerKind string) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: metav1.NamespaceDefault,
+		},
+		Spec: corev1.PodSpec{
+			NodeName: nodeName,
+		},
+		Status: corev1.PodStatus{
+			Phase: phase,
+		},
+	}
+	if ownerKind != "" {
+		controller := true
+		pod.OwnerReferences = []metav1.OwnerReference{{Kind: ownerKind, Name: name, Controller: &controller}}
+	}
+	return pod
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/go-kit/kit/endpoint"
+
+	apiv2 "k8c.io/kubermatic/v2/pkg/api/v2"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1/helper"
+	handlercommon "k8c.io/kubermatic/v2/pkg/handler/common"
+	"k8c.io/kubermatic/v2/pkg/handler/middleware"
+	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
+	"k8c.io/kubermatic/v2/pkg/provider"
+
+	corev1 "k8s.io/api/core/v1"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	driftResourceApiserver = "apiserver"
+	driftResourceEtcd      = "etcd"
+	driftResourceAddon     = "addon"
+)
+
+// GetDriftEndpoint returns the parts of the cluster which are not in sync with its spec yet. The report is built
+// from the conditions and the health the controllers recorded in the status of the cluster and its addons.
+func GetDriftEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(GetClusterReq)
+		privilegedClusterProvider := ctx.Value(middleware.PrivilegedClusterProviderContextKey).(provider.PrivilegedClusterProvider)
+
+		cluster, err := handlercommon.GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, req.ProjectID, req.ClusterID, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		seedClient := privilegedClusterProvider.GetSeedClusterAdminRuntimeClient()
+		addons := &kubermaticv1.AddonList{}
+		if err := seedClient.List(ctx, addons, ctrlruntimeclient.InNamespace(cluster.Status.NamespaceName)); err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		return clusterDrift(cluster, addons.Items), nil
+	}
+}
+
+func clusterDrift(cluster *kubermaticv1.Cluster, addons []kubermaticv1.Addon) apiv2.ClusterDrift {
+	var drifts []apiv2.ResourceDrift
+
+	controllerCondition := kubermaticv1.ClusterConditionClusterControllerReconcilingSuccess
+	if cluster.IsOpenshift() {
+		controllerCondition = kubermaticv1.ClusterConditionOpenshiftControllerReconcilingSuccess
+	}
+	if reason, failed := failedConditionReason(cluster, controllerCondition, "the control plane was not reconciled yet"); failed {
+		drifts = append(drifts, apiv2.ResourceDrift{Resource: driftResourceApiserver, Reason: reason})
+	} else if reason, failed := failedConditionReason(cluster, kubermaticv1.ClusterConditionSeedResourcesUpToDate, "the control plane is still being rolled out"); failed {
+		drifts = append(drifts, apiv2.ResourceDrift{Resource: driftResourceApiserver, Reason: reason})
+	} else if cluster.Status.ExtendedHealth.Apiserver != kubermaticv1.HealthStatusUp {
+		drifts = append(drifts, apiv2.ResourceDrift{Resource: driftResourceApiserver, Reason: "the apiserver deployment has no ready replicas"})
+	}
+
+	if cluster.Status.ExtendedHealth.Etcd != kubermaticv1.HealthStatusUp {
+		drifts = append(drifts, apiv2.ResourceDrift{Resource: driftResourceEtcd, Reason: "the etcd statefulset has less than 2 ready members"})
+	} else if reason, failed := failedConditionReason(cluster, kubermaticv1.ClusterConditionEtcdClusterInitialized, "the etcd cluster is not initialized yet"); failed {
+		drifts = append(drifts, apiv2.ResourceDrift{Resource: driftResourceEtcd, Reason: reason})
+	}
+
+	if reason, failed := failedConditionReason(cluster, kubermaticv1.ClusterConditionAddonControllerReconcilingSuccess, "the addons were not reconciled yet"); failed {
+		drifts = append(drifts, apiv2.ResourceDrift{Resource: driftResourceAddon, Reason: reason})
+	}
+	sort.Slice(addons, func(i, j int) bool {
+		return addons[i].Name < addons[j].Name
+	})
+	for _, addon := range addons {
+		if addon.DeletionTimestamp != nil {
+			drifts = append(drifts, apiv2.ResourceDrift{Resource: driftResourceAddon, Name: addon.Name, Reason: "the addon is being removed"})
+			continue
+		}
+		if !addonResourcesCreated(addon) {
+			drifts = append(drifts, apiv2.ResourceDrift{Resource: driftResourceAddon, Name: addon.Name, Reason: "the addon resources were not created yet"})
+		}
+	}
+
+	return apiv2.ClusterDrift{
+		InSync:    len(drifts) == 0,
+		OutOfSync: drifts,
+	}
+}
+
+// failedConditionReason returns the reason extended by the message of the condition when the condition is False.
+// Conditions which were not set yet are not considered failed.
+func failedConditionReason(cluster *kubermaticv1.Cluster, conditionType kubermaticv1.ClusterConditionType, reason string) (string, bool) {
+	_, condition := kubermaticv1helper.GetClusterCondition(cluster, conditionType)
+	if condition == nil || condition.Status != corev1.ConditionFalse {
+		return "", false
+	}
+	if condition.Message != "" {
+		reason = fmt.Sprintf("%s: %s", reason, condition.Message)
+	}
+	return reason, true
+}
+
+func addonResourcesCreated(addon kubermaticv1.Addon) bool {
+	for _, condition := range addon.Status.Conditions {
+		if condition.Type == kubermaticv1.AddonResourcesCreated {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/handler/test"
+	"k8c.io/kubermatic/v2/pkg/handler/test/hack"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestGetClusterDrift(t *testing.T) {
+	t.Parallel()
+	creationTime := time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC)
+	reconcilingCluster := test.GenCluster(test.DefaultClusterID, test.DefaultClusterName, test.GenDefaultProject().Name, creationTime, func(cluster *kubermaticv1.Cluster) {
+		cluster.Status.ExtendedHealth.Etcd = kubermaticv1.HealthStatusProvisioning
+		cluster.Status.Conditions = []kubermaticv1.ClusterCondition{
+			{
+				Type:    kubermaticv1.ClusterConditionClusterControllerReconcilingSuccess,
+				Status:  corev1.ConditionFalse,
+				Message: "failed to reconcile the apiserver deployment",
+			},
+			{
+				Type:   kubermaticv1.ClusterConditionAddonControllerReconcilingSuccess,
+				Status: corev1.ConditionTrue,
+			},
+		}
+	})
+
+	testcases := []struct {
+		Name                   string
+		ExpectedResponse       string
+		HTTPStatus             int
+		ProjectToSync          string
+		ClusterToSync          string
+		ExistingAPIUser        *apiv1.User
+		ExistingKubermaticObjs []runtime.Object
+	}{
+		{
+			Name:             "scenario 1: the cluster is in sync with its spec",
+			ExpectedResponse: `{"inSync":true}`,
+			HTTPStatus:       http.StatusOK,
+			ProjectToSync:    test.GenDefaultProject().Name,
+			ClusterToSync:    test.GenDefaultCluster().Name,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenDefaultCluster(),
+				genInstalledAddon("canal", test.GenDefaultCluster(), corev1.ConditionTrue),
+			),
+		},
+		{
+			Name:             "scenario 2: the out of sync resources are reported",
+			ExpectedResponse: `{"inSync":false,"outOfSync":[{"resource":"apiserver","reason":"the control plane was not reconciled yet: failed to reconcile the apiserver deployment"},{"resource":"etcd","reason":"the etcd statefulset has less than 2 ready members"},{"resource":"addon","name":"dns","reason":"the addon resources were not created yet"}]}`,
+			HTTPStatus:       http.StatusOK,
+			ProjectToSync:    test.GenDefaultProject().Name,
+			ClusterToSync:    test.GenDefaultCluster().Name,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				reconcilingCluster,
+				genInstalledAddon("canal", reconcilingCluster, corev1.ConditionTrue),
+				genInstalledAddon("dns", reconcilingCluster, corev1.ConditionFalse),
+			),
+		},
+		{
+			Name:             "scenario 3: the user John can not get the drift of Bob's cluster",
+			ExpectedResponse: `{"error":{"code":403,"message":"forbidden: \"john@acme.com\" doesn't belong to the given project = my-first-project-ID"}}`,
+			HTTPStatus:       http.StatusForbidden,
+			ProjectToSync:    test.GenDefaultProject().Name,
+			ClusterToSync:    test.GenDefaultCluster().Name,
+			ExistingAPIUser:  test.GenAPIUser("John", "john@acme.com"),
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenDefaultCluster(),
+				genUser("John", "john@acme.com", false),
+			),
+		},
+		{
+			Name:             "scenario 4: the admin John can get the drift of Bob's cluster",
+			ExpectedResponse: `{"inSync":true}`,
+			HTTPStatus:       http.StatusOK,
+			ProjectToSync:    test.GenDefaultProject().Name,
+			ClusterToSync:    test.GenDefaultCluster().Name,
+			ExistingAPIUser:  test.GenAPIUser("John", "john@acme.com"),
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenDefaultCluster(),
+				genUser("John", "john@acme.com", true),
+			),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", fmt.Sprintf("/api/v2/projects/%s/clusters/%s/drift", tc.ProjectToSync, tc.ClusterToSync), nil)
+			res := httptest.NewRecorder()
+			ep, err := test.CreateTestEndpoint(*tc.ExistingAPIUser, nil, tc.ExistingKubermaticObjs, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+
+			test.CompareWithResult(t, res, tc.ExpectedResponse)
+		})
+	}
+}
+
+func genInstalledAddon(name string, cluster *kubermaticv1.Cluster, resourcesCreated corev1.ConditionStatus) *kubermaticv1.Addon {
+	addon := test.GenTestAddon(name, nil, cluster, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC))
+	addon.Status.Conditions = []kubermaticv1.AddonCondition{
+		{
+			Type:   kubermaticv1.AddonResourcesCreated,
+			Status: resourcesCreated,
+		},
+	}
+	return addon
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	"k8c.io/kubermatic/v2/pkg/handler"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// eventsWatchInterval is the interval in which the cluster events are polled
+// while a client is watching them.
+var eventsWatchInterval = 2 * time.Second
+
+// eventStream is returned by the events endpoint when the client asked to
+// watch the events. It is turned into a Server-Sent Events response by
+// EncodeClusterEvents. The user is already authorized when the stream is
+// created, list only reads the events from the seed.
+type eventStream struct {
+	list func() ([]apiv1.Event, error)
+}
+
+// EncodeClusterEvents writes the cluster events either as a JSON list or, when the
+// events are watched, as a text/event-stream until the request is cancelled.
+func EncodeClusterEvents(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	stream, ok := response.(*eventStream)
+	if !ok {
+		return handler.EncodeJSON(ctx, w, response)
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flush(w)
+
+	// seen holds the events sent by the previous listing, an event which is
+	// no longer listed is forgotten so that the map does not grow
+	seen := sets.NewString()
+	for {
+		events, err := stream.list()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			// the response is already streaming, so the error can only be
+			// reported as an event
+			fmt.Fprintf(w, "event: error\ndata: %q\n\n", err.Error())
+			flush(w)
+			return nil
+		}
+
+		listed := sets.NewString()
+		for _, event := range events {
+			// an event is sent again when it was updated with a new occurrence
+			key := fmt.Sprintf("%s/%s/%d", event.ID, event.Name, event.Count)
+			listed.Insert(key)
+			if seen.Has(key) {
+				continue
+			}
+
+			data, err := json.Marshal(event)
+			if err != nil {
+				return err
+			}
+			if _, err := fmt.Fprintf(w, "data: %s\n\n", data); err != nil {
+				return err
+			}
+		}
+		seen = listed
+		flush(w)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(eventsWatchInterval):
+		}
+	}
+}
+
+func flush(w http.ResponseWriter) {
+	if flusher, ok := w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
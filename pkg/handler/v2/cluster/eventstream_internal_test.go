@@ -0,0 +1,63 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+)
+
+func TestEventStreamForgetsUnlistedEvents(t *testing.T) {
+	interval := eventsWatchInterval
+	eventsWatchInterval = time.Millisecond
+	defer func() { eventsWatchInterval = interval }()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	started := apiv1.Event{ObjectMeta: apiv1.ObjectMeta{ID: "event-1", Name: "event-1"}, Message: "started", Count: 1}
+	listings := [][]apiv1.Event{
+		{started},
+		{started},
+		{},
+		{started},
+	}
+	stream := &eventStream{list: func() ([]apiv1.Event, error) {
+		if len(listings) == 0 {
+			cancel()
+			return nil, ctx.Err()
+		}
+		events := listings[0]
+		listings = listings[1:]
+		return events, nil
+	}}
+
+	res := httptest.NewRecorder()
+	if err := EncodeClusterEvents(ctx, res, stream); err != nil {
+		t.Fatalf("failed to stream the events: %v", err)
+	}
+
+	// the event is sent once while it is listed, and again after it was no longer listed
+	if sent := strings.Count(res.Body.String(), `"message":"started"`); sent != 2 {
+		t.Fatalf("expected the event to be sent 2 times, got %d: %s", sent, res.Body.String())
+	}
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/go-kit/kit/endpoint"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	handlercommon "k8c.io/kubermatic/v2/pkg/handler/common"
+	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
+	"k8c.io/kubermatic/v2/pkg/provider"
+
+	"k8s.io/klog"
+	"sigs.k8s.io/yaml"
+)
+
+// ExportEndpoint returns the clusters of the project as a YAML manifest with one document per cluster.
+// Every document only holds the sanitized spec of a cluster and can be passed to the importClusterV2 endpoint.
+func ExportEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, seedsGetter provider.SeedsGetter, clusterProviderGetter provider.ClusterProviderGetter, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(ExportClustersReq)
+
+		seeds, err := seedsGetter()
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		manifest := &clustersManifest{projectID: req.ProjectID}
+		for _, seed := range seeds {
+			// if a Seed is bad, do not forward that error to the user, but only log
+			clusterProvider, err := clusterProviderGetter(seed)
+			if err != nil {
+				klog.Errorf("failed to create cluster provider for seed %s: %v", seed.Name, err)
+				continue
+			}
+			apiClusters, err := handlercommon.GetExternalClusters(ctx, userInfoGetter, clusterProvider, projectProvider, privilegedProjectProvider, req.ProjectID)
+			if err != nil {
+				return nil, common.KubernetesErrorToHTTPError(err)
+			}
+			for _, apiCluster := range apiClusters {
+				manifest.clusters = append(manifest.clusters, exportCluster(apiCluster))
+			}
+		}
+
+		sort.SliceStable(manifest.clusters, func(i, j int) bool {
+			return manifest.clusters[i].Name < manifest.clusters[j].Name
+		})
+		return manifest, nil
+	}
+}
+
+// exportedCluster is the representation of a cluster in the export manifest. Only the fields which are
+// needed to recreate the cluster are kept, the cloud spec is marshalled without credentials by the ClusterSpec.
+type exportedCluster struct {
+	Name   string            `json:"name"`
+	Labels map[string]string `json:"labels,omitempty"`
+	Type   string            `json:"type"`
+	Spec   apiv1.ClusterSpec `json:"spec"`
+}
+
+type clustersManifest struct {
+	projectID string
+	clusters  []*exportedCluster
+}
+
+func exportCluster(cluster *apiv1.Cluster) *exportedCluster {
+	exported := &exportedCluster{
+		Name:   cluster.Name,
+		Labels: cluster.Labels,
+		Type:   cluster.Type,
+		Spec:   cluster.Spec,
+	}
+
+	// the Alertmanager configuration can contain the credentials of the receivers
+	exported.Spec.AlertmanagerConfig = ""
+	// the referenced secret only exists in the seed cluster of the cluster
+	if exported.Spec.RegistryMirror != nil {
+		exported.Spec.RegistryMirror = &kubermaticv1.RegistryMirrorSettings{URL: exported.Spec.RegistryMirror.URL}
+	}
+
+	return exported
+}
+
+// EncodeClustersManifest writes the clusters of the manifest as YAML documents
+func EncodeClustersManifest(c context.Context, w http.ResponseWriter, response interface{}) error {
+	manifest := response.(*clustersManifest)
+
+	w.Header().Set("Content-Type", "application/yaml")
+	w.Header().Set("Content-disposition", fmt.Sprintf("attachment; filename=clusters-%s.yaml", manifest.projectID))
+	w.Header().Add("Cache-Control", "no-cache")
+
+	for i, cluster := range manifest.clusters {
+		b, err := yaml.Marshal(cluster)
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			b = append([]byte("---\n"), b...)
+		}
+		if _, err := w.Write(b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ExportClustersReq defines HTTP request for exportClustersV2 endpoint
+// swagger:parameters exportClustersV2
+type ExportClustersReq struct {
+	common.ProjectReq
+}
+
+func DecodeExportClustersReq(c context.Context, r *http.Request) (interface{}, error) {
+	pr, err := common.DecodeProjectRequest(c, r)
+	if err != nil {
+		return nil, err
+	}
+
+	return ExportClustersReq{ProjectReq: pr.(common.ProjectReq)}, nil
+}
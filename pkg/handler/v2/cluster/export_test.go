@@ -0,0 +1,121 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	providerconfig "github.com/kubermatic/machine-controller/pkg/providerconfig/types"
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/handler/test"
+	"k8c.io/kubermatic/v2/pkg/handler/test/hack"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestExportClusters(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		Name                   string
+		ExpectedResponse       string
+		HTTPStatus             int
+		ExistingKubermaticObjs []runtime.Object
+		ExistingAPIUser        *apiv1.User
+	}{
+		{
+			Name: "scenario 1: the clusters of the project are exported without credentials",
+			ExpectedResponse: `name: clusterAbc
+spec:
+  cloud:
+    dc: FakeDatacenter
+    fake: {}
+  oidc: {}
+  version: 9.9.9
+type: kubernetes
+---
+labels:
+  team: platform
+name: clusterDef
+spec:
+  cloud:
+    dc: FakeDatacenter
+    fake: {}
+  oidc: {}
+  registryMirror:
+    url: https://registry.example.com/mirror
+  version: 9.9.9
+type: kubernetes
+`,
+			HTTPStatus: http.StatusOK,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenCluster("clusterDefID", "clusterDef", test.GenDefaultProject().Name, time.Date(2013, 02, 04, 8, 0, 0, 0, time.UTC), func(cluster *kubermaticv1.Cluster) {
+					cluster.Labels["team"] = "platform"
+					cluster.Spec.AlertmanagerConfig = "receivers:\n- name: slack\n  slack_configs:\n  - api_url: https://hooks.slack.com/services/secret\n"
+					cluster.Spec.RegistryMirror = &kubermaticv1.RegistryMirrorSettings{
+						URL: "https://registry.example.com/mirror",
+						CredentialsReference: &providerconfig.GlobalSecretKeySelector{
+							ObjectReference: corev1.ObjectReference{Name: "registry-credentials", Namespace: "kubermatic"},
+						},
+					}
+				}),
+				test.GenCluster("clusterAbcID", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC)),
+			),
+			ExistingAPIUser: test.GenDefaultAPIUser(),
+		},
+		{
+			Name:                   "scenario 2: the regular user John can not export Bob's clusters",
+			ExpectedResponse:       `{"error":{"code":403,"message":"forbidden: \"john@acme.com\" doesn't belong to the given project = my-first-project-ID"}}`,
+			HTTPStatus:             http.StatusForbidden,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(test.GenDefaultCluster(), genUser("John", "john@acme.com", false)),
+			ExistingAPIUser:        test.GenAPIUser("John", "john@acme.com"),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", fmt.Sprintf("/api/v2/projects/%s/clusters/export", test.GenDefaultProject().Name), nil)
+			res := httptest.NewRecorder()
+			ep, err := test.CreateTestEndpoint(*tc.ExistingAPIUser, []runtime.Object{}, tc.ExistingKubermaticObjs, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+
+			if tc.HTTPStatus != http.StatusOK {
+				test.CompareWithResult(t, res, tc.ExpectedResponse)
+				return
+			}
+			if contentType := res.Header().Get("Content-Type"); contentType != "application/yaml" {
+				t.Fatalf("Expected content type application/yaml, got %q", contentType)
+			}
+			if res.Body.String() != tc.ExpectedResponse {
+				t.Fatalf("Expected manifest:\n%s\ngot:\n%s", tc.ExpectedResponse, res.Body.String())
+			}
+		})
+	}
+}
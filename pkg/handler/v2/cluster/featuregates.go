@@ -0,0 +1,130 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/go-kit/kit/endpoint"
+
+	httpproberapi "k8c.io/kubermatic/v2/cmd/http-prober/api"
+	apiv2 "k8c.io/kubermatic/v2/pkg/api/v2"
+	handlercommon "k8c.io/kubermatic/v2/pkg/handler/common"
+	"k8c.io/kubermatic/v2/pkg/handler/middleware"
+	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/resources"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+const featureGatesFlag = "--feature-gates"
+
+// kubeletFeatureGates are the feature gates the machine-controller sets in the kubelet configuration of every node.
+// They are part of the node userdata and can not be read from the seed cluster.
+var kubeletFeatureGates = map[string]bool{
+	"RotateKubeletServerCertificate": true,
+}
+
+// GetFeatureGatesEndpoint returns the feature gates set on the components of the cluster
+func GetFeatureGatesEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(GetClusterReq)
+		privilegedClusterProvider := ctx.Value(middleware.PrivilegedClusterProviderContextKey).(provider.PrivilegedClusterProvider)
+
+		cluster, err := handlercommon.GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, req.ProjectID, req.ClusterID, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		seedClient := privilegedClusterProvider.GetSeedClusterAdminRuntimeClient()
+		gates := apiv2.ClusterFeatureGates{Kubelet: kubeletFeatureGates}
+		for name, target := range map[string]*map[string]bool{
+			resources.ApiserverDeploymentName:         &gates.Apiserver,
+			resources.ControllerManagerDeploymentName: &gates.ControllerManager,
+			resources.SchedulerDeploymentName:         &gates.Scheduler,
+		} {
+			podSpec, err := componentPodSpec(ctx, seedClient, cluster.Status.NamespaceName, name, false)
+			if err != nil {
+				if kerrors.IsNotFound(err) {
+					continue
+				}
+				return nil, common.KubernetesErrorToHTTPError(err)
+			}
+			*target = parseFeatureGates(componentCommand(podSpec, name))
+		}
+
+		return gates, nil
+	}
+}
+
+// componentCommand returns the command and arguments of the container named after the component. Containers
+// wrapped by the http-prober have their original command serialized in the -command argument.
+func componentCommand(podSpec *corev1.PodSpec, name string) []string {
+	for _, container := range podSpec.Containers {
+		if container.Name != name {
+			continue
+		}
+		command := append(append([]string{}, container.Command...), container.Args...)
+		for i := 0; i < len(command)-1; i++ {
+			if command[i] != "-command" {
+				continue
+			}
+			wrapped := httpproberapi.Command{}
+			if err := json.Unmarshal([]byte(command[i+1]), &wrapped); err == nil {
+				return append([]string{wrapped.Command}, wrapped.Args...)
+			}
+		}
+		return command
+	}
+	return nil
+}
+
+// parseFeatureGates returns the feature gates of the last --feature-gates flag of the command. Malformed
+// entries are ignored as the component would not start with them anyway.
+func parseFeatureGates(command []string) map[string]bool {
+	var value string
+	for i, arg := range command {
+		switch {
+		case arg == featureGatesFlag && i+1 < len(command):
+			value = command[i+1]
+		case strings.HasPrefix(arg, featureGatesFlag+"="):
+			value = strings.TrimPrefix(arg, featureGatesFlag+"=")
+		}
+	}
+
+	gates := map[string]bool{}
+	for _, gate := range strings.Split(value, ",") {
+		parts := strings.SplitN(strings.TrimSpace(gate), "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			continue
+		}
+		enabled, err := strconv.ParseBool(strings.TrimSpace(parts[1]))
+		if err != nil {
+			continue
+		}
+		gates[parts[0]] = enabled
+	}
+	if len(gates) == 0 {
+		return nil
+	}
+	return gates
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	"k8c.io/kubermatic/v2/pkg/handler/test"
+	"k8c.io/kubermatic/v2/pkg/handler/test/hack"
+	"k8c.io/kubermatic/v2/pkg/resources"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestGetClusterFeatureGates(t *testing.T) {
+	t.Parallel()
+	clusterNamespace := test.GenDefaultCluster().Status.NamespaceName
+	testcases := []struct {
+		Name                   string
+		ExpectedResponse       string
+		HTTPStatus             int
+		ProjectToSync          string
+		ClusterToSync          string
+		ExistingAPIUser        *apiv1.User
+		ExistingKubeObjs       []runtime.Object
+		ExistingKubermaticObjs []runtime.Object
+	}{
+		{
+			Name:             "scenario 1: get the feature gates of the cluster components",
+			ExpectedResponse: `{"apiserver":{"EphemeralContainers":true},"controllerManager":{"RotateKubeletClientCertificate":true,"RotateKubeletServerCertificate":true},"scheduler":{"EvenPodsSpread":false},"kubelet":{"RotateKubeletServerCertificate":true}}`,
+			HTTPStatus:       http.StatusOK,
+			ProjectToSync:    test.GenDefaultProject().Name,
+			ClusterToSync:    test.GenDefaultCluster().Name,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExistingKubeObjs: []runtime.Object{
+				genFeatureGatesDeployment(clusterNamespace, resources.ApiserverDeploymentName, []string{"/usr/local/bin/kube-apiserver"}, []string{"--feature-gates=EphemeralContainers=true"}),
+				genFeatureGatesDeployment(clusterNamespace, resources.ControllerManagerDeploymentName, []string{"/http-prober-bin/http-prober"}, []string{
+					"-endpoint", "https://apiserver-external.cluster-defClusterID.svc.cluster.local./healthz",
+					"-command", `{"command":"/usr/local/bin/kube-controller-manager","args":["--kubeconfig","/etc/kubernetes/kubeconfig/kubeconfig","--feature-gates","RotateKubeletClientCertificate=true,RotateKubeletServerCertificate=true"]}`,
+				}),
+				genFeatureGatesDeployment(clusterNamespace, resources.SchedulerDeploymentName, []string{"/usr/local/bin/kube-scheduler"}, []string{"--feature-gates", "EvenPodsSpread=false"}),
+			},
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(test.GenDefaultCluster()),
+		},
+		{
+			Name:             "scenario 2: components without feature gates or which were not deployed yet are omitted",
+			ExpectedResponse: `{"kubelet":{"RotateKubeletServerCertificate":true}}`,
+			HTTPStatus:       http.StatusOK,
+			ProjectToSync:    test.GenDefaultProject().Name,
+			ClusterToSync:    test.GenDefaultCluster().Name,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExistingKubeObjs: []runtime.Object{
+				genFeatureGatesDeployment(clusterNamespace, resources.ApiserverDeploymentName, []string{"/usr/local/bin/kube-apiserver"}, []string{"--secure-port", "6443"}),
+			},
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(test.GenDefaultCluster()),
+		},
+		{
+			Name:             "scenario 3: the user John can not get the feature gates of Bob's cluster",
+			ExpectedResponse: `{"error":{"code":403,"message":"forbidden: \"john@acme.com\" doesn't belong to the given project = my-first-project-ID"}}`,
+			HTTPStatus:       http.StatusForbidden,
+			ProjectToSync:    test.GenDefaultProject().Name,
+			ClusterToSync:    test.GenDefaultCluster().Name,
+			ExistingAPIUser:  test.GenAPIUser("John", "john@acme.com"),
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenDefaultCluster(),
+				genUser("John", "john@acme.com", false),
+			),
+		},
+		{
+			Name:             "scenario 4: the admin John can get the feature gates of Bob's cluster",
+			ExpectedResponse: `{"kubelet":{"RotateKubeletServerCertificate":true}}`,
+			HTTPStatus:       http.StatusOK,
+			ProjectToSync:    test.GenDefaultProject().Name,
+			ClusterToSync:    test.GenDefaultCluster().Name,
+			ExistingAPIUser:  test.GenAPIUser("John", "john@acme.com"),
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenDefaultCluster(),
+				genUser("John", "john@acme.com", true),
+			),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", fmt.Sprintf("/api/v2/projects/%s/clusters/%s/featuregates", tc.ProjectToSync, tc.ClusterToSync), nil)
+			res := httptest.NewRecorder()
+			ep, err := test.CreateTestEndpoint(*tc.ExistingAPIUser, tc.ExistingKubeObjs, tc.ExistingKubermaticObjs, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+
+			test.CompareWithResult(t, res, tc.ExpectedResponse)
+		})
+	}
+}
+
+func genFeatureGatesDeployment(namespace, name string, command, args []string) *appsv1.Deployment {
+	deployment := genComponentDeployment(namespace, name, "k8s.gcr.io/"+name+":v1.18.8")
+	deployment.Spec.Template.Spec.Containers[0].Command = command
+	deployment.Spec.Template.Spec.Containers[0].Args = args
+	return deployment
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/prometheus/client_golang/prometheus"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/util/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// ImportEndpoint creates a cluster from the uploaded YAML, the cloud provider credentials are taken from the given preset.
+// The cluster goes through the same validation as a cluster created by the CreateEndpoint.
+func ImportEndpoint(sshKeyProvider provider.SSHKeyProvider, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, seedsGetter provider.SeedsGetter,
+	initNodeDeploymentFailures *prometheus.CounterVec, eventRecorderProvider provider.EventRecorderProvider, credentialManager provider.PresetProvider,
+	exposeStrategy corev1.ServiceType, userInfoGetter provider.UserInfoGetter, settingsProvider provider.SettingsProvider, updateManager common.UpdateManager, addonConfigProvider provider.AddonConfigProvider) endpoint.Endpoint {
+	createEndpoint := CreateEndpoint(sshKeyProvider, projectProvider, privilegedProjectProvider, seedsGetter, initNodeDeploymentFailures, eventRecorderProvider, credentialManager, exposeStrategy, userInfoGetter, settingsProvider, updateManager, addonConfigProvider)
+
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(ImportClusterReq)
+
+		cluster := req.Body
+		cluster.Credential = req.Preset
+		return createEndpoint(ctx, CreateClusterReq{
+			ProjectReq: req.ProjectReq,
+			Body:       apiv1.CreateClusterSpec{Cluster: cluster},
+			seedName:   req.seedName,
+		})
+	}
+}
+
+// ImportClusterReq defines HTTP request for importClusterV2 endpoint
+// swagger:parameters importClusterV2
+type ImportClusterReq struct {
+	common.ProjectReq
+	// The name of the preset used to resolve the cloud provider credentials
+	// in: query
+	// required: true
+	Preset string `json:"preset"`
+	// The cluster in YAML format, as returned by getClusterV2
+	// in: body
+	Body apiv1.Cluster
+
+	// private field for the seed name. Needed for the cluster provider.
+	seedName string
+}
+
+// GetSeedCluster returns the SeedCluster object
+func (req ImportClusterReq) GetSeedCluster() apiv1.SeedCluster {
+	return apiv1.SeedCluster{
+		SeedName: req.seedName,
+	}
+}
+
+func DecodeImportReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req ImportClusterReq
+
+	pr, err := common.DecodeProjectRequest(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.ProjectReq = pr.(common.ProjectReq)
+
+	req.Preset = r.URL.Query().Get("preset")
+	if req.Preset == "" {
+		return nil, errors.NewBadRequest("the preset query parameter is required")
+	}
+
+	rawCluster, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.UnmarshalStrict(rawCluster, &req.Body); err != nil {
+		return nil, errors.NewBadRequest("invalid cluster YAML: %v", err)
+	}
+
+	if len(req.Body.Type) == 0 {
+		req.Body.Type = apiv1.KubernetesClusterType
+	}
+
+	seedName, err := findSeedNameForDatacenter(c, req.Body.Spec.Cloud.DatacenterName)
+	if err != nil {
+		return nil, err
+	}
+	req.seedName = seedName
+	return req, nil
+}
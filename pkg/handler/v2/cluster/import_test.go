@@ -0,0 +1,131 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	"k8c.io/kubermatic/v2/pkg/handler/test"
+	"k8c.io/kubermatic/v2/pkg/handler/test/hack"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const exportedClusterYAML = `id: exportedClusterID
+name: keen-snyder
+creationTimestamp: "2013-02-03T19:54:00Z"
+type: kubernetes
+spec:
+  cloud:
+    dc: fake-dc
+    fake: {}
+  version: "1.15.0"
+  oidc: {}
+status:
+  version: "1.15.0"
+  url: https://w225mx4z66.asia-east1-a-1.cloud.kubermatic.io:31885
+`
+
+func TestImportCluster(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		Name                   string
+		Body                   string
+		Preset                 string
+		ExpectedResponse       string
+		HTTPStatus             int
+		RewriteClusterID       bool
+		ExistingKubermaticObjs []runtime.Object
+		ExistingAPIUser        *apiv1.User
+	}{
+		{
+			Name:                   "scenario 1: a cluster is imported with the credentials of the given preset",
+			Body:                   exportedClusterYAML,
+			Preset:                 "fake",
+			ExpectedResponse:       `{"id":"%s","name":"keen-snyder","creationTimestamp":"0001-01-01T00:00:00Z","type":"kubernetes","spec":{"cloud":{"dc":"fake-dc","fake":{}},"version":"1.15.0","oidc":{},"exposeStrategy":"NodePort"},"status":{"version":"1.15.0","url":""}}`,
+			RewriteClusterID:       true,
+			HTTPStatus:             http.StatusCreated,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		{
+			Name:                   "scenario 2: the preset doesn't exist",
+			Body:                   exportedClusterYAML,
+			Preset:                 "default",
+			ExpectedResponse:       `{"error":{"code":400,"message":"invalid credentials: missing preset 'default' for the user 'bob@acme.com'"}}`,
+			HTTPStatus:             http.StatusBadRequest,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		{
+			Name:                   "scenario 3: the preset is required",
+			Body:                   exportedClusterYAML,
+			ExpectedResponse:       `{"error":{"code":400,"message":"the preset query parameter is required"}}`,
+			HTTPStatus:             http.StatusBadRequest,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		{
+			Name:                   "scenario 4: the uploaded YAML contains unknown fields",
+			Body:                   exportedClusterYAML + "unknown: field\n",
+			Preset:                 "fake",
+			ExpectedResponse:       `{"error":{"code":400,"message":"invalid cluster YAML: error unmarshaling JSON: while decoding JSON: json: unknown field \"unknown\""}}`,
+			HTTPStatus:             http.StatusBadRequest,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			url := fmt.Sprintf("/api/v2/projects/%s/clusters/import", test.GenDefaultProject().Name)
+			if tc.Preset != "" {
+				url = fmt.Sprintf("%s?preset=%s", url, tc.Preset)
+			}
+			req := httptest.NewRequest("POST", url, strings.NewReader(tc.Body))
+			res := httptest.NewRecorder()
+			ep, err := test.CreateTestEndpoint(*tc.ExistingAPIUser, []runtime.Object{}, tc.ExistingKubermaticObjs, test.GenDefaultVersions(), nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+
+			expectedResponse := tc.ExpectedResponse
+			// since Cluster.Name is automatically generated by the system just rewrite it.
+			if tc.RewriteClusterID {
+				actualCluster := &apiv1.Cluster{}
+				if err := json.Unmarshal(res.Body.Bytes(), actualCluster); err != nil {
+					t.Fatal(err)
+				}
+				expectedResponse = fmt.Sprintf(tc.ExpectedResponse, actualCluster.ID)
+			}
+
+			test.CompareWithResult(t, res, expectedResponse)
+		})
+	}
+}
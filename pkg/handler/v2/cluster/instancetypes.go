@@ -0,0 +1,166 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/go-kit/kit/endpoint"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	apiv2 "k8c.io/kubermatic/v2/pkg/api/v2"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	handlercommon "k8c.io/kubermatic/v2/pkg/handler/common"
+	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
+	v1provider "k8c.io/kubermatic/v2/pkg/handler/v1/provider"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/util/errors"
+)
+
+var (
+	// vsphereSizes are the sizes offered for vSphere nodes, vSphere has no API listing instance types
+	vsphereSizes = []apiv2.VSphereSize{
+		{Name: "small", CPUs: 2, Memory: 4096},
+		{Name: "medium", CPUs: 4, Memory: 8192},
+		{Name: "large", CPUs: 8, Memory: 16384},
+	}
+	// kubevirtSizes are the sizes offered for KubeVirt nodes, KubeVirt has no API listing instance types
+	kubevirtSizes = []apiv2.KubevirtSize{
+		{Name: "small", CPUs: "2", Memory: "4Gi"},
+		{Name: "medium", CPUs: "4", Memory: "8Gi"},
+		{Name: "large", CPUs: "8", Memory: "16Gi"},
+	}
+)
+
+// ListInstanceTypesEndpoint returns the node instance types available in the datacenter of the cluster.
+// The instance types are fetched from the cloud provider using the credentials of the cluster,
+// providers which don't offer such an API (like AWS, vSphere or KubeVirt) return a static list.
+func ListInstanceTypesEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, seedsGetter provider.SeedsGetter, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	awsSizes := v1provider.AWSSizeNoCredentialsEndpoint(projectProvider, privilegedProjectProvider, seedsGetter, userInfoGetter)
+	alibabaInstanceTypes := v1provider.AlibabaInstanceTypesWithClusterCredentialsEndpoint(projectProvider, privilegedProjectProvider, seedsGetter, userInfoGetter)
+	azureSizes := v1provider.AzureSizeWithClusterCredentialsEndpoint(projectProvider, privilegedProjectProvider, seedsGetter, userInfoGetter)
+	digitaloceanSizes := v1provider.DigitaloceanSizeWithClusterCredentialsEndpoint(projectProvider, privilegedProjectProvider, userInfoGetter)
+	gcpSizes := v1provider.GCPSizeWithClusterCredentialsEndpoint(projectProvider, privilegedProjectProvider, userInfoGetter)
+	hetznerSizes := v1provider.HetznerSizeWithClusterCredentialsEndpoint(projectProvider, privilegedProjectProvider, userInfoGetter)
+	openstackSizes := v1provider.OpenstackSizeWithClusterCredentialsEndpoint(projectProvider, privilegedProjectProvider, seedsGetter, userInfoGetter)
+	packetSizes := v1provider.PacketSizesWithClusterCredentialsEndpoint(projectProvider, privilegedProjectProvider, userInfoGetter)
+
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(GetClusterReq)
+		cluster, err := handlercommon.GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, req.ProjectID, req.ClusterID, &provider.ClusterGetOptions{CheckInitStatus: true})
+		if err != nil {
+			return nil, err
+		}
+
+		clusterReq := common.GetClusterReq{
+			DCReq:     common.DCReq{ProjectReq: req.ProjectReq},
+			ClusterID: req.ClusterID,
+		}
+
+		var instanceTypes interface{}
+		cloud := cluster.Spec.Cloud
+		switch {
+		case cloud.AWS != nil:
+			instanceTypes, err = awsSizes(ctx, clusterReq)
+		case cloud.Alibaba != nil:
+			dc, dcErr := getDatacenter(ctx, userInfoGetter, seedsGetter, cloud.DatacenterName)
+			if dcErr != nil {
+				return nil, dcErr
+			}
+			if dc.Spec.Alibaba == nil {
+				return nil, errors.NewNotFound("cloud spec (dc) for ", req.ClusterID)
+			}
+			instanceTypes, err = alibabaInstanceTypes(ctx, v1provider.AlibabaNoCredentialReq{GetClusterReq: clusterReq, Region: dc.Spec.Alibaba.Region})
+		case cloud.Azure != nil:
+			instanceTypes, err = azureSizes(ctx, v1provider.AzureSizeNoCredentialsReq{GetClusterReq: clusterReq})
+		case cloud.Digitalocean != nil:
+			instanceTypes, err = digitaloceanSizes(ctx, v1provider.DoSizesNoCredentialsReq{GetClusterReq: clusterReq})
+		case cloud.GCP != nil:
+			dc, dcErr := getDatacenter(ctx, userInfoGetter, seedsGetter, cloud.DatacenterName)
+			if dcErr != nil {
+				return nil, dcErr
+			}
+			if dc.Spec.GCP == nil || len(dc.Spec.GCP.ZoneSuffixes) == 0 {
+				return nil, errors.NewNotFound("cloud spec (dc) for ", req.ClusterID)
+			}
+			zone := fmt.Sprintf("%s-%s", dc.Spec.GCP.Region, dc.Spec.GCP.ZoneSuffixes[0])
+			instanceTypes, err = gcpSizes(ctx, v1provider.GCPTypesNoCredentialReq{GetClusterReq: clusterReq, Zone: zone})
+		case cloud.Hetzner != nil:
+			instanceTypes, err = hetznerSizes(ctx, v1provider.HetznerSizesNoCredentialsReq{GetClusterReq: clusterReq})
+		case cloud.Openstack != nil:
+			instanceTypes, err = openstackSizes(ctx, v1provider.OpenstackNoCredentialsReq{GetClusterReq: clusterReq})
+		case cloud.Packet != nil:
+			instanceTypes, err = packetSizes(ctx, v1provider.PacketSizesNoCredentialsReq{GetClusterReq: clusterReq})
+		case cloud.VSphere != nil:
+			instanceTypes = vsphereSizes
+		case cloud.Kubevirt != nil:
+			instanceTypes = kubevirtSizes
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		return convertInstanceTypesToAPI(instanceTypes)
+	}
+}
+
+func getDatacenter(ctx context.Context, userInfoGetter provider.UserInfoGetter, seedsGetter provider.SeedsGetter, datacenterName string) (*kubermaticv1.Datacenter, error) {
+	adminUserInfo, err := userInfoGetter(ctx, "")
+	if err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+	_, dc, err := provider.DatacenterFromSeedMap(adminUserInfo, seedsGetter, datacenterName)
+	if err != nil {
+		return nil, errors.New(http.StatusInternalServerError, err.Error())
+	}
+	return dc, nil
+}
+
+func convertInstanceTypesToAPI(instanceTypes interface{}) (*apiv2.InstanceTypeList, error) {
+	result := &apiv2.InstanceTypeList{}
+
+	switch sizes := instanceTypes.(type) {
+	case nil:
+		// the cloud provider doesn't offer instance types, e.g. a bring-your-own cluster
+	case apiv1.AWSSizeList:
+		result.AWS = sizes
+	case apiv1.AlibabaInstanceTypeList:
+		result.Alibaba = sizes
+	case apiv1.AzureSizeList:
+		result.Azure = sizes
+	case apiv1.DigitaloceanSizeList:
+		result.Digitalocean = &sizes
+	case apiv1.GCPMachineSizeList:
+		result.GCP = sizes
+	case apiv1.HetznerSizeList:
+		result.Hetzner = &sizes
+	case []apiv1.OpenstackSize:
+		result.Openstack = sizes
+	case apiv1.PacketSizeList:
+		result.Packet = sizes
+	case []apiv2.VSphereSize:
+		result.VSphere = sizes
+	case []apiv2.KubevirtSize:
+		result.Kubevirt = sizes
+	default:
+		return nil, errors.New(http.StatusInternalServerError, fmt.Sprintf("unsupported instance type list %T", instanceTypes))
+	}
+
+	return result, nil
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	apiv2 "k8c.io/kubermatic/v2/pkg/api/v2"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/handler/test"
+	"k8c.io/kubermatic/v2/pkg/handler/test/hack"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestListClusterInstanceTypes(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		Name                   string
+		ExpectedResponse       string
+		HTTPStatus             int
+		ProjectToSync          string
+		ClusterToSync          string
+		ExistingAPIUser        *apiv1.User
+		ExistingKubermaticObjs []runtime.Object
+	}{
+		{
+			Name:                   "scenario 1: a provider without instance types returns an empty list",
+			ExpectedResponse:       `{}`,
+			HTTPStatus:             http.StatusOK,
+			ProjectToSync:          test.GenDefaultProject().Name,
+			ClusterToSync:          test.GenDefaultCluster().Name,
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(test.GenDefaultCluster()),
+		},
+		{
+			Name:             "scenario 2: the user John can not list instance types of Bob's cluster",
+			ExpectedResponse: `{"error":{"code":403,"message":"forbidden: \"john@acme.com\" doesn't belong to the given project = my-first-project-ID"}}`,
+			HTTPStatus:       http.StatusForbidden,
+			ProjectToSync:    test.GenDefaultProject().Name,
+			ClusterToSync:    test.GenDefaultCluster().Name,
+			ExistingAPIUser:  test.GenAPIUser("John", "john@acme.com"),
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenDefaultCluster(),
+				genUser("John", "john@acme.com", false),
+			),
+		},
+		{
+			Name:             "scenario 3: instance types can not be listed before the cluster is ready",
+			ExpectedResponse: `{"error":{"code":503,"message":"Cluster components are not ready yet"}}`,
+			HTTPStatus:       http.StatusServiceUnavailable,
+			ProjectToSync:    test.GenDefaultProject().Name,
+			ClusterToSync:    test.GenDefaultCluster().Name,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenCluster(test.DefaultClusterID, test.DefaultClusterName, test.GenDefaultProject().Name, test.GenDefaultCluster().CreationTimestamp.Time, func(cluster *kubermaticv1.Cluster) {
+					cluster.Status.ExtendedHealth.Apiserver = kubermaticv1.HealthStatusDown
+				}),
+			),
+		},
+		{
+			Name:             "scenario 4: a vSphere cluster returns the static vSphere sizes",
+			ExpectedResponse: `{"vsphere":[{"name":"small","cpus":2,"memory":4096},{"name":"medium","cpus":4,"memory":8192},{"name":"large","cpus":8,"memory":16384}]}`,
+			HTTPStatus:       http.StatusOK,
+			ProjectToSync:    test.GenDefaultProject().Name,
+			ClusterToSync:    test.GenDefaultCluster().Name,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenCluster(test.DefaultClusterID, test.DefaultClusterName, test.GenDefaultProject().Name, test.GenDefaultCluster().CreationTimestamp.Time, func(cluster *kubermaticv1.Cluster) {
+					cluster.Spec.Cloud = kubermaticv1.CloudSpec{DatacenterName: "regular-do1", VSphere: &kubermaticv1.VSphereCloudSpec{}}
+				}),
+			),
+		},
+		{
+			Name:             "scenario 5: a KubeVirt cluster returns the static KubeVirt sizes",
+			ExpectedResponse: `{"kubevirt":[{"name":"small","cpus":"2","memory":"4Gi"},{"name":"medium","cpus":"4","memory":"8Gi"},{"name":"large","cpus":"8","memory":"16Gi"}]}`,
+			HTTPStatus:       http.StatusOK,
+			ProjectToSync:    test.GenDefaultProject().Name,
+			ClusterToSync:    test.GenDefaultCluster().Name,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenCluster(test.DefaultClusterID, test.DefaultClusterName, test.GenDefaultProject().Name, test.GenDefaultCluster().CreationTimestamp.Time, func(cluster *kubermaticv1.Cluster) {
+					cluster.Spec.Cloud = kubermaticv1.CloudSpec{DatacenterName: "regular-do1", Kubevirt: &kubermaticv1.KubevirtCloudSpec{}}
+				}),
+			),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", fmt.Sprintf("/api/v2/projects/%s/clusters/%s/nodes/instancetypes", tc.ProjectToSync, tc.ClusterToSync), nil)
+			res := httptest.NewRecorder()
+			ep, err := test.CreateTestEndpoint(*tc.ExistingAPIUser, []runtime.Object{}, tc.ExistingKubermaticObjs, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+
+			test.CompareWithResult(t, res, tc.ExpectedResponse)
+		})
+	}
+}
+
+// TestListClusterInstanceTypesAWS lists the instance types of an AWS cluster, which goes through
+// the AWS sizes endpoint with the request built from the cluster and its datacenter.
+func TestListClusterInstanceTypesAWS(t *testing.T) {
+	t.Parallel()
+	const datacenterName = "aws-eu-central-1a"
+	seedsGetter := func() (map[string]*kubermaticv1.Seed, error) {
+		seed := test.GenTestSeed()
+		seed.Spec.Datacenters[datacenterName] = kubermaticv1.Datacenter{
+			Location: "Frankfurt",
+			Country:  "DE",
+			Spec: kubermaticv1.DatacenterSpec{
+				AWS: &kubermaticv1.DatacenterSpecAWS{Region: "eu-central-1"},
+			},
+		}
+		return map[string]*kubermaticv1.Seed{"us-central1": seed}, nil
+	}
+	cluster := test.GenCluster(test.DefaultClusterID, test.DefaultClusterName, test.GenDefaultProject().Name, test.GenDefaultCluster().CreationTimestamp.Time, func(cluster *kubermaticv1.Cluster) {
+		cluster.Spec.Cloud = kubermaticv1.CloudSpec{DatacenterName: datacenterName, AWS: &kubermaticv1.AWSCloudSpec{}}
+	})
+
+	req := httptest.NewRequest("GET", fmt.Sprintf("/api/v2/projects/%s/clusters/%s/nodes/instancetypes", test.GenDefaultProject().Name, cluster.Name), nil)
+	res := httptest.NewRecorder()
+	ep, _, err := test.CreateTestEndpointAndGetClients(*test.GenDefaultAPIUser(), seedsGetter, []runtime.Object{}, nil, test.GenDefaultKubermaticObjects(cluster), nil, nil, hack.NewTestRouting)
+	if err != nil {
+		t.Fatalf("failed to create test endpoint due to %v", err)
+	}
+
+	ep.ServeHTTP(res, req)
+
+	if res.Code != http.StatusOK {
+		t.Fatalf("Expected HTTP status code %d, got %d: %s", http.StatusOK, res.Code, res.Body.String())
+	}
+
+	instanceTypes := &apiv2.InstanceTypeList{}
+	if err := json.Unmarshal(res.Body.Bytes(), instanceTypes); err != nil {
+		t.Fatalf("failed to decode the instance types: %v", err)
+	}
+	if len(instanceTypes.AWS) == 0 {
+		t.Fatalf("expected the AWS sizes of the region, got %s", res.Body.String())
+	}
+	for _, size := range instanceTypes.AWS {
+		if size.Price <= 0 {
+			t.Fatalf("expected only the sizes available in the region, got %s without a price", size.Name)
+		}
+	}
+	if instanceTypes.Azure != nil || instanceTypes.GCP != nil || instanceTypes.VSphere != nil {
+		t.Fatalf("expected only the AWS sizes, got %s", res.Body.String())
+	}
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-kit/kit/endpoint"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	apiv2 "k8c.io/kubermatic/v2/pkg/api/v2"
+	"k8c.io/kubermatic/v2/pkg/controller/master-controller-manager/rbac"
+	handlercommon "k8c.io/kubermatic/v2/pkg/handler/common"
+	"k8c.io/kubermatic/v2/pkg/handler/middleware"
+	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/util/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	certutil "k8s.io/client-go/util/cert"
+)
+
+const (
+	// DefaultJoinTokenTTL is the lifetime of a join token when the request does not specify one
+	DefaultJoinTokenTTL = time.Hour
+	// MaxJoinTokenTTL is the longest lifetime of a join token, longer requested lifetimes are capped to it
+	MaxJoinTokenTTL = 24 * time.Hour
+
+	bootstrapTokenSecretPrefix = "bootstrap-token-"
+	bootstrapTokenGroup        = "system:bootstrappers:kubeadm:default-node-token"
+	bootstrapTokenCharset      = "0123456789abcdefghijklmnopqrstuvwxyz"
+)
+
+// GetJoinCommandEndpoint creates a short-lived bootstrap token in the user cluster and returns the kubeadm
+// command joining a node with it. Only clusters with nodes brought by the user can be joined manually and
+// only the project owners and admins are allowed to create join tokens.
+func GetJoinCommandEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(joinCommandReq)
+		clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
+		privilegedClusterProvider := ctx.Value(middleware.PrivilegedClusterProviderContextKey).(provider.PrivilegedClusterProvider)
+
+		cluster, err := handlercommon.GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, req.ProjectID, req.ClusterID, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		adminUserInfo, err := userInfoGetter(ctx, "")
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		if !adminUserInfo.IsAdmin {
+			userInfo, err := userInfoGetter(ctx, req.ProjectID)
+			if err != nil {
+				return nil, common.KubernetesErrorToHTTPError(err)
+			}
+			if rbac.ExtractGroupPrefix(userInfo.Group) != rbac.OwnerGroupNamePrefix {
+				return nil, errors.New(http.StatusForbidden, fmt.Sprintf("forbidden: \"%s\" is not an owner of the project", userInfo.Email))
+			}
+		}
+
+		if cluster.Spec.Cloud.BringYourOwn == nil {
+			return nil, errors.NewBadRequest("cluster %s does not support joining nodes manually", cluster.Name)
+		}
+		apiserverURL, err := url.Parse(cluster.Address.URL)
+		if err != nil || apiserverURL.Host == "" {
+			return nil, errors.NewBadRequest("the API server of cluster %s has no address yet", cluster.Name)
+		}
+
+		caSecret := &corev1.Secret{}
+		if err := privilegedClusterProvider.GetSeedClusterAdminRuntimeClient().Get(ctx, types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: resources.CASecretName}, caSecret); err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		caCerts, err := certutil.ParseCertsPEM(caSecret.Data[resources.CACertSecretKey])
+		if err != nil {
+			return nil, errors.New(http.StatusInternalServerError, fmt.Sprintf("failed to parse the ca certificate: %v", err))
+		}
+		caCertHash := sha256.Sum256(caCerts[0].RawSubjectPublicKeyInfo)
+
+		tokenID, err := randomBootstrapTokenString(6)
+		if err != nil {
+			return nil, err
+		}
+		tokenSecret, err := randomBootstrapTokenString(16)
+		if err != nil {
+			return nil, err
+		}
+		expiration := time.Now().Add(req.ttl).UTC().Truncate(time.Second)
+
+		client, err := clusterProvider.GetAdminClientForCustomerCluster(cluster)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		// The secret layout is defined by the bootstrap token authenticator of the kube-apiserver
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      bootstrapTokenSecretPrefix + tokenID,
+				Namespace: metav1.NamespaceSystem,
+			},
+			Type: corev1.SecretTypeBootstrapToken,
+			Data: map[string][]byte{
+				"description":                    []byte(fmt.Sprintf("Join token created by %s", adminUserInfo.Email)),
+				"token-id":                       []byte(tokenID),
+				"token-secret":                   []byte(tokenSecret),
+				"expiration":                     []byte(expiration.Format(time.RFC3339)),
+				"usage-bootstrap-authentication": []byte("true"),
+				"usage-bootstrap-signing":        []byte("true"),
+				"auth-extra-groups":              []byte(bootstrapTokenGroup),
+			},
+		}
+		if err := client.Create(ctx, secret); err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		return &apiv2.JoinCommand{
+			Command:    fmt.Sprintf("kubeadm join %s --token %s.%s --discovery-token-ca-cert-hash sha256:%s", apiserverURL.Host, tokenID, tokenSecret, hex.EncodeToString(caCertHash[:])),
+			Expiration: apiv1.NewTime(expiration),
+		}, nil
+	}
+}
+
+// randomBootstrapTokenString returns a random string of the given length made of the characters allowed in bootstrap tokens
+func randomBootstrapTokenString(length int) (string, error) {
+	var builder strings.Builder
+	for i := 0; i < length; i++ {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(bootstrapTokenCharset))))
+		if err != nil {
+			return "", fmt.Errorf("failed to generate the join token: %v", err)
+		}
+		builder.WriteByte(bootstrapTokenCharset[n.Int64()])
+	}
+	return builder.String(), nil
+}
+
+// joinCommandReq defines HTTP request for getClusterJoinCommandV2 endpoint
+// swagger:parameters getClusterJoinCommandV2
+type joinCommandReq struct {
+	GetClusterReq
+
+	// TTL is the lifetime of the join token, e.g. 30m. It defaults to 1h and is capped to 24h
+	// in: query
+	TTL string `json:"ttl,omitempty"`
+
+	// private field for the parsed TTL
+	ttl time.Duration
+}
+
+func DecodeJoinCommandReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req joinCommandReq
+
+	clusterReq, err := DecodeGetClusterReq(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.GetClusterReq = clusterReq.(GetClusterReq)
+
+	req.ttl = DefaultJoinTokenTTL
+	req.TTL = r.URL.Query().Get("ttl")
+	if req.TTL != "" {
+		req.ttl, err = time.ParseDuration(req.TTL)
+		if err != nil || req.ttl <= 0 {
+			return nil, errors.NewBadRequest("invalid ttl %q, must be a positive duration", req.TTL)
+		}
+	}
+	if req.ttl > MaxJoinTokenTTL {
+		req.ttl = MaxJoinTokenTTL
+	}
+
+	return req, nil
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	apiv2 "k8c.io/kubermatic/v2/pkg/api/v2"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/handler/test"
+	"k8c.io/kubermatic/v2/pkg/handler/test/hack"
+	"k8c.io/kubermatic/v2/pkg/resources"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	certutil "k8s.io/client-go/util/cert"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestGetClusterJoinCommand(t *testing.T) {
+	t.Parallel()
+	caSecret := genCertificateSecret(t, test.GenDefaultCluster().Status.NamespaceName, resources.CASecretName, resources.CACertSecretKey, time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC))
+	caCerts, err := certutil.ParseCertsPEM(caSecret.Data[resources.CACertSecretKey])
+	if err != nil {
+		t.Fatalf("failed to parse the ca certificate: %v", err)
+	}
+	caCertHash := sha256.Sum256(caCerts[0].RawSubjectPublicKeyInfo)
+	expectedCommand := regexp.MustCompile(fmt.Sprintf(`^kubeadm join w225mx4z66\.asia-east1-a-1\.cloud\.kubermatic\.io:31885 --token ([a-z0-9]{6})\.[a-z0-9]{16} --discovery-token-ca-cert-hash sha256:%s$`, hex.EncodeToString(caCertHash[:])))
+
+	byoCluster := test.GenDefaultCluster()
+	byoCluster.Spec.Cloud = kubermaticv1.CloudSpec{
+		DatacenterName: "FakeDatacenter",
+		BringYourOwn:   &kubermaticv1.BringYourOwnCloudSpec{},
+	}
+
+	testcases := []struct {
+		Name                   string
+		QueryParams            string
+		ExpectedResponse       string
+		ExpectedTTL            time.Duration
+		HTTPStatus             int
+		ExistingAPIUser        *apiv1.User
+		ExistingKubermaticObjs []runtime.Object
+	}{
+		{
+			Name:                   "scenario 1: the owner gets the join command of a bring your own cluster",
+			ExpectedTTL:            time.Hour,
+			HTTPStatus:             http.StatusOK,
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(byoCluster.DeepCopy()),
+		},
+		{
+			Name:                   "scenario 2: the lifetime of the join token is capped",
+			QueryParams:            "?ttl=720h",
+			ExpectedTTL:            24 * time.Hour,
+			HTTPStatus:             http.StatusOK,
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(byoCluster.DeepCopy()),
+		},
+		{
+			Name:                   "scenario 3: the lifetime of the join token must be a positive duration",
+			QueryParams:            "?ttl=-1h",
+			ExpectedResponse:       `{"error":{"code":400,"message":"invalid ttl \"-1h\", must be a positive duration"}}`,
+			HTTPStatus:             http.StatusBadRequest,
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(byoCluster.DeepCopy()),
+		},
+		{
+			Name:                   "scenario 4: nodes can not be joined manually to a cluster of a cloud provider",
+			ExpectedResponse:       `{"error":{"code":400,"message":"cluster defClusterID does not support joining nodes manually"}}`,
+			HTTPStatus:             http.StatusBadRequest,
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(test.GenDefaultCluster()),
+		},
+		{
+			Name:             "scenario 5: the editor John can not get the join command",
+			ExpectedResponse: `{"error":{"code":403,"message":"forbidden: \"john@acme.com\" is not an owner of the project"}}`,
+			HTTPStatus:       http.StatusForbidden,
+			ExistingAPIUser:  test.GenAPIUser("John", "john@acme.com"),
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				byoCluster.DeepCopy(),
+				genUser("John", "john@acme.com", false),
+				test.GenBinding(test.GenDefaultProject().Name, "john@acme.com", "editors"),
+			),
+		},
+		{
+			Name:            "scenario 6: the admin John can get the join command",
+			ExpectedTTL:     time.Hour,
+			HTTPStatus:      http.StatusOK,
+			ExistingAPIUser: test.GenAPIUser("John", "john@acme.com"),
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				byoCluster.DeepCopy(),
+				genUser("John", "john@acme.com", true),
+			),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", fmt.Sprintf("/api/v2/projects/%s/clusters/%s/joincommand%s", test.GenDefaultProject().Name, test.GenDefaultCluster().Name, tc.QueryParams), nil)
+			res := httptest.NewRecorder()
+			ep, clients, err := test.CreateTestEndpointAndGetClients(*tc.ExistingAPIUser, nil, []runtime.Object{caSecret.DeepCopy()}, nil, tc.ExistingKubermaticObjs, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			start := time.Now().Truncate(time.Second)
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+			if tc.HTTPStatus != http.StatusOK {
+				test.CompareWithResult(t, res, tc.ExpectedResponse)
+				return
+			}
+
+			joinCommand := &apiv2.JoinCommand{}
+			if err := json.Unmarshal(res.Body.Bytes(), joinCommand); err != nil {
+				t.Fatalf("failed to unmarshal the join command: %v", err)
+			}
+			match := expectedCommand.FindStringSubmatch(joinCommand.Command)
+			if match == nil {
+				t.Fatalf("join command %q does not match %q", joinCommand.Command, expectedCommand)
+			}
+			if ttl := joinCommand.Expiration.Sub(start); ttl < tc.ExpectedTTL || ttl > tc.ExpectedTTL+time.Minute {
+				t.Fatalf("expected the join token to expire in %v, got %v", tc.ExpectedTTL, ttl)
+			}
+
+			secret := &corev1.Secret{}
+			if err := clients.FakeClient.Get(context.Background(), ctrlruntimeclient.ObjectKey{Namespace: metav1.NamespaceSystem, Name: "bootstrap-token-" + match[1]}, secret); err != nil {
+				t.Fatalf("failed to get the bootstrap token secret: %v", err)
+			}
+			if secret.Type != corev1.SecretTypeBootstrapToken {
+				t.Fatalf("expected the secret type %q, got %q", corev1.SecretTypeBootstrapToken, secret.Type)
+			}
+			if expiration := string(secret.Data["expiration"]); expiration != joinCommand.Expiration.UTC().Format(time.RFC3339) {
+				t.Fatalf("expected the secret to expire at %s, got %s", joinCommand.Expiration.UTC().Format(time.RFC3339), expiration)
+			}
+		})
+	}
+}
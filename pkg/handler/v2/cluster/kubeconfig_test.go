@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
@@ -163,6 +164,26 @@ func TestGetMasterKubeconfig(t *testing.T) {
 			ExistingAPIUser:        *test.GenAPIUser("bob", "bob@acme.com"),
 			ExpectedResponseString: `{"error":{"code":403,"message":"forbidden: \"bob@acme.com\" doesn't belong to the given project = foo-ID"}}`,
 		},
+		{
+			Name:         "scenario 5: the kubeconfig is not available before the control plane is ready",
+			HTTPStatus:   http.StatusServiceUnavailable,
+			ProjectToGet: "foo-ID",
+			ClusterToGet: "cluster-foo",
+			ExistingKubermaticObjs: []runtime.Object{
+				/*add projects*/
+				test.GenProject("foo", kubermaticapiv1.ProjectActive, test.DefaultCreationTimestamp()),
+				/*add bindings*/
+				test.GenBinding("foo-ID", "john@acme.com", "owners"),
+
+				/*add users*/
+				test.GenUser("", "john", "john@acme.com"),
+				test.GenCluster("cluster-foo", "cluster-foo", "foo-ID", test.DefaultCreationTimestamp(), func(cluster *kubermaticapiv1.Cluster) {
+					cluster.Status.ExtendedHealth.Apiserver = kubermaticapiv1.HealthStatusProvisioning
+				}),
+			},
+			ExistingAPIUser:        *test.GenAPIUser("john", "john@acme.com"),
+			ExpectedResponseString: `{"error":{"code":503,"message":"the control plane of cluster cluster-foo is not ready yet"}}`,
+		},
 	}
 
 	for _, tc := range testcases {
@@ -181,6 +202,11 @@ func TestGetMasterKubeconfig(t *testing.T) {
 			if res.Code != tc.HTTPStatus {
 				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
 			}
+			if res.Code == http.StatusOK {
+				if disposition := res.Header().Get("Content-Disposition"); !strings.HasPrefix(disposition, "attachment; filename=kubeconfig-") {
+					t.Fatalf("Expected the kubeconfig to be an attachment, got Content-Disposition %q", disposition)
+				}
+			}
 
 			test.CompareWithResult(t, res, tc.ExpectedResponseString)
 		})
@@ -207,3 +233,109 @@ users:
   user:
     token: %s`, tokenID)
 }
+
+func TestGetOidcKubeconfig(t *testing.T) {
+	t.Parallel()
+	oidcCluster := func(cluster *kubermaticapiv1.Cluster) {
+		cluster.Spec.OIDC = kubermaticapiv1.OIDCSettings{
+			IssuerURL:    "https://dev.kubermatic.io/dex",
+			ClientID:     "kubermatic",
+			ClientSecret: "secret",
+		}
+	}
+	adminKubeconfig := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "cluster-cluster-foo",
+			Name:      "admin-kubeconfig",
+		},
+		Data: map[string][]byte{
+			"kubeconfig": []byte(test.GenerateTestKubeconfig("cluster-foo", test.IDToken)),
+		},
+	}
+
+	testcases := []struct {
+		Name                   string
+		ExpectedResponseString string
+		HTTPStatus             int
+		ExistingAPIUser        apiv1.User
+		ExistingKubermaticObjs []runtime.Object
+	}{
+		{
+			Name:       "scenario 1: owner gets the OIDC kubeconfig",
+			HTTPStatus: http.StatusOK,
+			ExistingKubermaticObjs: []runtime.Object{
+				test.GenProject("foo", kubermaticapiv1.ProjectActive, test.DefaultCreationTimestamp()),
+				test.GenBinding("foo-ID", "john@acme.com", "owners"),
+				test.GenUser("", "john", "john@acme.com"),
+				test.GenCluster("cluster-foo", "cluster-foo", "foo-ID", test.DefaultCreationTimestamp(), oidcCluster),
+			},
+			ExistingAPIUser: *test.GenAPIUser("john", "john@acme.com"),
+			ExpectedResponseString: `apiVersion: v1
+clusters:
+- cluster:
+    server: test.fake.io
+  name: cluster-foo
+contexts:
+- context:
+    cluster: cluster-foo
+    user: default
+  name: default
+current-context: default
+kind: Config
+preferences: {}
+users:
+- name: default
+  user:
+    auth-provider:
+      config:
+        client-id: kubermatic
+        client-secret: secret
+        idp-issuer-url: https://dev.kubermatic.io/dex
+      name: oidc`,
+		},
+		{
+			Name:       "scenario 2: the OIDC kubeconfig can not be generated without OIDC settings",
+			HTTPStatus: http.StatusBadRequest,
+			ExistingKubermaticObjs: []runtime.Object{
+				test.GenProject("foo", kubermaticapiv1.ProjectActive, test.DefaultCreationTimestamp()),
+				test.GenBinding("foo-ID", "john@acme.com", "owners"),
+				test.GenUser("", "john", "john@acme.com"),
+				test.GenCluster("cluster-foo", "cluster-foo", "foo-ID", test.DefaultCreationTimestamp()),
+			},
+			ExistingAPIUser:        *test.GenAPIUser("john", "john@acme.com"),
+			ExpectedResponseString: `{"error":{"code":400,"message":"cluster cluster-foo has no OIDC settings configured"}}`,
+		},
+		{
+			Name:       "scenario 3: the user Bob can not get John's OIDC kubeconfig",
+			HTTPStatus: http.StatusForbidden,
+			ExistingKubermaticObjs: []runtime.Object{
+				test.GenProject("foo", kubermaticapiv1.ProjectActive, test.DefaultCreationTimestamp()),
+				test.GenBinding("foo-ID", "john@acme.com", "owners"),
+				test.GenUser("", "john", "john@acme.com"),
+				genUser("bob", "bob@acme.com", false),
+				test.GenCluster("cluster-foo", "cluster-foo", "foo-ID", test.DefaultCreationTimestamp(), oidcCluster),
+			},
+			ExistingAPIUser:        *test.GenAPIUser("bob", "bob@acme.com"),
+			ExpectedResponseString: `{"error":{"code":403,"message":"forbidden: \"bob@acme.com\" doesn't belong to the given project = foo-ID"}}`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/api/v2/projects/foo-ID/clusters/cluster-foo/oidckubeconfig", nil)
+			res := httptest.NewRecorder()
+			ep, _, err := test.CreateTestEndpointAndGetClients(tc.ExistingAPIUser, nil, []runtime.Object{adminKubeconfig}, []runtime.Object{}, tc.ExistingKubermaticObjs, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+
+			test.CompareWithResult(t, res, tc.ExpectedResponseString)
+		})
+	}
+}
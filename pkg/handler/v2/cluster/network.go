@@ -0,0 +1,104 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"math"
+	"net"
+
+	"github.com/go-kit/kit/endpoint"
+
+	apiv2 "k8c.io/kubermatic/v2/pkg/api/v2"
+	handlercommon "k8c.io/kubermatic/v2/pkg/handler/common"
+	"k8c.io/kubermatic/v2/pkg/handler/middleware"
+	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
+	"k8c.io/kubermatic/v2/pkg/provider"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// GetNetworkUsageEndpoint returns the number of allocated pod and service IPs compared to the capacity of the cluster networks
+func GetNetworkUsageEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(GetClusterReq)
+		clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
+
+		cluster, err := handlercommon.GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, req.ProjectID, req.ClusterID, &provider.ClusterGetOptions{CheckInitStatus: true})
+		if err != nil {
+			return nil, err
+		}
+
+		client, err := common.GetClusterClient(ctx, userInfoGetter, clusterProvider, cluster, req.ProjectID)
+		if err != nil {
+			return nil, clusterUnreachableToHTTPError(err)
+		}
+
+		pods := &corev1.PodList{}
+		if err := client.List(ctx, pods); err != nil {
+			return nil, clusterUnreachableToHTTPError(err)
+		}
+		services := &corev1.ServiceList{}
+		if err := client.List(ctx, services); err != nil {
+			return nil, clusterUnreachableToHTTPError(err)
+		}
+
+		podIPs := 0
+		for _, pod := range pods.Items {
+			// pods in the host network use the IP of their node
+			if pod.Status.PodIP != "" && !pod.Spec.HostNetwork {
+				podIPs++
+			}
+		}
+		serviceIPs := 0
+		for _, service := range services.Items {
+			if service.Spec.ClusterIP != "" && service.Spec.ClusterIP != corev1.ClusterIPNone {
+				serviceIPs++
+			}
+		}
+
+		return apiv2.ClusterNetworkUsage{
+			Pods:     networkUsage(cluster.Spec.ClusterNetwork.Pods.CIDRBlocks, podIPs),
+			Services: networkUsage(cluster.Spec.ClusterNetwork.Services.CIDRBlocks, serviceIPs),
+		}, nil
+	}
+}
+
+// networkUsage returns the usage of a network with the given CIDR blocks. Invalid blocks are ignored
+// and the capacity is capped at the maximum int64 value.
+func networkUsage(cidrBlocks []string, allocated int) apiv2.NetworkUsage {
+	usage := apiv2.NetworkUsage{
+		CIDRBlocks: make([]string, 0, len(cidrBlocks)),
+		Allocated:  allocated,
+	}
+	for _, block := range cidrBlocks {
+		_, ipNet, err := net.ParseCIDR(block)
+		if err != nil {
+			continue
+		}
+		usage.CIDRBlocks = append(usage.CIDRBlocks, block)
+
+		ones, bits := ipNet.Mask.Size()
+		hostBits := uint(bits - ones)
+		if hostBits >= 63 || usage.Capacity > math.MaxInt64-int64(1)<<hostBits {
+			usage.Capacity = math.MaxInt64
+			continue
+		}
+		usage.Capacity += int64(1) << hostBits
+	}
+	return usage
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-kit/kit/endpoint"
+	prometheusapi "github.com/prometheus/client_golang/api"
+	prometheusv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	apiv2 "k8c.io/kubermatic/v2/pkg/api/v2"
+	handlercommon "k8c.io/kubermatic/v2/pkg/handler/common"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/util/errors"
+)
+
+const (
+	// DefaultNodeHistoryRange is the time range returned when no start is given
+	DefaultNodeHistoryRange = 24 * time.Hour
+	// DefaultNodeHistoryStep is the resolution of the returned samples when no step is given
+	DefaultNodeHistoryStep = time.Hour
+	// maxNodeHistorySamples is the maximum number of points Prometheus returns for a single series
+	maxNodeHistorySamples = 11000
+
+	nodeCountQuery = `sum(machine_controller_machines{namespace="%s"})`
+)
+
+// GetNodeHistoryEndpoint returns the number of nodes of the cluster over time as recorded by Prometheus.
+// An empty series is returned when no metrics are available.
+func GetNodeHistoryEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter, prometheusClient prometheusapi.Client) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(nodeHistoryReq)
+
+		cluster, err := handlercommon.GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, req.ProjectID, req.ClusterID, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		samples := []apiv2.NodeCountSample{}
+		if prometheusClient == nil {
+			return samples, nil
+		}
+
+		value, _, err := prometheusv1.NewAPI(prometheusClient).QueryRange(ctx, fmt.Sprintf(nodeCountQuery, cluster.Status.NamespaceName), prometheusv1.Range{
+			Start: req.from,
+			End:   req.to,
+			Step:  req.step,
+		})
+		if err != nil {
+			return samples, nil
+		}
+
+		matrix, ok := value.(model.Matrix)
+		if !ok || len(matrix) == 0 {
+			return samples, nil
+		}
+		for _, pair := range matrix[0].Values {
+			samples = append(samples, apiv2.NodeCountSample{
+				Timestamp: apiv1.NewTime(pair.Timestamp.Time()),
+				Count:     int(pair.Value),
+			})
+		}
+
+		return samples, nil
+	}
+}
+
+// nodeHistoryReq defines HTTP request for getClusterNodeHistoryV2
+// swagger:parameters getClusterNodeHistoryV2
+type nodeHistoryReq struct {
+	GetClusterReq
+
+	// From is the start of the time range in RFC3339 format, defaults to 24h before the end
+	// in: query
+	From string `json:"from,omitempty"`
+	// To is the end of the time range in RFC3339 format, defaults to now
+	// in: query
+	To string `json:"to,omitempty"`
+	// Step is the resolution of the samples, e.g. 30m. It defaults to 1h
+	// in: query
+	Step string `json:"step,omitempty"`
+
+	// private fields for the parsed range
+	from time.Time
+	to   time.Time
+	step time.Duration
+}
+
+func DecodeNodeHistoryReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req nodeHistoryReq
+
+	clusterReq, err := DecodeGetClusterReq(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.GetClusterReq = clusterReq.(GetClusterReq)
+
+	req.to = time.Now()
+	req.To = r.URL.Query().Get("to")
+	if req.To != "" {
+		req.to, err = time.Parse(time.RFC3339, req.To)
+		if err != nil {
+			return nil, errors.NewBadRequest("invalid to %q, must be a RFC3339 timestamp", req.To)
+		}
+	}
+
+	req.from = req.to.Add(-DefaultNodeHistoryRange)
+	req.From = r.URL.Query().Get("from")
+	if req.From != "" {
+		req.from, err = time.Parse(time.RFC3339, req.From)
+		if err != nil {
+			return nil, errors.NewBadRequest("invalid from %q, must be a RFC3339 timestamp", req.From)
+		}
+	}
+	if !req.from.Before(req.to) {
+		return nil, errors.NewBadRequest("the start of the time range must be before its end")
+	}
+
+	req.step = DefaultNodeHistoryStep
+	req.Step = r.URL.Query().Get("step")
+	if req.Step != "" {
+		req.step, err = time.ParseDuration(req.Step)
+		if err != nil || req.step <= 0 {
+			return nil, errors.NewBadRequest("invalid step %q, must be a positive duration", req.Step)
+		}
+	}
+	if req.to.Sub(req.from)/req.step > maxNodeHistorySamples {
+		return nil, errors.NewBadRequest("the time range contains more than %d samples, increase the step", maxNodeHistorySamples)
+	}
+
+	return req, nil
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"k8c.io/kubermatic/v2/pkg/handler/test"
+	"k8c.io/kubermatic/v2/pkg/handler/test/hack"
+)
+
+func TestGetClusterNodeHistory(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		Name             string
+		Query            string
+		ExpectedResponse string
+		HTTPStatus       int
+	}{
+		{
+			Name:             "scenario 1: an empty series is returned when no metrics are available",
+			ExpectedResponse: `[]`,
+			HTTPStatus:       http.StatusOK,
+		},
+		{
+			Name:             "scenario 2: an empty series is returned for a given time range",
+			Query:            "from=2020-10-01T00:00:00Z&to=2020-10-02T00:00:00Z&step=30m",
+			ExpectedResponse: `[]`,
+			HTTPStatus:       http.StatusOK,
+		},
+		{
+			Name:             "scenario 3: the start of the time range must be before its end",
+			Query:            "from=2020-10-02T00:00:00Z&to=2020-10-01T00:00:00Z",
+			ExpectedResponse: `{"error":{"code":400,"message":"the start of the time range must be before its end"}}`,
+			HTTPStatus:       http.StatusBadRequest,
+		},
+		{
+			Name:             "scenario 4: the step must be a positive duration",
+			Query:            "step=-1h",
+			ExpectedResponse: `{"error":{"code":400,"message":"invalid step \"-1h\", must be a positive duration"}}`,
+			HTTPStatus:       http.StatusBadRequest,
+		},
+		{
+			Name:             "scenario 5: the time range must not contain too many samples",
+			Query:            "from=2020-10-01T00:00:00Z&to=2020-10-02T00:00:00Z&step=1s",
+			ExpectedResponse: `{"error":{"code":400,"message":"the time range contains more than 11000 samples, increase the step"}}`,
+			HTTPStatus:       http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", fmt.Sprintf("/api/v2/projects/%s/clusters/%s/nodehistory?%s", test.GenDefaultProject().Name, test.GenDefaultCluster().Name, tc.Query), nil)
+			res := httptest.NewRecorder()
+			ep, err := test.CreateTestEndpoint(*test.GenDefaultAPIUser(), nil, test.GenDefaultKubermaticObjects(test.GenDefaultCluster()), nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+
+			test.CompareWithResult(t, res, tc.ExpectedResponse)
+		})
+	}
+}
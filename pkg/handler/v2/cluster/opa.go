@@ -0,0 +1,142 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/prometheus/common/expfmt"
+
+	apiv2 "k8c.io/kubermatic/v2/pkg/api/v2"
+	handlercommon "k8c.io/kubermatic/v2/pkg/handler/common"
+	"k8c.io/kubermatic/v2/pkg/handler/middleware"
+	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/util/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// gatekeeperNamespace is the namespace the Gatekeeper addon is deployed to
+	gatekeeperNamespace = "gatekeeper-system"
+	// gatekeeperMetricsPort is the port the Gatekeeper pods expose their Prometheus metrics on
+	gatekeeperMetricsPort = "8888"
+	// gatekeeperRequestDurationMetric is the histogram of the time the Gatekeeper webhook takes to evaluate an admission request
+	gatekeeperRequestDurationMetric = "gatekeeper_validation_request_duration_seconds"
+)
+
+// gatekeeperWebhookPodLabels select the Gatekeeper pods serving the validating webhook, the audit pods are not selected
+var gatekeeperWebhookPodLabels = map[string]string{"gatekeeper.sh/operation": "webhook"}
+
+// gatekeeperWebhookMetrics are the number of admission requests the Gatekeeper webhook evaluated and the time it took
+type gatekeeperWebhookMetrics struct {
+	requests        uint64
+	durationSeconds float64
+}
+
+// GetOPAStatusEndpoint returns the number of admission requests the Gatekeeper webhook of the cluster evaluated and
+// their average latency. The metrics are read from the webhook pods through the API server of the cluster.
+func GetOPAStatusEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(GetClusterReq)
+		clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
+		privilegedClusterProvider := ctx.Value(middleware.PrivilegedClusterProviderContextKey).(provider.PrivilegedClusterProvider)
+
+		cluster, err := handlercommon.GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, req.ProjectID, req.ClusterID, &provider.ClusterGetOptions{CheckInitStatus: true})
+		if err != nil {
+			return nil, err
+		}
+
+		enabled, err := handlercommon.IsOPAEnabled(ctx, privilegedClusterProvider.GetSeedClusterAdminRuntimeClient(), cluster)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		if !enabled {
+			return nil, errors.NewBadRequest("OPA is not enabled for cluster %q", cluster.Name)
+		}
+
+		client, err := common.GetClusterClient(ctx, userInfoGetter, clusterProvider, cluster, req.ProjectID)
+		if err != nil {
+			return nil, clusterUnreachableToHTTPError(err)
+		}
+		pods := &corev1.PodList{}
+		if err := client.List(ctx, pods, ctrlruntimeclient.InNamespace(gatekeeperNamespace), ctrlruntimeclient.MatchingLabels(gatekeeperWebhookPodLabels)); err != nil {
+			return nil, clusterUnreachableToHTTPError(err)
+		}
+
+		status := &apiv2.OPAStatus{}
+		total := gatekeeperWebhookMetrics{}
+		var clientset kubernetes.Interface
+		for _, pod := range pods.Items {
+			if pod.Status.Phase != corev1.PodRunning {
+				continue
+			}
+			if clientset == nil {
+				if clientset, err = common.GetClusterClientset(ctx, userInfoGetter, clusterProvider, cluster, req.ProjectID); err != nil {
+					return nil, clusterUnreachableToHTTPError(err)
+				}
+			}
+
+			raw, err := clientset.CoreV1().Pods(pod.Namespace).ProxyGet("http", pod.Name, gatekeeperMetricsPort, "metrics", nil).DoRaw(ctx)
+			if err != nil {
+				return nil, clusterUnreachableToHTTPError(err)
+			}
+			metrics, err := parseGatekeeperWebhookMetrics(raw)
+			if err != nil {
+				return nil, errors.New(http.StatusInternalServerError, fmt.Sprintf("failed to parse the metrics of the Gatekeeper pod %q: %v", pod.Name, err))
+			}
+
+			status.WebhookPods++
+			total.requests += metrics.requests
+			total.durationSeconds += metrics.durationSeconds
+		}
+
+		status.WebhookRequests = int64(total.requests)
+		if total.requests > 0 {
+			status.AverageWebhookLatencyMilliseconds = total.durationSeconds / float64(total.requests) * 1000
+		}
+		return status, nil
+	}
+}
+
+// parseGatekeeperWebhookMetrics sums the request duration histogram of the Gatekeeper webhook over all admission
+// statuses. The histogram is only exported once the webhook evaluated its first request.
+func parseGatekeeperWebhookMetrics(raw []byte) (gatekeeperWebhookMetrics, error) {
+	parser := expfmt.TextParser{}
+	families, err := parser.TextToMetricFamilies(bytes.NewReader(raw))
+	if err != nil {
+		return gatekeeperWebhookMetrics{}, err
+	}
+
+	result := gatekeeperWebhookMetrics{}
+	family, ok := families[gatekeeperRequestDurationMetric]
+	if !ok {
+		return result, nil
+	}
+	for _, metric := range family.GetMetric() {
+		result.requests += metric.GetHistogram().GetSampleCount()
+		result.durationSeconds += metric.GetHistogram().GetSampleSum()
+	}
+	return result, nil
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"testing"
+)
+
+func TestParseGatekeeperWebhookMetrics(t *testing.T) {
+	testcases := []struct {
+		name     string
+		raw      string
+		expected gatekeeperWebhookMetrics
+	}{
+		{
+			name: "requests of all admission statuses are summed",
+			raw: `# HELP gatekeeper_validation_request_count The number of requests that are routed to validation webhook
+# TYPE gatekeeper_validation_request_count counter
+gatekeeper_validation_request_count{admission_status="allow"} 6
+gatekeeper_validation_request_count{admission_status="deny"} 2
+# HELP gatekeeper_validation_request_duration_seconds The response time in seconds
+# TYPE gatekeeper_validation_request_duration_seconds histogram
+gatekeeper_validation_request_duration_seconds_bucket{admission_status="allow",le="0.01"} 4
+gatekeeper_validation_request_duration_seconds_bucket{admission_status="allow",le="+Inf"} 6
+gatekeeper_validation_request_duration_seconds_sum{admission_status="allow"} 0.09
+gatekeeper_validation_request_duration_seconds_count{admission_status="allow"} 6
+gatekeeper_validation_request_duration_seconds_bucket{admission_status="deny",le="0.01"} 0
+gatekeeper_validation_request_duration_seconds_bucket{admission_status="deny",le="+Inf"} 2
+gatekeeper_validation_request_duration_seconds_sum{admission_status="deny"} 0.07
+gatekeeper_validation_request_duration_seconds_count{admission_status="deny"} 2
+`,
+			expected: gatekeeperWebhookMetrics{requests: 8, durationSeconds: 0.16},
+		},
+		{
+			name: "no requests were evaluated yet",
+			raw: `# HELP gatekeeper_constraint_templates Number of observed constraint templates
+# TYPE gatekeeper_constraint_templates gauge
+gatekeeper_constraint_templates{status="active"} 1
+`,
+			expected: gatekeeperWebhookMetrics{},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			metrics, err := parseGatekeeperWebhookMetrics([]byte(tc.raw))
+			if err != nil {
+				t.Fatalf("failed to parse the metrics: %v", err)
+			}
+			if metrics.requests != tc.expected.requests {
+				t.Errorf("expected %d requests, got %d", tc.expected.requests, metrics.requests)
+			}
+			if diff := metrics.durationSeconds - tc.expected.durationSeconds; diff > 1e-9 || diff < -1e-9 {
+				t.Errorf("expected a duration of %fs, got %fs", tc.expected.durationSeconds, metrics.durationSeconds)
+			}
+		})
+	}
+}
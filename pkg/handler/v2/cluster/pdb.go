@@ -0,0 +1,75 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+
+	apiv2 "k8c.io/kubermatic/v2/pkg/api/v2"
+	handlercommon "k8c.io/kubermatic/v2/pkg/handler/common"
+	"k8c.io/kubermatic/v2/pkg/handler/middleware"
+	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/resources"
+
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// controlPlaneBudgets are the PodDisruptionBudgets protecting the control plane of a cluster in the seed
+var controlPlaneBudgets = []string{
+	resources.ApiserverPodDisruptionBudgetName,
+	resources.EtcdPodDisruptionBudgetName,
+}
+
+// GetControlPlanePDBEndpoint returns the status of the PodDisruptionBudgets of the apiserver and etcd of the
+// cluster. Budgets which were not created yet are omitted.
+func GetControlPlanePDBEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(GetClusterReq)
+		privilegedClusterProvider := ctx.Value(middleware.PrivilegedClusterProviderContextKey).(provider.PrivilegedClusterProvider)
+
+		cluster, err := handlercommon.GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, req.ProjectID, req.ClusterID, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		seedClient := privilegedClusterProvider.GetSeedClusterAdminRuntimeClient()
+		budgets := make([]apiv2.ControlPlanePodDisruptionBudget, 0, len(controlPlaneBudgets))
+		for _, name := range controlPlaneBudgets {
+			pdb := &policyv1beta1.PodDisruptionBudget{}
+			if err := seedClient.Get(ctx, types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: name}, pdb); err != nil {
+				if kerrors.IsNotFound(err) {
+					continue
+				}
+				return nil, common.KubernetesErrorToHTTPError(err)
+			}
+			budgets = append(budgets, apiv2.ControlPlanePodDisruptionBudget{
+				Name:               pdb.Name,
+				CurrentHealthy:     pdb.Status.CurrentHealthy,
+				DesiredHealthy:     pdb.Status.DesiredHealthy,
+				ExpectedPods:       pdb.Status.ExpectedPods,
+				DisruptionsAllowed: pdb.Status.DisruptionsAllowed,
+			})
+		}
+
+		return budgets, nil
+	}
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/go-kit/kit/endpoint"
+
+	apiv2 "k8c.io/kubermatic/v2/pkg/api/v2"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	handlercommon "k8c.io/kubermatic/v2/pkg/handler/common"
+	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/util/errors"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GetRawEndpoint returns the cluster object as it is stored in the seed cluster, including its status.
+// Secrets stored inline in the object, like cloud provider credentials, are removed from the response.
+// Only admins are allowed to use this endpoint.
+func GetRawEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(GetClusterReq)
+
+		adminUserInfo, err := userInfoGetter(ctx, "")
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		if !adminUserInfo.IsAdmin {
+			return nil, errors.New(http.StatusForbidden, fmt.Sprintf("forbidden: \"%s\" doesn't have admin rights", adminUserInfo.Email))
+		}
+
+		cluster, err := handlercommon.GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, req.ProjectID, req.ClusterID, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		rawCluster := redactCluster(cluster)
+		rawCluster.TypeMeta = metav1.TypeMeta{
+			APIVersion: kubermaticv1.SchemeGroupVersion.String(),
+			Kind:       kubermaticv1.ClusterKindName,
+		}
+		return &apiv2.RawCluster{Cluster: *rawCluster}, nil
+	}
+}
+
+// redactCluster returns a copy of the cluster without the secrets which can be stored inline in the object
+func redactCluster(cluster *kubermaticv1.Cluster) *kubermaticv1.Cluster {
+	redacted := cluster.DeepCopy()
+	redacted.Address.AdminToken = ""
+	redacted.Spec.OIDC.ClientSecret = ""
+	if redacted.Spec.Openshift != nil {
+		redacted.Spec.Openshift.ImagePullSecret = ""
+	}
+
+	cloud := &redacted.Spec.Cloud
+	if cloud.Fake != nil {
+		cloud.Fake.Token = ""
+	}
+	if cloud.Digitalocean != nil {
+		cloud.Digitalocean.Token = ""
+	}
+	if cloud.AWS != nil {
+		cloud.AWS.SecretAccessKey = ""
+	}
+	if cloud.Azure != nil {
+		cloud.Azure.ClientSecret = ""
+	}
+	if cloud.Openstack != nil {
+		cloud.Openstack.Password = ""
+	}
+	if cloud.Packet != nil {
+		cloud.Packet.APIKey = ""
+	}
+	if cloud.Hetzner != nil {
+		cloud.Hetzner.Token = ""
+	}
+	if cloud.VSphere != nil {
+		cloud.VSphere.Password = ""
+		cloud.VSphere.InfraManagementUser.Password = ""
+	}
+	if cloud.GCP != nil {
+		cloud.GCP.ServiceAccount = ""
+	}
+	if cloud.Kubevirt != nil {
+		cloud.Kubevirt.Kubeconfig = ""
+	}
+	if cloud.Alibaba != nil {
+		cloud.Alibaba.AccessKeySecret = ""
+	}
+	return redacted
+}
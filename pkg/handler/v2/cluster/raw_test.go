@@ -0,0 +1,180 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/handler/test"
+	"k8c.io/kubermatic/v2/pkg/handler/test/hack"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestGetClusterRaw(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		Name                   string
+		HTTPStatus             int
+		ExpectedResponse       string
+		ProjectToSync          string
+		ClusterToSync          string
+		ExistingAPIUser        *apiv1.User
+		ExistingKubermaticObjs []runtime.Object
+	}{
+		{
+			Name:            "scenario 1: the admin John can get the raw cluster object of Bob's cluster",
+			HTTPStatus:      http.StatusOK,
+			ProjectToSync:   test.GenDefaultProject().Name,
+			ClusterToSync:   test.GenDefaultCluster().Name,
+			ExistingAPIUser: test.GenAPIUser("John", "john@acme.com"),
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenDefaultCluster(),
+				genUser("John", "john@acme.com", true),
+			),
+		},
+		{
+			Name:             "scenario 2: the project owner Bob can not get the raw cluster object",
+			HTTPStatus:       http.StatusForbidden,
+			ExpectedResponse: `{"error":{"code":403,"message":"forbidden: \"bob@acme.com\" doesn't have admin rights"}}`,
+			ProjectToSync:    test.GenDefaultProject().Name,
+			ClusterToSync:    test.GenDefaultCluster().Name,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenDefaultCluster(),
+			),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", fmt.Sprintf("/api/v2/projects/%s/clusters/%s/raw", tc.ProjectToSync, tc.ClusterToSync), nil)
+			res := httptest.NewRecorder()
+			ep, err := test.CreateTestEndpoint(*tc.ExistingAPIUser, []runtime.Object{}, tc.ExistingKubermaticObjs, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+
+			if tc.HTTPStatus != http.StatusOK {
+				test.CompareWithResult(t, res, tc.ExpectedResponse)
+				return
+			}
+
+			rawCluster := &kubermaticv1.Cluster{}
+			if err := json.Unmarshal(res.Body.Bytes(), rawCluster); err != nil {
+				t.Fatalf("failed to decode the raw cluster: %v", err)
+			}
+			expectedCluster := test.GenDefaultCluster()
+			if rawCluster.Kind != kubermaticv1.ClusterKindName || rawCluster.Name != expectedCluster.Name {
+				t.Fatalf("expected cluster %s of kind %s, got %s of kind %s", expectedCluster.Name, kubermaticv1.ClusterKindName, rawCluster.Name, rawCluster.Kind)
+			}
+			if rawCluster.Status.NamespaceName != expectedCluster.Status.NamespaceName {
+				t.Fatalf("expected the cluster status to be returned, got namespace %q", rawCluster.Status.NamespaceName)
+			}
+			if rawCluster.Spec.Cloud.DatacenterName != expectedCluster.Spec.Cloud.DatacenterName {
+				t.Fatalf("expected datacenter %s, got %s", expectedCluster.Spec.Cloud.DatacenterName, rawCluster.Spec.Cloud.DatacenterName)
+			}
+		})
+	}
+}
+
+func TestGetClusterRawRedactsSecrets(t *testing.T) {
+	t.Parallel()
+	secrets := map[string]func(*kubermaticv1.Cluster, string){
+		"admin-token":        func(c *kubermaticv1.Cluster, v string) { c.Address.AdminToken = v },
+		"oidc-client-secret": func(c *kubermaticv1.Cluster, v string) { c.Spec.OIDC.ClientSecret = v },
+		"openshift-pull-secret": func(c *kubermaticv1.Cluster, v string) {
+			c.Spec.Openshift = &kubermaticv1.Openshift{ImagePullSecret: v}
+		},
+		"fake-token": func(c *kubermaticv1.Cluster, v string) { c.Spec.Cloud.Fake.Token = v },
+		"digitalocean-token": func(c *kubermaticv1.Cluster, v string) {
+			c.Spec.Cloud.Digitalocean = &kubermaticv1.DigitaloceanCloudSpec{Token: v}
+		},
+		"aws-secret-access-key": func(c *kubermaticv1.Cluster, v string) {
+			c.Spec.Cloud.AWS = &kubermaticv1.AWSCloudSpec{SecretAccessKey: v}
+		},
+		"azure-client-secret": func(c *kubermaticv1.Cluster, v string) {
+			c.Spec.Cloud.Azure = &kubermaticv1.AzureCloudSpec{ClientSecret: v}
+		},
+		"openstack-password": func(c *kubermaticv1.Cluster, v string) {
+			c.Spec.Cloud.Openstack = &kubermaticv1.OpenstackCloudSpec{Password: v}
+		},
+		"packet-api-key": func(c *kubermaticv1.Cluster, v string) {
+			c.Spec.Cloud.Packet = &kubermaticv1.PacketCloudSpec{APIKey: v}
+		},
+		"hetzner-token": func(c *kubermaticv1.Cluster, v string) {
+			c.Spec.Cloud.Hetzner = &kubermaticv1.HetznerCloudSpec{Token: v}
+		},
+		"gcp-service-account": func(c *kubermaticv1.Cluster, v string) {
+			c.Spec.Cloud.GCP = &kubermaticv1.GCPCloudSpec{ServiceAccount: v}
+		},
+		"kubevirt-kubeconfig": func(c *kubermaticv1.Cluster, v string) {
+			c.Spec.Cloud.Kubevirt = &kubermaticv1.KubevirtCloudSpec{Kubeconfig: v}
+		},
+		"alibaba-access-key-secret": func(c *kubermaticv1.Cluster, v string) {
+			c.Spec.Cloud.Alibaba = &kubermaticv1.AlibabaCloudSpec{AccessKeySecret: v}
+		},
+		"vsphere-password": func(c *kubermaticv1.Cluster, v string) {
+			c.Spec.Cloud.VSphere = &kubermaticv1.VSphereCloudSpec{Password: v}
+		},
+		"vsphere-infra-password": func(c *kubermaticv1.Cluster, v string) {
+			if c.Spec.Cloud.VSphere == nil {
+				c.Spec.Cloud.VSphere = &kubermaticv1.VSphereCloudSpec{}
+			}
+			c.Spec.Cloud.VSphere.InfraManagementUser.Password = v
+		},
+	}
+
+	for name, setSecret := range secrets {
+		name, setSecret := name, setSecret
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			secret := fmt.Sprintf("secret-value-of-%s", name)
+			cluster := test.GenDefaultCluster()
+			setSecret(cluster, secret)
+
+			req := httptest.NewRequest("GET", fmt.Sprintf("/api/v2/projects/%s/clusters/%s/raw", test.GenDefaultProject().Name, cluster.Name), nil)
+			res := httptest.NewRecorder()
+			ep, err := test.CreateTestEndpoint(*test.GenAPIUser("John", "john@acme.com"), []runtime.Object{}, test.GenDefaultKubermaticObjects(cluster, genUser("John", "john@acme.com", true)), nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != http.StatusOK {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", http.StatusOK, res.Code, res.Body.String())
+			}
+			if strings.Contains(res.Body.String(), secret) {
+				t.Fatalf("expected the %s to be removed from the raw cluster, got %s", name, res.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/go-kit/kit/endpoint"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	apiv2 "k8c.io/kubermatic/v2/pkg/api/v2"
+	"k8c.io/kubermatic/v2/pkg/controller/master-controller-manager/rbac"
+	handlercommon "k8c.io/kubermatic/v2/pkg/handler/common"
+	"k8c.io/kubermatic/v2/pkg/handler/middleware"
+	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/util/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// crashLoopBackOffReason is the reason of the waiting state of a container which is restarted with a back-off
+const crashLoopBackOffReason = "CrashLoopBackOff"
+
+// restartComponents are the control plane components whose restarts are returned. Their pods are labeled
+// with the name of the component and the container running it is named after it.
+var restartComponents = []string{
+	resources.ApiserverDeploymentName,
+	resources.EtcdStatefulSetName,
+	resources.ControllerManagerDeploymentName,
+}
+
+// GetControlPlaneRestartsEndpoint returns the restarts of the apiserver, etcd and controller-manager pods of the
+// cluster in the seed, including whether they are crash looping. Only the project owners and admins are allowed
+// to get them.
+func GetControlPlaneRestartsEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(GetClusterReq)
+		privilegedClusterProvider := ctx.Value(middleware.PrivilegedClusterProviderContextKey).(provider.PrivilegedClusterProvider)
+
+		cluster, err := handlercommon.GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, req.ProjectID, req.ClusterID, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		adminUserInfo, err := userInfoGetter(ctx, "")
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		if !adminUserInfo.IsAdmin {
+			userInfo, err := userInfoGetter(ctx, req.ProjectID)
+			if err != nil {
+				return nil, common.KubernetesErrorToHTTPError(err)
+			}
+			if rbac.ExtractGroupPrefix(userInfo.Group) != rbac.OwnerGroupNamePrefix {
+				return nil, errors.New(http.StatusForbidden, fmt.Sprintf("forbidden: \"%s\" is not an owner of the project", userInfo.Email))
+			}
+		}
+
+		seedClient := privilegedClusterProvider.GetSeedClusterAdminRuntimeClient()
+		restarts := []apiv2.ControlPlanePodRestarts{}
+		for _, component := range restartComponents {
+			pods := &corev1.PodList{}
+			if err := seedClient.List(ctx, pods, ctrlruntimeclient.InNamespace(cluster.Status.NamespaceName), ctrlruntimeclient.MatchingLabels{resources.AppLabelKey: component}); err != nil {
+				return nil, common.KubernetesErrorToHTTPError(err)
+			}
+			for _, pod := range pods.Items {
+				status := componentContainerStatus(pod.Status.ContainerStatuses, component)
+				if status == nil {
+					continue
+				}
+				restarts = append(restarts, convertContainerStatusToPodRestarts(component, pod.Name, status))
+			}
+		}
+
+		return restarts, nil
+	}
+}
+
+// componentContainerStatus returns the status of the container named after the component, sidecars are ignored
+func componentContainerStatus(statuses []corev1.ContainerStatus, name string) *corev1.ContainerStatus {
+	for i := range statuses {
+		if statuses[i].Name == name {
+			return &statuses[i]
+		}
+	}
+	return nil
+}
+
+func convertContainerStatusToPodRestarts(component, podName string, status *corev1.ContainerStatus) apiv2.ControlPlanePodRestarts {
+	restarts := apiv2.ControlPlanePodRestarts{
+		Component:    component,
+		Pod:          podName,
+		RestartCount: status.RestartCount,
+		CrashLooping: status.State.Waiting != nil && status.State.Waiting.Reason == crashLoopBackOffReason,
+	}
+	if terminated := status.LastTerminationState.Terminated; terminated != nil {
+		restarts.Reason = terminated.Reason
+		restarts.ExitCode = terminated.ExitCode
+		if !terminated.FinishedAt.IsZero() {
+			lastRestart := apiv1.NewTime(terminated.FinishedAt.Time)
+			restarts.LastRestart = &lastRestart
+		}
+	}
+	return restarts
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	"k8c.io/kubermatic/v2/pkg/handler/test"
+	"k8c.io/kubermatic/v2/pkg/handler/test/hack"
+	"k8c.io/kubermatic/v2/pkg/resources"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestGetClusterControlPlaneRestarts(t *testing.T) {
+	t.Parallel()
+	clusterNamespace := test.GenDefaultCluster().Status.NamespaceName
+
+	crashLoopingApiserver := genComponentPod(clusterNamespace, "apiserver-7d9f8", resources.ApiserverDeploymentName, corev1.ContainerStatus{
+		Name:         resources.ApiserverDeploymentName,
+		RestartCount: 3,
+		State: corev1.ContainerState{
+			Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"},
+		},
+		LastTerminationState: corev1.ContainerState{
+			Terminated: &corev1.ContainerStateTerminated{
+				Reason:     "OOMKilled",
+				ExitCode:   137,
+				FinishedAt: metav1.NewTime(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+	})
+	healthyEtcd := genComponentPod(clusterNamespace, "etcd-0", resources.EtcdStatefulSetName, corev1.ContainerStatus{
+		Name: resources.EtcdStatefulSetName,
+		State: corev1.ContainerState{
+			Running: &corev1.ContainerStateRunning{},
+		},
+	})
+	restartedControllerManager := genComponentPod(clusterNamespace, "controller-manager-5c6b4", resources.ControllerManagerDeploymentName, corev1.ContainerStatus{
+		Name:         resources.ControllerManagerDeploymentName,
+		RestartCount: 1,
+		State: corev1.ContainerState{
+			Running: &corev1.ContainerStateRunning{},
+		},
+		LastTerminationState: corev1.ContainerState{
+			Terminated: &corev1.ContainerStateTerminated{
+				Reason:     "Error",
+				ExitCode:   1,
+				FinishedAt: metav1.NewTime(time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+	})
+
+	testcases := []struct {
+		Name                   string
+		ExpectedResponse       string
+		HTTPStatus             int
+		ExistingAPIUser        *apiv1.User
+		ExistingKubeObjs       []runtime.Object
+		ExistingKubermaticObjs []runtime.Object
+	}{
+		{
+			Name:             "scenario 1: the owner gets the restarts of the control plane components",
+			ExpectedResponse: `[{"component":"apiserver","pod":"apiserver-7d9f8","restartCount":3,"crashLooping":true,"reason":"OOMKilled","exitCode":137,"lastRestart":"2020-01-01T00:00:00Z"},{"component":"etcd","pod":"etcd-0","restartCount":0,"crashLooping":false},{"component":"controller-manager","pod":"controller-manager-5c6b4","restartCount":1,"crashLooping":false,"reason":"Error","exitCode":1,"lastRestart":"2020-01-02T00:00:00Z"}]`,
+			HTTPStatus:       http.StatusOK,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExistingKubeObjs: []runtime.Object{
+				crashLoopingApiserver,
+				healthyEtcd,
+				restartedControllerManager,
+				// pods of other clusters are ignored
+				genComponentPod("cluster-other", "apiserver-1a2b3", resources.ApiserverDeploymentName, corev1.ContainerStatus{Name: resources.ApiserverDeploymentName, RestartCount: 5}),
+			},
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(test.GenDefaultCluster()),
+		},
+		{
+			Name:                   "scenario 2: no restarts are returned when the control plane was not deployed yet",
+			ExpectedResponse:       `[]`,
+			HTTPStatus:             http.StatusOK,
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(test.GenDefaultCluster()),
+		},
+		{
+			Name:             "scenario 3: the editor John can not get the restarts",
+			ExpectedResponse: `{"error":{"code":403,"message":"forbidden: \"john@acme.com\" is not an owner of the project"}}`,
+			HTTPStatus:       http.StatusForbidden,
+			ExistingAPIUser:  test.GenAPIUser("John", "john@acme.com"),
+			ExistingKubeObjs: []runtime.Object{crashLoopingApiserver},
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenDefaultCluster(),
+				genUser("John", "john@acme.com", false),
+				test.GenBinding(test.GenDefaultProject().Name, "john@acme.com", "editors"),
+			),
+		},
+		{
+			Name:             "scenario 4: the admin John can get the restarts of Bob's cluster",
+			ExpectedResponse: `[{"component":"etcd","pod":"etcd-0","restartCount":0,"crashLooping":false}]`,
+			HTTPStatus:       http.StatusOK,
+			ExistingAPIUser:  test.GenAPIUser("John", "john@acme.com"),
+			ExistingKubeObjs: []runtime.Object{healthyEtcd},
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenDefaultCluster(),
+				genUser("John", "john@acme.com", true),
+			),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", fmt.Sprintf("/api/v2/projects/%s/clusters/%s/controlplane/restarts", test.GenDefaultProject().Name, test.GenDefaultCluster().Name), nil)
+			res := httptest.NewRecorder()
+			ep, err := test.CreateTestEndpoint(*tc.ExistingAPIUser, tc.ExistingKubeObjs, tc.ExistingKubermaticObjs, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+
+			test.CompareWithResult(t, res, tc.ExpectedResponse)
+		})
+	}
+}
+
+func genComponentPod(namespace, name, component string, status corev1.ContainerStatus) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    map[string]string{resources.AppLabelKey: component},
+		},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{
+				status,
+				// sidecar restarts are ignored
+				{Name: "openvpn-client", RestartCount: 10},
+			},
+		},
+	}
+}
@@ -0,0 +1,195 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/gorilla/mux"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	handlercommon "k8c.io/kubermatic/v2/pkg/handler/common"
+	"k8c.io/kubermatic/v2/pkg/handler/middleware"
+	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/util/errors"
+)
+
+// CopySSHKeysEndpoint assigns all ssh keys of the source cluster to the destination cluster.
+// Keys which are already assigned to the destination cluster are skipped.
+func CopySSHKeysEndpoint(sshKeyProvider provider.SSHKeyProvider, privilegedSSHKeyProvider provider.PrivilegedSSHKeyProvider, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(CopySSHKeysReq)
+		clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
+		privilegedClusterProvider := ctx.Value(middleware.PrivilegedClusterProviderContextKey).(provider.PrivilegedClusterProvider)
+
+		if req.ClusterID == req.SourceClusterID {
+			return nil, errors.NewBadRequest("the source and the destination cluster must be different")
+		}
+
+		project, err := common.GetProject(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, req.ProjectID, nil)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		for _, clusterID := range []string{req.ClusterID, req.SourceClusterID} {
+			cluster, err := handlercommon.GetInternalCluster(ctx, userInfoGetter, clusterProvider, privilegedClusterProvider, project, req.ProjectID, clusterID, &provider.ClusterGetOptions{})
+			if err != nil {
+				return nil, common.KubernetesErrorToHTTPError(err)
+			}
+			if cluster.Labels[kubermaticv1.ProjectIDLabelKey] != project.Name {
+				return nil, errors.NewBadRequest("the cluster %s does not belong to the given project %s", clusterID, project.Name)
+			}
+			if clusterID == req.ClusterID {
+				if err := handlercommon.ValidateSSHKeyAssignment(cluster); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		sourceKeys, err := sshKeyProvider.List(project, &provider.SSHKeyListOptions{ClusterName: req.SourceClusterID})
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		copiedKeys := make([]*kubermaticv1.UserSSHKey, 0)
+		for _, sshKey := range sourceKeys {
+			if sshKey.IsUsedByCluster(req.ClusterID) {
+				continue
+			}
+			sshKey.AddToCluster(req.ClusterID)
+			if err := handlercommon.UpdateClusterSSHKey(ctx, userInfoGetter, sshKeyProvider, privilegedSSHKeyProvider, sshKey, req.ProjectID); err != nil {
+				return nil, err
+			}
+			copiedKeys = append(copiedKeys, sshKey)
+		}
+
+		return common.ConvertInternalSSHKeysToExternal(copiedKeys), nil
+	}
+}
+
+// AssignSSHKeyEndpoint assigns the SSH key to the cluster. Assigning a key which is already assigned is a no-op.
+func AssignSSHKeyEndpoint(sshKeyProvider provider.SSHKeyProvider, privilegedSSHKeyProvider provider.PrivilegedSSHKeyProvider, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(ClusterSSHKeyReq)
+		return handlercommon.AssignSSHKeyEndpoint(ctx, userInfoGetter, sshKeyProvider, privilegedSSHKeyProvider, projectProvider, privilegedProjectProvider, req.ProjectID, req.ClusterID, req.KeyID)
+	}
+}
+
+// ListSSHKeysEndpoint lists the SSH keys which are assigned to the cluster.
+func ListSSHKeysEndpoint(sshKeyProvider provider.SSHKeyProvider, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(GetClusterReq)
+		return handlercommon.ListSSHKeysEndpoint(ctx, userInfoGetter, sshKeyProvider, projectProvider, privilegedProjectProvider, req.ProjectID, req.ClusterID)
+	}
+}
+
+// DetachSSHKeyEndpoint removes the SSH key from the cluster.
+func DetachSSHKeyEndpoint(sshKeyProvider provider.SSHKeyProvider, privilegedSSHKeyProvider provider.PrivilegedSSHKeyProvider, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(ClusterSSHKeyReq)
+		return handlercommon.DetachSSHKeyEndpoint(ctx, userInfoGetter, sshKeyProvider, privilegedSSHKeyProvider, projectProvider, privilegedProjectProvider, req.ProjectID, req.ClusterID, req.KeyID)
+	}
+}
+
+// ClusterSSHKeyReq defines HTTP request for assignSSHKeyToClusterV2 and detachSSHKeyFromClusterV2 endpoints
+// swagger:parameters assignSSHKeyToClusterV2 detachSSHKeyFromClusterV2
+type ClusterSSHKeyReq struct {
+	common.ProjectReq
+	// in: path
+	// required: true
+	ClusterID string `json:"cluster_id"`
+	// in: path
+	// required: true
+	KeyID string `json:"key_id"`
+}
+
+// GetSeedCluster returns the SeedCluster object
+func (req ClusterSSHKeyReq) GetSeedCluster() apiv1.SeedCluster {
+	return apiv1.SeedCluster{
+		ClusterID: req.ClusterID,
+	}
+}
+
+func DecodeClusterSSHKeyReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req ClusterSSHKeyReq
+	clusterID, err := common.DecodeClusterID(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.ClusterID = clusterID
+
+	pr, err := common.DecodeProjectRequest(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.ProjectReq = pr.(common.ProjectReq)
+
+	keyID := mux.Vars(r)["key_id"]
+	if keyID == "" {
+		return nil, fmt.Errorf("'key_id' parameter is required but was not provided")
+	}
+	req.KeyID = keyID
+
+	return req, nil
+}
+
+// CopySSHKeysReq defines HTTP request for copySSHKeysToClusterV2 endpoint
+// swagger:parameters copySSHKeysToClusterV2
+type CopySSHKeysReq struct {
+	common.ProjectReq
+	// in: path
+	// required: true
+	ClusterID string `json:"cluster_id"`
+	// in: path
+	// required: true
+	SourceClusterID string `json:"source_cluster_id"`
+}
+
+// GetSeedCluster returns the SeedCluster object
+func (req CopySSHKeysReq) GetSeedCluster() apiv1.SeedCluster {
+	return apiv1.SeedCluster{
+		ClusterID: req.ClusterID,
+	}
+}
+
+func DecodeCopySSHKeysReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req CopySSHKeysReq
+	clusterID, err := common.DecodeClusterID(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.ClusterID = clusterID
+
+	pr, err := common.DecodeProjectRequest(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.ProjectReq = pr.(common.ProjectReq)
+
+	sourceClusterID := mux.Vars(r)["source_cluster_id"]
+	if sourceClusterID == "" {
+		return nil, fmt.Errorf("'source_cluster_id' parameter is required but was not provided")
+	}
+	req.SourceClusterID = sourceClusterID
+
+	return req, nil
+}
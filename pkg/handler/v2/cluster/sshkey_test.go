@@ -0,0 +1,365 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/handler/test"
+	"k8c.io/kubermatic/v2/pkg/handler/test/hack"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestCopySSHKeysToCluster(t *testing.T) {
+	t.Parallel()
+	creationTime := time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC)
+	testcases := []struct {
+		Name                   string
+		ProjectToSync          string
+		ClusterToSync          string
+		SourceClusterToSync    string
+		ExpectedResponse       string
+		HTTPStatus             int
+		ExistingAPIUser        *apiv1.User
+		ExistingKubermaticObjs []runtime.Object
+		ExpectedSSHKeyClusters map[string][]string
+	}{
+		{
+			Name:                "scenario 1: ssh keys of the source cluster are assigned to the destination cluster",
+			ProjectToSync:       test.GenDefaultProject().Name,
+			ClusterToSync:       "dstClusterID",
+			SourceClusterToSync: "srcClusterID",
+			ExpectedResponse:    `[{"id":"key-abc-first","name":"first","creationTimestamp":"0001-01-01T00:00:00Z","spec":{"fingerprint":"","publicKey":""}}]`,
+			HTTPStatus:          http.StatusCreated,
+			ExistingAPIUser:     test.GenDefaultAPIUser(),
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenCluster("srcClusterID", "src", test.GenDefaultProject().Name, creationTime),
+				test.GenCluster("dstClusterID", "dst", test.GenDefaultProject().Name, creationTime),
+				genSSHKey("key-abc-first", "first", test.GenDefaultProject().Name, "srcClusterID"),
+				genSSHKey("key-abc-second", "second", test.GenDefaultProject().Name, "srcClusterID", "dstClusterID"),
+				genSSHKey("key-abc-third", "third", test.GenDefaultProject().Name),
+			),
+			ExpectedSSHKeyClusters: map[string][]string{
+				"key-abc-first": {"srcClusterID", "dstClusterID"},
+			},
+		},
+		{
+			Name:                "scenario 2: nothing is assigned when the source cluster has no ssh keys",
+			ProjectToSync:       test.GenDefaultProject().Name,
+			ClusterToSync:       "dstClusterID",
+			SourceClusterToSync: "srcClusterID",
+			ExpectedResponse:    `[]`,
+			HTTPStatus:          http.StatusCreated,
+			ExistingAPIUser:     test.GenDefaultAPIUser(),
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenCluster("srcClusterID", "src", test.GenDefaultProject().Name, creationTime),
+				test.GenCluster("dstClusterID", "dst", test.GenDefaultProject().Name, creationTime),
+				genSSHKey("key-abc-first", "first", test.GenDefaultProject().Name, "dstClusterID"),
+			),
+		},
+		{
+			Name:                "scenario 3: ssh keys can not be copied from a cluster that belongs to another project",
+			ProjectToSync:       test.GenDefaultProject().Name,
+			ClusterToSync:       "dstClusterID",
+			SourceClusterToSync: "srcClusterID",
+			ExpectedResponse:    `{"error":{"code":400,"message":"the cluster srcClusterID does not belong to the given project my-first-project-ID"}}`,
+			HTTPStatus:          http.StatusBadRequest,
+			ExistingAPIUser:     test.GenDefaultAPIUser(),
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenCluster("srcClusterID", "src", "other-project-ID", creationTime),
+				test.GenCluster("dstClusterID", "dst", test.GenDefaultProject().Name, creationTime),
+			),
+		},
+		{
+			Name:                "scenario 4: the source and the destination cluster must be different",
+			ProjectToSync:       test.GenDefaultProject().Name,
+			ClusterToSync:       "dstClusterID",
+			SourceClusterToSync: "dstClusterID",
+			ExpectedResponse:    `{"error":{"code":400,"message":"the source and the destination cluster must be different"}}`,
+			HTTPStatus:          http.StatusBadRequest,
+			ExistingAPIUser:     test.GenDefaultAPIUser(),
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenCluster("dstClusterID", "dst", test.GenDefaultProject().Name, creationTime),
+			),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", fmt.Sprintf("/api/v2/projects/%s/clusters/%s/sshkeys/copyfrom/%s", tc.ProjectToSync, tc.ClusterToSync, tc.SourceClusterToSync), nil)
+			res := httptest.NewRecorder()
+			ep, clientsSets, err := test.CreateTestEndpointAndGetClients(*tc.ExistingAPIUser, nil, []runtime.Object{}, []runtime.Object{}, tc.ExistingKubermaticObjs, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+
+			test.CompareWithResult(t, res, tc.ExpectedResponse)
+
+			for keyName, expectedClusters := range tc.ExpectedSSHKeyClusters {
+				sshKey := &kubermaticv1.UserSSHKey{}
+				if err := clientsSets.FakeClient.Get(context.Background(), ctrlruntimeclient.ObjectKey{Name: keyName}, sshKey); err != nil {
+					t.Fatalf("failed to get ssh key %s: %v", keyName, err)
+				}
+				if fmt.Sprint(sshKey.Spec.Clusters) != fmt.Sprint(expectedClusters) {
+					t.Fatalf("expected ssh key %s to be assigned to %v, got %v", keyName, expectedClusters, sshKey.Spec.Clusters)
+				}
+			}
+		})
+	}
+}
+
+func TestAssignAndDetachClusterSSHKey(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		Name                   string
+		Method                 string
+		KeyToSync              string
+		ExpectedResponse       string
+		HTTPStatus             int
+		ExistingAPIUser        *apiv1.User
+		ExistingKubermaticObjs []runtime.Object
+		ExpectedSSHKeyClusters []string
+	}{
+		{
+			Name:             "scenario 1: an ssh key is assigned to the cluster",
+			Method:           http.MethodPut,
+			KeyToSync:        "key-abc-first",
+			ExpectedResponse: `{"id":"key-abc-first","name":"first","creationTimestamp":"0001-01-01T00:00:00Z","spec":{"fingerprint":"","publicKey":""}}`,
+			HTTPStatus:       http.StatusOK,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenDefaultCluster(),
+				genSSHKey("key-abc-first", "first", test.GenDefaultProject().Name, "otherClusterID"),
+			),
+			ExpectedSSHKeyClusters: []string{"otherClusterID", test.DefaultClusterID},
+		},
+		{
+			Name:             "scenario 2: assigning an already assigned ssh key does not duplicate it",
+			Method:           http.MethodPut,
+			KeyToSync:        "key-abc-first",
+			ExpectedResponse: `{"id":"key-abc-first","name":"first","creationTimestamp":"0001-01-01T00:00:00Z","spec":{"fingerprint":"","publicKey":""}}`,
+			HTTPStatus:       http.StatusOK,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenDefaultCluster(),
+				genSSHKey("key-abc-first", "first", test.GenDefaultProject().Name, test.DefaultClusterID),
+			),
+			ExpectedSSHKeyClusters: []string{test.DefaultClusterID},
+		},
+		{
+			Name:             "scenario 3: an ssh key is detached from the cluster",
+			Method:           http.MethodDelete,
+			KeyToSync:        "key-abc-first",
+			ExpectedResponse: `{}`,
+			HTTPStatus:       http.StatusOK,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenDefaultCluster(),
+				genSSHKey("key-abc-first", "first", test.GenDefaultProject().Name, "otherClusterID", test.DefaultClusterID),
+			),
+			ExpectedSSHKeyClusters: []string{"otherClusterID"},
+		},
+		{
+			Name:             "scenario 4: an ssh key of another project can not be detached",
+			Method:           http.MethodDelete,
+			KeyToSync:        "key-abc-first",
+			ExpectedResponse: `{"error":{"code":404,"message":"sshkey \"key-abc-first\" not found"}}`,
+			HTTPStatus:       http.StatusNotFound,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenDefaultCluster(),
+				genSSHKey("key-abc-first", "first", "other-project-ID", test.DefaultClusterID),
+			),
+			ExpectedSSHKeyClusters: []string{test.DefaultClusterID},
+		},
+		{
+			Name:             "scenario 5: the user John can not assign an ssh key to Bob's cluster",
+			Method:           http.MethodPut,
+			KeyToSync:        "key-abc-first",
+			ExpectedResponse: `{"error":{"code":403,"message":"forbidden: \"john@acme.com\" doesn't belong to the given project = my-first-project-ID"}}`,
+			HTTPStatus:       http.StatusForbidden,
+			ExistingAPIUser:  test.GenAPIUser("John", "john@acme.com"),
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenDefaultCluster(),
+				genUser("John", "john@acme.com", false),
+				genSSHKey("key-abc-first", "first", test.GenDefaultProject().Name),
+			),
+			ExpectedSSHKeyClusters: []string{},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.Method, fmt.Sprintf("/api/v2/projects/%s/clusters/%s/sshkeys/%s", test.GenDefaultProject().Name, test.DefaultClusterID, tc.KeyToSync), nil)
+			res := httptest.NewRecorder()
+			ep, clientsSets, err := test.CreateTestEndpointAndGetClients(*tc.ExistingAPIUser, nil, []runtime.Object{}, []runtime.Object{}, tc.ExistingKubermaticObjs, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+
+			test.CompareWithResult(t, res, tc.ExpectedResponse)
+
+			sshKey := &kubermaticv1.UserSSHKey{}
+			if err := clientsSets.FakeClient.Get(context.Background(), ctrlruntimeclient.ObjectKey{Name: tc.KeyToSync}, sshKey); err != nil {
+				t.Fatalf("failed to get ssh key %s: %v", tc.KeyToSync, err)
+			}
+			if fmt.Sprint(sshKey.Spec.Clusters) != fmt.Sprint(tc.ExpectedSSHKeyClusters) {
+				t.Fatalf("expected ssh key %s to be assigned to %v, got %v", tc.KeyToSync, tc.ExpectedSSHKeyClusters, sshKey.Spec.Clusters)
+			}
+		})
+	}
+}
+
+func TestListSSHKeysAssignedToCluster(t *testing.T) {
+	t.Parallel()
+	creationTime := time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC)
+	withCreationTime := func(key *kubermaticv1.UserSSHKey, offset time.Duration) *kubermaticv1.UserSSHKey {
+		key.CreationTimestamp = metav1.NewTime(creationTime.Add(offset))
+		return key
+	}
+
+	testcases := []struct {
+		Name                   string
+		ExpectedKeys           []apiv1.SSHKey
+		ExpectedResponse       string
+		HTTPStatus             int
+		ExistingAPIUser        *apiv1.User
+		ExistingKubermaticObjs []runtime.Object
+	}{
+		{
+			Name: "scenario 1: only the ssh keys assigned to the cluster are listed",
+			ExpectedKeys: []apiv1.SSHKey{
+				{
+					ObjectMeta: apiv1.ObjectMeta{
+						ID:                "key-abc-first",
+						Name:              "first",
+						CreationTimestamp: apiv1.NewTime(creationTime),
+					},
+				},
+				{
+					ObjectMeta: apiv1.ObjectMeta{
+						ID:                "key-abc-third",
+						Name:              "third",
+						CreationTimestamp: apiv1.NewTime(creationTime.Add(2 * time.Minute)),
+					},
+				},
+			},
+			HTTPStatus:      http.StatusOK,
+			ExistingAPIUser: test.GenDefaultAPIUser(),
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenDefaultCluster(),
+				withCreationTime(genSSHKey("key-abc-first", "first", test.GenDefaultProject().Name, test.DefaultClusterID), 0),
+				withCreationTime(genSSHKey("key-abc-second", "second", test.GenDefaultProject().Name, "otherClusterID"), time.Minute),
+				withCreationTime(genSSHKey("key-abc-third", "third", test.GenDefaultProject().Name, "otherClusterID", test.DefaultClusterID), 2*time.Minute),
+				withCreationTime(genSSHKey("key-abc-fourth", "fourth", test.GenDefaultProject().Name), 3*time.Minute),
+			),
+		},
+		{
+			Name:            "scenario 2: no ssh keys are assigned to the cluster",
+			ExpectedKeys:    []apiv1.SSHKey{},
+			HTTPStatus:      http.StatusOK,
+			ExistingAPIUser: test.GenDefaultAPIUser(),
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenDefaultCluster(),
+				genSSHKey("key-abc-second", "second", test.GenDefaultProject().Name, "otherClusterID"),
+			),
+		},
+		{
+			Name:             "scenario 3: the user John can not list the ssh keys of Bob's cluster",
+			ExpectedResponse: `{"error":{"code":403,"message":"forbidden: \"john@acme.com\" doesn't belong to the given project = my-first-project-ID"}}`,
+			HTTPStatus:       http.StatusForbidden,
+			ExistingAPIUser:  test.GenAPIUser("John", "john@acme.com"),
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenDefaultCluster(),
+				genUser("John", "john@acme.com", false),
+				genSSHKey("key-abc-first", "first", test.GenDefaultProject().Name, test.DefaultClusterID),
+			),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/v2/projects/%s/clusters/%s/sshkeys", test.GenDefaultProject().Name, test.DefaultClusterID), nil)
+			res := httptest.NewRecorder()
+			ep, err := test.CreateTestEndpoint(*tc.ExistingAPIUser, []runtime.Object{}, tc.ExistingKubermaticObjs, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+
+			if tc.HTTPStatus != http.StatusOK {
+				test.CompareWithResult(t, res, tc.ExpectedResponse)
+				return
+			}
+
+			actualKeys := test.NewSSHKeyV1SliceWrapper{}
+			actualKeys.DecodeOrDie(res.Body, t).Sort()
+
+			wrappedExpectedKeys := test.NewSSHKeyV1SliceWrapper(tc.ExpectedKeys)
+			wrappedExpectedKeys.Sort()
+
+			actualKeys.EqualOrDie(wrappedExpectedKeys, t)
+		})
+	}
+}
+
+func genSSHKey(id, name, projectID string, clusters ...string) *kubermaticv1.UserSSHKey {
+	return &kubermaticv1.UserSSHKey{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: id,
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: "kubermatic.k8s.io/v1",
+					Kind:       "Project",
+					UID:        "",
+					Name:       projectID,
+				},
+			},
+		},
+		Spec: kubermaticv1.SSHKeySpec{
+			Name:     name,
+			Clusters: clusters,
+		},
+	}
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-kit/kit/endpoint"
+
+	apiv2 "k8c.io/kubermatic/v2/pkg/api/v2"
+	handlercommon "k8c.io/kubermatic/v2/pkg/handler/common"
+	"k8c.io/kubermatic/v2/pkg/handler/middleware"
+	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
+	"k8c.io/kubermatic/v2/pkg/provider"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ListVolumesEndpoint returns the PersistentVolumes of the cluster together with their backing cloud disks
+func ListVolumesEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(GetClusterReq)
+		clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
+
+		cluster, err := handlercommon.GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, req.ProjectID, req.ClusterID, &provider.ClusterGetOptions{CheckInitStatus: true})
+		if err != nil {
+			return nil, err
+		}
+
+		client, err := common.GetClusterClient(ctx, userInfoGetter, clusterProvider, cluster, req.ProjectID)
+		if err != nil {
+			return nil, clusterUnreachableToHTTPError(err)
+		}
+
+		volumes := &corev1.PersistentVolumeList{}
+		if err := client.List(ctx, volumes); err != nil {
+			return nil, clusterUnreachableToHTTPError(err)
+		}
+
+		result := make([]apiv2.ClusterVolume, 0, len(volumes.Items))
+		for _, volume := range volumes.Items {
+			apiVolume := apiv2.ClusterVolume{
+				Name:         volume.Name,
+				StorageClass: volume.Spec.StorageClassName,
+				Phase:        string(volume.Status.Phase),
+				DiskID:       cloudDiskID(volume.Spec.PersistentVolumeSource),
+			}
+			if capacity, ok := volume.Spec.Capacity[corev1.ResourceStorage]; ok {
+				apiVolume.Capacity = capacity.String()
+			}
+			if claim := volume.Spec.ClaimRef; claim != nil {
+				apiVolume.Claim = fmt.Sprintf("%s/%s", claim.Namespace, claim.Name)
+			}
+			result = append(result, apiVolume)
+		}
+
+		return result, nil
+	}
+}
+
+// cloudDiskID returns the identifier of the cloud disk backing the given volume source, for
+// CSI volumes this is the volume handle of the driver.
+func cloudDiskID(source corev1.PersistentVolumeSource) string {
+	switch {
+	case source.AWSElasticBlockStore != nil:
+		return source.AWSElasticBlockStore.VolumeID
+	case source.GCEPersistentDisk != nil:
+		return source.GCEPersistentDisk.PDName
+	case source.AzureDisk != nil:
+		return source.AzureDisk.DiskURI
+	case source.Cinder != nil:
+		return source.Cinder.VolumeID
+	case source.VsphereVolume != nil:
+		return source.VsphereVolume.VolumePath
+	case source.CSI != nil:
+		return source.CSI.VolumeHandle
+	}
+	return ""
+}
@@ -0,0 +1,225 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-kit/kit/endpoint"
+
+	apiv2 "k8c.io/kubermatic/v2/pkg/api/v2"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	handlercommon "k8c.io/kubermatic/v2/pkg/handler/common"
+	"k8c.io/kubermatic/v2/pkg/handler/middleware"
+	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
+	"k8c.io/kubermatic/v2/pkg/provider"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// defaultWebhookTimeout is the time the API server waits for a webhook which does not set a timeout
+	defaultWebhookTimeout = 10 * time.Second
+
+	validatingWebhookType = "validating"
+	mutatingWebhookType   = "mutating"
+)
+
+// webhook is an admission webhook registered in a user cluster
+type webhook struct {
+	diagnostic   apiv2.WebhookDiagnostic
+	clientConfig admissionregistrationv1.WebhookClientConfig
+	timeout      time.Duration
+}
+
+// GetWebhookDiagnosticsEndpoint probes the admission webhooks registered in the cluster which are backed by a service
+// through the API server of the cluster. A webhook which does not answer within its timeout is reported as
+// unreachable. Webhooks called by URL are only reported with their configuration, the Kubermatic API can not tell
+// whether the API server of the cluster reaches them.
+func GetWebhookDiagnosticsEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(GetClusterReq)
+		clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
+
+		cluster, err := handlercommon.GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, req.ProjectID, req.ClusterID, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		client, err := clusterProvider.GetAdminClientForCustomerCluster(cluster)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		webhooks, err := listWebhooks(ctx, client)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		// webhooks backed by a service are only reachable through the API server of the cluster
+		var clientset kubernetes.Interface
+		for _, webhook := range webhooks {
+			if webhook.clientConfig.Service != nil {
+				if clientset, err = userClusterClientset(clusterProvider, cluster); err != nil {
+					return nil, common.KubernetesErrorToHTTPError(err)
+				}
+				break
+			}
+		}
+
+		diagnostics := make([]apiv2.WebhookDiagnostic, len(webhooks))
+		wg := sync.WaitGroup{}
+		for i := range webhooks {
+			if webhooks[i].clientConfig.Service == nil {
+				diagnostics[i] = webhooks[i].diagnostic
+				continue
+			}
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				diagnostics[i] = probeWebhook(ctx, clientset, webhooks[i])
+			}(i)
+		}
+		wg.Wait()
+
+		return diagnostics, nil
+	}
+}
+
+// listWebhooks returns the validating webhooks followed by the mutating webhooks, ordered by their configuration
+func listWebhooks(ctx context.Context, client ctrlruntimeclient.Client) ([]webhook, error) {
+	validatingConfigs := &admissionregistrationv1.ValidatingWebhookConfigurationList{}
+	if err := client.List(ctx, validatingConfigs); err != nil {
+		return nil, err
+	}
+	sort.Slice(validatingConfigs.Items, func(i, j int) bool {
+		return validatingConfigs.Items[i].Name < validatingConfigs.Items[j].Name
+	})
+	mutatingConfigs := &admissionregistrationv1.MutatingWebhookConfigurationList{}
+	if err := client.List(ctx, mutatingConfigs); err != nil {
+		return nil, err
+	}
+	sort.Slice(mutatingConfigs.Items, func(i, j int) bool {
+		return mutatingConfigs.Items[i].Name < mutatingConfigs.Items[j].Name
+	})
+
+	webhooks := []webhook{}
+	for _, config := range validatingConfigs.Items {
+		for _, w := range config.Webhooks {
+			webhooks = append(webhooks, newWebhook(validatingWebhookType, config.Name, w.Name, w.ClientConfig, w.FailurePolicy, w.TimeoutSeconds))
+		}
+	}
+	for _, config := range mutatingConfigs.Items {
+		for _, w := range config.Webhooks {
+			webhooks = append(webhooks, newWebhook(mutatingWebhookType, config.Name, w.Name, w.ClientConfig, w.FailurePolicy, w.TimeoutSeconds))
+		}
+	}
+	return webhooks, nil
+}
+
+func newWebhook(webhookType, configuration, name string, clientConfig admissionregistrationv1.WebhookClientConfig, failurePolicy *admissionregistrationv1.FailurePolicyType, timeoutSeconds *int32) webhook {
+	w := webhook{
+		diagnostic: apiv2.WebhookDiagnostic{
+			Configuration: configuration,
+			Name:          name,
+			Type:          webhookType,
+		},
+		clientConfig: clientConfig,
+		timeout:      defaultWebhookTimeout,
+	}
+	if failurePolicy != nil {
+		w.diagnostic.FailurePolicy = string(*failurePolicy)
+	}
+	if timeoutSeconds != nil {
+		w.timeout = time.Duration(*timeoutSeconds) * time.Second
+	}
+
+	switch {
+	case clientConfig.URL != nil:
+		w.diagnostic.Endpoint = *clientConfig.URL
+	case clientConfig.Service != nil:
+		w.diagnostic.Endpoint = fmt.Sprintf("https://%s.%s.svc:%d%s", clientConfig.Service.Name, clientConfig.Service.Namespace, webhookServicePort(clientConfig.Service), webhookServicePath(clientConfig.Service))
+	}
+	return w
+}
+
+func probeWebhook(ctx context.Context, clientset kubernetes.Interface, w webhook) apiv2.WebhookDiagnostic {
+	diagnostic := w.diagnostic
+	diagnostic.Probed = true
+
+	ctx, cancel := context.WithTimeout(ctx, w.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := probeWebhookService(ctx, clientset, w.clientConfig.Service)
+	diagnostic.LatencyMilliseconds = time.Since(start).Milliseconds()
+
+	diagnostic.Reachable = err == nil
+	if err != nil {
+		diagnostic.Error = err.Error()
+	}
+	return diagnostic
+}
+
+// probeWebhookService sends a request to the webhook through the service proxy of the API server
+func probeWebhookService(ctx context.Context, clientset kubernetes.Interface, service *admissionregistrationv1.ServiceReference) error {
+	_, err := clientset.CoreV1().Services(service.Namespace).ProxyGet("https", service.Name, strconv.Itoa(int(webhookServicePort(service))), webhookServicePath(service), nil).DoRaw(ctx)
+	if statusErr, ok := err.(*kerrors.StatusError); ok {
+		switch statusErr.Status().Code {
+		case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return err
+		}
+		// any other error status was sent by the webhook itself
+		return nil
+	}
+	return err
+}
+
+func webhookServicePort(service *admissionregistrationv1.ServiceReference) int32 {
+	if service.Port != nil {
+		return *service.Port
+	}
+	return 443
+}
+
+func webhookServicePath(service *admissionregistrationv1.ServiceReference) string {
+	if service.Path != nil {
+		return *service.Path
+	}
+	return ""
+}
+
+func userClusterClientset(clusterProvider provider.ClusterProvider, cluster *kubermaticv1.Cluster) (kubernetes.Interface, error) {
+	kubeconfig, err := clusterProvider.GetAdminKubeconfigForCustomerCluster(cluster)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := clientcmd.NewDefaultClientConfig(*kubeconfig, &clientcmd.ConfigOverrides{}).ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	return kubernetes.NewForConfig(cfg)
+}
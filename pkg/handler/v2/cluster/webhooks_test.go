@@ -0,0 +1,108 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	apiv2 "k8c.io/kubermatic/v2/pkg/api/v2"
+	"k8c.io/kubermatic/v2/pkg/handler/test"
+	"k8c.io/kubermatic/v2/pkg/handler/test/hack"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestGetClusterWebhookDiagnostics(t *testing.T) {
+	t.Parallel()
+	validatingURL := "https://policies.example.com/validate"
+	mutatingURL := "https://10.0.0.1:8443/mutate"
+
+	fail := admissionregistrationv1.Fail
+	ignore := admissionregistrationv1.Ignore
+	timeout := int32(2)
+
+	existingKubeObjs := []runtime.Object{
+		&admissionregistrationv1.ValidatingWebhookConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Name: "policies"},
+			Webhooks: []admissionregistrationv1.ValidatingWebhook{
+				{
+					Name:           "validate.policies.example.com",
+					ClientConfig:   admissionregistrationv1.WebhookClientConfig{URL: &validatingURL},
+					FailurePolicy:  &fail,
+					TimeoutSeconds: &timeout,
+				},
+			},
+		},
+		&admissionregistrationv1.MutatingWebhookConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Name: "defaults"},
+			Webhooks: []admissionregistrationv1.MutatingWebhook{
+				{
+					Name:           "mutate.defaults.example.com",
+					ClientConfig:   admissionregistrationv1.WebhookClientConfig{URL: &mutatingURL},
+					FailurePolicy:  &ignore,
+					TimeoutSeconds: &timeout,
+				},
+			},
+		},
+	}
+
+	req := httptest.NewRequest("GET", fmt.Sprintf("/api/v2/projects/%s/clusters/%s/webhooks/diagnostics", test.GenDefaultProject().Name, test.GenDefaultCluster().Name), nil)
+	res := httptest.NewRecorder()
+	ep, err := test.CreateTestEndpoint(*test.GenDefaultAPIUser(), existingKubeObjs, test.GenDefaultKubermaticObjects(test.GenDefaultCluster()), nil, nil, hack.NewTestRouting)
+	if err != nil {
+		t.Fatalf("failed to create test endpoint due to %v", err)
+	}
+
+	ep.ServeHTTP(res, req)
+
+	if res.Code != http.StatusOK {
+		t.Fatalf("Expected HTTP status code %d, got %d: %s", http.StatusOK, res.Code, res.Body.String())
+	}
+
+	diagnostics := []apiv2.WebhookDiagnostic{}
+	if err := json.Unmarshal(res.Body.Bytes(), &diagnostics); err != nil {
+		t.Fatalf("failed to decode the webhook diagnostics: %v", err)
+	}
+	if len(diagnostics) != 2 {
+		t.Fatalf("expected 2 webhook diagnostics, got %d: %s", len(diagnostics), res.Body.String())
+	}
+
+	validating := diagnostics[0]
+	if validating.Type != "validating" || validating.Name != "validate.policies.example.com" || validating.Configuration != "policies" {
+		t.Errorf("expected the validating webhook first, got %+v", validating)
+	}
+	if validating.Probed || validating.Reachable || validating.Error != "" {
+		t.Errorf("expected the validating webhook called by URL not to be probed, got %+v", validating)
+	}
+	if validating.Endpoint != validatingURL || validating.FailurePolicy != "Fail" {
+		t.Errorf("expected endpoint %s with failure policy Fail, got %+v", validatingURL, validating)
+	}
+
+	mutating := diagnostics[1]
+	if mutating.Type != "mutating" || mutating.Name != "mutate.defaults.example.com" || mutating.Configuration != "defaults" {
+		t.Errorf("expected the mutating webhook second, got %+v", mutating)
+	}
+	if mutating.Probed || mutating.Endpoint != mutatingURL || mutating.FailurePolicy != "Ignore" {
+		t.Errorf("expected the mutating webhook called by URL %s not to be probed, got %+v", mutatingURL, mutating)
+	}
+}
@@ -0,0 +1,284 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package constraint
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/gorilla/mux"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	apiv2 "k8c.io/kubermatic/v2/pkg/api/v2"
+	"k8c.io/kubermatic/v2/pkg/controller/master-controller-manager/rbac"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	handlercommon "k8c.io/kubermatic/v2/pkg/handler/common"
+	"k8c.io/kubermatic/v2/pkg/handler/middleware"
+	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/util/errors"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func ListEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listConstraintsReq)
+		privilegedClusterProvider := ctx.Value(middleware.PrivilegedClusterProviderContextKey).(provider.PrivilegedClusterProvider)
+
+		cluster, err := handlercommon.GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, req.ProjectID, req.ClusterID, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		constraintList := &kubermaticv1.ConstraintList{}
+		if err := privilegedClusterProvider.GetSeedClusterAdminRuntimeClient().List(ctx, constraintList, ctrlruntimeclient.InNamespace(cluster.Status.NamespaceName)); err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		apiConstraints := make([]*apiv2.Constraint, 0, len(constraintList.Items))
+		for i := range constraintList.Items {
+			apiConstraints = append(apiConstraints, convertConstraintToAPI(&constraintList.Items[i]))
+		}
+		sort.Slice(apiConstraints, func(i, j int) bool {
+			return apiConstraints[i].Name < apiConstraints[j].Name
+		})
+
+		return apiConstraints, nil
+	}
+}
+
+func GetEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(constraintReq)
+		privilegedClusterProvider := ctx.Value(middleware.PrivilegedClusterProviderContextKey).(provider.PrivilegedClusterProvider)
+
+		cluster, err := handlercommon.GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, req.ProjectID, req.ClusterID, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		constraint := &kubermaticv1.Constraint{}
+		if err := privilegedClusterProvider.GetSeedClusterAdminRuntimeClient().Get(ctx, types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: req.Name}, constraint); err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		return convertConstraintToAPI(constraint), nil
+	}
+}
+
+func CreateEndpoint(constraintTemplateProvider provider.ConstraintTemplateProvider, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(createConstraintReq)
+		privilegedClusterProvider := ctx.Value(middleware.PrivilegedClusterProviderContextKey).(provider.PrivilegedClusterProvider)
+
+		if err := req.Validate(); err != nil {
+			return nil, errors.NewBadRequest(err.Error())
+		}
+
+		cluster, err := handlercommon.GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, req.ProjectID, req.ClusterID, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := checkWriteAccess(ctx, userInfoGetter, req.ProjectID); err != nil {
+			return nil, err
+		}
+
+		// gatekeeper names the constraint templates after the lowercased kind of the constraints they define
+		templateName := strings.ToLower(req.Body.Spec.ConstraintType)
+		ct, err := constraintTemplateProvider.Get(templateName)
+		if err != nil {
+			if kerrors.IsNotFound(err) {
+				return nil, errors.NewBadRequest("the constraint template %q of constraint type %q does not exist", templateName, req.Body.Spec.ConstraintType)
+			}
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		if ct.Spec.CRD.Spec.Names.Kind != req.Body.Spec.ConstraintType {
+			return nil, errors.NewBadRequest("the constraint template %q defines the constraint type %q, not %q", templateName, ct.Spec.CRD.Spec.Names.Kind, req.Body.Spec.ConstraintType)
+		}
+
+		constraint := &kubermaticv1.Constraint{}
+		constraint.Name = req.Body.Name
+		constraint.Namespace = cluster.Status.NamespaceName
+		constraint.Spec = req.Body.Spec
+
+		if err := privilegedClusterProvider.GetSeedClusterAdminRuntimeClient().Create(ctx, constraint); err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		return convertConstraintToAPI(constraint), nil
+	}
+}
+
+func DeleteEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(constraintReq)
+		privilegedClusterProvider := ctx.Value(middleware.PrivilegedClusterProviderContextKey).(provider.PrivilegedClusterProvider)
+
+		cluster, err := handlercommon.GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, req.ProjectID, req.ClusterID, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := checkWriteAccess(ctx, userInfoGetter, req.ProjectID); err != nil {
+			return nil, err
+		}
+
+		seedClient := privilegedClusterProvider.GetSeedClusterAdminRuntimeClient()
+		constraint := &kubermaticv1.Constraint{}
+		if err := seedClient.Get(ctx, types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: req.Name}, constraint); err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		if err := seedClient.Delete(ctx, constraint); err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		return nil, nil
+	}
+}
+
+// checkWriteAccess makes sure the user may change the constraints of the cluster. The constraints are written with
+// the admin client of the seed, so the viewers of the project have to be rejected here.
+func checkWriteAccess(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectID string) error {
+	adminUserInfo, err := userInfoGetter(ctx, "")
+	if err != nil {
+		return common.KubernetesErrorToHTTPError(err)
+	}
+	if adminUserInfo.IsAdmin {
+		return nil
+	}
+
+	userInfo, err := userInfoGetter(ctx, projectID)
+	if err != nil {
+		return common.KubernetesErrorToHTTPError(err)
+	}
+	if strings.HasPrefix(userInfo.Group, rbac.ViewerGroupNamePrefix) {
+		return errors.New(http.StatusForbidden, fmt.Sprintf("forbidden: %q is a viewer of the project %s and can not change its constraints", userInfo.Email, projectID))
+	}
+	return nil
+}
+
+func convertConstraintToAPI(constraint *kubermaticv1.Constraint) *apiv2.Constraint {
+	return &apiv2.Constraint{
+		Name: constraint.Name,
+		Spec: constraint.Spec,
+	}
+}
+
+// listConstraintsReq represents a request for the constraints of a cluster
+// swagger:parameters listConstraints
+type listConstraintsReq struct {
+	common.ProjectReq
+	// in: path
+	// required: true
+	ClusterID string `json:"cluster_id"`
+}
+
+// GetSeedCluster returns the SeedCluster object
+func (req listConstraintsReq) GetSeedCluster() apiv1.SeedCluster {
+	return apiv1.SeedCluster{
+		ClusterID: req.ClusterID,
+	}
+}
+
+func DecodeListConstraintsReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req listConstraintsReq
+
+	clusterID, err := common.DecodeClusterID(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.ClusterID = clusterID
+
+	pr, err := common.DecodeProjectRequest(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.ProjectReq = pr.(common.ProjectReq)
+
+	return req, nil
+}
+
+// constraintReq represents a request for a specific constraint of a cluster
+// swagger:parameters getConstraint deleteConstraint
+type constraintReq struct {
+	listConstraintsReq
+	// in: path
+	// required: true
+	Name string `json:"constraint_name"`
+}
+
+func DecodeConstraintReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req constraintReq
+
+	listReq, err := DecodeListConstraintsReq(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.listConstraintsReq = listReq.(listConstraintsReq)
+
+	req.Name = mux.Vars(r)["constraint_name"]
+	if req.Name == "" {
+		return nil, fmt.Errorf("'constraint_name' parameter is required but was not provided")
+	}
+
+	return req, nil
+}
+
+// createConstraintReq represents a request for creating a constraint of a cluster
+// swagger:parameters createConstraint
+type createConstraintReq struct {
+	listConstraintsReq
+	// in: body
+	// required: true
+	Body apiv2.Constraint
+}
+
+func DecodeCreateConstraintReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req createConstraintReq
+
+	listReq, err := DecodeListConstraintsReq(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.listConstraintsReq = listReq.(listConstraintsReq)
+
+	if err := json.NewDecoder(r.Body).Decode(&req.Body); err != nil {
+		return nil, errors.NewBadRequest("invalid constraint: %v", err)
+	}
+
+	return req, nil
+}
+
+// Validate validates createConstraint request
+func (req createConstraintReq) Validate() error {
+	if len(req.Body.Name) == 0 {
+		return fmt.Errorf("the constraint name cannot be empty")
+	}
+	if len(req.Body.Spec.ConstraintType) == 0 {
+		return fmt.Errorf("the constraint type cannot be empty")
+	}
+	return nil
+}
@@ -0,0 +1,349 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package constraint_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/handler/test"
+	"k8c.io/kubermatic/v2/pkg/handler/test/hack"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const clusterNamespace = "cluster-" + test.DefaultClusterID
+
+func TestListConstraints(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		Name             string
+		ExpectedResponse string
+		HTTPStatus       int
+		ExistingAPIUser  *apiv1.User
+		ExistingObjects  []runtime.Object
+	}{
+		{
+			Name:             "scenario 1: list the constraints of the cluster ordered by name",
+			ExpectedResponse: `[{"name":"ct1","spec":{"constraintType":"RequiredLabels","match":{"kinds":[{"kinds":["Namespace"],"apiGroups":[""]}]},"parameters":{"labels":["gatekeeper"]}}},{"name":"ct2","spec":{"constraintType":"RequiredLabels","match":{"kinds":[{"kinds":["Namespace"],"apiGroups":[""]}]},"parameters":{"labels":["gatekeeper"]}}}]`,
+			HTTPStatus:       http.StatusOK,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExistingObjects: test.GenDefaultKubermaticObjects(
+				test.GenDefaultCluster(),
+				genConstraint("ct2", clusterNamespace),
+				genConstraint("ct1", clusterNamespace),
+				genConstraint("ct3", "cluster-other"),
+			),
+		},
+		{
+			Name:             "scenario 2: the user John can not list the constraints of Bob's cluster",
+			ExpectedResponse: `{"error":{"code":403,"message":"forbidden: \"john@acme.com\" doesn't belong to the given project = my-first-project-ID"}}`,
+			HTTPStatus:       http.StatusForbidden,
+			ExistingAPIUser:  test.GenAPIUser("John", "john@acme.com"),
+			ExistingObjects: test.GenDefaultKubermaticObjects(
+				test.GenDefaultCluster(),
+				test.GenUser("", "John", "john@acme.com"),
+				genConstraint("ct1", clusterNamespace),
+			),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/v2/projects/%s/clusters/%s/constraints", test.GenDefaultProject().Name, test.DefaultClusterID), nil)
+			res := httptest.NewRecorder()
+			ep, err := test.CreateTestEndpoint(*tc.ExistingAPIUser, nil, tc.ExistingObjects, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+
+			test.CompareWithResult(t, res, tc.ExpectedResponse)
+		})
+	}
+}
+
+func TestGetConstraint(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		Name             string
+		ConstraintName   string
+		ExpectedResponse string
+		HTTPStatus       int
+		ExistingAPIUser  *apiv1.User
+		ExistingObjects  []runtime.Object
+	}{
+		{
+			Name:             "scenario 1: get the constraint of the cluster",
+			ConstraintName:   "ct1",
+			ExpectedResponse: `{"name":"ct1","spec":{"constraintType":"RequiredLabels","match":{"kinds":[{"kinds":["Namespace"],"apiGroups":[""]}]},"parameters":{"labels":["gatekeeper"]}}}`,
+			HTTPStatus:       http.StatusOK,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExistingObjects: test.GenDefaultKubermaticObjects(
+				test.GenDefaultCluster(),
+				genConstraint("ct1", clusterNamespace),
+			),
+		},
+		{
+			Name:             "scenario 2: the constraint of another cluster is not found",
+			ConstraintName:   "ct1",
+			ExpectedResponse: `{"error":{"code":404,"message":"constraints.kubermatic.k8s.io \"ct1\" not found"}}`,
+			HTTPStatus:       http.StatusNotFound,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExistingObjects: test.GenDefaultKubermaticObjects(
+				test.GenDefaultCluster(),
+				genConstraint("ct1", "cluster-other"),
+			),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/v2/projects/%s/clusters/%s/constraints/%s", test.GenDefaultProject().Name, test.DefaultClusterID, tc.ConstraintName), nil)
+			res := httptest.NewRecorder()
+			ep, err := test.CreateTestEndpoint(*tc.ExistingAPIUser, nil, tc.ExistingObjects, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+
+			test.CompareWithResult(t, res, tc.ExpectedResponse)
+		})
+	}
+}
+
+func TestCreateConstraint(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		Name             string
+		Body             string
+		ExpectedResponse string
+		HTTPStatus       int
+		ExistingAPIUser  *apiv1.User
+		ExistingObjects  []runtime.Object
+	}{
+		{
+			Name:             "scenario 1: create a constraint of an existing constraint template",
+			Body:             `{"name":"ct1","spec":{"constraintType":"RequiredLabels","match":{"kinds":[{"kinds":["Namespace"],"apiGroups":[""]}]},"parameters":{"labels":["gatekeeper"]}}}`,
+			ExpectedResponse: `{"name":"ct1","spec":{"constraintType":"RequiredLabels","match":{"kinds":[{"kinds":["Namespace"],"apiGroups":[""]}]},"parameters":{"labels":["gatekeeper"]}}}`,
+			HTTPStatus:       http.StatusCreated,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExistingObjects: test.GenDefaultKubermaticObjects(
+				test.GenDefaultCluster(),
+				genConstraintTemplate("requiredlabels", "RequiredLabels"),
+			),
+		},
+		{
+			Name:             "scenario 2: a constraint of a missing constraint template can not be created",
+			Body:             `{"name":"ct1","spec":{"constraintType":"RequiredLabels"}}`,
+			ExpectedResponse: `{"error":{"code":400,"message":"the constraint template \"requiredlabels\" of constraint type \"RequiredLabels\" does not exist"}}`,
+			HTTPStatus:       http.StatusBadRequest,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExistingObjects: test.GenDefaultKubermaticObjects(
+				test.GenDefaultCluster(),
+				genConstraintTemplate("otherlabels", "OtherLabels"),
+			),
+		},
+		{
+			Name:             "scenario 3: the constraint type is required",
+			Body:             `{"name":"ct1","spec":{}}`,
+			ExpectedResponse: `{"error":{"code":400,"message":"the constraint type cannot be empty"}}`,
+			HTTPStatus:       http.StatusBadRequest,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExistingObjects: test.GenDefaultKubermaticObjects(
+				test.GenDefaultCluster(),
+			),
+		},
+		{
+			Name:             "scenario 4: the user John can not create a constraint for Bob's cluster",
+			Body:             `{"name":"ct1","spec":{"constraintType":"RequiredLabels"}}`,
+			ExpectedResponse: `{"error":{"code":403,"message":"forbidden: \"john@acme.com\" doesn't belong to the given project = my-first-project-ID"}}`,
+			HTTPStatus:       http.StatusForbidden,
+			ExistingAPIUser:  test.GenAPIUser("John", "john@acme.com"),
+			ExistingObjects: test.GenDefaultKubermaticObjects(
+				test.GenDefaultCluster(),
+				test.GenUser("", "John", "john@acme.com"),
+				genConstraintTemplate("requiredlabels", "RequiredLabels"),
+			),
+		},
+		{
+			Name:             "scenario 5: the constraint type has to match the kind of the constraint template",
+			Body:             `{"name":"ct1","spec":{"constraintType":"RequiredLabels"}}`,
+			ExpectedResponse: `{"error":{"code":400,"message":"the constraint template \"requiredlabels\" defines the constraint type \"RequiredLabel\", not \"RequiredLabels\""}}`,
+			HTTPStatus:       http.StatusBadRequest,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExistingObjects: test.GenDefaultKubermaticObjects(
+				test.GenDefaultCluster(),
+				genConstraintTemplate("requiredlabels", "RequiredLabel"),
+			),
+		},
+		{
+			Name:             "scenario 6: the viewer John can not create a constraint",
+			Body:             `{"name":"ct1","spec":{"constraintType":"RequiredLabels"}}`,
+			ExpectedResponse: `{"error":{"code":403,"message":"forbidden: \"john@acme.com\" is a viewer of the project my-first-project-ID and can not change its constraints"}}`,
+			HTTPStatus:       http.StatusForbidden,
+			ExistingAPIUser:  test.GenAPIUser("John", "john@acme.com"),
+			ExistingObjects: test.GenDefaultKubermaticObjects(
+				test.GenDefaultCluster(),
+				test.GenUser("", "John", "john@acme.com"),
+				test.GenBinding(test.GenDefaultProject().Name, "john@acme.com", "viewers"),
+				genConstraintTemplate("requiredlabels", "RequiredLabels"),
+			),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/v2/projects/%s/clusters/%s/constraints", test.GenDefaultProject().Name, test.DefaultClusterID), strings.NewReader(tc.Body))
+			res := httptest.NewRecorder()
+			ep, clientsSets, err := test.CreateTestEndpointAndGetClients(*tc.ExistingAPIUser, nil, nil, nil, tc.ExistingObjects, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+
+			test.CompareWithResult(t, res, tc.ExpectedResponse)
+
+			constraint := &kubermaticv1.Constraint{}
+			err = clientsSets.FakeClient.Get(context.Background(), types.NamespacedName{Namespace: clusterNamespace, Name: "ct1"}, constraint)
+			if tc.HTTPStatus == http.StatusCreated && err != nil {
+				t.Fatalf("failed to get the created constraint: %v", err)
+			}
+			if tc.HTTPStatus != http.StatusCreated && !kerrors.IsNotFound(err) {
+				t.Fatalf("expected the constraint not to be created, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestDeleteConstraint(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		Name             string
+		ExpectedResponse string
+		HTTPStatus       int
+		ExistingAPIUser  *apiv1.User
+		ExistingObjects  []runtime.Object
+	}{
+		{
+			Name:             "scenario 1: delete the constraint of the cluster",
+			ExpectedResponse: `{}`,
+			HTTPStatus:       http.StatusOK,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExistingObjects: test.GenDefaultKubermaticObjects(
+				test.GenDefaultCluster(),
+				genConstraint("ct1", clusterNamespace),
+			),
+		},
+		{
+			Name:             "scenario 2: the user John can not delete the constraint of Bob's cluster",
+			ExpectedResponse: `{"error":{"code":403,"message":"forbidden: \"john@acme.com\" doesn't belong to the given project = my-first-project-ID"}}`,
+			HTTPStatus:       http.StatusForbidden,
+			ExistingAPIUser:  test.GenAPIUser("John", "john@acme.com"),
+			ExistingObjects: test.GenDefaultKubermaticObjects(
+				test.GenDefaultCluster(),
+				test.GenUser("", "John", "john@acme.com"),
+				genConstraint("ct1", clusterNamespace),
+			),
+		},
+		{
+			Name:             "scenario 3: the viewer John can not delete the constraint",
+			ExpectedResponse: `{"error":{"code":403,"message":"forbidden: \"john@acme.com\" is a viewer of the project my-first-project-ID and can not change its constraints"}}`,
+			HTTPStatus:       http.StatusForbidden,
+			ExistingAPIUser:  test.GenAPIUser("John", "john@acme.com"),
+			ExistingObjects: test.GenDefaultKubermaticObjects(
+				test.GenDefaultCluster(),
+				test.GenUser("", "John", "john@acme.com"),
+				test.GenBinding(test.GenDefaultProject().Name, "john@acme.com", "viewers"),
+				genConstraint("ct1", clusterNamespace),
+			),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/api/v2/projects/%s/clusters/%s/constraints/ct1", test.GenDefaultProject().Name, test.DefaultClusterID), nil)
+			res := httptest.NewRecorder()
+			ep, clientsSets, err := test.CreateTestEndpointAndGetClients(*tc.ExistingAPIUser, nil, nil, nil, tc.ExistingObjects, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+
+			test.CompareWithResult(t, res, tc.ExpectedResponse)
+
+			err = clientsSets.FakeClient.Get(context.Background(), types.NamespacedName{Namespace: clusterNamespace, Name: "ct1"}, &kubermaticv1.Constraint{})
+			if deleted := kerrors.IsNotFound(err); deleted != (tc.HTTPStatus == http.StatusOK) {
+				t.Fatalf("expected the constraint to be deleted: %v, got error: %v", tc.HTTPStatus == http.StatusOK, err)
+			}
+		})
+	}
+}
+
+func genConstraint(name, namespace string) *kubermaticv1.Constraint {
+	constraint := &kubermaticv1.Constraint{}
+	constraint.Name = name
+	constraint.Namespace = namespace
+	constraint.Spec = kubermaticv1.ConstraintSpec{
+		ConstraintType: "RequiredLabels",
+		Match: kubermaticv1.Match{
+			Kinds: []kubermaticv1.Kind{
+				{Kinds: []string{"Namespace"}, APIGroups: []string{""}},
+			},
+		},
+		Parameters: map[string]json.RawMessage{
+			"labels": json.RawMessage(`["gatekeeper"]`),
+		},
+	}
+	return constraint
+}
+
+func genConstraintTemplate(name, kind string) *kubermaticv1.ConstraintTemplate {
+	ct := &kubermaticv1.ConstraintTemplate{}
+	ct.Name = name
+	ct.Spec.CRD.Spec.Names.Kind = kind
+	return ct
+}
@@ -0,0 +1,231 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package constrainttemplate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/go-kit/kit/endpoint"
+
+	apiv2 "k8c.io/kubermatic/v2/pkg/api/v2"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	handlercommon "k8c.io/kubermatic/v2/pkg/handler/common"
+	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/util/errors"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// GatekeeperTemplateAPIVersion is the API version of the constraint templates Gatekeeper reads in the user clusters
+	GatekeeperTemplateAPIVersion = "templates.gatekeeper.sh/v1beta1"
+	// GatekeeperTemplateKind is the kind of the constraint templates Gatekeeper reads in the user clusters
+	GatekeeperTemplateKind = "ConstraintTemplate"
+)
+
+// AssignEndpoint syncs the constraint template into the given clusters, or into all clusters. The template is written
+// to the user clusters as a Gatekeeper constraint template, clusters without OPA enabled are skipped. A cluster the
+// template can not be synced to does not stop the others, instead the outcome is reported for every cluster.
+func AssignEndpoint(constraintTemplateProvider provider.ConstraintTemplateProvider, userInfoGetter provider.UserInfoGetter,
+	seedsGetter provider.SeedsGetter, seedClientGetter provider.SeedClientGetter, clusterProviderGetter provider.ClusterProviderGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(assignConstraintTemplateReq)
+
+		adminUserInfo, err := userInfoGetter(ctx, "")
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		if !adminUserInfo.IsAdmin {
+			return nil, errors.New(http.StatusForbidden, fmt.Sprintf("forbidden: \"%s\" doesn't have admin rights", adminUserInfo.Email))
+		}
+
+		if err := req.Validate(); err != nil {
+			return nil, errors.NewBadRequest(err.Error())
+		}
+
+		constraintTemplate, err := constraintTemplateProvider.Get(req.Name)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		seeds, err := seedsGetter()
+		if err != nil {
+			return nil, errors.New(http.StatusInternalServerError, fmt.Sprintf("failed to list seeds: %v", err))
+		}
+
+		missing := sets.NewString(req.Body.ClusterIDs...)
+		result := make([]apiv2.ConstraintTemplateAssignmentStatus, 0, missing.Len())
+		for _, seed := range seeds {
+			seedClient, err := seedClientGetter(seed)
+			if err != nil {
+				return nil, errors.New(http.StatusInternalServerError, fmt.Sprintf("failed to get seed client: %v", err))
+			}
+			clusterProvider, err := clusterProviderGetter(seed)
+			if err != nil {
+				return nil, errors.New(http.StatusInternalServerError, err.Error())
+			}
+
+			clusters := &kubermaticv1.ClusterList{}
+			if err := seedClient.List(ctx, clusters); err != nil {
+				return nil, errors.New(http.StatusInternalServerError, fmt.Sprintf("failed to list clusters of seed %q: %v", seed.Name, err))
+			}
+			for i := range clusters.Items {
+				cluster := &clusters.Items[i]
+				if !req.Body.All && !missing.Has(cluster.Name) {
+					continue
+				}
+				missing.Delete(cluster.Name)
+				result = append(result, assignToCluster(ctx, seedClient, clusterProvider, cluster, constraintTemplate))
+			}
+		}
+
+		for _, clusterID := range missing.List() {
+			result = append(result, apiv2.ConstraintTemplateAssignmentStatus{
+				ClusterID: clusterID,
+				Status:    apiv2.ConstraintTemplateAssignmentFailed,
+				Message:   "cluster not found",
+			})
+		}
+		sort.Slice(result, func(i, j int) bool {
+			return result[i].ClusterID < result[j].ClusterID
+		})
+
+		return result, nil
+	}
+}
+
+// assignToCluster syncs the constraint template into the user cluster when OPA is enabled for the cluster
+func assignToCluster(ctx context.Context, seedClient ctrlruntimeclient.Client, clusterProvider provider.ClusterProvider, cluster *kubermaticv1.Cluster, ct *kubermaticv1.ConstraintTemplate) apiv2.ConstraintTemplateAssignmentStatus {
+	status := apiv2.ConstraintTemplateAssignmentStatus{ClusterID: cluster.Name}
+
+	enabled, err := handlercommon.IsOPAEnabled(ctx, seedClient, cluster)
+	if err != nil {
+		status.Status = apiv2.ConstraintTemplateAssignmentFailed
+		status.Message = fmt.Sprintf("failed to check if OPA is enabled: %v", err)
+		return status
+	}
+	if !enabled {
+		status.Status = apiv2.ConstraintTemplateAssignmentSkipped
+		status.Message = "OPA is not enabled for the cluster"
+		return status
+	}
+	if cluster.Status.ExtendedHealth.Apiserver != kubermaticv1.HealthStatusUp {
+		status.Status = apiv2.ConstraintTemplateAssignmentFailed
+		status.Message = "the API server of the cluster is not running"
+		return status
+	}
+
+	client, err := clusterProvider.GetAdminClientForCustomerCluster(cluster)
+	if err == nil {
+		err = syncGatekeeperTemplate(ctx, client, ct)
+	}
+	if err != nil {
+		status.Status = apiv2.ConstraintTemplateAssignmentFailed
+		status.Message = err.Error()
+		return status
+	}
+
+	status.Status = apiv2.ConstraintTemplateAssigned
+	return status
+}
+
+// syncGatekeeperTemplate creates the Gatekeeper constraint template in the user cluster, or updates its spec
+func syncGatekeeperTemplate(ctx context.Context, client ctrlruntimeclient.Client, ct *kubermaticv1.ConstraintTemplate) error {
+	spec, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&ct.Spec)
+	if err != nil {
+		return fmt.Errorf("failed to encode the constraint template spec: %v", err)
+	}
+
+	template := newGatekeeperTemplate(ct.Name)
+	err = client.Get(ctx, types.NamespacedName{Name: ct.Name}, template)
+	if kerrors.IsNotFound(err) {
+		template = newGatekeeperTemplate(ct.Name)
+		template.Object["spec"] = spec
+		if err := client.Create(ctx, template); err != nil {
+			return fmt.Errorf("failed to create the gatekeeper constraint template: %v", err)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get the gatekeeper constraint template: %v", err)
+	}
+
+	template.Object["spec"] = spec
+	if err := client.Update(ctx, template); err != nil {
+		return fmt.Errorf("failed to update the gatekeeper constraint template: %v", err)
+	}
+	return nil
+}
+
+// newGatekeeperTemplate returns the Gatekeeper constraint template with the given name, without its spec
+func newGatekeeperTemplate(name string) *unstructured.Unstructured {
+	template := &unstructured.Unstructured{}
+	template.SetAPIVersion(GatekeeperTemplateAPIVersion)
+	template.SetKind(GatekeeperTemplateKind)
+	template.SetName(name)
+	return template
+}
+
+// assignConstraintTemplateReq defines HTTP request for assignConstraintTemplate endpoint
+// swagger:parameters assignConstraintTemplate
+type assignConstraintTemplateReq struct {
+	constraintTemplateReq
+
+	// in: body
+	// required: true
+	Body apiv2.ConstraintTemplateAssignment
+}
+
+func DecodeAssignConstraintTemplateReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req assignConstraintTemplateReq
+
+	ctReq, err := DecodeConstraintTemplateRequest(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.constraintTemplateReq = ctReq.(constraintTemplateReq)
+
+	if err := json.NewDecoder(r.Body).Decode(&req.Body); err != nil {
+		return nil, errors.NewBadRequest("cannot decode the clusters to assign the constraint template to: %v", err)
+	}
+
+	return req, nil
+}
+
+// Validate validates assignConstraintTemplate request
+func (req assignConstraintTemplateReq) Validate() error {
+	if err := req.constraintTemplateReq.Validate(); err != nil {
+		return err
+	}
+	if req.Body.All && len(req.Body.ClusterIDs) > 0 {
+		return fmt.Errorf("either all or clusterIDs can be set, not both")
+	}
+	if !req.Body.All && len(req.Body.ClusterIDs) == 0 {
+		return fmt.Errorf("clusterIDs must not be empty")
+	}
+	return nil
+}
@@ -18,21 +18,33 @@ package constrainttemplate
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 
+	jsonpatch "github.com/evanphx/json-patch"
 	"github.com/go-kit/kit/endpoint"
 	"github.com/gorilla/mux"
+	"github.com/open-policy-agent/frameworks/constraint/pkg/apis/templates/v1beta1"
 
 	apiv2 "k8c.io/kubermatic/v2/pkg/api/v2"
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
 	"k8c.io/kubermatic/v2/pkg/provider"
 	"k8c.io/kubermatic/v2/pkg/util/errors"
+
+	"k8s.io/apimachinery/pkg/util/sets"
 )
 
 func ListEndpoint(constraintTemplateProvider provider.ConstraintTemplateProvider) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listConstraintTemplatesReq)
+
 		constraintTemplateList, err := constraintTemplateProvider.List()
 		if err != nil {
 			return nil, common.KubernetesErrorToHTTPError(err)
@@ -42,9 +54,65 @@ func ListEndpoint(constraintTemplateProvider provider.ConstraintTemplateProvider
 		for _, ct := range constraintTemplateList.Items {
 			apiCT = append(apiCT, convertCTToAPI(&ct))
 		}
+		sort.Slice(apiCT, func(i, j int) bool {
+			return apiCT[i].Name < apiCT[j].Name
+		})
+
+		// keep returning the flat list to clients which do not paginate
+		if req.Limit == 0 {
+			return apiCT, nil
+		}
+
+		start := (req.Page - 1) * req.Limit
+		if start > len(apiCT) {
+			start = len(apiCT)
+		}
+		end := start + req.Limit
+		if end > len(apiCT) {
+			end = len(apiCT)
+		}
+		return &apiv2.ConstraintTemplateList{
+			Items:      apiCT[start:end],
+			TotalCount: len(apiCT),
+		}, nil
+	}
+}
+
+// listConstraintTemplatesReq represents a request for a list of constraint templates
+// swagger:parameters listConstraintTemplates
+type listConstraintTemplatesReq struct {
+	// Limit is the number of constraint templates per page. A page with the total count is returned
+	// instead of the plain list when it is set.
+	// in: query
+	Limit int `json:"limit,omitempty"`
+	// Page is the number of the page to return, starting at 1. Defaults to 1.
+	// in: query
+	Page int `json:"page,omitempty"`
+}
+
+func DecodeListConstraintTemplatesReq(c context.Context, r *http.Request) (interface{}, error) {
+	req := listConstraintTemplatesReq{Page: 1}
+
+	if limit := r.URL.Query().Get("limit"); limit != "" {
+		value, err := strconv.Atoi(limit)
+		if err != nil || value < 1 {
+			return nil, errors.NewBadRequest("invalid limit %q, must be a positive number", limit)
+		}
+		req.Limit = value
+	}
 
-		return apiCT, nil
+	if page := r.URL.Query().Get("page"); page != "" {
+		if req.Limit == 0 {
+			return nil, errors.NewBadRequest("the page can only be set together with the limit")
+		}
+		value, err := strconv.Atoi(page)
+		if err != nil || value < 1 {
+			return nil, errors.NewBadRequest("invalid page %q, must be a positive number", page)
+		}
+		req.Page = value
 	}
+
+	return req, nil
 }
 
 func GetEndpoint(constraintTemplateProvider provider.ConstraintTemplateProvider) endpoint.Endpoint {
@@ -64,6 +132,156 @@ func GetEndpoint(constraintTemplateProvider provider.ConstraintTemplateProvider)
 	}
 }
 
+func CreateEndpoint(constraintTemplateProvider provider.ConstraintTemplateProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(createConstraintTemplateReq)
+
+		adminUserInfo, err := userInfoGetter(ctx, "")
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		if !adminUserInfo.IsAdmin {
+			return nil, errors.New(http.StatusForbidden, fmt.Sprintf("forbidden: \"%s\" doesn't have admin rights", adminUserInfo.Email))
+		}
+
+		if err := req.Validate(); err != nil {
+			return nil, errors.NewBadRequest(err.Error())
+		}
+		if err := compileRego(constraintTemplateProvider, req.Body.Spec); err != nil {
+			return nil, errors.NewBadRequest(err.Error())
+		}
+
+		ct := &kubermaticv1.ConstraintTemplate{}
+		ct.Name = req.Body.Name
+		ct.Spec = req.Body.Spec
+
+		created, err := constraintTemplateProvider.Create(ct)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		return convertCTToAPI(created), nil
+	}
+}
+
+func PatchEndpoint(constraintTemplateProvider provider.ConstraintTemplateProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(patchConstraintTemplateReq)
+
+		adminUserInfo, err := userInfoGetter(ctx, "")
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		if !adminUserInfo.IsAdmin {
+			return nil, errors.New(http.StatusForbidden, fmt.Sprintf("forbidden: \"%s\" doesn't have admin rights", adminUserInfo.Email))
+		}
+
+		if err := req.Validate(); err != nil {
+			return nil, errors.NewBadRequest(err.Error())
+		}
+
+		constraintTemplate, err := constraintTemplateProvider.Get(req.Name)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		existingSpecJSON, err := json.Marshal(constraintTemplate.Spec)
+		if err != nil {
+			return nil, errors.NewBadRequest("cannot decode existing constraint template spec: %v", err)
+		}
+		patchedSpecJSON, err := jsonpatch.MergePatch(existingSpecJSON, req.Patch)
+		if err != nil {
+			return nil, errors.NewBadRequest("cannot patch constraint template spec: %v", err)
+		}
+		var patchedSpec v1beta1.ConstraintTemplateSpec
+		if err := json.Unmarshal(patchedSpecJSON, &patchedSpec); err != nil {
+			return nil, errors.NewBadRequest("cannot decode patched constraint template spec: %v", err)
+		}
+		if err := validateSpec(patchedSpec); err != nil {
+			return nil, errors.NewBadRequest(err.Error())
+		}
+		if err := compileRego(constraintTemplateProvider, patchedSpec); err != nil {
+			return nil, errors.NewBadRequest(err.Error())
+		}
+
+		// the resource version of the fetched object makes concurrent modifications fail with a conflict
+		constraintTemplate.Spec = patchedSpec
+		updated, err := constraintTemplateProvider.Update(constraintTemplate)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		return convertCTToAPI(updated), nil
+	}
+}
+
+func DeleteEndpoint(constraintTemplateProvider provider.ConstraintTemplateProvider, userInfoGetter provider.UserInfoGetter,
+	seedsGetter provider.SeedsGetter, seedClientGetter provider.SeedClientGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(constraintTemplateReq)
+
+		adminUserInfo, err := userInfoGetter(ctx, "")
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		if !adminUserInfo.IsAdmin {
+			return nil, errors.New(http.StatusForbidden, fmt.Sprintf("forbidden: \"%s\" doesn't have admin rights", adminUserInfo.Email))
+		}
+
+		if err := req.Validate(); err != nil {
+			return nil, errors.NewBadRequest(err.Error())
+		}
+
+		constraintTemplate, err := constraintTemplateProvider.Get(req.Name)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		constraints, err := listTemplateConstraints(ctx, constraintTemplate, seedsGetter, seedClientGetter)
+		if err != nil {
+			return nil, err
+		}
+		if len(constraints) > 0 {
+			return nil, errors.New(http.StatusConflict, fmt.Sprintf("constraint template %q is still used by the constraints: %s", req.Name, strings.Join(constraints, ", ")))
+		}
+
+		if err := constraintTemplateProvider.Delete(constraintTemplate); err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		return nil, nil
+	}
+}
+
+// listTemplateConstraints returns the namespaced names of the constraints of all seeds which have the
+// constraint type defined by the given constraint template
+func listTemplateConstraints(ctx context.Context, ct *kubermaticv1.ConstraintTemplate, seedsGetter provider.SeedsGetter, seedClientGetter provider.SeedClientGetter) ([]string, error) {
+	seeds, err := seedsGetter()
+	if err != nil {
+		return nil, errors.New(http.StatusInternalServerError, fmt.Sprintf("failed to list seeds: %v", err))
+	}
+
+	names := sets.NewString()
+	for _, seed := range seeds {
+		seedClient, err := seedClientGetter(seed)
+		if err != nil {
+			return nil, errors.New(http.StatusInternalServerError, fmt.Sprintf("failed to get seed client: %v", err))
+		}
+
+		constraints := &kubermaticv1.ConstraintList{}
+		if err := seedClient.List(ctx, constraints); err != nil {
+			return nil, errors.New(http.StatusInternalServerError, fmt.Sprintf("failed to list constraints of seed %q: %v", seed.Name, err))
+		}
+		for _, constraint := range constraints.Items {
+			if constraint.Spec.ConstraintType == ct.Spec.CRD.Spec.Names.Kind {
+				names.Insert(fmt.Sprintf("%s/%s", constraint.Namespace, constraint.Name))
+			}
+		}
+	}
+
+	return names.List(), nil
+}
+
 func convertCTToAPI(ct *kubermaticv1.ConstraintTemplate) *apiv2.ConstraintTemplate {
 	return &apiv2.ConstraintTemplate{
 		Name: ct.Name,
@@ -72,7 +290,7 @@ func convertCTToAPI(ct *kubermaticv1.ConstraintTemplate) *apiv2.ConstraintTempla
 }
 
 // constraintTemplateReq represents a request for a specific constraintTemplate
-// swagger:parameters getConstraintTemplate
+// swagger:parameters getConstraintTemplate deleteConstraintTemplate
 type constraintTemplateReq struct {
 	// in: path
 	// required: true
@@ -97,3 +315,110 @@ func (req constraintTemplateReq) Validate() error {
 	}
 	return nil
 }
+
+// createConstraintTemplateReq represents a request for creating constraint templates
+// swagger:parameters createConstraintTemplate
+type createConstraintTemplateReq struct {
+	// in: body
+	// required: true
+	Body apiv2.ConstraintTemplate
+}
+
+func DecodeCreateConstraintTemplateRequest(c context.Context, r *http.Request) (interface{}, error) {
+	var req createConstraintTemplateReq
+
+	if err := json.NewDecoder(r.Body).Decode(&req.Body); err != nil {
+		return nil, errors.NewBadRequest("invalid constraint template: %v", err)
+	}
+
+	return req, nil
+}
+
+// patchConstraintTemplateReq defines HTTP request for patchConstraintTemplate endpoint
+// swagger:parameters patchConstraintTemplate
+type patchConstraintTemplateReq struct {
+	constraintTemplateReq
+
+	// Patch is a JSON merge patch of the constraint template spec
+	// in: body
+	Patch json.RawMessage
+}
+
+func DecodePatchConstraintTemplateReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req patchConstraintTemplateReq
+
+	ctReq, err := DecodeConstraintTemplateRequest(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.constraintTemplateReq = ctReq.(constraintTemplateReq)
+
+	if req.Patch, err = ioutil.ReadAll(r.Body); err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// regoPackageRegexp matches the package declaration every rego module has to start with
+var regoPackageRegexp = regexp.MustCompile(`^\s*package\s+[A-Za-z_][A-Za-z0-9_.]*\s*$`)
+
+// Validate validates createConstraintTemplate request
+func (req createConstraintTemplateReq) Validate() error {
+	if len(req.Body.Name) == 0 {
+		return fmt.Errorf("the constraint template name cannot be empty")
+	}
+	return validateSpec(req.Body.Spec)
+}
+
+// validateSpec checks that the CRD and the targets of a constraint template are well-formed
+func validateSpec(spec v1beta1.ConstraintTemplateSpec) error {
+	crd := spec.CRD.Spec
+	if len(crd.Names.Kind) == 0 {
+		return fmt.Errorf("the constraint template CRD kind cannot be empty")
+	}
+	if crd.Validation != nil && crd.Validation.OpenAPIV3Schema != nil {
+		if schemaType := crd.Validation.OpenAPIV3Schema.Type; schemaType != "" && schemaType != "object" {
+			return fmt.Errorf("the constraint template CRD schema must be of type \"object\", got %q", schemaType)
+		}
+	}
+
+	if len(spec.Targets) == 0 {
+		return fmt.Errorf("the constraint template must have at least one target")
+	}
+	for _, target := range spec.Targets {
+		if len(target.Target) == 0 {
+			return fmt.Errorf("the constraint template target name cannot be empty")
+		}
+		if !hasRegoPackage(target.Rego) {
+			return fmt.Errorf("the rego of target %q must start with a package declaration", target.Target)
+		}
+	}
+	return nil
+}
+
+// compileRego compiles the rego of the targets when the provider supports it, so that syntax errors are
+// reported before the template is stored instead of being rejected by gatekeeper later
+func compileRego(constraintTemplateProvider provider.ConstraintTemplateProvider, spec v1beta1.ConstraintTemplateSpec) error {
+	compiler, ok := constraintTemplateProvider.(provider.RegoCompiler)
+	if !ok {
+		return nil
+	}
+	for _, target := range spec.Targets {
+		if err := compiler.CompileRego(target.Rego, target.Libs); err != nil {
+			return fmt.Errorf("the rego of target %q does not compile: %v", target.Target, err)
+		}
+	}
+	return nil
+}
+
+func hasRegoPackage(rego string) bool {
+	for _, line := range strings.Split(rego, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		return regoPackageRegexp.MatchString(line)
+	}
+	return false
+}
@@ -17,20 +17,26 @@ limitations under the License.
 package constrainttemplate_test
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/open-policy-agent/frameworks/constraint/pkg/apis/templates/v1beta1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 
 	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
 	apiv2 "k8c.io/kubermatic/v2/pkg/api/v2"
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/handler/test"
 	"k8c.io/kubermatic/v2/pkg/handler/test/hack"
+	constrainttemplate "k8c.io/kubermatic/v2/pkg/handler/v2/constraint_template"
 )
 
 func TestListConstraintTemplates(t *testing.T) {
@@ -84,6 +90,94 @@ func TestListConstraintTemplates(t *testing.T) {
 	}
 }
 
+func TestListConstraintTemplatesPaginated(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		Name               string
+		Query              string
+		ExpectedNames      []string
+		ExpectedTotalCount int
+		ExpectedResponse   string
+		HTTPStatus         int
+	}{
+		{
+			Name:               "scenario 1: list the first page",
+			Query:              "limit=2",
+			ExpectedNames:      []string{"ct1", "ct2"},
+			ExpectedTotalCount: 3,
+			HTTPStatus:         http.StatusOK,
+		},
+		{
+			Name:               "scenario 2: list the last page",
+			Query:              "limit=2&page=2",
+			ExpectedNames:      []string{"ct3"},
+			ExpectedTotalCount: 3,
+			HTTPStatus:         http.StatusOK,
+		},
+		{
+			Name:               "scenario 3: list a page past the end",
+			Query:              "limit=2&page=3",
+			ExpectedNames:      []string{},
+			ExpectedTotalCount: 3,
+			HTTPStatus:         http.StatusOK,
+		},
+		{
+			Name:             "scenario 4: the page can not be set without the limit",
+			Query:            "page=2",
+			ExpectedResponse: `{"error":{"code":400,"message":"the page can only be set together with the limit"}}`,
+			HTTPStatus:       http.StatusBadRequest,
+		},
+		{
+			Name:             "scenario 5: the limit must be positive",
+			Query:            "limit=0",
+			ExpectedResponse: `{"error":{"code":400,"message":"invalid limit \"0\", must be a positive number"}}`,
+			HTTPStatus:       http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", fmt.Sprintf("/api/v2/constrainttemplates?%s", tc.Query), strings.NewReader(""))
+			res := httptest.NewRecorder()
+			existingObjects := test.GenDefaultKubermaticObjects(
+				genConstraintTemplate("ct3"),
+				genConstraintTemplate("ct1"),
+				genConstraintTemplate("ct2"),
+			)
+			ep, err := test.CreateTestEndpoint(*test.GenDefaultAPIUser(), nil, existingObjects, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+
+			if tc.HTTPStatus != http.StatusOK {
+				test.CompareWithResult(t, res, tc.ExpectedResponse)
+				return
+			}
+
+			list := &apiv2.ConstraintTemplateList{}
+			if err := json.Unmarshal(res.Body.Bytes(), list); err != nil {
+				t.Fatalf("failed to unmarshal the response: %v", err)
+			}
+			if list.TotalCount != tc.ExpectedTotalCount {
+				t.Errorf("expected the total count %d, got %d", tc.ExpectedTotalCount, list.TotalCount)
+			}
+			names := []string{}
+			for _, ct := range list.Items {
+				names = append(names, ct.Name)
+			}
+			if strings.Join(names, ",") != strings.Join(tc.ExpectedNames, ",") {
+				t.Errorf("expected the constraint templates %v, got %v", tc.ExpectedNames, names)
+			}
+		})
+	}
+}
+
 func TestGetConstraintTemplates(t *testing.T) {
 	t.Parallel()
 	testcases := []struct {
@@ -138,6 +232,406 @@ func TestGetConstraintTemplates(t *testing.T) {
 	}
 }
 
+func TestCreateConstraintTemplates(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		Name             string
+		CTToCreate       apiv2.ConstraintTemplate
+		ExpectedResponse string
+		HTTPStatus       int
+		ExistingAPIUser  *apiv1.User
+		ExistingObjects  []runtime.Object
+	}{
+		{
+			Name:             "scenario 1: the admin John can create a constraint template",
+			CTToCreate:       test.GenDefaultConstraintTemplate("ct1"),
+			ExpectedResponse: `{"name":"ct1","spec":{"crd":{"spec":{"names":{"kind":"labelconstraint","shortNames":["lc"]}}},"targets":[{"target":"admission.k8s.gatekeeper.sh","rego":"\n\t\tpackage k8srequiredlabels\n\n        deny[{\"msg\": msg, \"details\": {\"missing_labels\": missing}}] {\n          provided := {label | input.review.object.metadata.labels[label]}\n          required := {label | label := input.parameters.labels[_]}\n          missing := required - provided\n          count(missing) \u003e 0\n          msg := sprintf(\"you must provide labels: %v\", [missing])\n        }"}]},"status":{}}`,
+			HTTPStatus:       http.StatusCreated,
+			ExistingObjects:  test.GenDefaultKubermaticObjects(genAdminUser("John", "john@acme.com")),
+			ExistingAPIUser:  test.GenAPIUser("John", "john@acme.com"),
+		},
+		{
+			Name:             "scenario 2: a constraint template with the same name can not be created twice",
+			CTToCreate:       test.GenDefaultConstraintTemplate("ct1"),
+			ExpectedResponse: `{"error":{"code":409,"message":"constrainttemplates.kubermatic.k8s.io \"ct1\" already exists"}}`,
+			HTTPStatus:       http.StatusConflict,
+			ExistingObjects: test.GenDefaultKubermaticObjects(
+				genAdminUser("John", "john@acme.com"),
+				genConstraintTemplate("ct1"),
+			),
+			ExistingAPIUser: test.GenAPIUser("John", "john@acme.com"),
+		},
+		{
+			Name: "scenario 3: a constraint template with a rego without package can not be created",
+			CTToCreate: func() apiv2.ConstraintTemplate {
+				ct := test.GenDefaultConstraintTemplate("ct1")
+				ct.Spec.Targets[0].Rego = "deny[msg] { msg := \"denied\" }"
+				return ct
+			}(),
+			ExpectedResponse: `{"error":{"code":400,"message":"the rego of target \"admission.k8s.gatekeeper.sh\" must start with a package declaration"}}`,
+			HTTPStatus:       http.StatusBadRequest,
+			ExistingObjects:  test.GenDefaultKubermaticObjects(genAdminUser("John", "john@acme.com")),
+			ExistingAPIUser:  test.GenAPIUser("John", "john@acme.com"),
+		},
+		{
+			Name: "scenario 4: a constraint template without CRD kind can not be created",
+			CTToCreate: func() apiv2.ConstraintTemplate {
+				ct := test.GenDefaultConstraintTemplate("ct1")
+				ct.Spec.CRD.Spec.Names.Kind = ""
+				return ct
+			}(),
+			ExpectedResponse: `{"error":{"code":400,"message":"the constraint template CRD kind cannot be empty"}}`,
+			HTTPStatus:       http.StatusBadRequest,
+			ExistingObjects:  test.GenDefaultKubermaticObjects(genAdminUser("John", "john@acme.com")),
+			ExistingAPIUser:  test.GenAPIUser("John", "john@acme.com"),
+		},
+		{
+			Name: "scenario 5: a constraint template with a rego which does not compile can not be created",
+			CTToCreate: func() apiv2.ConstraintTemplate {
+				ct := test.GenDefaultConstraintTemplate("ct1")
+				ct.Spec.Targets[0].Rego = "package k8srequiredlabels\n\nviolation[{\"msg\": msg}] { true }"
+				return ct
+			}(),
+			ExpectedResponse: `{"error":{"code":400,"message":"the rego of target \"admission.k8s.gatekeeper.sh\" does not compile: 1 error occurred: rego:3: rego_unsafe_var_error: var msg is unsafe"}}`,
+			HTTPStatus:       http.StatusBadRequest,
+			ExistingObjects:  test.GenDefaultKubermaticObjects(genAdminUser("John", "john@acme.com")),
+			ExistingAPIUser:  test.GenAPIUser("John", "john@acme.com"),
+		},
+		{
+			Name:             "scenario 6: the regular user Bob can not create a constraint template",
+			CTToCreate:       test.GenDefaultConstraintTemplate("ct1"),
+			ExpectedResponse: `{"error":{"code":403,"message":"forbidden: \"bob@acme.com\" doesn't have admin rights"}}`,
+			HTTPStatus:       http.StatusForbidden,
+			ExistingObjects:  test.GenDefaultKubermaticObjects(),
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			body, err := json.Marshal(tc.CTToCreate)
+			if err != nil {
+				t.Fatalf("failed to marshal the constraint template: %v", err)
+			}
+			req := httptest.NewRequest("POST", "/api/v2/constrainttemplates", strings.NewReader(string(body)))
+			res := httptest.NewRecorder()
+			ep, err := test.CreateTestEndpoint(*tc.ExistingAPIUser, nil, tc.ExistingObjects, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+
+			test.CompareWithResult(t, res, tc.ExpectedResponse)
+		})
+	}
+}
+
+func TestPatchConstraintTemplates(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		Name             string
+		CTName           string
+		Patch            string
+		ExpectedResponse string
+		HTTPStatus       int
+		ExistingAPIUser  *apiv1.User
+		ExistingObjects  []runtime.Object
+	}{
+		{
+			Name:             "scenario 1: the admin John can patch the spec of a constraint template",
+			CTName:           "ct1",
+			Patch:            `{"crd":{"spec":{"names":{"kind":"requiredlabels","shortNames":null}}},"targets":[{"target":"admission.k8s.gatekeeper.sh","rego":"package k8srequiredlabels\n\nviolation[{\"msg\": msg}] { msg := \"denied\" }"}]}`,
+			ExpectedResponse: `{"name":"ct1","spec":{"crd":{"spec":{"names":{"kind":"requiredlabels"}}},"targets":[{"target":"admission.k8s.gatekeeper.sh","rego":"package k8srequiredlabels\n\nviolation[{\"msg\": msg}] { msg := \"denied\" }"}]},"status":{}}`,
+			HTTPStatus:       http.StatusOK,
+			ExistingObjects: test.GenDefaultKubermaticObjects(
+				genAdminUser("John", "john@acme.com"),
+				genConstraintTemplate("ct1"),
+			),
+			ExistingAPIUser: test.GenAPIUser("John", "john@acme.com"),
+		},
+		{
+			Name:             "scenario 2: a patch with an invalid rego is rejected",
+			CTName:           "ct1",
+			Patch:            `{"targets":[{"target":"admission.k8s.gatekeeper.sh","rego":"violation[{\"msg\": msg}] { false }"}]}`,
+			ExpectedResponse: `{"error":{"code":400,"message":"the rego of target \"admission.k8s.gatekeeper.sh\" must start with a package declaration"}}`,
+			HTTPStatus:       http.StatusBadRequest,
+			ExistingObjects: test.GenDefaultKubermaticObjects(
+				genAdminUser("John", "john@acme.com"),
+				genConstraintTemplate("ct1"),
+			),
+			ExistingAPIUser: test.GenAPIUser("John", "john@acme.com"),
+		},
+		{
+			Name:             "scenario 3: a patch with a rego which does not compile is rejected",
+			CTName:           "ct1",
+			Patch:            `{"targets":[{"target":"admission.k8s.gatekeeper.sh","rego":"package k8srequiredlabels\n\nviolation[{\"msg\": msg}] { true }"}]}`,
+			ExpectedResponse: `{"error":{"code":400,"message":"the rego of target \"admission.k8s.gatekeeper.sh\" does not compile: 1 error occurred: rego:3: rego_unsafe_var_error: var msg is unsafe"}}`,
+			HTTPStatus:       http.StatusBadRequest,
+			ExistingObjects: test.GenDefaultKubermaticObjects(
+				genAdminUser("John", "john@acme.com"),
+				genConstraintTemplate("ct1"),
+			),
+			ExistingAPIUser: test.GenAPIUser("John", "john@acme.com"),
+		},
+		{
+			Name:             "scenario 4: a non-existing constraint template can not be patched",
+			CTName:           "missing",
+			Patch:            `{"crd":{"spec":{"names":{"kind":"requiredlabels"}}}}`,
+			ExpectedResponse: `{"error":{"code":404,"message":"constrainttemplates.kubermatic.k8s.io \"missing\" not found"}}`,
+			HTTPStatus:       http.StatusNotFound,
+			ExistingObjects: test.GenDefaultKubermaticObjects(
+				genAdminUser("John", "john@acme.com"),
+				genConstraintTemplate("ct1"),
+			),
+			ExistingAPIUser: test.GenAPIUser("John", "john@acme.com"),
+		},
+		{
+			Name:             "scenario 5: the regular user Bob can not patch a constraint template",
+			CTName:           "ct1",
+			Patch:            `{"crd":{"spec":{"names":{"kind":"requiredlabels"}}}}`,
+			ExpectedResponse: `{"error":{"code":403,"message":"forbidden: \"bob@acme.com\" doesn't have admin rights"}}`,
+			HTTPStatus:       http.StatusForbidden,
+			ExistingObjects:  test.GenDefaultKubermaticObjects(genConstraintTemplate("ct1")),
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest("PATCH", fmt.Sprintf("/api/v2/constrainttemplates/%s", tc.CTName), strings.NewReader(tc.Patch))
+			res := httptest.NewRecorder()
+			ep, err := test.CreateTestEndpoint(*tc.ExistingAPIUser, nil, tc.ExistingObjects, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+
+			test.CompareWithResult(t, res, tc.ExpectedResponse)
+		})
+	}
+}
+
+func TestDeleteConstraintTemplates(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		Name             string
+		CTName           string
+		ExpectedResponse string
+		HTTPStatus       int
+		ExistingAPIUser  *apiv1.User
+		ExistingObjects  []runtime.Object
+	}{
+		{
+			Name:             "scenario 1: the admin John can delete a constraint template",
+			CTName:           "ct1",
+			ExpectedResponse: `{}`,
+			HTTPStatus:       http.StatusOK,
+			ExistingObjects: test.GenDefaultKubermaticObjects(
+				genAdminUser("John", "john@acme.com"),
+				genConstraintTemplate("ct1"),
+				genConstraint("ns-must-have-owner", "cluster-abcd", "otherconstraint"),
+			),
+			ExistingAPIUser: test.GenAPIUser("John", "john@acme.com"),
+		},
+		{
+			Name:             "scenario 2: a non-existing constraint template can not be deleted",
+			CTName:           "missing",
+			ExpectedResponse: `{"error":{"code":404,"message":"constrainttemplates.kubermatic.k8s.io \"missing\" not found"}}`,
+			HTTPStatus:       http.StatusNotFound,
+			ExistingObjects: test.GenDefaultKubermaticObjects(
+				genAdminUser("John", "john@acme.com"),
+				genConstraintTemplate("ct1"),
+			),
+			ExistingAPIUser: test.GenAPIUser("John", "john@acme.com"),
+		},
+		{
+			Name:             "scenario 3: a constraint template used by constraints can not be deleted",
+			CTName:           "ct1",
+			ExpectedResponse: `{"error":{"code":409,"message":"constraint template \"ct1\" is still used by the constraints: cluster-abcd/ns-must-have-gk, cluster-defg/pod-must-have-owner"}}`,
+			HTTPStatus:       http.StatusConflict,
+			ExistingObjects: test.GenDefaultKubermaticObjects(
+				genAdminUser("John", "john@acme.com"),
+				genConstraintTemplate("ct1"),
+				genConstraint("pod-must-have-owner", "cluster-defg", "labelconstraint"),
+				genConstraint("ns-must-have-gk", "cluster-abcd", "labelconstraint"),
+				genConstraint("ns-must-have-owner", "cluster-abcd", "otherconstraint"),
+			),
+			ExistingAPIUser: test.GenAPIUser("John", "john@acme.com"),
+		},
+		{
+			Name:             "scenario 4: the regular user Bob can not delete a constraint template",
+			CTName:           "ct1",
+			ExpectedResponse: `{"error":{"code":403,"message":"forbidden: \"bob@acme.com\" doesn't have admin rights"}}`,
+			HTTPStatus:       http.StatusForbidden,
+			ExistingObjects:  test.GenDefaultKubermaticObjects(genConstraintTemplate("ct1")),
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest("DELETE", fmt.Sprintf("/api/v2/constrainttemplates/%s", tc.CTName), strings.NewReader(""))
+			res := httptest.NewRecorder()
+			ep, err := test.CreateTestEndpoint(*tc.ExistingAPIUser, nil, tc.ExistingObjects, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+
+			test.CompareWithResult(t, res, tc.ExpectedResponse)
+		})
+	}
+}
+
+func TestAssignConstraintTemplate(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		Name              string
+		CTName            string
+		Body              string
+		ExpectedResponse  string
+		HTTPStatus        int
+		ExpectedTemplates []string
+		ExistingAPIUser   *apiv1.User
+		ExistingKubeObjs  []runtime.Object
+		ExistingObjects   []runtime.Object
+	}{
+		{
+			Name:              "scenario 1: the admin John can assign a constraint template to all clusters",
+			CTName:            "ct1",
+			Body:              `{"all":true}`,
+			ExpectedResponse:  `[{"clusterID":"clusterAbcID","status":"skipped","message":"OPA is not enabled for the cluster"},{"clusterID":"defClusterID","status":"assigned"}]`,
+			HTTPStatus:        http.StatusOK,
+			ExpectedTemplates: []string{"ct1"},
+			ExistingObjects: test.GenDefaultKubermaticObjects(
+				genAdminUser("John", "john@acme.com"),
+				genConstraintTemplate("ct1"),
+				test.GenDefaultCluster(),
+				test.GenCluster("clusterAbcID", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC)),
+				test.GenTestAddon("gatekeeper", nil, test.GenDefaultCluster(), time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC)),
+			),
+			ExistingAPIUser: test.GenAPIUser("John", "john@acme.com"),
+		},
+		{
+			Name:              "scenario 2: the template of an assigned constraint template is updated and unknown clusters are reported",
+			CTName:            "ct1",
+			Body:              `{"clusterIDs":["defClusterID","missing","defClusterID"]}`,
+			ExpectedResponse:  `[{"clusterID":"defClusterID","status":"assigned"},{"clusterID":"missing","status":"failed","message":"cluster not found"}]`,
+			HTTPStatus:        http.StatusOK,
+			ExpectedTemplates: []string{"ct1"},
+			ExistingKubeObjs: []runtime.Object{
+				genGatekeeperTemplate("ct1", "oldconstraint"),
+			},
+			ExistingObjects: test.GenDefaultKubermaticObjects(
+				genAdminUser("John", "john@acme.com"),
+				genConstraintTemplate("ct1"),
+				test.GenDefaultCluster(),
+				test.GenTestAddon("gatekeeper", nil, test.GenDefaultCluster(), time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC)),
+			),
+			ExistingAPIUser: test.GenAPIUser("John", "john@acme.com"),
+		},
+		{
+			Name:             "scenario 3: the clusters can not be listed together with all clusters",
+			CTName:           "ct1",
+			Body:             `{"all":true,"clusterIDs":["defClusterID"]}`,
+			ExpectedResponse: `{"error":{"code":400,"message":"either all or clusterIDs can be set, not both"}}`,
+			HTTPStatus:       http.StatusBadRequest,
+			ExistingObjects: test.GenDefaultKubermaticObjects(
+				genAdminUser("John", "john@acme.com"),
+				genConstraintTemplate("ct1"),
+			),
+			ExistingAPIUser: test.GenAPIUser("John", "john@acme.com"),
+		},
+		{
+			Name:             "scenario 4: the regular user Bob can not assign a constraint template",
+			CTName:           "ct1",
+			Body:             `{"all":true}`,
+			ExpectedResponse: `{"error":{"code":403,"message":"forbidden: \"bob@acme.com\" doesn't have admin rights"}}`,
+			HTTPStatus:       http.StatusForbidden,
+			ExistingObjects:  test.GenDefaultKubermaticObjects(genConstraintTemplate("ct1")),
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", fmt.Sprintf("/api/v2/constrainttemplates/%s/assign", tc.CTName), strings.NewReader(tc.Body))
+			res := httptest.NewRecorder()
+			ep, clients, err := test.CreateTestEndpointAndGetClients(*tc.ExistingAPIUser, nil, tc.ExistingKubeObjs, nil, tc.ExistingObjects, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+
+			test.CompareWithResult(t, res, tc.ExpectedResponse)
+
+			for _, name := range tc.ExpectedTemplates {
+				template := genGatekeeperTemplate(name, "")
+				if err := clients.FakeClient.Get(context.Background(), types.NamespacedName{Name: name}, template); err != nil {
+					t.Fatalf("failed to get the gatekeeper constraint template %q: %v", name, err)
+				}
+				kind, _, _ := unstructured.NestedString(template.Object, "spec", "crd", "spec", "names", "kind")
+				if kind != "labelconstraint" {
+					t.Fatalf("expected the gatekeeper constraint template %q to define the kind labelconstraint, got %q", name, kind)
+				}
+			}
+		})
+	}
+}
+
+func genGatekeeperTemplate(name, kind string) *unstructured.Unstructured {
+	template := &unstructured.Unstructured{}
+	template.SetAPIVersion(constrainttemplate.GatekeeperTemplateAPIVersion)
+	template.SetKind(constrainttemplate.GatekeeperTemplateKind)
+	template.SetName(name)
+	if kind != "" {
+		template.Object["spec"] = map[string]interface{}{
+			"crd": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"names": map[string]interface{}{"kind": kind},
+				},
+			},
+		}
+	}
+	return template
+}
+
+func genConstraint(name, namespace, kind string) *kubermaticv1.Constraint {
+	constraint := &kubermaticv1.Constraint{}
+	constraint.Name = name
+	constraint.Namespace = namespace
+	constraint.Spec = kubermaticv1.ConstraintSpec{
+		ConstraintType: kind,
+	}
+	return constraint
+}
+
+func genAdminUser(name, email string) *kubermaticv1.User {
+	user := test.GenUser("", name, email)
+	user.Spec.IsAdmin = true
+	return user
+}
+
 func genConstraintTemplate(name string) *kubermaticv1.ConstraintTemplate {
 	ct := &kubermaticv1.ConstraintTemplate{}
 	ct.Name = name
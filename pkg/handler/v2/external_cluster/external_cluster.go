@@ -31,6 +31,7 @@ import (
 	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
 	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
 	"k8c.io/kubermatic/v2/pkg/provider"
+	ksemver "k8c.io/kubermatic/v2/pkg/semver"
 	"k8c.io/kubermatic/v2/pkg/util/errors"
 
 	corev1 "k8s.io/api/core/v1"
@@ -72,25 +73,89 @@ func CreateEndpoint(userInfoGetter provider.UserInfoGetter, projectProvider prov
 			return nil, common.KubernetesErrorToHTTPError(err)
 		}
 
-		newCluster := genExternalCluster(req.Body.Name)
+		createdCluster, err := importCluster(ctx, userInfoGetter, clusterProvider, privilegedClusterProvider, req.Body, project)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		return convertClusterToAPI(createdCluster), nil
+	}
+}
+
+// AdoptEndpoint imports an existing cluster from its kubeconfig. The cluster must be reachable and run
+// a Kubernetes version supported by Kubermatic. Only the nodes of the adopted cluster are managed,
+// its control plane stays where it is.
+func AdoptEndpoint(userInfoGetter provider.UserInfoGetter, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, clusterProvider provider.ExternalClusterProvider, privilegedClusterProvider provider.PrivilegedExternalClusterProvider, updateManager common.UpdateManager) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(createClusterReq)
+		if err := req.Validate(); err != nil {
+			return nil, errors.NewBadRequest(err.Error())
+		}
 
-		kuberneteshelper.AddFinalizer(newCluster, apiv1.ExternalClusterKubeconfigCleanupFinalizer)
+		config, err := base64.StdEncoding.DecodeString(req.Body.Kubeconfig)
+		if err != nil {
+			return nil, errors.NewBadRequest(err.Error())
+		}
 
-		if err := clusterProvider.CreateOrUpdateKubeconfigSecretForCluster(ctx, newCluster, req.Body.Kubeconfig); err != nil {
+		cfg, err := clientcmd.Load(config)
+		if err != nil {
+			return nil, errors.NewBadRequest("invalid kubeconfig: %v", err)
+		}
+
+		version, err := clusterProvider.GetVersionForConfig(cfg)
+		if err != nil {
+			return nil, errors.NewBadRequest("cannot connect to the kubernetes cluster: %v", err)
+		}
+		if err := validateAdoptedVersion(updateManager, version); err != nil {
+			return nil, err
+		}
+
+		project, err := common.GetProject(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, req.ProjectID, &provider.ProjectGetOptions{IncludeUninitialized: false})
+		if err != nil {
 			return nil, common.KubernetesErrorToHTTPError(err)
 		}
 
-		createdCluster, err := createNewCluster(ctx, userInfoGetter, clusterProvider, privilegedClusterProvider, newCluster, project)
+		createdCluster, err := importCluster(ctx, userInfoGetter, clusterProvider, privilegedClusterProvider, req.Body, project)
 		if err != nil {
 			return nil, common.KubernetesErrorToHTTPError(err)
 		}
 
-		return convertClusterToAPI(createdCluster), nil
+		apiCluster := convertClusterToAPI(createdCluster)
+		apiCluster.Spec.Version = *version
+		apiCluster.Status.Version = *version
+		return apiCluster, nil
 	}
 }
 
-// createClusterReq defines HTTP request for createExternalCluster
-// swagger:parameters createExternalCluster
+// validateAdoptedVersion checks that the minor version of an adopted cluster matches one of the configured versions
+func validateAdoptedVersion(updateManager common.UpdateManager, version *ksemver.Semver) error {
+	versions, err := updateManager.GetVersions(apiv1.KubernetesClusterType)
+	if err != nil {
+		return err
+	}
+	for _, v := range versions {
+		if v.Version.Major() == version.Semver().Major() && v.Version.Minor() == version.Semver().Minor() {
+			return nil
+		}
+	}
+	return errors.NewBadRequest("unsupported kubernetes version %s", version.String())
+}
+
+// importCluster stores the kubeconfig of the given cluster and creates the external cluster for it
+func importCluster(ctx context.Context, userInfoGetter provider.UserInfoGetter, clusterProvider provider.ExternalClusterProvider, privilegedClusterProvider provider.PrivilegedExternalClusterProvider, clusterBody body, project *kubermaticapiv1.Project) (*kubermaticapiv1.ExternalCluster, error) {
+	newCluster := genExternalCluster(clusterBody.Name)
+
+	kuberneteshelper.AddFinalizer(newCluster, apiv1.ExternalClusterKubeconfigCleanupFinalizer)
+
+	if err := clusterProvider.CreateOrUpdateKubeconfigSecretForCluster(ctx, newCluster, clusterBody.Kubeconfig); err != nil {
+		return nil, err
+	}
+
+	return createNewCluster(ctx, userInfoGetter, clusterProvider, privilegedClusterProvider, newCluster, project)
+}
+
+// createClusterReq defines HTTP request for createExternalCluster and adoptClusterV2
+// swagger:parameters createExternalCluster adoptClusterV2
 type createClusterReq struct {
 	common.ProjectReq
 	// in: body
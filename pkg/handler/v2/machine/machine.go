@@ -0,0 +1,336 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/gorilla/mux"
+
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	apiv2 "k8c.io/kubermatic/v2/pkg/api/v2"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	handlercommon "k8c.io/kubermatic/v2/pkg/handler/common"
+	"k8c.io/kubermatic/v2/pkg/handler/middleware"
+	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/util/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// reportedNodeConditions are the node conditions exposed for machine deployment nodes
+var reportedNodeConditions = []corev1.NodeConditionType{
+	corev1.NodeReady,
+	corev1.NodeDiskPressure,
+	corev1.NodeMemoryPressure,
+}
+
+func ListMachineDeploymentNodesEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listMachineDeploymentNodesReq)
+		clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
+
+		cluster, err := handlercommon.GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, req.ProjectID, req.ClusterID, &provider.ClusterGetOptions{CheckInitStatus: true})
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		client, err := common.GetClusterClient(ctx, userInfoGetter, clusterProvider, cluster, req.ProjectID)
+		if err != nil {
+			return nil, clusterUnreachableToHTTPError(err)
+		}
+
+		machineDeployment := &clusterv1alpha1.MachineDeployment{}
+		if err := client.Get(ctx, types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: req.MachineDeploymentID}, machineDeployment); err != nil {
+			return nil, clusterUnreachableToHTTPError(err)
+		}
+
+		machines := &clusterv1alpha1.MachineList{}
+		if err := client.List(ctx, machines, &ctrlruntimeclient.ListOptions{Namespace: metav1.NamespaceSystem, LabelSelector: labels.SelectorFromSet(machineDeployment.Spec.Selector.MatchLabels)}); err != nil {
+			return nil, clusterUnreachableToHTTPError(err)
+		}
+
+		adminClient, err := clusterProvider.GetAdminClientForCustomerCluster(cluster)
+		if err != nil {
+			return nil, clusterUnreachableToHTTPError(err)
+		}
+
+		nodeList := &corev1.NodeList{}
+		if err := adminClient.List(ctx, nodeList); err != nil {
+			return nil, clusterUnreachableToHTTPError(err)
+		}
+
+		nodes := make([]apiv2.MachineDeploymentNode, 0)
+		for i := range machines.Items {
+			node := handlercommon.GetNodeForMachine(&machines.Items[i], nodeList.Items)
+			if node == nil {
+				continue
+			}
+			nodes = append(nodes, convertNodeToAPI(node))
+		}
+
+		return nodes, nil
+	}
+}
+
+// GetMachineDeploymentRolloutEndpoint returns how far the rollout of the current spec of a machine deployment,
+// e.g. after an upgrade of its nodes, has progressed.
+func GetMachineDeploymentRolloutEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(getMachineDeploymentRolloutReq)
+		clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
+
+		cluster, err := handlercommon.GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, req.ProjectID, req.ClusterID, &provider.ClusterGetOptions{CheckInitStatus: true})
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		client, err := common.GetClusterClient(ctx, userInfoGetter, clusterProvider, cluster, req.ProjectID)
+		if err != nil {
+			return nil, clusterUnreachableToHTTPError(err)
+		}
+
+		machineDeployment := &clusterv1alpha1.MachineDeployment{}
+		if err := client.Get(ctx, types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: req.MachineDeploymentID}, machineDeployment); err != nil {
+			return nil, clusterUnreachableToHTTPError(err)
+		}
+
+		return rolloutStatus(machineDeployment), nil
+	}
+}
+
+func rolloutStatus(md *clusterv1alpha1.MachineDeployment) apiv2.MachineDeploymentRolloutStatus {
+	status := apiv2.MachineDeploymentRolloutStatus{
+		UpdatedReplicas:   md.Status.UpdatedReplicas,
+		AvailableReplicas: md.Status.AvailableReplicas,
+		Phase:             apiv2.MachineDeploymentRolloutProgressing,
+	}
+	if md.Spec.Replicas != nil {
+		status.Replicas = *md.Spec.Replicas
+	}
+
+	switch {
+	case md.Spec.Paused:
+		status.Phase = apiv2.MachineDeploymentRolloutPaused
+	// the status only describes the current spec once the controller observed it, and the rollout is
+	// not complete as long as machines of the previous spec are left
+	case md.Status.ObservedGeneration >= md.Generation &&
+		status.UpdatedReplicas == status.Replicas &&
+		md.Status.Replicas == status.Replicas &&
+		status.AvailableReplicas == status.Replicas:
+		status.Phase = apiv2.MachineDeploymentRolloutComplete
+	}
+	return status
+}
+
+// ListProjectMachineDeploymentsEndpoint lists the machine deployments of all clusters of the project.
+// Clusters which can not be reached are reported in the response instead of failing the request.
+func ListProjectMachineDeploymentsEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, seedsGetter provider.SeedsGetter, clusterProviderGetter provider.ClusterProviderGetter, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listProjectMachineDeploymentsReq)
+		result := &apiv2.ProjectMachineDeploymentList{
+			MachineDeployments: make([]apiv2.ProjectMachineDeployment, 0),
+		}
+
+		project, err := common.GetProject(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, req.ProjectID, nil)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		seeds, err := seedsGetter()
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		for _, seed := range seeds {
+			// if a Seed is bad, do not forward that error to the user, but only log
+			clusterProvider, err := clusterProviderGetter(seed)
+			if err != nil {
+				klog.Errorf("failed to create cluster provider for seed %s: %v", seed.Name, err)
+				continue
+			}
+
+			clusters, err := clusterProvider.List(project, nil)
+			if err != nil {
+				return nil, common.KubernetesErrorToHTTPError(err)
+			}
+
+			for i := range clusters.Items {
+				cluster := &clusters.Items[i]
+				machineDeployments, err := listClusterMachineDeployments(ctx, userInfoGetter, clusterProvider, cluster, req.ProjectID)
+				if err != nil {
+					if result.UnreachableClusters == nil {
+						result.UnreachableClusters = map[string]string{}
+					}
+					result.UnreachableClusters[cluster.Name] = err.Error()
+					continue
+				}
+				result.MachineDeployments = append(result.MachineDeployments, machineDeployments...)
+			}
+		}
+
+		return result, nil
+	}
+}
+
+// listClusterMachineDeployments returns the machine deployments of the given cluster
+func listClusterMachineDeployments(ctx context.Context, userInfoGetter provider.UserInfoGetter, clusterProvider provider.ClusterProvider, cluster *kubermaticv1.Cluster, projectID string) ([]apiv2.ProjectMachineDeployment, error) {
+	if !cluster.Status.ExtendedHealth.AllHealthy() {
+		return nil, errors.New(http.StatusServiceUnavailable, "Cluster components are not ready yet")
+	}
+
+	client, err := common.GetClusterClient(ctx, userInfoGetter, clusterProvider, cluster, projectID)
+	if err != nil {
+		return nil, clusterUnreachableToHTTPError(err)
+	}
+
+	machineDeployments := &clusterv1alpha1.MachineDeploymentList{}
+	if err := client.List(ctx, machineDeployments, ctrlruntimeclient.InNamespace(metav1.NamespaceSystem)); err != nil {
+		return nil, clusterUnreachableToHTTPError(err)
+	}
+
+	result := make([]apiv2.ProjectMachineDeployment, 0, len(machineDeployments.Items))
+	for _, md := range machineDeployments.Items {
+		apiMachineDeployment := apiv2.ProjectMachineDeployment{
+			ClusterID:     cluster.Name,
+			Name:          md.Name,
+			ReadyReplicas: md.Status.ReadyReplicas,
+			Version:       md.Spec.Template.Spec.Versions.Kubelet,
+		}
+		if md.Spec.Replicas != nil {
+			apiMachineDeployment.Replicas = *md.Spec.Replicas
+		}
+		result = append(result, apiMachineDeployment)
+	}
+	return result, nil
+}
+
+// clusterUnreachableToHTTPError maps errors returned by the user cluster API to HTTP errors.
+// Errors which are not returned by the API server itself mean that the cluster could not be reached.
+func clusterUnreachableToHTTPError(err error) error {
+	if _, ok := err.(*kerrors.StatusError); ok {
+		return common.KubernetesErrorToHTTPError(err)
+	}
+	return errors.New(http.StatusServiceUnavailable, fmt.Sprintf("cluster is not reachable: %v", err))
+}
+
+func convertNodeToAPI(node *corev1.Node) apiv2.MachineDeploymentNode {
+	conditions := make([]apiv2.NodeCondition, 0)
+	for _, conditionType := range reportedNodeConditions {
+		for _, condition := range node.Status.Conditions {
+			if condition.Type != conditionType {
+				continue
+			}
+			conditions = append(conditions, apiv2.NodeCondition{
+				Type:    string(condition.Type),
+				Status:  string(condition.Status),
+				Reason:  condition.Reason,
+				Message: condition.Message,
+			})
+		}
+	}
+
+	return apiv2.MachineDeploymentNode{
+		Name:       node.Name,
+		Version:    node.Status.NodeInfo.KubeletVersion,
+		Conditions: conditions,
+	}
+}
+
+// listProjectMachineDeploymentsReq defines HTTP request for listProjectMachineDeployments
+// swagger:parameters listProjectMachineDeployments
+type listProjectMachineDeploymentsReq struct {
+	common.ProjectReq
+}
+
+func DecodeListProjectMachineDeployments(c context.Context, r *http.Request) (interface{}, error) {
+	pr, err := common.DecodeProjectRequest(c, r)
+	if err != nil {
+		return nil, err
+	}
+
+	return listProjectMachineDeploymentsReq{ProjectReq: pr.(common.ProjectReq)}, nil
+}
+
+// listMachineDeploymentNodesReq defines HTTP request for listMachineDeploymentNodes
+// swagger:parameters listMachineDeploymentNodes
+type listMachineDeploymentNodesReq struct {
+	common.ProjectReq
+	// in: path
+	// required: true
+	ClusterID string `json:"cluster_id"`
+	// in: path
+	// required: true
+	MachineDeploymentID string `json:"machinedeployment_id"`
+}
+
+// getMachineDeploymentRolloutReq defines HTTP request for getMachineDeploymentRollout
+// swagger:parameters getMachineDeploymentRollout
+type getMachineDeploymentRolloutReq struct {
+	listMachineDeploymentNodesReq
+}
+
+func DecodeGetMachineDeploymentRollout(c context.Context, r *http.Request) (interface{}, error) {
+	req, err := DecodeListMachineDeploymentNodes(c, r)
+	if err != nil {
+		return nil, err
+	}
+
+	return getMachineDeploymentRolloutReq{listMachineDeploymentNodesReq: req.(listMachineDeploymentNodesReq)}, nil
+}
+
+// GetSeedCluster returns the SeedCluster object
+func (req listMachineDeploymentNodesReq) GetSeedCluster() apiv1.SeedCluster {
+	return apiv1.SeedCluster{
+		ClusterID: req.ClusterID,
+	}
+}
+
+func DecodeListMachineDeploymentNodes(c context.Context, r *http.Request) (interface{}, error) {
+	var req listMachineDeploymentNodesReq
+
+	clusterID, err := common.DecodeClusterID(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.ClusterID = clusterID
+
+	pr, err := common.DecodeProjectRequest(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.ProjectReq = pr.(common.ProjectReq)
+
+	machineDeploymentID := mux.Vars(r)["machinedeployment_id"]
+	if machineDeploymentID == "" {
+		return nil, fmt.Errorf("'machinedeployment_id' parameter is required but was not provided")
+	}
+	req.MachineDeploymentID = machineDeploymentID
+
+	return req, nil
+}
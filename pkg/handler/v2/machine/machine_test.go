@@ -0,0 +1,385 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/handler/test"
+	"k8c.io/kubermatic/v2/pkg/handler/test/hack"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const rawProviderSpec = `{"cloudProvider":"digitalocean","cloudProviderSpec":{"token":"dummy-token","region":"fra1","size":"2GB"},"operatingSystem":"ubuntu","operatingSystemSpec":{"distUpgradeOnBoot":true}}`
+
+func TestListMachineDeploymentNodes(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		Name                       string
+		ExpectedResponse           string
+		HTTPStatus                 int
+		ProjectIDToSync            string
+		ClusterIDToSync            string
+		ExistingAPIUser            *apiv1.User
+		ExistingNodes              []*corev1.Node
+		ExistingMachineDeployments []*clusterv1alpha1.MachineDeployment
+		ExistingMachines           []*clusterv1alpha1.Machine
+		ExistingKubermaticObjs     []runtime.Object
+		MachineDeploymentID        string
+	}{
+		{
+			Name:                   "scenario 1: list nodes that belong to the given machine deployment",
+			HTTPStatus:             http.StatusOK,
+			ClusterIDToSync:        test.GenDefaultCluster().Name,
+			ProjectIDToSync:        test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(test.GenDefaultCluster()),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+			ExistingMachineDeployments: []*clusterv1alpha1.MachineDeployment{
+				test.GenTestMachineDeployment("venus", rawProviderSpec, map[string]string{"md-id": "123"}, false),
+				test.GenTestMachineDeployment("mars", rawProviderSpec, map[string]string{"md-id": "345"}, false),
+			},
+			ExistingMachines: []*clusterv1alpha1.Machine{
+				test.GenTestMachine("venus-1", rawProviderSpec, map[string]string{"md-id": "123"}, nil),
+				test.GenTestMachine("venus-2", rawProviderSpec, map[string]string{"md-id": "123"}, nil),
+				test.GenTestMachine("mars-1", rawProviderSpec, map[string]string{"md-id": "345"}, nil),
+			},
+			ExistingNodes: []*corev1.Node{
+				genTestNode("venus-1", corev1.ConditionTrue, corev1.ConditionFalse),
+				genTestNode("venus-2", corev1.ConditionFalse, corev1.ConditionTrue),
+				genTestNode("mars-1", corev1.ConditionTrue, corev1.ConditionFalse),
+			},
+			MachineDeploymentID: "venus",
+			ExpectedResponse:    `[{"name":"venus-1","version":"v9.9.9","conditions":[{"type":"Ready","status":"True"},{"type":"DiskPressure","status":"False"},{"type":"MemoryPressure","status":"False"}]},{"name":"venus-2","version":"v9.9.9","conditions":[{"type":"Ready","status":"False","reason":"KubeletNotReady","message":"container runtime is down"},{"type":"DiskPressure","status":"True"},{"type":"MemoryPressure","status":"True"}]}]`,
+		},
+		{
+			Name:                   "scenario 2: machines without a node are skipped",
+			HTTPStatus:             http.StatusOK,
+			ClusterIDToSync:        test.GenDefaultCluster().Name,
+			ProjectIDToSync:        test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(test.GenDefaultCluster()),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+			ExistingMachineDeployments: []*clusterv1alpha1.MachineDeployment{
+				test.GenTestMachineDeployment("venus", rawProviderSpec, map[string]string{"md-id": "123"}, false),
+			},
+			ExistingMachines: []*clusterv1alpha1.Machine{
+				test.GenTestMachine("venus-1", rawProviderSpec, map[string]string{"md-id": "123"}, nil),
+			},
+			MachineDeploymentID: "venus",
+			ExpectedResponse:    `[]`,
+		},
+		{
+			Name:            "scenario 3: the admin John can list nodes of Bob's machine deployment",
+			HTTPStatus:      http.StatusOK,
+			ClusterIDToSync: test.GenDefaultCluster().Name,
+			ProjectIDToSync: test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenDefaultCluster(),
+				genUser("John", "john@acme.com", true),
+			),
+			ExistingAPIUser: test.GenAPIUser("John", "john@acme.com"),
+			ExistingMachineDeployments: []*clusterv1alpha1.MachineDeployment{
+				test.GenTestMachineDeployment("venus", rawProviderSpec, map[string]string{"md-id": "123"}, false),
+			},
+			ExistingMachines: []*clusterv1alpha1.Machine{
+				test.GenTestMachine("venus-1", rawProviderSpec, map[string]string{"md-id": "123"}, nil),
+			},
+			ExistingNodes: []*corev1.Node{
+				genTestNode("venus-1", corev1.ConditionTrue, corev1.ConditionFalse),
+			},
+			MachineDeploymentID: "venus",
+			ExpectedResponse:    `[{"name":"venus-1","version":"v9.9.9","conditions":[{"type":"Ready","status":"True"},{"type":"DiskPressure","status":"False"},{"type":"MemoryPressure","status":"False"}]}]`,
+		},
+		{
+			Name:            "scenario 4: the user John can not list nodes of Bob's machine deployment",
+			HTTPStatus:      http.StatusForbidden,
+			ClusterIDToSync: test.GenDefaultCluster().Name,
+			ProjectIDToSync: test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenDefaultCluster(),
+				genUser("John", "john@acme.com", false),
+			),
+			ExistingAPIUser:     test.GenAPIUser("John", "john@acme.com"),
+			MachineDeploymentID: "venus",
+			ExpectedResponse:    `{"error":{"code":403,"message":"forbidden: \"john@acme.com\" doesn't belong to the given project = my-first-project-ID"}}`,
+		},
+		{
+			Name:            "scenario 5: the cluster is not reachable",
+			HTTPStatus:      http.StatusServiceUnavailable,
+			ClusterIDToSync: test.GenDefaultCluster().Name,
+			ProjectIDToSync: test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenCluster(test.DefaultClusterID, test.DefaultClusterName, test.GenDefaultProject().Name, test.GenDefaultCluster().CreationTimestamp.Time, func(cluster *kubermaticv1.Cluster) {
+					cluster.Status.ExtendedHealth.Apiserver = kubermaticv1.HealthStatusDown
+				}),
+			),
+			ExistingAPIUser:     test.GenDefaultAPIUser(),
+			MachineDeploymentID: "venus",
+			ExpectedResponse:    `{"error":{"code":503,"message":"Cluster components are not ready yet"}}`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", fmt.Sprintf("/api/v2/projects/%s/clusters/%s/machinedeployments/%s/nodes", tc.ProjectIDToSync, tc.ClusterIDToSync, tc.MachineDeploymentID), strings.NewReader(""))
+			res := httptest.NewRecorder()
+			machineObj := []runtime.Object{}
+			kubernetesObj := []runtime.Object{}
+			for _, existingNode := range tc.ExistingNodes {
+				kubernetesObj = append(kubernetesObj, existingNode)
+			}
+			for _, existingMachineDeployment := range tc.ExistingMachineDeployments {
+				machineObj = append(machineObj, existingMachineDeployment)
+			}
+			for _, existingMachine := range tc.ExistingMachines {
+				machineObj = append(machineObj, existingMachine)
+			}
+			ep, _, err := test.CreateTestEndpointAndGetClients(*tc.ExistingAPIUser, nil, kubernetesObj, machineObj, tc.ExistingKubermaticObjs, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+
+			test.CompareWithResult(t, res, tc.ExpectedResponse)
+		})
+	}
+}
+
+func TestListProjectMachineDeployments(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		Name                       string
+		ExpectedResponse           string
+		HTTPStatus                 int
+		ProjectIDToSync            string
+		ExistingAPIUser            *apiv1.User
+		ExistingMachineDeployments []*clusterv1alpha1.MachineDeployment
+		ExistingKubermaticObjs     []runtime.Object
+	}{
+		{
+			Name:                   "scenario 1: list machine deployments of all clusters in the project",
+			HTTPStatus:             http.StatusOK,
+			ProjectIDToSync:        test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(test.GenDefaultCluster()),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+			ExistingMachineDeployments: []*clusterv1alpha1.MachineDeployment{
+				func() *clusterv1alpha1.MachineDeployment {
+					md := test.GenTestMachineDeployment("venus", rawProviderSpec, map[string]string{"md-id": "123"}, false)
+					md.Status.ReadyReplicas = 1
+					return md
+				}(),
+				test.GenTestMachineDeployment("mars", rawProviderSpec, map[string]string{"md-id": "345"}, false),
+			},
+			ExpectedResponse: `{"machineDeployments":[{"clusterID":"defClusterID","name":"mars","replicas":1,"readyReplicas":0,"version":"v9.9.9"},{"clusterID":"defClusterID","name":"venus","replicas":1,"readyReplicas":1,"version":"v9.9.9"}]}`,
+		},
+		{
+			Name:            "scenario 2: clusters that are not reachable are reported",
+			HTTPStatus:      http.StatusOK,
+			ProjectIDToSync: test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenCluster(test.DefaultClusterID, test.DefaultClusterName, test.GenDefaultProject().Name, test.GenDefaultCluster().CreationTimestamp.Time, func(cluster *kubermaticv1.Cluster) {
+					cluster.Status.ExtendedHealth.Apiserver = kubermaticv1.HealthStatusDown
+				}),
+			),
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExpectedResponse: `{"machineDeployments":[],"unreachableClusters":{"defClusterID":"Cluster components are not ready yet"}}`,
+		},
+		{
+			Name:            "scenario 3: the user John can not list machine deployments of Bob's project",
+			HTTPStatus:      http.StatusForbidden,
+			ProjectIDToSync: test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenDefaultCluster(),
+				genUser("John", "john@acme.com", false),
+			),
+			ExistingAPIUser:  test.GenAPIUser("John", "john@acme.com"),
+			ExpectedResponse: `{"error":{"code":403,"message":"forbidden: \"john@acme.com\" doesn't belong to the given project = my-first-project-ID"}}`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", fmt.Sprintf("/api/v2/projects/%s/machinedeployments", tc.ProjectIDToSync), strings.NewReader(""))
+			res := httptest.NewRecorder()
+			machineObj := []runtime.Object{}
+			for _, existingMachineDeployment := range tc.ExistingMachineDeployments {
+				machineObj = append(machineObj, existingMachineDeployment)
+			}
+			ep, _, err := test.CreateTestEndpointAndGetClients(*tc.ExistingAPIUser, nil, nil, machineObj, tc.ExistingKubermaticObjs, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+
+			test.CompareWithResult(t, res, tc.ExpectedResponse)
+		})
+	}
+}
+
+func TestGetMachineDeploymentRollout(t *testing.T) {
+	t.Parallel()
+	genRollingMachineDeployment := func(replicas, statusReplicas, updated, available int32, paused bool) *clusterv1alpha1.MachineDeployment {
+		md := test.GenTestMachineDeployment("venus", rawProviderSpec, map[string]string{"md-id": "123"}, false)
+		md.Spec.Replicas = &replicas
+		md.Spec.Paused = paused
+		md.Status.Replicas = statusReplicas
+		md.Status.UpdatedReplicas = updated
+		md.Status.AvailableReplicas = available
+		return md
+	}
+
+	testcases := []struct {
+		Name                      string
+		ExpectedResponse          string
+		HTTPStatus                int
+		ExistingAPIUser           *apiv1.User
+		ExistingMachineDeployment *clusterv1alpha1.MachineDeployment
+		ExistingKubermaticObjs    []runtime.Object
+		MachineDeploymentID       string
+	}{
+		{
+			Name:                      "scenario 1: the rollout is in progress while old machines are left",
+			HTTPStatus:                http.StatusOK,
+			ExistingKubermaticObjs:    test.GenDefaultKubermaticObjects(test.GenDefaultCluster()),
+			ExistingAPIUser:           test.GenDefaultAPIUser(),
+			ExistingMachineDeployment: genRollingMachineDeployment(3, 4, 1, 3, false),
+			MachineDeploymentID:       "venus",
+			ExpectedResponse:          `{"replicas":3,"updatedReplicas":1,"availableReplicas":3,"phase":"Progressing"}`,
+		},
+		{
+			Name:                      "scenario 2: the rollout is complete",
+			HTTPStatus:                http.StatusOK,
+			ExistingKubermaticObjs:    test.GenDefaultKubermaticObjects(test.GenDefaultCluster()),
+			ExistingAPIUser:           test.GenDefaultAPIUser(),
+			ExistingMachineDeployment: genRollingMachineDeployment(3, 3, 3, 3, false),
+			MachineDeploymentID:       "venus",
+			ExpectedResponse:          `{"replicas":3,"updatedReplicas":3,"availableReplicas":3,"phase":"Complete"}`,
+		},
+		{
+			Name:                      "scenario 3: the rollout is paused",
+			HTTPStatus:                http.StatusOK,
+			ExistingKubermaticObjs:    test.GenDefaultKubermaticObjects(test.GenDefaultCluster()),
+			ExistingAPIUser:           test.GenDefaultAPIUser(),
+			ExistingMachineDeployment: genRollingMachineDeployment(3, 3, 1, 3, true),
+			MachineDeploymentID:       "venus",
+			ExpectedResponse:          `{"replicas":3,"updatedReplicas":1,"availableReplicas":3,"phase":"Paused"}`,
+		},
+		{
+			Name:                      "scenario 4: the machine deployment does not exist",
+			HTTPStatus:                http.StatusNotFound,
+			ExistingKubermaticObjs:    test.GenDefaultKubermaticObjects(test.GenDefaultCluster()),
+			ExistingAPIUser:           test.GenDefaultAPIUser(),
+			ExistingMachineDeployment: genRollingMachineDeployment(3, 3, 3, 3, false),
+			MachineDeploymentID:       "mars",
+			ExpectedResponse:          `{"error":{"code":404,"message":"machinedeployments.cluster.k8s.io \"mars\" not found"}}`,
+		},
+		{
+			Name:       "scenario 5: the user John can not get the rollout of Bob's machine deployment",
+			HTTPStatus: http.StatusForbidden,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenDefaultCluster(),
+				genUser("John", "john@acme.com", false),
+			),
+			ExistingAPIUser:           test.GenAPIUser("John", "john@acme.com"),
+			ExistingMachineDeployment: genRollingMachineDeployment(3, 3, 3, 3, false),
+			MachineDeploymentID:       "venus",
+			ExpectedResponse:          `{"error":{"code":403,"message":"forbidden: \"john@acme.com\" doesn't belong to the given project = my-first-project-ID"}}`,
+		},
+		{
+			Name:       "scenario 6: the cluster is not reachable",
+			HTTPStatus: http.StatusServiceUnavailable,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenCluster(test.DefaultClusterID, test.DefaultClusterName, test.GenDefaultProject().Name, test.GenDefaultCluster().CreationTimestamp.Time, func(cluster *kubermaticv1.Cluster) {
+					cluster.Status.ExtendedHealth.Apiserver = kubermaticv1.HealthStatusDown
+				}),
+			),
+			ExistingAPIUser:           test.GenDefaultAPIUser(),
+			ExistingMachineDeployment: genRollingMachineDeployment(3, 3, 3, 3, false),
+			MachineDeploymentID:       "venus",
+			ExpectedResponse:          `{"error":{"code":503,"message":"Cluster components are not ready yet"}}`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", fmt.Sprintf("/api/v2/projects/%s/clusters/%s/machinedeployments/%s/rollout", test.GenDefaultProject().Name, test.DefaultClusterID, tc.MachineDeploymentID), strings.NewReader(""))
+			res := httptest.NewRecorder()
+			machineObj := []runtime.Object{tc.ExistingMachineDeployment}
+			ep, _, err := test.CreateTestEndpointAndGetClients(*tc.ExistingAPIUser, nil, []runtime.Object{}, machineObj, tc.ExistingKubermaticObjs, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+
+			test.CompareWithResult(t, res, tc.ExpectedResponse)
+		})
+	}
+}
+
+func genTestNode(name string, ready, pressure corev1.ConditionStatus) *corev1.Node {
+	readyCondition := corev1.NodeCondition{Type: corev1.NodeReady, Status: ready}
+	if ready != corev1.ConditionTrue {
+		readyCondition.Reason = "KubeletNotReady"
+		readyCondition.Message = "container runtime is down"
+	}
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{
+				{Type: corev1.NodePIDPressure, Status: corev1.ConditionFalse},
+				{Type: corev1.NodeMemoryPressure, Status: pressure},
+				{Type: corev1.NodeDiskPressure, Status: pressure},
+				readyCondition,
+			},
+			NodeInfo: corev1.NodeSystemInfo{
+				KubeletVersion: "v9.9.9",
+			},
+		},
+	}
+}
+
+func genUser(name, email string, isAdmin bool) *kubermaticv1.User {
+	user := test.GenUser("", name, email)
+	user.Spec.IsAdmin = isAdmin
+	return user
+}
@@ -32,6 +32,7 @@ import (
 
 	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
 	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/version"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 )
@@ -77,6 +78,7 @@ type Routing struct {
 	externalClusterProvider               provider.ExternalClusterProvider
 	privilegedExternalClusterProvider     provider.PrivilegedExternalClusterProvider
 	constraintTemplateProvider            provider.ConstraintTemplateProvider
+	securityAdvisories                    []*version.SecurityAdvisory
 }
 
 // NewV2Routing creates a new Routing.
@@ -121,6 +123,7 @@ func NewV2Routing(routingParams handler.RoutingParams) Routing {
 		externalClusterProvider:               routingParams.ExternalClusterProvider,
 		privilegedExternalClusterProvider:     routingParams.PrivilegedExternalClusterProvider,
 		constraintTemplateProvider:            routingParams.ConstraintTemplateProvider,
+		securityAdvisories:                    routingParams.SecurityAdvisories,
 	}
 }
 
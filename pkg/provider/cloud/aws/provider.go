@@ -352,7 +352,7 @@ func getSecurityGroupByID(client ec2iface.EC2API, vpc *ec2.Vpc, id string) (*ec2
 // Create security group ("sg") with name `name` in `vpc`. The name
 // in a sg must be unique within the vpc (no pre-existing sg with
 // that name is allowed).
-func createSecurityGroup(client ec2iface.EC2API, vpcID, clusterName string) (string, error) {
+func createSecurityGroup(client ec2iface.EC2API, vpcID, clusterName string, disableSSH bool) (string, error) {
 	var securityGroupID string
 
 	newSecurityGroupName := resourceNamePrefix + clusterName
@@ -385,41 +385,45 @@ func createSecurityGroup(client ec2iface.EC2API, vpcID, clusterName string) (str
 	klog.V(2).Infof("Security group %s for cluster %s created with id %s.", newSecurityGroupName, clusterName, securityGroupID)
 
 	// Add permissions.
+	permissions := []*ec2.IpPermission{
+		(&ec2.IpPermission{}).
+			// all protocols from within the sg
+			SetIpProtocol("-1").
+			SetUserIdGroupPairs([]*ec2.UserIdGroupPair{
+				(&ec2.UserIdGroupPair{}).
+					SetGroupId(securityGroupID),
+			}),
+		(&ec2.IpPermission{}).
+			// ICMP from/to everywhere
+			SetIpProtocol("icmp").
+			SetFromPort(-1). // any port
+			SetToPort(-1).   // any port
+			SetIpRanges([]*ec2.IpRange{
+				{CidrIp: aws.String("0.0.0.0/0")},
+			}),
+		(&ec2.IpPermission{}).
+			// ICMPv6 from/to everywhere
+			SetIpProtocol("icmpv6").
+			SetFromPort(-1). // any port
+			SetToPort(-1).   // any port
+			SetIpv6Ranges([]*ec2.Ipv6Range{
+				{CidrIpv6: aws.String("::/0")},
+			}),
+	}
+	if !disableSSH {
+		permissions = append(permissions, (&ec2.IpPermission{}).
+			// tcp:22 from everywhere
+			SetIpProtocol("tcp").
+			SetFromPort(provider.DefaultSSHPort).
+			SetToPort(provider.DefaultSSHPort).
+			SetIpRanges([]*ec2.IpRange{
+				{CidrIp: aws.String("0.0.0.0/0")},
+			}))
+	}
+
 	_, err = client.AuthorizeSecurityGroupIngress(&ec2.AuthorizeSecurityGroupIngressInput{
-		GroupId: aws.String(securityGroupID),
-		IpPermissions: []*ec2.IpPermission{
-			(&ec2.IpPermission{}).
-				// all protocols from within the sg
-				SetIpProtocol("-1").
-				SetUserIdGroupPairs([]*ec2.UserIdGroupPair{
-					(&ec2.UserIdGroupPair{}).
-						SetGroupId(securityGroupID),
-				}),
-			(&ec2.IpPermission{}).
-				// tcp:22 from everywhere
-				SetIpProtocol("tcp").
-				SetFromPort(provider.DefaultSSHPort).
-				SetToPort(provider.DefaultSSHPort).
-				SetIpRanges([]*ec2.IpRange{
-					{CidrIp: aws.String("0.0.0.0/0")},
-				}),
-			(&ec2.IpPermission{}).
-				// ICMP from/to everywhere
-				SetIpProtocol("icmp").
-				SetFromPort(-1). // any port
-				SetToPort(-1).   // any port
-				SetIpRanges([]*ec2.IpRange{
-					{CidrIp: aws.String("0.0.0.0/0")},
-				}),
-			(&ec2.IpPermission{}).
-				// ICMPv6 from/to everywhere
-				SetIpProtocol("icmpv6").
-				SetFromPort(-1). // any port
-				SetToPort(-1).   // any port
-				SetIpv6Ranges([]*ec2.Ipv6Range{
-					{CidrIpv6: aws.String("::/0")},
-				}),
-		},
+		GroupId:       aws.String(securityGroupID),
+		IpPermissions: permissions,
 	})
 	if err != nil {
 		if awsErr, ok := err.(awserr.Error); !ok || awsErr.Code() != "InvalidPermission.Duplicate" {
@@ -450,7 +454,7 @@ func (a *AmazonEC2) InitializeCloudProvider(cluster *kubermaticv1.Cluster, updat
 	}
 
 	if cluster.Spec.Cloud.AWS.SecurityGroupID == "" {
-		securityGroupID, err := createSecurityGroup(client.EC2, cluster.Spec.Cloud.AWS.VPCID, cluster.Name)
+		securityGroupID, err := createSecurityGroup(client.EC2, cluster.Spec.Cloud.AWS.VPCID, cluster.Name, cluster.Spec.DisableNodeSSH)
 		if err != nil {
 			return nil, fmt.Errorf("failed to add security group for cluster %s: %v", cluster.Name, err)
 		}
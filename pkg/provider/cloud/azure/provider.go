@@ -348,7 +348,7 @@ func ensureResourceGroup(ctx context.Context, cloud kubermaticv1.CloudSpec, loca
 }
 
 // ensureSecurityGroup will create or update an Azure security group. The call is idempotent.
-func (a *Azure) ensureSecurityGroup(cloud kubermaticv1.CloudSpec, location string, clusterName string, credentials Credentials) error {
+func (a *Azure) ensureSecurityGroup(cloud kubermaticv1.CloudSpec, location string, clusterName string, disableSSH bool, credentials Credentials) error {
 	sgClient, err := getSecurityGroupsClient(cloud, credentials)
 	if err != nil {
 		return err
@@ -429,6 +429,15 @@ func (a *Azure) ensureSecurityGroup(cloud kubermaticv1.CloudSpec, location strin
 	updatedRules = append(*parameters.SecurityRules, udpDenyAllRule())
 	updatedRules = append(*parameters.SecurityRules, icmpAllowAllRule())
 	parameters.SecurityRules = &updatedRules
+	if disableSSH {
+		rules := make([]network.SecurityRule, 0, len(updatedRules))
+		for _, rule := range updatedRules {
+			if to.String(rule.Name) != "ssh_ingress" {
+				rules = append(rules, rule)
+			}
+		}
+		parameters.SecurityRules = &rules
+	}
 	if _, err = sgClient.CreateOrUpdate(a.ctx, cloud.Azure.ResourceGroup, cloud.Azure.SecurityGroup, parameters); err != nil {
 		return fmt.Errorf("failed to create or update resource group %q: %v", cloud.Azure.ResourceGroup, err)
 	}
@@ -606,7 +615,7 @@ func (a *Azure) InitializeCloudProvider(cluster *kubermaticv1.Cluster, update pr
 		cluster.Spec.Cloud.Azure.SecurityGroup = resourceNamePrefix + cluster.Name
 
 		logger.Infow("ensuring security group", "securityGroup", cluster.Spec.Cloud.Azure.SecurityGroup)
-		if err = a.ensureSecurityGroup(cluster.Spec.Cloud, location, cluster.Name, credentials); err != nil {
+		if err = a.ensureSecurityGroup(cluster.Spec.Cloud, location, cluster.Name, cluster.Spec.DisableNodeSSH, credentials); err != nil {
 			return cluster, err
 		}
 
@@ -150,7 +150,7 @@ func deleteSecurityGroup(netClient *gophercloud.ServiceClient, sgName string) er
 	return nil
 }
 
-func createKubermaticSecurityGroup(netClient *gophercloud.ServiceClient, clusterName string) (string, error) {
+func createKubermaticSecurityGroup(netClient *gophercloud.ServiceClient, clusterName string, disableSSH bool) (string, error) {
 	secGroupName := resourceNamePrefix + clusterName
 	secGroups, err := getSecurityGroups(netClient, ossecuritygroups.ListOpts{Name: secGroupName})
 	if err != nil {
@@ -194,15 +194,6 @@ func createKubermaticSecurityGroup(netClient *gophercloud.ServiceClient, cluster
 			SecGroupID:    securityGroupID,
 			RemoteGroupID: securityGroupID,
 		},
-		{
-			// Allows ssh from external
-			Direction:    osecuritygrouprules.DirIngress,
-			EtherType:    osecuritygrouprules.EtherType4,
-			SecGroupID:   securityGroupID,
-			PortRangeMin: provider.DefaultSSHPort,
-			PortRangeMax: provider.DefaultSSHPort,
-			Protocol:     osecuritygrouprules.ProtocolTCP,
-		},
 		{
 			// Allows ICMP traffic
 			Direction:  osecuritygrouprules.DirIngress,
@@ -218,6 +209,17 @@ func createKubermaticSecurityGroup(netClient *gophercloud.ServiceClient, cluster
 			Protocol:   osecuritygrouprules.ProtocolIPv6ICMP,
 		},
 	}
+	if !disableSSH {
+		rules = append(rules, osecuritygrouprules.CreateOpts{
+			// Allows ssh from external
+			Direction:    osecuritygrouprules.DirIngress,
+			EtherType:    osecuritygrouprules.EtherType4,
+			SecGroupID:   securityGroupID,
+			PortRangeMin: provider.DefaultSSHPort,
+			PortRangeMax: provider.DefaultSSHPort,
+			Protocol:     osecuritygrouprules.ProtocolTCP,
+		})
+	}
 
 	for _, opts := range rules {
 	reiterate:
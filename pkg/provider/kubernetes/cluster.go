@@ -50,6 +50,7 @@ import (
 // UserClusterConnectionProvider offers functions to interact with an user cluster
 type UserClusterConnectionProvider interface {
 	GetClient(*kubermaticv1.Cluster, ...k8cuserclusterclient.ConfigOption) (ctrlruntimeclient.Client, error)
+	GetClientConfig(*kubermaticv1.Cluster, ...k8cuserclusterclient.ConfigOption) (*restclient.Config, error)
 }
 
 // extractGroupPrefixFunc is a function that knows how to extract a prefix (owners, editors) from "projectID-owners" group,
@@ -383,6 +384,30 @@ func (p *ClusterProvider) GetClientForCustomerCluster(userInfo *provider.UserInf
 	return p.userClusterConnProvider.GetClient(c, p.withImpersonation(userInfo))
 }
 
+// GetAdminClientsetForCustomerCluster returns a clientset to interact with the given cluster, it is meant for the
+// APIs the runtime client does not support, like subresources
+//
+// Note that the clientset you will get has admin privileges
+func (p *ClusterProvider) GetAdminClientsetForCustomerCluster(c *kubermaticv1.Cluster) (kubernetes.Interface, error) {
+	cfg, err := p.userClusterConnProvider.GetClientConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	return kubernetes.NewForConfig(cfg)
+}
+
+// GetClientsetForCustomerCluster returns a clientset to interact with the given cluster, it is meant for the
+// APIs the runtime client does not support, like subresources
+//
+// Note that the clientset doesn't use admin account instead it authn/authz as userInfo(email, group)
+func (p *ClusterProvider) GetClientsetForCustomerCluster(userInfo *provider.UserInfo, c *kubermaticv1.Cluster) (kubernetes.Interface, error) {
+	cfg, err := p.userClusterConnProvider.GetClientConfig(c, p.withImpersonation(userInfo))
+	if err != nil {
+		return nil, err
+	}
+	return kubernetes.NewForConfig(cfg)
+}
+
 func (p *ClusterProvider) GetTokenForCustomerCluster(userInfo *provider.UserInfo, cluster *kubermaticv1.Cluster) (string, error) {
 	parts := strings.Split(userInfo.Group, "-")
 	switch parts[0] {
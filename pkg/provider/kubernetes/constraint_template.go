@@ -20,6 +20,8 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/open-policy-agent/opa/ast"
+
 	"k8s.io/apimachinery/pkg/types"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -65,3 +67,38 @@ func (p *ConstraintTemplateProvider) Get(name string) (*kubermaticv1.ConstraintT
 
 	return constraintTemplate, nil
 }
+
+// Create creates a constraint template
+func (p *ConstraintTemplateProvider) Create(ct *kubermaticv1.ConstraintTemplate) (*kubermaticv1.ConstraintTemplate, error) {
+	if err := p.clientPrivileged.Create(context.Background(), ct); err != nil {
+		return nil, err
+	}
+
+	return ct, nil
+}
+
+// Update updates a constraint template
+func (p *ConstraintTemplateProvider) Update(ct *kubermaticv1.ConstraintTemplate) (*kubermaticv1.ConstraintTemplate, error) {
+	if err := p.clientPrivileged.Update(context.Background(), ct); err != nil {
+		return nil, err
+	}
+
+	return ct, nil
+}
+
+// Delete deletes a constraint template
+func (p *ConstraintTemplateProvider) Delete(ct *kubermaticv1.ConstraintTemplate) error {
+	return p.clientPrivileged.Delete(context.Background(), ct)
+}
+
+// CompileRego compiles the rego of a constraint template target with the OPA compiler, the way gatekeeper does
+// when the template is created. The errors are prefixed with "rego" or "libs[i]" and the line number.
+func (p *ConstraintTemplateProvider) CompileRego(rego string, libs []string) error {
+	modules := map[string]string{"rego": rego}
+	for i, lib := range libs {
+		modules[fmt.Sprintf("libs[%d]", i)] = lib
+	}
+
+	_, err := ast.CompileModules(modules)
+	return err
+}
@@ -18,6 +18,7 @@ package kubernetes_test
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/go-test/deep"
@@ -119,3 +120,80 @@ func TestGetConstraintTemplates(t *testing.T) {
 		})
 	}
 }
+
+func TestCompileRego(t *testing.T) {
+	testCases := []struct {
+		name          string
+		rego          string
+		libs          []string
+		expectedError string
+	}{
+		{
+			name: "test: valid rego with libs",
+			rego: `package k8srequiredlabels
+
+violation[{"msg": msg}] {
+  not data.lib.helpers.has_owner
+  msg := "the owner label is missing"
+}`,
+			libs: []string{`package lib.helpers
+
+has_owner {
+  input.review.object.metadata.labels.owner
+}`},
+		},
+		{
+			name: "test: rego with a syntax error",
+			rego: `package k8srequiredlabels
+
+violation[{"msg": msg}] {
+  msg := "denied"
+`,
+			expectedError: "rego_parse_error",
+		},
+		{
+			name: "test: lib with a syntax error",
+			rego: `package k8srequiredlabels
+
+violation[{"msg": msg}] {
+  msg := "denied"
+}`,
+			libs:          []string{"package lib.helpers\n\nhas_owner {"},
+			expectedError: "libs[0]",
+		},
+		{
+			name: "test: rego with an unsafe variable",
+			rego: `package k8srequiredlabels
+
+violation[{"msg": msg}] { true }`,
+			expectedError: "rego:3: rego_unsafe_var_error: var msg is unsafe",
+		},
+	}
+
+	for idx := range testCases {
+		tc := testCases[idx]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := fakectrlruntimeclient.NewFakeClientWithScheme(scheme.Scheme)
+			fakeImpersonationClient := func(impCfg restclient.ImpersonationConfig) (ctrlruntimeclient.Client, error) {
+				return client, nil
+			}
+			provider, err := kubernetes.NewConstraintTemplateProvider(fakeImpersonationClient, client)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = provider.CompileRego(tc.rego, tc.libs)
+			if tc.expectedError == "" {
+				if err != nil {
+					t.Fatalf("expected the rego to compile, got: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.expectedError) {
+				t.Fatalf("expected an error containing %q, got: %v", tc.expectedError, err)
+			}
+		})
+	}
+}
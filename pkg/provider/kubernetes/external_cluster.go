@@ -239,6 +239,12 @@ func (p *ExternalClusterProvider) GetVersion(cluster *kubermaticapiv1.ExternalCl
 	if err != nil {
 		return nil, err
 	}
+	return p.GetVersionForConfig(cfg)
+}
+
+// GetVersionForConfig returns the version of the cluster the given kubeconfig points to. It fails
+// when the cluster can not be reached.
+func (p *ExternalClusterProvider) GetVersionForConfig(cfg *clientcmdapi.Config) (*ksemver.Semver, error) {
 	clientConfig, err := getRestConfig(cfg)
 	if err != nil {
 		return nil, err
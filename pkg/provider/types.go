@@ -188,6 +188,16 @@ type ClusterProvider interface {
 	// Note that the client doesn't use admin account instead it authn/authz as userInfo(email, group)
 	GetClientForCustomerCluster(*UserInfo, *kubermaticv1.Cluster) (ctrlruntimeclient.Client, error)
 
+	// GetAdminClientsetForCustomerCluster returns a clientset for the APIs of the given cluster the client does not support
+	//
+	// Note that the clientset you will get has admin privileges
+	GetAdminClientsetForCustomerCluster(*kubermaticv1.Cluster) (kubernetes.Interface, error)
+
+	// GetClientsetForCustomerCluster returns a clientset for the APIs of the given cluster the client does not support
+	//
+	// Note that the clientset doesn't use admin account instead it authn/authz as userInfo(email, group)
+	GetClientsetForCustomerCluster(*UserInfo, *kubermaticv1.Cluster) (kubernetes.Interface, error)
+
 	// GetTokenForCustomerCluster returns a token for the given cluster with permissions granted to group that
 	// user belongs to.
 	GetTokenForCustomerCluster(userInfo *UserInfo, cluster *kubermaticv1.Cluster) (string, error)
@@ -737,6 +747,8 @@ type ExternalClusterProvider interface {
 
 	GetVersion(cluster *kubermaticv1.ExternalCluster) (*ksemver.Semver, error)
 
+	GetVersionForConfig(cfg *clientcmdapi.Config) (*ksemver.Semver, error)
+
 	ListNodes(cluster *kubermaticv1.ExternalCluster) (*corev1.NodeList, error)
 
 	GetNode(cluster *kubermaticv1.ExternalCluster, nodeName string) (*corev1.Node, error)
@@ -780,4 +792,21 @@ type ConstraintTemplateProvider interface {
 
 	// Get gets the given constraint template
 	Get(name string) (*kubermaticv1.ConstraintTemplate, error)
+
+	// Create creates the given constraint template
+	Create(ct *kubermaticv1.ConstraintTemplate) (*kubermaticv1.ConstraintTemplate, error)
+
+	// Update updates the given constraint template
+	Update(ct *kubermaticv1.ConstraintTemplate) (*kubermaticv1.ConstraintTemplate, error)
+
+	// Delete deletes the given constraint template
+	Delete(ct *kubermaticv1.ConstraintTemplate) error
+}
+
+// RegoCompiler is implemented by the constraint template providers which can compile the rego of the
+// constraint template targets. Templates are only checked for rego errors by the providers implementing it.
+type RegoCompiler interface {
+	// CompileRego compiles the rego of a target together with its libs. The returned error contains
+	// the messages and line numbers reported by the compiler.
+	CompileRego(rego string, libs []string) error
 }
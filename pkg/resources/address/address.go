@@ -45,6 +45,15 @@ func SyncClusterAddress(ctx context.Context,
 		subdomain = seed.Spec.SeedDNSOverwrite
 	}
 
+	// Internal name
+	internalName := fmt.Sprintf("%s.%s.svc.cluster.local.", resources.ApiserverServiceName, cluster.Status.NamespaceName)
+	if cluster.Address.InternalName != internalName {
+		modifiers = append(modifiers, func(c *kubermaticv1.Cluster) {
+			c.Address.InternalName = internalName
+		})
+		log.Debugw("Set internal name for cluster", "internalName", internalName)
+	}
+
 	frontProxyLoadBalancerServiceIP := ""
 	if cluster.Spec.ExposeStrategy == corev1.ServiceTypeLoadBalancer {
 		frontProxyLoadBalancerService := &corev1.Service{}
@@ -60,6 +69,12 @@ func SyncClusterAddress(ctx context.Context,
 				frontProxyLoadBalancerServiceIP = ingress.IP
 			}
 		}
+		// Keep the current address until the load balancer is ready, so that clients are not pointed at an
+		// endpoint which does not exist yet, e.g. while the expose strategy of the cluster is changed
+		if frontProxyLoadBalancerServiceIP == "" {
+			log.Debug("Waiting for the front-loadbalancer service to get an IP")
+			return modifiers, nil
+		}
 	}
 
 	// External Name
@@ -77,15 +92,6 @@ func SyncClusterAddress(ctx context.Context,
 		log.Debugw("Set external name for cluster", "externalName", externalName)
 	}
 
-	// Internal name
-	internalName := fmt.Sprintf("%s.%s.svc.cluster.local.", resources.ApiserverServiceName, cluster.Status.NamespaceName)
-	if cluster.Address.InternalName != internalName {
-		modifiers = append(modifiers, func(c *kubermaticv1.Cluster) {
-			c.Address.InternalName = internalName
-		})
-		log.Debugw("Set internal name for cluster", "internalName", internalName)
-	}
-
 	// IP
 	ip := ""
 	if cluster.Spec.ExposeStrategy == corev1.ServiceTypeLoadBalancer {
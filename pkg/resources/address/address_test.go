@@ -63,6 +63,7 @@ func TestSyncClusterAddress(t *testing.T) {
 		apiserverService     corev1.Service
 		frontproxyService    corev1.Service
 		exposeStrategy       corev1.ServiceType
+		existingAddress      kubermaticv1.ClusterAddress
 		seedDNSOverwrite     string
 		expectedExternalName string
 		expectedIP           string
@@ -112,6 +113,25 @@ func TestSyncClusterAddress(t *testing.T) {
 			expectedPort:         int32(443),
 			expectedURL:          "https://1.2.3.4:443",
 		},
+		{
+			name: "Verify the address is kept until the load balancer has an IP",
+			apiserverService: corev1.Service{
+				Spec: corev1.ServiceSpec{
+					Ports: []corev1.ServicePort{{NodePort: int32(443)}},
+				},
+			},
+			exposeStrategy: corev1.ServiceTypeLoadBalancer,
+			existingAddress: kubermaticv1.ClusterAddress{
+				ExternalName: fmt.Sprintf("%s.%s.%s", fakeClusterName, fakeDCName, fakeExternalURL),
+				IP:           externalIP,
+				Port:         int32(32000),
+				URL:          fmt.Sprintf("https://%s.%s.%s:32000", fakeClusterName, fakeDCName, fakeExternalURL),
+			},
+			expectedExternalName: fmt.Sprintf("%s.%s.%s", fakeClusterName, fakeDCName, fakeExternalURL),
+			expectedIP:           externalIP,
+			expectedPort:         int32(32000),
+			expectedURL:          fmt.Sprintf("https://%s.%s.%s:32000", fakeClusterName, fakeDCName, fakeExternalURL),
+		},
 		{
 			name: "Verify properties for service type NodePort",
 			apiserverService: corev1.Service{
@@ -173,6 +193,7 @@ func TestSyncClusterAddress(t *testing.T) {
 					},
 					ExposeStrategy: tc.exposeStrategy,
 				},
+				Address: tc.existingAddress,
 				Status: kubermaticv1.ClusterStatus{
 					NamespaceName: fakeClusterNamespaceName,
 				},
@@ -18,6 +18,7 @@ package apiserver
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
@@ -70,6 +71,28 @@ rules:
 	}
 }
 
+// KonnectivityEgressSelectorConfigMapCreator returns the function to create the configmap holding the
+// egress selector configuration, which makes the apiserver reach the cluster via the Konnectivity server
+func KonnectivityEgressSelectorConfigMapCreator() reconciling.NamedConfigMapCreatorGetter {
+	return func() (string, reconciling.ConfigMapCreator) {
+		return resources.KonnectivityEgressSelectorConfigMapName, func(cm *corev1.ConfigMap) (*corev1.ConfigMap, error) {
+			cm.Data = map[string]string{
+				"egress-selector-configuration.yaml": fmt.Sprintf(`apiVersion: apiserver.k8s.io/v1alpha1
+kind: EgressSelectorConfiguration
+egressSelections:
+- name: cluster
+  connection:
+    proxyProtocol: GRPC
+    transport:
+      uds:
+        udsName: %s
+`, resources.KonnectivityUDSPath),
+			}
+			return cm, nil
+		}
+	}
+}
+
 // DeploymentCreator returns the function to create and update the API server deployment
 func DeploymentCreator(data *resources.TemplateData, enableOIDCAuthentication bool) reconciling.NamedDeploymentCreatorGetter {
 	return func() (string, reconciling.DeploymentCreator) {
@@ -99,6 +122,11 @@ func DeploymentCreator(data *resources.TemplateData, enableOIDCAuthentication bo
 				})
 			}
 
+			if data.Cluster().KonnectivityEnabled() {
+				volumes = append(volumes, getKonnectivityVolumes()...)
+				volumeMounts = append(volumeMounts, getKonnectivityVolumeMounts()...)
+			}
+
 			podLabels, err := data.GetPodTemplateLabels(name, volumes, nil)
 			if err != nil {
 				return nil, err
@@ -125,19 +153,11 @@ func DeploymentCreator(data *resources.TemplateData, enableOIDCAuthentication bo
 				etcdrunning.Container(etcdEndpoints, data),
 			}
 
-			openvpnSidecar, err := vpnsidecar.OpenVPNSidecarContainer(data, "openvpn-client")
+			sidecars, err := getConnectivitySidecars(data)
 			if err != nil {
-				return nil, fmt.Errorf("failed to get openvpn-client sidecar: %v", err)
+				return nil, err
 			}
 
-			dnatControllerSidecar, err := vpnsidecar.DnatControllerContainer(
-				data,
-				"dnat-controller",
-				fmt.Sprintf("https://127.0.0.1:%d", data.Cluster().Address.Port),
-			)
-			if err != nil {
-				return nil, fmt.Errorf("failed to get dnat-controller sidecar: %v", err)
-			}
 			auditLogEnabled := data.Cluster().Spec.AuditLogging != nil && data.Cluster().Spec.AuditLogging.Enabled
 			endpointReconcilingDisabled := false
 			if data.Cluster().Spec.ComponentsOverride.Apiserver.EndpointReconcilingDisabled != nil {
@@ -153,10 +173,8 @@ func DeploymentCreator(data *resources.TemplateData, enableOIDCAuthentication bo
 				return nil, err
 			}
 
-			dep.Spec.Template.Spec.Containers = []corev1.Container{
-				*openvpnSidecar,
-				*dnatControllerSidecar,
-				{
+			dep.Spec.Template.Spec.Containers = append(sidecars,
+				corev1.Container{
 					Name:    resources.ApiserverDeploymentName,
 					Image:   data.ImageRegistry(resources.RegistryK8SGCR) + "/kube-apiserver:v" + data.Cluster().Spec.Version.String(),
 					Command: []string{"/usr/local/bin/kube-apiserver"},
@@ -197,12 +215,13 @@ func DeploymentCreator(data *resources.TemplateData, enableOIDCAuthentication bo
 					},
 					VolumeMounts: volumeMounts,
 				},
-			}
+			)
 
 			defResourceRequirements := map[string]*corev1.ResourceRequirements{
-				name:                       defaultResourceRequirements.DeepCopy(),
-				openvpnSidecar.Name:        openvpnSidecar.Resources.DeepCopy(),
-				dnatControllerSidecar.Name: dnatControllerSidecar.Resources.DeepCopy(),
+				name: defaultResourceRequirements.DeepCopy(),
+			}
+			for _, sidecar := range sidecars {
+				defResourceRequirements[sidecar.Name] = sidecar.Resources.DeepCopy()
 			}
 			err = resources.SetResourceRequirements(dep.Spec.Template.Spec.Containers, defResourceRequirements, resources.GetOverrides(data.Cluster().Spec.ComponentsOverride), dep.Annotations)
 			if err != nil {
@@ -320,6 +339,10 @@ func getApiserverFlags(data *resources.TemplateData, etcdEndpoints []string, ena
 		flags = append(flags, "--endpoint-reconciler-type=none")
 	}
 
+	if data.Cluster().KonnectivityEnabled() {
+		flags = append(flags, "--egress-selector-config-file", "/etc/kubernetes/konnectivity/egress-selector-configuration.yaml")
+	}
+
 	if data.Cluster().Spec.Cloud.GCP != nil {
 		flags = append(flags, "--kubelet-preferred-address-types", "InternalIP")
 	} else {
@@ -357,6 +380,10 @@ func getApiserverFlags(data *resources.TemplateData, etcdEndpoints []string, ena
 		}
 	}
 
+	if logLevel := data.Cluster().Spec.ComponentsOverride.Apiserver.LogLevel; logLevel != nil {
+		flags = append(flags, "-v", strconv.Itoa(*logLevel))
+	}
+
 	return flags, nil
 }
 
@@ -420,6 +447,68 @@ func getVolumeMounts() []corev1.VolumeMount {
 	}, resources.GetHostCACertVolumeMounts()...)
 }
 
+// getConnectivitySidecars returns the sidecars connecting the apiserver to the user cluster networks
+func getConnectivitySidecars(data *resources.TemplateData) ([]corev1.Container, error) {
+	if data.Cluster().KonnectivityEnabled() {
+		konnectivityServerSidecar, err := vpnsidecar.KonnectivityServerContainer(data, "konnectivity-server")
+		if err != nil {
+			return nil, fmt.Errorf("failed to get konnectivity-server sidecar: %v", err)
+		}
+		return []corev1.Container{*konnectivityServerSidecar}, nil
+	}
+
+	openvpnSidecar, err := vpnsidecar.OpenVPNSidecarContainer(data, "openvpn-client")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get openvpn-client sidecar: %v", err)
+	}
+
+	dnatControllerSidecar, err := vpnsidecar.DnatControllerContainer(
+		data,
+		"dnat-controller",
+		fmt.Sprintf("https://127.0.0.1:%d", data.Cluster().Address.Port),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dnat-controller sidecar: %v", err)
+	}
+
+	return []corev1.Container{*openvpnSidecar, *dnatControllerSidecar}, nil
+}
+
+func getKonnectivityVolumes() []corev1.Volume {
+	return []corev1.Volume{
+		{
+			Name: resources.KonnectivityEgressSelectorConfigMapName,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: resources.KonnectivityEgressSelectorConfigMapName,
+					},
+				},
+			},
+		},
+		{
+			Name: resources.KonnectivityUDSVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		},
+	}
+}
+
+func getKonnectivityVolumeMounts() []corev1.VolumeMount {
+	return []corev1.VolumeMount{
+		{
+			Name:      resources.KonnectivityEgressSelectorConfigMapName,
+			MountPath: "/etc/kubernetes/konnectivity",
+			ReadOnly:  true,
+		},
+		{
+			Name:      resources.KonnectivityUDSVolumeName,
+			MountPath: resources.KonnectivityUDSMountPath,
+		},
+	}
+}
+
 func getVolumes() []corev1.Volume {
 	return append([]corev1.Volume{
 		{
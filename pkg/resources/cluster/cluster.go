@@ -26,6 +26,9 @@ import (
 	"k8c.io/kubermatic/v2/pkg/provider"
 	"k8c.io/kubermatic/v2/pkg/provider/cloud"
 	"k8c.io/kubermatic/v2/pkg/validation"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 // Spec builds ClusterSpec kubermatic Custom Resource from API Cluster
@@ -42,6 +45,36 @@ func Spec(apiCluster apiv1.Cluster, dc *kubermaticv1.Datacenter, secretKeyGetter
 		AuditLogging:                        apiCluster.Spec.AuditLogging,
 		Openshift:                           apiCluster.Spec.Openshift,
 		AdmissionPlugins:                    apiCluster.Spec.AdmissionPlugins,
+		Description:                         apiCluster.Spec.Description,
+		AdminGroups:                         apiCluster.Spec.AdminGroups,
+		Autoscaler:                          apiCluster.Spec.Autoscaler,
+		AddonVersions:                       apiCluster.Spec.AddonVersions,
+		ExternalDNS:                         apiCluster.Spec.ExternalDNS,
+		EgressAllowlist:                     apiCluster.Spec.EgressAllowlist,
+		Connectivity:                        apiCluster.Spec.Connectivity,
+		DisableNodeSSH:                      apiCluster.Spec.DisableNodeSSH,
+		AlertmanagerConfig:                  apiCluster.Spec.AlertmanagerConfig,
+		MaxPodsPerNode:                      apiCluster.Spec.MaxPodsPerNode,
+		ServiceNodePortRange:                apiCluster.Spec.ServiceNodePortRange,
+		RegistryMirror:                      apiCluster.Spec.RegistryMirror,
+		SchedulerConfig:                     apiCluster.Spec.SchedulerConfig,
+		DNS:                                 apiCluster.Spec.DNS,
+		MetricsServer:                       apiCluster.Spec.MetricsServer,
+		LoadBalancerClass:                   apiCluster.Spec.LoadBalancerClass,
+	}
+
+	if apiCluster.Spec.KubeProxy != nil {
+		spec.ClusterNetwork.ProxyMode = apiCluster.Spec.KubeProxy.Mode
+	}
+	if apiCluster.Spec.ClusterNetwork != nil {
+		spec.ClusterNetwork.IPFamily = apiCluster.Spec.ClusterNetwork.IPFamily
+		spec.ClusterNetwork.Pods.CIDRBlocks = apiCluster.Spec.ClusterNetwork.Pods
+		spec.ClusterNetwork.Services.CIDRBlocks = apiCluster.Spec.ClusterNetwork.Services
+	}
+	SetComponentLogLevels(&spec.ComponentsOverride, apiCluster.Spec.ComponentsOverride)
+	SetEtcdMaintenance(&spec.ComponentsOverride, apiCluster.Spec.ComponentsOverride)
+	if err := SetMachineControllerResources(&spec.ComponentsOverride, apiCluster.Spec.ComponentsOverride); err != nil {
+		return nil, err
 	}
 
 	providerName, err := provider.ClusterCloudProviderName(spec.Cloud)
@@ -62,3 +95,70 @@ func Spec(apiCluster apiv1.Cluster, dc *kubermaticv1.Datacenter, secretKeyGetter
 
 	return spec, validation.ValidateCreateClusterSpec(spec, dc, cloudProvider)
 }
+
+// SetComponentLogLevels sets the log levels of the control plane components from the API components override.
+// Components without a log level in the override get their log level unset.
+func SetComponentLogLevels(settings *kubermaticv1.ComponentSettings, override *apiv1.ComponentSettings) {
+	if override == nil {
+		override = &apiv1.ComponentSettings{}
+	}
+	settings.Apiserver.LogLevel = componentLogLevel(override.Apiserver)
+	settings.ControllerManager.LogLevel = componentLogLevel(override.ControllerManager)
+	settings.Scheduler.LogLevel = componentLogLevel(override.Scheduler)
+}
+
+func componentLogLevel(override *apiv1.ComponentOverride) *int {
+	if override == nil {
+		return nil
+	}
+	return override.LogLevel
+}
+
+// SetEtcdMaintenance sets the compaction mode and the defragmentation schedule of etcd from the API components override.
+func SetEtcdMaintenance(settings *kubermaticv1.ComponentSettings, override *apiv1.ComponentSettings) {
+	if override == nil || override.Etcd == nil {
+		settings.Etcd.CompactionMode = ""
+		settings.Etcd.DefragSchedule = ""
+		return
+	}
+	settings.Etcd.CompactionMode = override.Etcd.CompactionMode
+	settings.Etcd.DefragSchedule = override.Etcd.DefragSchedule
+}
+
+// SetMachineControllerResources sets the resource requirements of the machine-controller from the API components override.
+// It returns an error if any of the resource quantities is invalid.
+func SetMachineControllerResources(settings *kubermaticv1.ComponentSettings, override *apiv1.ComponentSettings) error {
+	if override == nil || override.MachineController == nil || override.MachineController.Resources == nil {
+		settings.MachineController.Resources = nil
+		return nil
+	}
+
+	requests, err := resourceList(override.MachineController.Resources.Requests)
+	if err != nil {
+		return fmt.Errorf("invalid machineController resource requests: %v", err)
+	}
+	limits, err := resourceList(override.MachineController.Resources.Limits)
+	if err != nil {
+		return fmt.Errorf("invalid machineController resource limits: %v", err)
+	}
+	settings.MachineController.Resources = &corev1.ResourceRequirements{
+		Requests: requests,
+		Limits:   limits,
+	}
+	return nil
+}
+
+func resourceList(quantities map[string]string) (corev1.ResourceList, error) {
+	if len(quantities) == 0 {
+		return nil, nil
+	}
+	list := corev1.ResourceList{}
+	for name, value := range quantities {
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s %q is not a valid quantity", name, value)
+		}
+		list[corev1.ResourceName(name)] = quantity
+	}
+	return list, nil
+}
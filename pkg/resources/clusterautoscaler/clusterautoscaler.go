@@ -32,6 +32,15 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 )
 
+const (
+	// MinSizeAnnotation is the MachineDeployment annotation holding the minimum number of
+	// replicas the cluster-autoscaler may scale the MachineDeployment down to
+	MinSizeAnnotation = "cluster.k8s.io/cluster-api-autoscaler-node-group-min-size"
+	// MaxSizeAnnotation is the MachineDeployment annotation holding the maximum number of
+	// replicas the cluster-autoscaler may scale the MachineDeployment up to
+	MaxSizeAnnotation = "cluster.k8s.io/cluster-api-autoscaler-node-group-max-size"
+)
+
 var (
 	defaultResourceRequirements = map[string]*corev1.ResourceRequirements{
 		resources.ClusterAutoscalerDeploymentName: {
@@ -97,22 +106,27 @@ func DeploymentCreator(data clusterautoscalerData) reconciling.NamedDeploymentCr
 
 			dep.Spec.Template.Spec.Volumes = volumes
 
+			args := []string{
+				"--kubeconfig", "/etc/kubernetes/kubeconfig/kubeconfig",
+				"--leader-elect-resource-lock", "configmaps",
+				// PercentageUsed treshold. If the current utilization of a node is above this, the CA will never
+				// scale it down. Default is 0.5. Increased, because otherwise small nodes never get scaled down
+				// because the DS pods on them alone manage to get the utilization above the 0.5 threshold.
+				"--scale-down-utilization-threshold", "0.7",
+				// For debugging you can add the following to increase verbosity and make scale down kick in without
+				// delay:
+				// -v=4 --scale-down-delay-after-failure=1s --scale-down-delay-after-add=1s
+			}
+			if settings := data.Cluster().Spec.Autoscaler; settings != nil && settings.ScaleDownDelayAfterAdd != "" {
+				args = append(args, "--scale-down-delay-after-add", settings.ScaleDownDelayAfterAdd)
+			}
+
 			dep.Spec.Template.Spec.Containers = []corev1.Container{
 				{
 					Name:    resources.ClusterAutoscalerDeploymentName,
 					Image:   data.ImageRegistry(resources.RegistryQuay) + "/kubermatic/kubernetes-cluster-autoscaler:" + tag,
 					Command: []string{"/cluster-autoscaler"},
-					Args: []string{
-						"--kubeconfig", "/etc/kubernetes/kubeconfig/kubeconfig",
-						"--leader-elect-resource-lock", "configmaps",
-						// PercentageUsed treshold. If the current utilization of a node is above this, the CA will never
-						// scale it down. Default is 0.5. Increased, because otherwise small nodes never get scaled down
-						// because the DS pods on them alone manage to get the utilization above the 0.5 threshold.
-						"--scale-down-utilization-threshold", "0.7",
-						// For debugging you can add the following to increase verbosity and make scale down kick in without
-						// delay:
-						// -v=4 --scale-down-delay-after-failure=1s --scale-down-delay-after-add=1s
-					},
+					Args:    args,
 					LivenessProbe: &corev1.Probe{
 						Handler: corev1.Handler{
 							HTTPGet: &corev1.HTTPGetAction{
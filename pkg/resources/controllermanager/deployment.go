@@ -18,6 +18,8 @@ package controllermanager
 
 import (
 	"fmt"
+	"net"
+	"strconv"
 	"strings"
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
@@ -244,6 +246,20 @@ func getFlags(data *resources.TemplateData) ([]string, error) {
 	// We're going to use the https endpoints for scraping the metrics starting from 1.12. Thus we can deactivate the http endpoint
 	flags = append(flags, "--port", "0")
 
+	if logLevel := data.Cluster().Spec.ComponentsOverride.ControllerManager.LogLevel; logLevel != nil {
+		flags = append(flags, "-v", strconv.Itoa(*logLevel))
+	}
+
+	// The node ranges are only resized when the max pods are set, to keep the ranges of existing clusters
+	if maxPods := data.Cluster().Spec.MaxPodsPerNode; maxPods != nil {
+		_, podNetwork, err := net.ParseCIDR(data.Cluster().Spec.ClusterNetwork.Pods.CIDRBlocks[0])
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the pod network range: %v", err)
+		}
+		_, bits := podNetwork.Mask.Size()
+		flags = append(flags, "--node-cidr-mask-size", strconv.Itoa(resources.NodeCIDRMaskSize(*maxPods, bits)))
+	}
+
 	return flags, nil
 }
 
@@ -204,8 +204,11 @@ func (d *TemplateData) NodeAccessNetwork() string {
 	return d.nodeAccessNetwork
 }
 
-// NodePortRange returns the node access network
+// NodePortRange returns the node port range of the cluster, or the one of the seed when the cluster does not set one
 func (d *TemplateData) NodePortRange() string {
+	if d.cluster != nil && d.cluster.Spec.ServiceNodePortRange != "" {
+		return d.cluster.Spec.ServiceNodePortRange
+	}
 	return d.nodePortRange
 }
 
@@ -247,6 +250,9 @@ func (d *TemplateData) ProviderName() string {
 
 // ImageRegistry returns the image registry to use or the passed in default if no override is specified
 func (d *TemplateData) ImageRegistry(defaultRegistry string) string {
+	if d.cluster.Spec.RegistryMirror != nil {
+		return d.cluster.Spec.RegistryMirror.ImageRegistry()
+	}
 	if d.OverwriteRegistry != "" {
 		return d.OverwriteRegistry
 	}
@@ -32,6 +32,9 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// defaultDefragSchedule is the schedule of the defragmentation for clusters which do not configure one
+const defaultDefragSchedule = "@every 3h"
+
 type cronJobCreatorData interface {
 	Cluster() *kubermaticv1.Cluster
 	ImageRegistry(string) string
@@ -52,7 +55,10 @@ func CronJobCreator(data cronJobCreatorData) reconciling.NamedCronJobCreatorGett
 			job.Spec.ConcurrencyPolicy = batchv1beta1.ForbidConcurrent
 			var historyLimit int32
 			job.Spec.SuccessfulJobsHistoryLimit = &historyLimit
-			job.Spec.Schedule = "@every 3h"
+			job.Spec.Schedule = defaultDefragSchedule
+			if schedule := data.Cluster().Spec.ComponentsOverride.Etcd.DefragSchedule; schedule != "" {
+				job.Spec.Schedule = schedule
+			}
 			job.Spec.JobTemplate.Spec.Template.Spec.RestartPolicy = corev1.RestartPolicyOnFailure
 			job.Spec.JobTemplate.Spec.Template.Spec.ImagePullSecrets = []corev1.LocalObjectReference{{Name: resources.ImagePullSecretName}}
 			job.Spec.JobTemplate.Spec.Template.Spec.Containers = []corev1.Container{
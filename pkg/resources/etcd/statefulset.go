@@ -116,7 +116,7 @@ func StatefulSetCreator(data etcdStatefulSetCreatorData, enableDataCorruptionChe
 
 					Image:           data.ImageRegistry(resources.RegistryGCR) + "/etcd-development/etcd:" + ImageTag(data.Cluster()),
 					ImagePullPolicy: corev1.PullIfNotPresent,
-					Command:         []string{"/opt/bin/etcd-launcher"}, Args: getLauncherArgs(enableDataCorruptionChecks),
+					Command:         []string{"/opt/bin/etcd-launcher"}, Args: getLauncherArgs(enableDataCorruptionChecks, data.Cluster().Spec.ComponentsOverride.Etcd.CompactionMode),
 					Env: []corev1.EnvVar{
 						{
 							Name: "POD_NAME",
@@ -368,7 +368,7 @@ func computeReplicas(data etcdStatefulSetCreatorData, set *appsv1.StatefulSet) i
 	return replicas
 }
 
-func getLauncherArgs(enableCorruptionCheck bool) []string {
+func getLauncherArgs(enableCorruptionCheck bool, compactionMode string) []string {
 	command := []string{"-namespace", "$(NAMESPACE)",
 		"-etcd-cluster-size", "$(ETCD_CLUSTER_SIZE)",
 		"-pod-name", "$(POD_NAME)",
@@ -379,5 +379,8 @@ func getLauncherArgs(enableCorruptionCheck bool) []string {
 	if enableCorruptionCheck {
 		command = append(command, "-enable-corruption-check")
 	}
+	if compactionMode != "" {
+		command = append(command, "-compaction-mode", compactionMode)
+	}
 	return command
 }
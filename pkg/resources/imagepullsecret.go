@@ -17,6 +17,9 @@ limitations under the License.
 package resources
 
 import (
+	"encoding/json"
+	"fmt"
+
 	"k8c.io/kubermatic/v2/pkg/resources/reconciling"
 
 	corev1 "k8s.io/api/core/v1"
@@ -38,3 +41,54 @@ func ImagePullSecretCreator(dockerPullConfigJSON []byte) reconciling.NamedSecret
 		}
 	}
 }
+
+// RegistryMirrorImagePullSecretCreator returns a creator function to create a ImagePullSecret which additionally
+// holds the credentials of the registry mirror of the cluster
+func RegistryMirrorImagePullSecretCreator(dockerPullConfigJSON []byte, data *TemplateData) reconciling.NamedSecretCreatorGetter {
+	return func() (string, reconciling.SecretCreator) {
+		return ImagePullSecretName, func(se *corev1.Secret) (*corev1.Secret, error) {
+			mirrorConfigJSON, err := data.GetGlobalSecretKeySelectorValue(data.Cluster().Spec.RegistryMirror.CredentialsReference, corev1.DockerConfigJsonKey)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get the registry mirror credentials: %v", err)
+			}
+
+			configJSON, err := mergeDockerConfigJSON(dockerPullConfigJSON, []byte(mirrorConfigJSON))
+			if err != nil {
+				return nil, err
+			}
+
+			se.Type = corev1.SecretTypeDockerConfigJson
+
+			if se.Data == nil {
+				se.Data = map[string][]byte{}
+			}
+
+			se.Data[corev1.DockerConfigJsonKey] = configJSON
+
+			return se, nil
+		}
+	}
+}
+
+type dockerConfigJSON struct {
+	Auths map[string]json.RawMessage `json:"auths"`
+}
+
+// mergeDockerConfigJSON merges the registry credentials of the given docker configs, the later ones
+// taking precedence.
+func mergeDockerConfigJSON(configs ...[]byte) ([]byte, error) {
+	merged := dockerConfigJSON{Auths: map[string]json.RawMessage{}}
+	for _, config := range configs {
+		if len(config) == 0 {
+			continue
+		}
+		parsed := dockerConfigJSON{}
+		if err := json.Unmarshal(config, &parsed); err != nil {
+			return nil, fmt.Errorf("failed to parse docker config: %v", err)
+		}
+		for registry, auth := range parsed.Auths {
+			merged.Auths[registry] = auth
+		}
+	}
+	return json.Marshal(merged)
+}
@@ -19,6 +19,7 @@ package machine
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/aws/aws-sdk-go/service/ec2"
 
@@ -41,6 +42,7 @@ import (
 	"github.com/kubermatic/machine-controller/pkg/userdata/ubuntu"
 	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/provider"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/json"
@@ -503,6 +505,47 @@ func getFlatcarOperatingSystemSpec(nodeSpec apiv1.NodeSpec) (*runtime.RawExtensi
 	return ext, nil
 }
 
+// supportedOperatingSystems are the operating systems the machine-controller can provision on a cloud provider.
+// Providers which are not listed are not restricted.
+var supportedOperatingSystems = map[string]sets.String{
+	provider.AWSCloudProvider:          osNames(providerconfig.OperatingSystemUbuntu, providerconfig.OperatingSystemCentOS, providerconfig.OperatingSystemCoreos, providerconfig.OperatingSystemFlatcar, providerconfig.OperatingSystemRHEL, providerconfig.OperatingSystemSLES),
+	provider.AzureCloudProvider:        osNames(providerconfig.OperatingSystemUbuntu, providerconfig.OperatingSystemCentOS, providerconfig.OperatingSystemCoreos, providerconfig.OperatingSystemFlatcar, providerconfig.OperatingSystemRHEL),
+	provider.DigitaloceanCloudProvider: osNames(providerconfig.OperatingSystemUbuntu, providerconfig.OperatingSystemCentOS),
+	provider.PacketCloudProvider:       osNames(providerconfig.OperatingSystemUbuntu, providerconfig.OperatingSystemCentOS, providerconfig.OperatingSystemCoreos, providerconfig.OperatingSystemFlatcar),
+	provider.GCPCloudProvider:          osNames(providerconfig.OperatingSystemUbuntu),
+	provider.HetznerCloudProvider:      osNames(providerconfig.OperatingSystemUbuntu, providerconfig.OperatingSystemCentOS),
+	provider.KubevirtCloudProvider:     osNames(providerconfig.OperatingSystemUbuntu, providerconfig.OperatingSystemCentOS, providerconfig.OperatingSystemCoreos, providerconfig.OperatingSystemFlatcar, providerconfig.OperatingSystemRHEL),
+	provider.OpenstackCloudProvider:    osNames(providerconfig.OperatingSystemUbuntu, providerconfig.OperatingSystemCentOS, providerconfig.OperatingSystemCoreos, providerconfig.OperatingSystemFlatcar, providerconfig.OperatingSystemRHEL),
+	provider.VSphereCloudProvider:      osNames(providerconfig.OperatingSystemUbuntu, providerconfig.OperatingSystemCentOS, providerconfig.OperatingSystemCoreos, providerconfig.OperatingSystemFlatcar, providerconfig.OperatingSystemRHEL),
+	provider.AlibabaCloudProvider:      osNames(providerconfig.OperatingSystemUbuntu, providerconfig.OperatingSystemCentOS),
+}
+
+func osNames(operatingSystems ...providerconfig.OperatingSystem) sets.String {
+	names := sets.NewString()
+	for _, os := range operatingSystems {
+		names.Insert(string(os))
+	}
+	return names
+}
+
+// ValidateOperatingSystem checks that the operating system of the node spec can be provisioned on the cloud provider
+// of the cluster.
+func ValidateOperatingSystem(cloud kubermaticv1.CloudSpec, nodeSpec apiv1.NodeSpec) error {
+	osName, err := getOsName(nodeSpec)
+	if err != nil {
+		return err
+	}
+	providerName, err := provider.ClusterCloudProviderName(cloud)
+	if err != nil {
+		return err
+	}
+	supported, restricted := supportedOperatingSystems[providerName]
+	if restricted && !supported.Has(string(osName)) {
+		return fmt.Errorf("operating system %s is not supported by the %s provider, supported are %s", osName, providerName, strings.Join(supported.List(), ", "))
+	}
+	return nil
+}
+
 // defaultIfEmpty returns the given value if not empty or the default value
 // otherwise.
 func defaultIfEmpty(value, defaultValue string) string {
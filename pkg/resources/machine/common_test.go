@@ -133,3 +133,51 @@ func TestGetVSphereProviderSpec(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateOperatingSystem(t *testing.T) {
+	tests := []struct {
+		name     string
+		cloud    kubermaticv1.CloudSpec
+		nodeSpec apiv1.NodeSpec
+		wantErr  string
+	}{
+		{
+			name:     "Ubuntu on GCP",
+			cloud:    kubermaticv1.CloudSpec{GCP: &kubermaticv1.GCPCloudSpec{}},
+			nodeSpec: apiv1.NodeSpec{OperatingSystem: apiv1.OperatingSystemSpec{Ubuntu: &apiv1.UbuntuSpec{}}},
+		},
+		{
+			name:     "Flatcar on AWS",
+			cloud:    kubermaticv1.CloudSpec{AWS: &kubermaticv1.AWSCloudSpec{}},
+			nodeSpec: apiv1.NodeSpec{OperatingSystem: apiv1.OperatingSystemSpec{Flatcar: &apiv1.FlatcarSpec{}}},
+		},
+		{
+			name:     "RHEL on Hetzner",
+			cloud:    kubermaticv1.CloudSpec{Hetzner: &kubermaticv1.HetznerCloudSpec{}},
+			nodeSpec: apiv1.NodeSpec{OperatingSystem: apiv1.OperatingSystemSpec{RHEL: &apiv1.RHELSpec{}}},
+			wantErr:  "operating system rhel is not supported by the hetzner provider, supported are centos, ubuntu",
+		},
+		{
+			name:     "Flatcar on the fake provider",
+			cloud:    kubermaticv1.CloudSpec{Fake: &kubermaticv1.FakeCloudSpec{}},
+			nodeSpec: apiv1.NodeSpec{OperatingSystem: apiv1.OperatingSystemSpec{Flatcar: &apiv1.FlatcarSpec{}}},
+		},
+		{
+			name:    "No operating system",
+			cloud:   kubermaticv1.CloudSpec{AWS: &kubermaticv1.AWSCloudSpec{}},
+			wantErr: "unknown operating system",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateOperatingSystem(test.cloud, test.nodeSpec)
+			if test.wantErr == "" && err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if test.wantErr != "" && (err == nil || err.Error() != test.wantErr) {
+				t.Fatalf("expected error %q, got %v", test.wantErr, err)
+			}
+		})
+	}
+}
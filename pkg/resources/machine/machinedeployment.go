@@ -133,9 +133,11 @@ func Deployment(c *kubermaticv1.Cluster, nd *apiv1.NodeDeployment, dc *kubermati
 
 func getProviderConfig(c *kubermaticv1.Cluster, nd *apiv1.NodeDeployment, dc *kubermaticv1.Datacenter, keys []*kubermaticv1.UserSSHKey, data resources.CredentialsData) (*providerconfig.Config, error) {
 	config := providerconfig.Config{}
-	config.SSHPublicKeys = make([]string, len(keys))
-	for i, key := range keys {
-		config.SSHPublicKeys[i] = key.Spec.PublicKey
+	config.SSHPublicKeys = make([]string, 0, len(keys))
+	if !c.Spec.DisableNodeSSH {
+		for _, key := range keys {
+			config.SSHPublicKeys = append(config.SSHPublicKeys, key.Spec.PublicKey)
+		}
 	}
 
 	var (
@@ -0,0 +1,42 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package prometheus
+
+import (
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/reconciling"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// AlertmanagerConfigSecretCreator returns a function to create the secret containing the Alertmanager
+// configuration the alerts of the cluster are routed with
+func AlertmanagerConfigSecretCreator(data *resources.TemplateData) reconciling.NamedSecretCreatorGetter {
+	return func() (string, reconciling.SecretCreator) {
+		return resources.AlertmanagerConfigSecretName, func(se *corev1.Secret) (*corev1.Secret, error) {
+			se.Labels = resources.BaseAppLabels(name, nil)
+			se.Type = corev1.SecretTypeOpaque
+
+			if se.Data == nil {
+				se.Data = map[string][]byte{}
+			}
+			se.Data[resources.AlertmanagerConfigSecretKey] = []byte(data.Cluster().Spec.AlertmanagerConfig)
+
+			return se, nil
+		}
+	}
+}
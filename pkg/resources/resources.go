@@ -118,6 +118,8 @@ const (
 	EtcdServiceName = "etcd"
 	//EtcdDefragCronJobName is the name for the defrag cronjob deployment
 	EtcdDefragCronJobName = "etcd-defragger"
+	//EtcdBackupJobAppName is the app label value of the jobs creating the etcd backups of a cluster
+	EtcdBackupJobAppName = "etcd-backup"
 	//OpenVPNServerServiceName is the name for the openvpn server service
 	OpenVPNServerServiceName = "openvpn-server"
 	//MachineControllerWebhookServiceName is the name of the machine-controller webhook service
@@ -153,6 +155,10 @@ const (
 	MachineControllerWebhookServingCertKeyKeyName = "key.pem"
 	//PrometheusApiserverClientCertificateSecretName is the name for the secret containing the client certificate used by prometheus to access the apiserver
 	PrometheusApiserverClientCertificateSecretName = "prometheus-apiserver-certificate"
+	// AlertmanagerConfigSecretName is the name of the secret containing the Alertmanager configuration of the cluster
+	AlertmanagerConfigSecretName = "alertmanager-config"
+	// AlertmanagerConfigSecretKey is the key of the Alertmanager configuration in its secret
+	AlertmanagerConfigSecretKey = "alertmanager.yaml"
 	// ClusterAutoscalerKubeconfigSecretName is the name of the kubeconfig secret used for
 	// the cluster-autoscaler
 	ClusterAutoscalerKubeconfigSecretName = "cluster-autoscaler-kubeconfig"
@@ -226,6 +232,18 @@ const (
 	PrometheusConfigConfigMapName = "prometheus"
 	//AuditConfigMapName is the name for the configmap that contains the content of the file that will be passed to the apiserver with the flag "--audit-policy-file".
 	AuditConfigMapName = "audit-config"
+	// KonnectivityEgressSelectorConfigMapName is the name for the configmap containing the egress selector configuration,
+	// which routes the cluster traffic of the apiserver through the Konnectivity server.
+	KonnectivityEgressSelectorConfigMapName = "konnectivity-egress-selector"
+	// SchedulerConfigMapName is the name for the configmap containing the scheduler configuration of the cluster,
+	// which is passed to the scheduler with the flag "--config".
+	SchedulerConfigMapName = "scheduler-config"
+	// KonnectivityUDSVolumeName is the name of the volume holding the unix socket shared between the apiserver and the Konnectivity server
+	KonnectivityUDSVolumeName = "konnectivity-uds"
+	// KonnectivityUDSMountPath is the path the KonnectivityUDSVolumeName volume is mounted at
+	KonnectivityUDSMountPath = "/etc/kubernetes/konnectivity-server"
+	// KonnectivityUDSPath is the path of the unix socket the Konnectivity server listens on
+	KonnectivityUDSPath = KonnectivityUDSMountPath + "/konnectivity-server.socket"
 
 	//PrometheusServiceAccountName is the name for the Prometheus serviceaccount
 	PrometheusServiceAccountName = "prometheus"
@@ -367,6 +385,20 @@ const (
 	IPVSProxyMode = "ipvs"
 	// IPTablesProxyMode defines the iptables kube-proxy mode.
 	IPTablesProxyMode = "iptables"
+
+	// IPv4IPFamily defines a cluster network with IPv4 addresses only.
+	IPv4IPFamily = "ipv4"
+	// IPv6IPFamily defines a cluster network with IPv6 addresses only.
+	IPv6IPFamily = "ipv6"
+	// DualStackIPFamily defines a cluster network with both IPv4 and IPv6 addresses.
+	DualStackIPFamily = "dualstack"
+
+	// DefaultPodsCIDR is the network range from which POD networks are allocated when the cluster does not specify one.
+	DefaultPodsCIDR = "172.25.0.0/16"
+	// DefaultServicesCIDR is the network range from which service VIPs are allocated when the cluster does not specify one.
+	DefaultServicesCIDR = "10.240.16.0/20"
+	// DefaultMaxPodsPerNode is the maximum number of pods per node when the cluster does not specify one.
+	DefaultMaxPodsPerNode = 110
 )
 
 const (
@@ -591,6 +623,17 @@ func UserClusterDNSResolverIP(cluster *kubermaticv1.Cluster) (string, error) {
 	return ip.String(), nil
 }
 
+// NodeCIDRMaskSize returns the mask size of the network range the controller-manager assigns to each node,
+// so that it holds at least twice as many addresses as pods may run on the node. This leaves room for the
+// addresses of terminated pods which are not reused right away. bits is the length of the addresses (32 for IPv4).
+func NodeCIDRMaskSize(maxPodsPerNode int32, bits int) int {
+	hostBits := 0
+	for int64(1)<<hostBits < 2*int64(maxPodsPerNode) {
+		hostBits++
+	}
+	return bits - hostBits
+}
+
 // InClusterApiserverIP returns the first usable IP of the service cidr.
 // Its the in cluster IP for the apiserver
 func InClusterApiserverIP(cluster *kubermaticv1.Cluster) (*net.IP, error) {
@@ -1013,6 +1056,9 @@ func GetOverrides(componentSettings kubermaticv1.ComponentSettings) map[string]*
 	if componentSettings.Prometheus.Resources != nil {
 		r[PrometheusStatefulSetName] = componentSettings.Prometheus.Resources.DeepCopy()
 	}
+	if componentSettings.MachineController.Resources != nil {
+		r[MachineControllerDeploymentName] = componentSettings.MachineController.Resources.DeepCopy()
+	}
 
 	return r
 }
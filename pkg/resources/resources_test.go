@@ -101,6 +101,59 @@ func TestUserClusterDNSResolverIP(t *testing.T) {
 	}
 }
 
+func TestNodeCIDRMaskSize(t *testing.T) {
+	testCases := []struct {
+		name           string
+		maxPods        int32
+		bits           int
+		expectedResult int
+	}{
+		{
+			name:           "default max pods",
+			maxPods:        DefaultMaxPodsPerNode,
+			bits:           32,
+			expectedResult: 24,
+		},
+		{
+			name:           "max pods filling the range",
+			maxPods:        64,
+			bits:           32,
+			expectedResult: 25,
+		},
+		{
+			name:           "IPv6 range",
+			maxPods:        250,
+			bits:           128,
+			expectedResult: 119,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if result := NodeCIDRMaskSize(tc.maxPods, tc.bits); result != tc.expectedResult {
+				t.Errorf("wrong result, expected: %d, result: %d", tc.expectedResult, result)
+			}
+		})
+	}
+}
+
+func TestMergeDockerConfigJSON(t *testing.T) {
+	pullConfig := []byte(`{"auths":{"quay.io":{"auth":"a3ViZXJtYXRpYzpzZWNyZXQ="},"registry.example.com":{"auth":"b2xkOm9sZA=="}}}`)
+	mirrorConfig := []byte(`{"auths":{"registry.example.com":{"auth":"bWlycm9yOnNlY3JldA=="}}}`)
+
+	result, err := mergeDockerConfigJSON(pullConfig, mirrorConfig)
+	if err != nil {
+		t.Fatalf("failed to merge the docker configs: %v", err)
+	}
+
+	expected := `{"auths":{"quay.io":{"auth":"a3ViZXJtYXRpYzpzZWNyZXQ="},"registry.example.com":{"auth":"bWlycm9yOnNlY3JldA=="}}}`
+	if string(result) != expected {
+		t.Errorf("wrong result, expected: %s, result: %s", expected, result)
+	}
+}
+
 func TestSetResourceRequirements(t *testing.T) {
 	defaultResourceRequirements := corev1.ResourceRequirements{
 		Requests: corev1.ResourceList{
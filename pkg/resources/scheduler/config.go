@@ -0,0 +1,73 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"fmt"
+
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/reconciling"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	configFileName  = "config.yaml"
+	configMountPath = "/etc/kubernetes/scheduler"
+	kubeconfigPath  = "/etc/kubernetes/kubeconfig/kubeconfig"
+)
+
+// ConfigMapCreator returns the function to create the configmap holding the scheduler configuration of the cluster
+func ConfigMapCreator(data *resources.TemplateData) reconciling.NamedConfigMapCreatorGetter {
+	return func() (string, reconciling.ConfigMapCreator) {
+		return resources.SchedulerConfigMapName, func(cm *corev1.ConfigMap) (*corev1.ConfigMap, error) {
+			config, err := Config(data.Cluster().Spec.SchedulerConfig)
+			if err != nil {
+				return nil, err
+			}
+
+			cm.Labels = resources.BaseAppLabels(name, nil)
+			cm.Data = map[string]string{
+				configFileName: config,
+			}
+			return cm, nil
+		}
+	}
+}
+
+// Config returns the scheduler configuration in YAML format. The client connection of the configuration
+// always uses the kubeconfig of the scheduler, as the --kubeconfig flag is ignored together with --config.
+func Config(rawConfig string) (string, error) {
+	config := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(rawConfig), &config); err != nil {
+		return "", fmt.Errorf("failed to parse the scheduler configuration: %v", err)
+	}
+
+	clientConnection, ok := config["clientConnection"].(map[string]interface{})
+	if !ok {
+		clientConnection = map[string]interface{}{}
+	}
+	clientConnection["kubeconfig"] = kubeconfigPath
+	config["clientConnection"] = clientConnection
+
+	out, err := yaml.Marshal(config)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode the scheduler configuration: %v", err)
+	}
+	return string(out), nil
+}
@@ -18,6 +18,7 @@ package scheduler
 
 import (
 	"fmt"
+	"strconv"
 
 	"k8c.io/kubermatic/v2/pkg/resources/apiserver"
 	"k8c.io/kubermatic/v2/pkg/resources/reconciling"
@@ -58,7 +59,7 @@ func DeploymentCreator(data *resources.TemplateData) reconciling.NamedDeployment
 			dep.Labels = resources.BaseAppLabels(name, nil)
 
 			flags := []string{
-				"--kubeconfig", "/etc/kubernetes/kubeconfig/kubeconfig",
+				"--kubeconfig", kubeconfigPath,
 				// These are used to validate tokens
 				"--authentication-kubeconfig", "/etc/kubernetes/kubeconfig/kubeconfig",
 				"--authorization-kubeconfig", "/etc/kubernetes/kubeconfig/kubeconfig",
@@ -67,6 +68,12 @@ func DeploymentCreator(data *resources.TemplateData) reconciling.NamedDeployment
 				// We're going to use the https endpoints for scraping the metrics starting from 1.13. Thus we can deactivate the http endpoint
 				"--port", "0",
 			}
+			if logLevel := data.Cluster().Spec.ComponentsOverride.Scheduler.LogLevel; logLevel != nil {
+				flags = append(flags, "-v", strconv.Itoa(*logLevel))
+			}
+			if data.Cluster().Spec.SchedulerConfig != "" {
+				flags = append(flags, "--config", configMountPath+"/"+configFileName)
+			}
 
 			dep.Spec.Replicas = resources.Int32(1)
 			if data.Cluster().Spec.ComponentsOverride.Scheduler.Replicas != nil {
@@ -79,6 +86,23 @@ func DeploymentCreator(data *resources.TemplateData) reconciling.NamedDeployment
 
 			volumes := getVolumes()
 			volumeMounts := getVolumeMounts()
+			if data.Cluster().Spec.SchedulerConfig != "" {
+				volumes = append(volumes, corev1.Volume{
+					Name: resources.SchedulerConfigMapName,
+					VolumeSource: corev1.VolumeSource{
+						ConfigMap: &corev1.ConfigMapVolumeSource{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: resources.SchedulerConfigMapName,
+							},
+						},
+					},
+				})
+				volumeMounts = append(volumeMounts, corev1.VolumeMount{
+					Name:      resources.SchedulerConfigMapName,
+					MountPath: configMountPath,
+					ReadOnly:  true,
+				})
+			}
 
 			podLabels, err := data.GetPodTemplateLabels(name, volumes, nil)
 			if err != nil {
@@ -140,6 +140,18 @@ func DeploymentCreator(data userclusterControllerData, openshift bool) reconcili
 				args = append(args, "-update-window-start", data.Cluster().Spec.UpdateWindow.Start, "-update-window-length", data.Cluster().Spec.UpdateWindow.Length)
 			}
 
+			if len(data.Cluster().Spec.AdminGroups) > 0 {
+				args = append(args, "-admin-groups", strings.Join(data.Cluster().Spec.AdminGroups, ","))
+			}
+
+			if dns := data.Cluster().Spec.DNS; dns != nil && len(dns.Upstreams) > 0 {
+				upstreams, err := json.Marshal(dns.Upstreams)
+				if err != nil {
+					return nil, fmt.Errorf("failed to marshal the DNS upstreams: %v", err)
+				}
+				args = append(args, "-dns-upstreams", string(upstreams))
+			}
+
 			labelArgsValue, err := getLabelsArgValue(data.Cluster())
 			if err != nil {
 				return nil, fmt.Errorf("faild to get label args value: %v", err)
@@ -0,0 +1,77 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vpnsidecar
+
+import (
+	"k8c.io/kubermatic/v2/pkg/resources"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+var (
+	konnectivityServerResourceRequirements = corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("16Mi"),
+			corev1.ResourceCPU:    resource.MustParse("5m"),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("128Mi"),
+			corev1.ResourceCPU:    resource.MustParse("100m"),
+		},
+	}
+)
+
+type konnectivityServerData interface {
+	ImageRegistry(string) string
+}
+
+// KonnectivityServerContainer returns a `corev1.Container` for running the
+// Konnectivity server alongside the apiserver, which proxies the apiserver
+// traffic to the user cluster networks via the Konnectivity agents.
+// Also required but not provided by this func:
+// * volumes: resources.ApiserverTLSSecretName, resources.KonnectivityUDSVolumeName
+func KonnectivityServerContainer(data konnectivityServerData, name string) (*corev1.Container, error) {
+	return &corev1.Container{
+		Name:    name,
+		Image:   data.ImageRegistry(resources.RegistryK8SGCR) + "/kas-network-proxy/proxy-server:v0.0.12",
+		Command: []string{"/proxy-server"},
+		Args: []string{
+			"--logtostderr=true",
+			"--uds-name", resources.KonnectivityUDSPath,
+			"--cluster-cert", "/etc/kubernetes/tls/apiserver-tls.crt",
+			"--cluster-key", "/etc/kubernetes/tls/apiserver-tls.key",
+			"--mode", "grpc",
+			"--server-port", "0",
+			"--agent-port", "8132",
+			"--admin-port", "8133",
+			"--health-port", "8134",
+		},
+		Resources: konnectivityServerResourceRequirements,
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				MountPath: "/etc/kubernetes/tls",
+				Name:      resources.ApiserverTLSSecretName,
+				ReadOnly:  true,
+			},
+			{
+				MountPath: resources.KonnectivityUDSMountPath,
+				Name:      resources.KonnectivityUDSVolumeName,
+			},
+		},
+	}, nil
+}
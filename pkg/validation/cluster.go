@@ -21,6 +21,12 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
@@ -29,9 +35,18 @@ import (
 	kubernetesprovider "k8c.io/kubermatic/v2/pkg/provider/kubernetes"
 	"k8c.io/kubermatic/v2/pkg/resources"
 
+	"github.com/Masterminds/semver"
 	"github.com/coreos/locksmith/pkg/timeutil"
+	alertmanagerconfig "github.com/prometheus/alertmanager/config"
+	"github.com/robfig/cron"
 	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilerror "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	utilvalidation "k8s.io/apimachinery/pkg/util/validation"
+	schedulerv1alpha2 "k8s.io/kube-scheduler/config/v1alpha2"
+	schedulerv1beta1 "k8s.io/kube-scheduler/config/v1beta1"
+	"sigs.k8s.io/yaml"
 )
 
 var (
@@ -39,18 +54,108 @@ var (
 	ErrCloudChangeNotAllowed = errors.New("not allowed to change the cloud provider")
 )
 
+const (
+	// defaultCNIPlugin is the CNI plugin deployed to user clusters
+	defaultCNIPlugin = "canal"
+	// ciliumCNIPlugin is a CNI plugin which, unlike canal, supports IPv6 only clusters
+	ciliumCNIPlugin = "cilium"
+)
+
+// MaxClusterDescriptionLength is the maximum number of characters allowed in a cluster description
+const MaxClusterDescriptionLength = 255
+
+// MaxComponentLogLevel is the highest log verbosity supported by the control plane components
+const MaxComponentLogLevel = 10
+
+// cniIPFamilies are the IP families supported by the CNI plugins
+var cniIPFamilies = map[string]sets.String{
+	defaultCNIPlugin: sets.NewString(resources.IPv4IPFamily, resources.DualStackIPFamily),
+	ciliumCNIPlugin:  sets.NewString(resources.IPv4IPFamily, resources.IPv6IPFamily, resources.DualStackIPFamily),
+}
+
+// SupportedExternalDNSProviders are the DNS providers the external-dns addon can be configured for
+var SupportedExternalDNSProviders = sets.NewString("aws", "azure", "cloudflare", "digitalocean", "google")
+
 // ValidateCreateClusterSpec validates the given cluster spec
 func ValidateCreateClusterSpec(spec *kubermaticv1.ClusterSpec, dc *kubermaticv1.Datacenter, cloudProvider provider.CloudProvider) error {
 	if spec.HumanReadableName == "" {
 		return errors.New("no name specified")
 	}
 
-	if err := ValidateCloudSpec(spec.Cloud, dc); err != nil {
-		return fmt.Errorf("invalid cloud spec: %v", err)
+	if spec.Version.Semver() == nil || spec.Version.String() == "" {
+		return errors.New("spec.version is required")
 	}
 
-	if spec.Version.Semver() == nil || spec.Version.String() == "" {
-		return errors.New(`invalid cloud spec "Version" is required but was not specified`)
+	if err := validateClusterDescription(spec.Description); err != nil {
+		return err
+	}
+
+	if err := validateAdminGroups(spec.AdminGroups); err != nil {
+		return err
+	}
+
+	if err := validateProxyMode(spec.ClusterNetwork.ProxyMode); err != nil {
+		return err
+	}
+
+	if err := validateIPFamily(spec.ClusterNetwork, defaultCNIPlugin); err != nil {
+		return err
+	}
+
+	if err := validateMaxPodsPerNode(spec.MaxPodsPerNode, spec.ClusterNetwork.Pods.CIDRBlocks); err != nil {
+		return err
+	}
+
+	if err := validateServiceNodePortRange(spec.ServiceNodePortRange); err != nil {
+		return err
+	}
+
+	if err := validateNodeNetworksOverlap(spec.ClusterNetwork, dc.Spec.NodeNetworks); err != nil {
+		return err
+	}
+
+	if err := validateLoadBalancerClass(spec.LoadBalancerClass, dc.Spec.LoadBalancerClasses); err != nil {
+		return err
+	}
+
+	if err := validateComponentsOverride(spec.ComponentsOverride); err != nil {
+		return err
+	}
+
+	if err := validateAutoscaler(spec.Autoscaler); err != nil {
+		return err
+	}
+
+	if err := validateExternalDNS(spec.ExternalDNS); err != nil {
+		return err
+	}
+
+	if err := validateEgressAllowlist(spec.EgressAllowlist); err != nil {
+		return err
+	}
+
+	if err := validateConnectivity(spec); err != nil {
+		return err
+	}
+
+	if err := validateAlertmanagerConfig(spec.AlertmanagerConfig); err != nil {
+		return err
+	}
+
+	if err := validateRegistryMirror(spec.RegistryMirror); err != nil {
+		return err
+	}
+
+	if err := validateSchedulerConfig(spec.SchedulerConfig, spec.Version.Semver()); err != nil {
+		return err
+	}
+
+	if err := validateDNS(spec.DNS); err != nil {
+		return err
+	}
+
+	if err := ValidateCloudSpec(spec.Cloud, dc); err != nil {
+		return fmt.Errorf("invalid cloud spec: %v", err)
 	}
 
 	if err := cloudProvider.ValidateCloudSpec(spec.Cloud); err != nil {
@@ -64,6 +169,456 @@ func ValidateCreateClusterSpec(spec *kubermaticv1.ClusterSpec, dc *kubermaticv1.
 	return nil
 }
 
+func validateClusterDescription(description string) error {
+	if utf8.RuneCountInString(description) > MaxClusterDescriptionLength {
+		return fmt.Errorf("cluster description must not be longer than %d characters", MaxClusterDescriptionLength)
+	}
+	return nil
+}
+
+// validateAdminGroups makes sure the admin groups can be used as RBAC subjects. Commas are
+// rejected because the groups are passed as a comma separated list to the user cluster controller.
+func validateAdminGroups(groups []string) error {
+	seen := map[string]bool{}
+	for _, group := range groups {
+		if group == "" {
+			return errors.New("admin group name must not be empty")
+		}
+		if strings.HasPrefix(group, "system:") {
+			return fmt.Errorf("invalid admin group %q: the \"system:\" prefix is reserved", group)
+		}
+		if strings.IndexFunc(group, func(r rune) bool { return r == ',' || unicode.IsSpace(r) || unicode.IsControl(r) }) >= 0 {
+			return fmt.Errorf("invalid admin group %q: must not contain commas, whitespace or control characters", group)
+		}
+		if seen[group] {
+			return fmt.Errorf("admin group %q is specified more than once", group)
+		}
+		seen[group] = true
+	}
+	return nil
+}
+
+// ValidateInitialNamespaces makes sure the namespaces which are created in a new cluster have valid and unique names
+func ValidateInitialNamespaces(namespaces []string) error {
+	seen := map[string]bool{}
+	for _, namespace := range namespaces {
+		if errs := utilvalidation.IsDNS1123Label(namespace); len(errs) > 0 {
+			return fmt.Errorf("invalid initial namespace %q: %s", namespace, strings.Join(errs, ", "))
+		}
+		if seen[namespace] {
+			return fmt.Errorf("initial namespace %q is specified more than once", namespace)
+		}
+		seen[namespace] = true
+	}
+	return nil
+}
+
+// validateProxyMode checks that the kube-proxy mode is supported.
+// An empty mode is allowed and gets defaulted later on.
+func validateProxyMode(mode string) error {
+	switch mode {
+	case "", resources.IPVSProxyMode, resources.IPTablesProxyMode:
+		return nil
+	default:
+		return fmt.Errorf("unsupported kube-proxy mode %q, must be one of %q or %q", mode, resources.IPVSProxyMode, resources.IPTablesProxyMode)
+	}
+}
+
+// validateIPFamily checks that the IP family is supported by the given CNI plugin and matches the
+// network ranges of the cluster. An empty family is allowed and gets defaulted to ipv4 later on.
+func validateIPFamily(network kubermaticv1.ClusterNetworkingConfig, cniPlugin string) error {
+	family := network.IPFamily
+	switch family {
+	case "":
+		family = resources.IPv4IPFamily
+	case resources.IPv4IPFamily, resources.IPv6IPFamily, resources.DualStackIPFamily:
+	default:
+		return fmt.Errorf("unsupported IP family %q, must be one of %q, %q or %q", family, resources.IPv4IPFamily, resources.IPv6IPFamily, resources.DualStackIPFamily)
+	}
+
+	if !cniIPFamilies[cniPlugin].Has(family) {
+		return fmt.Errorf("IP family %q is not supported by the %s CNI", family, cniPlugin)
+	}
+
+	ranges := []struct {
+		name       string
+		cidrBlocks []string
+	}{
+		{name: "pods", cidrBlocks: network.Pods.CIDRBlocks},
+		{name: "services", cidrBlocks: network.Services.CIDRBlocks},
+	}
+	for _, r := range ranges {
+		// the default network ranges are IPv4 only
+		if len(r.cidrBlocks) == 0 {
+			if family != resources.IPv4IPFamily {
+				return fmt.Errorf("the %s network ranges must be specified for the %s IP family", r.name, family)
+			}
+			continue
+		}
+
+		var ipv4Blocks, ipv6Blocks int
+		for _, cidr := range r.cidrBlocks {
+			ip, _, err := net.ParseCIDR(cidr)
+			if err != nil {
+				return fmt.Errorf("invalid %s network range %q: %v", r.name, cidr, err)
+			}
+			if ip.To4() != nil {
+				ipv4Blocks++
+			} else {
+				ipv6Blocks++
+			}
+		}
+
+		switch family {
+		case resources.IPv4IPFamily:
+			if ipv6Blocks > 0 {
+				return fmt.Errorf("the %s network ranges must only contain IPv4 ranges for the %s IP family", r.name, family)
+			}
+		case resources.IPv6IPFamily:
+			if ipv4Blocks > 0 {
+				return fmt.Errorf("the %s network ranges must only contain IPv6 ranges for the %s IP family", r.name, family)
+			}
+		case resources.DualStackIPFamily:
+			if ipv4Blocks != 1 || ipv6Blocks != 1 {
+				return fmt.Errorf("the %s network ranges must contain exactly one IPv4 and one IPv6 range for the %s IP family", r.name, family)
+			}
+		}
+	}
+	return nil
+}
+
+// validateMaxPodsPerNode checks that the network range of a node holding the max pods fits into the
+// pod network ranges. The default pod network range is used when the ranges are empty.
+func validateMaxPodsPerNode(maxPods *int32, podCIDRBlocks []string) error {
+	if maxPods == nil {
+		return nil
+	}
+	if *maxPods < 1 {
+		return fmt.Errorf("invalid maxPodsPerNode %d: must be at least 1", *maxPods)
+	}
+
+	if len(podCIDRBlocks) == 0 {
+		podCIDRBlocks = []string{resources.DefaultPodsCIDR}
+	}
+	for _, cidr := range podCIDRBlocks {
+		_, podNetwork, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return fmt.Errorf("invalid pods network range %q: %v", cidr, err)
+		}
+		ones, bits := podNetwork.Mask.Size()
+		if maskSize := resources.NodeCIDRMaskSize(*maxPods, bits); maskSize < ones {
+			return fmt.Errorf("invalid maxPodsPerNode %d: each node needs a /%d network range to hold twice as many addresses, which does not fit into the pods network range %s", *maxPods, maskSize, cidr)
+		}
+	}
+	return nil
+}
+
+// reservedNodePorts are the ports the components running on every node listen on
+var reservedNodePorts = []struct {
+	port      int
+	component string
+}{
+	{port: 10249, component: "kube-proxy metrics"},
+	{port: 10250, component: "kubelet"},
+	{port: 10256, component: "kube-proxy health check"},
+}
+
+// validateServiceNodePortRange checks that the node port range has the form "<first>-<last>" and does not contain
+// privileged ports or ports reserved for the node components
+func validateServiceNodePortRange(portRange string) error {
+	if portRange == "" {
+		return nil
+	}
+
+	bounds := strings.Split(portRange, "-")
+	if len(bounds) != 2 {
+		return fmt.Errorf("invalid service node port range %q: must have the form <first port>-<last port>", portRange)
+	}
+	first, err := strconv.Atoi(bounds[0])
+	if err != nil {
+		return fmt.Errorf("invalid service node port range %q: invalid first port: %v", portRange, err)
+	}
+	last, err := strconv.Atoi(bounds[1])
+	if err != nil {
+		return fmt.Errorf("invalid service node port range %q: invalid last port: %v", portRange, err)
+	}
+	if first > last {
+		return fmt.Errorf("invalid service node port range %q: the first port must not be greater than the last port", portRange)
+	}
+	if first < 1024 || last > 65535 {
+		return fmt.Errorf("invalid service node port range %q: the ports must be between 1024 and 65535", portRange)
+	}
+	for _, reserved := range reservedNodePorts {
+		if first <= reserved.port && reserved.port <= last {
+			return fmt.Errorf("invalid service node port range %q: it overlaps the port %d of the %s", portRange, reserved.port, reserved.component)
+		}
+	}
+	return nil
+}
+
+// validateNodeNetworksOverlap checks that the pods and services network ranges of the cluster do not overlap the
+// node networks of the datacenter. Empty network ranges are checked with the defaults the cluster will get.
+func validateNodeNetworksOverlap(network kubermaticv1.ClusterNetworkingConfig, nodeNetworks []string) error {
+	if len(nodeNetworks) == 0 {
+		return nil
+	}
+
+	podCIDRBlocks := network.Pods.CIDRBlocks
+	if len(podCIDRBlocks) == 0 {
+		podCIDRBlocks = []string{resources.DefaultPodsCIDR}
+	}
+	serviceCIDRBlocks := network.Services.CIDRBlocks
+	if len(serviceCIDRBlocks) == 0 {
+		serviceCIDRBlocks = []string{resources.DefaultServicesCIDR}
+	}
+	clusterNetworks := []struct {
+		field      string
+		name       string
+		cidrBlocks []string
+	}{
+		{field: "podCIDR", name: "pods", cidrBlocks: podCIDRBlocks},
+		{field: "servicesCIDR", name: "services", cidrBlocks: serviceCIDRBlocks},
+	}
+
+	for _, nodeCIDR := range nodeNetworks {
+		_, nodeNetwork, err := net.ParseCIDR(nodeCIDR)
+		if err != nil {
+			return fmt.Errorf("invalid datacenter node network %q: %v", nodeCIDR, err)
+		}
+		for _, clusterNetwork := range clusterNetworks {
+			for _, cidr := range clusterNetwork.cidrBlocks {
+				_, clusterCIDR, err := net.ParseCIDR(cidr)
+				if err != nil {
+					return fmt.Errorf("invalid %s network range %q: %v", clusterNetwork.name, cidr, err)
+				}
+				if clusterCIDR.Contains(nodeNetwork.IP) || nodeNetwork.Contains(clusterCIDR.IP) {
+					return fmt.Errorf("%s overlaps datacenter node network: the %s network range %s overlaps %s", clusterNetwork.field, clusterNetwork.name, cidr, nodeCIDR)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// validateLoadBalancerClass checks that the default load balancer class of the cluster is offered by the datacenter
+func validateLoadBalancerClass(class string, supportedClasses []string) error {
+	if class == "" {
+		return nil
+	}
+	if !sets.NewString(supportedClasses...).Has(class) {
+		if len(supportedClasses) == 0 {
+			return fmt.Errorf("invalid load balancer class %q: the datacenter does not support selecting a load balancer class", class)
+		}
+		return fmt.Errorf("invalid load balancer class %q: must be one of %s", class, strings.Join(supportedClasses, ", "))
+	}
+	return nil
+}
+
+// schedulerConfigAPIVersion returns the API version of the KubeSchedulerConfiguration supported by the given Kubernetes version.
+func schedulerConfigAPIVersion(version *semver.Version) string {
+	switch {
+	case version.Major() == 1 && version.Minor() < 18:
+		return "kubescheduler.config.k8s.io/v1alpha1"
+	case version.Major() == 1 && version.Minor() == 18:
+		return "kubescheduler.config.k8s.io/v1alpha2"
+	default:
+		return "kubescheduler.config.k8s.io/v1beta1"
+	}
+}
+
+// schedulerConfigTypes are the versioned KubeSchedulerConfiguration types, keyed by their API version.
+// k8s.io/kube-scheduler no longer ships the v1alpha1 types, so the configuration of Kubernetes 1.17
+// and older is only checked for its kind and API version.
+var schedulerConfigTypes = map[string]func() interface{}{
+	"kubescheduler.config.k8s.io/v1alpha2": func() interface{} { return &schedulerv1alpha2.KubeSchedulerConfiguration{} },
+	"kubescheduler.config.k8s.io/v1beta1":  func() interface{} { return &schedulerv1beta1.KubeSchedulerConfiguration{} },
+}
+
+// validateSchedulerConfig checks that the scheduler configuration is a KubeSchedulerConfiguration
+// of the API version supported by the Kubernetes version of the cluster. The configuration is
+// decoded strictly, so misspelled or unknown fields are rejected instead of being ignored by the scheduler.
+func validateSchedulerConfig(config string, version *semver.Version) error {
+	if config == "" || version == nil {
+		return nil
+	}
+
+	typeMeta := &metav1.TypeMeta{}
+	if err := yaml.Unmarshal([]byte(config), typeMeta); err != nil {
+		return fmt.Errorf("invalid scheduler configuration: %v", err)
+	}
+	if typeMeta.Kind != "KubeSchedulerConfiguration" {
+		return fmt.Errorf("invalid scheduler configuration: kind must be KubeSchedulerConfiguration, got %q", typeMeta.Kind)
+	}
+	if apiVersion := schedulerConfigAPIVersion(version); typeMeta.APIVersion != apiVersion {
+		return fmt.Errorf("invalid scheduler configuration: apiVersion must be %s for Kubernetes %s, got %q", apiVersion, version, typeMeta.APIVersion)
+	}
+	if newConfig, ok := schedulerConfigTypes[typeMeta.APIVersion]; ok {
+		if err := yaml.UnmarshalStrict([]byte(config), newConfig()); err != nil {
+			return fmt.Errorf("invalid scheduler configuration: %v", err)
+		}
+	}
+	return nil
+}
+
+// validateDNS checks that the DNS upstreams are IP addresses with an optional port and that their
+// zones are valid domain names.
+func validateDNS(settings *kubermaticv1.DNSSettings) error {
+	if settings == nil {
+		return nil
+	}
+	for _, upstream := range settings.Upstreams {
+		if upstream.Zone != "" {
+			if errs := utilvalidation.IsDNS1123Subdomain(strings.TrimSuffix(upstream.Zone, ".")); len(errs) > 0 {
+				return fmt.Errorf("invalid DNS upstream zone %q: %s", upstream.Zone, strings.Join(errs, ", "))
+			}
+		}
+		if err := validateDNSUpstreamAddress(upstream.Address); err != nil {
+			return fmt.Errorf("invalid DNS upstream address %q: %v", upstream.Address, err)
+		}
+	}
+	return nil
+}
+
+func validateDNSUpstreamAddress(address string) error {
+	if net.ParseIP(address) != nil {
+		return nil
+	}
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return errors.New("must be an IP address or IP:port")
+	}
+	if net.ParseIP(host) == nil {
+		return errors.New("must be an IP address or IP:port")
+	}
+	if portNumber, err := strconv.Atoi(port); err != nil || portNumber < 1 || portNumber > 65535 {
+		return errors.New("port must be between 1 and 65535")
+	}
+	return nil
+}
+
+// validateRegistryMirror checks that the URL of the registry mirror is an absolute http(s) URL.
+func validateRegistryMirror(mirror *kubermaticv1.RegistryMirrorSettings) error {
+	if mirror == nil {
+		return nil
+	}
+	u, err := url.Parse(mirror.URL)
+	if err != nil {
+		return fmt.Errorf("invalid registry mirror URL %q: %v", mirror.URL, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid registry mirror URL %q: must be an absolute http or https URL", mirror.URL)
+	}
+	if u.User != nil || u.RawQuery != "" || u.Fragment != "" {
+		return fmt.Errorf("invalid registry mirror URL %q: must not contain credentials, a query or a fragment", mirror.URL)
+	}
+	return nil
+}
+
+// validateComponentsOverride checks that the log levels of the control plane components are within the supported range
+// and that the etcd maintenance settings are valid.
+func validateComponentsOverride(components kubermaticv1.ComponentSettings) error {
+	logLevels := []struct {
+		component string
+		logLevel  *int
+	}{
+		{component: "apiserver", logLevel: components.Apiserver.LogLevel},
+		{component: "controllerManager", logLevel: components.ControllerManager.LogLevel},
+		{component: "scheduler", logLevel: components.Scheduler.LogLevel},
+	}
+	for _, l := range logLevels {
+		if l.logLevel != nil && (*l.logLevel < 0 || *l.logLevel > MaxComponentLogLevel) {
+			return fmt.Errorf("invalid log level %d for %s: must be between 0 and %d", *l.logLevel, l.component, MaxComponentLogLevel)
+		}
+	}
+	return validateEtcdMaintenance(components.Etcd)
+}
+
+// validateEtcdMaintenance checks that the etcd compaction mode is supported and the defragmentation schedule is a valid cron expression.
+func validateEtcdMaintenance(settings kubermaticv1.EtcdStatefulSetSettings) error {
+	switch settings.CompactionMode {
+	case "", kubermaticv1.EtcdCompactionModePeriodic, kubermaticv1.EtcdCompactionModeRevision:
+	default:
+		return fmt.Errorf("invalid etcd compaction mode %q: must be %q or %q", settings.CompactionMode, kubermaticv1.EtcdCompactionModePeriodic, kubermaticv1.EtcdCompactionModeRevision)
+	}
+	if settings.DefragSchedule != "" {
+		if _, err := cron.ParseStandard(settings.DefragSchedule); err != nil {
+			return fmt.Errorf("invalid etcd defrag schedule %q: %v", settings.DefragSchedule, err)
+		}
+	}
+	return nil
+}
+
+// validateAutoscaler checks that the cluster-autoscaler settings are valid durations.
+func validateAutoscaler(settings *kubermaticv1.AutoscalerSettings) error {
+	if settings == nil || settings.ScaleDownDelayAfterAdd == "" {
+		return nil
+	}
+	delay, err := time.ParseDuration(settings.ScaleDownDelayAfterAdd)
+	if err != nil {
+		return fmt.Errorf("invalid autoscaler scaleDownDelayAfterAdd %q: %v", settings.ScaleDownDelayAfterAdd, err)
+	}
+	if delay <= 0 {
+		return fmt.Errorf("invalid autoscaler scaleDownDelayAfterAdd %q: must be a positive duration", settings.ScaleDownDelayAfterAdd)
+	}
+	return nil
+}
+
+// validateExternalDNS checks that the external-dns provider is supported and the zone and
+// credentials secret are valid names.
+func validateExternalDNS(settings *kubermaticv1.ExternalDNSSettings) error {
+	if settings == nil {
+		return nil
+	}
+	if !SupportedExternalDNSProviders.Has(settings.Provider) {
+		return fmt.Errorf("unsupported external DNS provider %q, must be one of %v", settings.Provider, SupportedExternalDNSProviders.List())
+	}
+	if errs := utilvalidation.IsDNS1123Subdomain(settings.Zone); len(errs) > 0 {
+		return fmt.Errorf("invalid external DNS zone %q: %s", settings.Zone, strings.Join(errs, ", "))
+	}
+	if settings.CredentialsSecret != "" {
+		if errs := utilvalidation.IsDNS1123Subdomain(settings.CredentialsSecret); len(errs) > 0 {
+			return fmt.Errorf("invalid external DNS credentials secret %q: %s", settings.CredentialsSecret, strings.Join(errs, ", "))
+		}
+	}
+	return nil
+}
+
+// validateEgressAllowlist checks that all entries of the egress allowlist are valid CIDRs
+func validateEgressAllowlist(cidrs []string) error {
+	for _, cidr := range cidrs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return fmt.Errorf("invalid egress allowlist CIDR %q: %v", cidr, err)
+		}
+	}
+	return nil
+}
+
+// validateConnectivity checks that the connectivity is known and supported
+func validateConnectivity(spec *kubermaticv1.ClusterSpec) error {
+	switch spec.Connectivity {
+	case "", kubermaticv1.ClusterConnectivityOpenVPN:
+		return nil
+	case kubermaticv1.ClusterConnectivityKonnectivity:
+		// the control plane runs the konnectivity server, but nothing deploys the agents into the user
+		// cluster or exposes the server to them, so the cluster would lose its connection to the nodes
+		return fmt.Errorf("the %s connectivity is not supported yet, the konnectivity agent is not deployed to the user clusters", spec.Connectivity)
+	default:
+		return fmt.Errorf("invalid connectivity %q, must be one of %q or %q", spec.Connectivity, kubermaticv1.ClusterConnectivityOpenVPN, kubermaticv1.ClusterConnectivityKonnectivity)
+	}
+}
+
+// validateAlertmanagerConfig checks that the Alertmanager configuration is accepted by Alertmanager
+func validateAlertmanagerConfig(config string) error {
+	if config == "" {
+		return nil
+	}
+
+	if _, err := alertmanagerconfig.Load(config); err != nil {
+		return fmt.Errorf("invalid alertmanager config: %v", err)
+	}
+	return nil
+}
+
 func validateMachineNetworksFromClusterSpec(spec *kubermaticv1.ClusterSpec) error {
 	networks := spec.MachineNetworks
 
@@ -152,6 +707,54 @@ func ValidateUpdateCluster(ctx context.Context, newCluster, oldCluster *kubermat
 		return err
 	}
 
+	if err := validateClusterDescription(newCluster.Spec.Description); err != nil {
+		return err
+	}
+
+	if err := validateComponentsOverride(newCluster.Spec.ComponentsOverride); err != nil {
+		return err
+	}
+
+	if err := validateServiceNodePortRange(newCluster.Spec.ServiceNodePortRange); err != nil {
+		return err
+	}
+
+	if err := validateAutoscaler(newCluster.Spec.Autoscaler); err != nil {
+		return err
+	}
+
+	if err := validateExternalDNS(newCluster.Spec.ExternalDNS); err != nil {
+		return err
+	}
+
+	if err := validateEgressAllowlist(newCluster.Spec.EgressAllowlist); err != nil {
+		return err
+	}
+
+	if err := validateConnectivity(&newCluster.Spec); err != nil {
+		return err
+	}
+
+	if err := validateAlertmanagerConfig(newCluster.Spec.AlertmanagerConfig); err != nil {
+		return err
+	}
+
+	if err := validateRegistryMirror(newCluster.Spec.RegistryMirror); err != nil {
+		return err
+	}
+
+	if err := validateSchedulerConfig(newCluster.Spec.SchedulerConfig, newCluster.Spec.Version.Semver()); err != nil {
+		return err
+	}
+
+	if err := validateDNS(newCluster.Spec.DNS); err != nil {
+		return err
+	}
+
+	if newCluster.Spec.Connectivity != oldCluster.Spec.Connectivity {
+		return errors.New("changing the connectivity is not allowed")
+	}
+
 	if newCluster.Address.ExternalName != oldCluster.Address.ExternalName {
 		return errors.New("changing the external name is not allowed")
 	}
@@ -187,6 +790,10 @@ func ValidateUpdateCluster(ctx context.Context, newCluster, oldCluster *kubermat
 		return fmt.Errorf("invalid cloud spec: %v", err)
 	}
 
+	if err := validateLoadBalancerClass(newCluster.Spec.LoadBalancerClass, dc.Spec.LoadBalancerClasses); err != nil {
+		return err
+	}
+
 	// We ignore the error, since we're here to check the new config, not the old one.
 	oldProviderName, _ := provider.ClusterCloudProviderName(oldCluster.Spec.Cloud)
 
@@ -0,0 +1,83 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package version
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/Masterminds/semver"
+
+	"sigs.k8s.io/yaml"
+)
+
+// SecurityAdvisory describes a known vulnerability affecting a range of Kubernetes versions
+type SecurityAdvisory struct {
+	// ID is the identifier of the advisory, e.g. CVE-2020-8558
+	ID          string `json:"id"`
+	Description string `json:"description,omitempty"`
+	URL         string `json:"url,omitempty"`
+	// AffectedVersions is a semver constraint matching all affected versions, e.g. ">= 1.18.0, < 1.18.4"
+	AffectedVersions string `json:"affectedVersions"`
+}
+
+// LoadSecurityAdvisories loads the security advisory database from a given path
+func LoadSecurityAdvisories(path string) ([]*SecurityAdvisory, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	bytes, err := ioutil.ReadAll(bufio.NewReader(f))
+	if err != nil {
+		return nil, err
+	}
+
+	s := struct {
+		SecurityAdvisories []*SecurityAdvisory `json:"securityAdvisories"`
+	}{}
+
+	err = yaml.UnmarshalStrict(bytes, &s)
+	if err != nil {
+		return nil, err
+	}
+	for _, advisory := range s.SecurityAdvisories {
+		if _, err := semver.NewConstraint(advisory.AffectedVersions); err != nil {
+			return nil, fmt.Errorf("invalid affectedVersions %q of advisory %s: %v", advisory.AffectedVersions, advisory.ID, err)
+		}
+	}
+
+	return s.SecurityAdvisories, nil
+}
+
+// SecurityAdvisoriesForVersion returns the advisories which affect the given version
+func SecurityAdvisoriesForVersion(advisories []*SecurityAdvisory, version *semver.Version) ([]*SecurityAdvisory, error) {
+	var result []*SecurityAdvisory
+	for _, advisory := range advisories {
+		constraint, err := semver.NewConstraint(advisory.AffectedVersions)
+		if err != nil {
+			return nil, fmt.Errorf("invalid affectedVersions %q of advisory %s: %v", advisory.AffectedVersions, advisory.ID, err)
+		}
+		if constraint.Check(version) {
+			result = append(result, advisory)
+		}
+	}
+
+	return result, nil
+}
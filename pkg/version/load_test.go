@@ -51,3 +51,34 @@ updates:
 		t.Fatal("Setting automaticNodeUpdate: true didn't result in automatic: true")
 	}
 }
+
+func TestLoadSecurityAdvisories(t *testing.T) {
+	fileContent := []byte(`
+securityAdvisories:
+- id: CVE-2020-8558
+  description: Node setting allows for neighboring hosts to bypass localhost boundary
+  url: https://github.com/kubernetes/kubernetes/issues/92315
+  affectedVersions: ">= 1.18.0, < 1.18.4"
+`)
+	file, err := ioutil.TempFile("/tmp", "kubermatic-test")
+	if err != nil {
+		t.Fatalf("failed to create tempfile: %v", err)
+	}
+	defer file.Close()
+	defer os.Remove(file.Name())
+
+	if _, err := file.Write(fileContent); err != nil {
+		t.Fatalf("failed to write to tempfile: %v", err)
+	}
+
+	advisories, err := LoadSecurityAdvisories(file.Name())
+	if err != nil {
+		t.Fatalf("failed to load security advisories file: %v", err)
+	}
+	if n := len(advisories); n != 1 {
+		t.Fatalf("expected to get exactly one advisory, got %d", n)
+	}
+	if advisories[0].ID != "CVE-2020-8558" {
+		t.Fatalf("expected advisory CVE-2020-8558, got %s", advisories[0].ID)
+	}
+}
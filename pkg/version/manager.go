@@ -41,9 +41,11 @@ type Manager struct {
 
 // Version is the object representing a Kubernetes version.
 type Version struct {
-	Version *semver.Version `json:"version"`
-	Default bool            `json:"default,omitempty"`
-	Type    string          `json:"type,omitempty"`
+	Version    *semver.Version `json:"version"`
+	Default    bool            `json:"default,omitempty"`
+	Type       string          `json:"type,omitempty"`
+	Deprecated bool            `json:"deprecated,omitempty"`
+	EndOfLife  bool            `json:"endOfLife,omitempty"`
 }
 
 // Update represents an update option for a cluster
@@ -291,3 +291,60 @@ func compareVersions(t *testing.T, versions, expected []*Version) {
 		}
 	}
 }
+
+func TestSecurityAdvisoriesForVersion(t *testing.T) {
+	t.Parallel()
+
+	advisories := []*SecurityAdvisory{
+		{
+			ID:               "CVE-2020-8558",
+			AffectedVersions: ">= 1.18.0, < 1.18.4",
+		},
+		{
+			ID:               "CVE-2020-8559",
+			AffectedVersions: "< 1.16.13 || >= 1.17.0, < 1.17.9",
+		},
+	}
+
+	tests := []struct {
+		name        string
+		version     string
+		expectedIDs []string
+	}{
+		{
+			name:        "version affected by a single advisory",
+			version:     "1.18.2",
+			expectedIDs: []string{"CVE-2020-8558"},
+		},
+		{
+			name:        "version affected by an advisory with multiple ranges",
+			version:     "1.17.5",
+			expectedIDs: []string{"CVE-2020-8559"},
+		},
+		{
+			name:    "version not affected by any advisory",
+			version: "1.18.4",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := SecurityAdvisoriesForVersion(advisories, semver.MustParse(tc.version))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var ids []string
+			for _, advisory := range result {
+				ids = append(ids, advisory.ID)
+			}
+			if len(ids) != len(tc.expectedIDs) {
+				t.Fatalf("expected advisories %v, got %v", tc.expectedIDs, ids)
+			}
+			for i := range ids {
+				if ids[i] != tc.expectedIDs[i] {
+					t.Fatalf("expected advisories %v, got %v", tc.expectedIDs, ids)
+				}
+			}
+		})
+	}
+}